@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newCompletionCommand creates the `completion` command, it prints the shell completion script of the "root" command.
+// The bash and fish scripts complete the resource names too, i.e the connection names, through the current context.
+func newCompletionCommand(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion [bash|zsh|fish|powershell]",
+		Short: "Print the shell completion script of " + app.Name,
+		Long: `Print the shell completion script of ` + app.Name + `.
+
+Bash, requires the bash-completion package:
+  source <(` + app.Name + ` completion bash)
+  # or permanently
+  ` + app.Name + ` completion bash > /etc/bash_completion.d/` + app.Name + `
+
+Zsh:
+  ` + app.Name + ` completion zsh > "${fpath[1]}/_` + app.Name + `"
+
+Fish:
+  ` + app.Name + ` completion fish > ~/.config/fish/completions/` + app.Name + `.fish
+
+PowerShell:
+  ` + app.Name + ` completion powershell | Out-String | Invoke-Expression
+
+The bash and fish completions suggest the resource names of the current context as well, i.e the connection names.`,
+		Example: `completion bash
+completion zsh`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  cobra.ExactValidArgs(1),
+		DisableFlagsInUseLine: true,
+		SilenceErrors:         true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			out := cmd.OutOrStdout()
+
+			switch args[0] {
+			case "bash":
+				return root.GenBashCompletion(out)
+			case "zsh":
+				return root.GenZshCompletion(out)
+			case "fish":
+				return root.GenFishCompletion(out, true)
+			case "powershell":
+				return root.GenPowerShellCompletion(out)
+			default:
+				return fmt.Errorf("unsupported shell [%s], expected bash, zsh, fish or powershell", args[0])
+			}
+		},
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/landoop/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionCommand(t *testing.T) {
+	root := &cobra.Command{Use: "lenses-cli", TraverseChildren: true}
+	root.PersistentFlags().Bool("no-color", false, "")
+
+	connections := &cobra.Command{Use: "connections", TraverseChildren: true, Run: func(*cobra.Command, []string) {}}
+	connections.Flags().String("name", "", "")
+	connections.RegisterFlagCompletionFunc("name", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"slack"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	root.AddCommand(connections)
+	root.AddCommand(newCompletionCommand(root))
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		// the parent's flags are parsed before the command's, see `TraverseChildren`.
+		output, err := test.ExecuteCommand(root, "--no-color", "completion", shell)
+		assert.Nil(t, err, shell)
+		assert.Contains(t, output, "lenses-cli", shell)
+		if shell == "fish" {
+			// the fish script asks the cli for all of its suggestions.
+			assert.Contains(t, output, cobra.ShellCompRequestCmd, shell)
+		} else {
+			assert.Contains(t, output, "connections", shell)
+		}
+	}
+
+	// the dynamic resource names.
+	output, err := test.ExecuteCommand(root, "completion", "bash")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "__lenses-cli_handle_go_custom_completion")
+
+	output, err = test.ExecuteCommand(root, cobra.ShellCompNoDescRequestCmd, "connections", "--name", "")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "slack\n")
+
+	_, err = test.ExecuteCommand(root, "completion", "tcsh")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// serverError is a `api.ResourceError` which carries a descriptive message of the server,
+// it does not expose the status code, so the generic friendly error of the code is not used instead of the message.
+type serverError struct {
+	err api.ResourceError
+}
+
+func (e serverError) Error() string {
+	if id := e.err.RequestID(); id != "" {
+		return fmt.Sprintf("%s (request id: %s)", e.err.Message(), id)
+	}
+
+	return e.err.Message()
+}
+
+func (e serverError) Unwrap() error {
+	return e.err
+}
+
+// preferServerErrors makes the "cmd" and its children to report the server's message of their resource errors,
+// instead of the generic message registered for the error's status code, see `bite.FriendlyError`.
+func preferServerErrors(cmd *cobra.Command) {
+	if runE := cmd.RunE; runE != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			return toServerError(runE(cmd, args))
+		}
+	}
+
+	for _, child := range cmd.Commands() {
+		preferServerErrors(child)
+	}
+}
+
+func toServerError(err error) error {
+	var resErr api.ResourceError
+	if errors.As(err, &resErr) && resErr.Message() != "" {
+		return serverError{err: resErr}
+	}
+
+	return err
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// newGenDocsCommand creates the hidden `gen-docs` command, it generates the man pages or the markdown documentation
+// of all the commands of the "root" command, one file per command, i.e for the packages.
+func newGenDocsCommand(root *cobra.Command) *cobra.Command {
+	var dir, format string
+
+	cmd := &cobra.Command{
+		Use:   "gen-docs",
+		Short: "Generate the documentation of all the commands, as man pages or markdown",
+		Example: `gen-docs --dir ./docs --format man
+gen-docs --dir ./docs --format markdown`,
+		Hidden:        true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return err
+			}
+
+			// no generation date, the files should change only when the commands change.
+			root.DisableAutoGenTag = true
+
+			switch strings.ToLower(format) {
+			case "man":
+				header := &doc.GenManHeader{
+					Title:   strings.ToUpper(app.Name),
+					Section: "1",
+					Source:  app.Name + " " + buildVersion,
+					Manual:  "Lenses CLI Manual",
+				}
+				if err := doc.GenManTree(root, header, dir); err != nil {
+					return err
+				}
+			case "markdown", "md":
+				if err := doc.GenMarkdownTree(root, dir); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("invalid format [%s], expected man or markdown", format)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Documentation written to [%s]\n", dir)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "./docs", "Directory to write the documentation to, it's created if it does not exist")
+	cmd.Flags().StringVar(&format, "format", "man", "Format of the documentation, man or markdown")
+
+	return cmd
+}
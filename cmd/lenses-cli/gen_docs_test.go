@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenDocsCommand(t *testing.T) {
+	root := &cobra.Command{Use: "lenses-cli", TraverseChildren: true}
+	connections := &cobra.Command{Use: "connections", Short: "List connections", Run: func(*cobra.Command, []string) {}}
+	connections.AddCommand(&cobra.Command{Use: "create", Short: "Create a connection", Run: func(*cobra.Command, []string) {}})
+	root.AddCommand(connections)
+	root.AddCommand(newGenDocsCommand(root))
+
+	dir, err := ioutil.TempDir("", "lenses-cli-docs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	manDir, markdownDir := filepath.Join(dir, "man"), filepath.Join(dir, "markdown")
+
+	_, err = test.ExecuteCommand(root, "gen-docs", "--dir", manDir, "--format", "man")
+	assert.Nil(t, err)
+	for _, name := range []string{"lenses-cli.1", "lenses-cli-connections.1", "lenses-cli-connections-create.1"} {
+		assert.FileExists(t, filepath.Join(manDir, name))
+	}
+
+	_, err = test.ExecuteCommand(root, "gen-docs", "--dir", markdownDir, "--format", "markdown")
+	assert.Nil(t, err)
+	for _, name := range []string{"lenses-cli.md", "lenses-cli_connections.md", "lenses-cli_connections_create.md"} {
+		assert.FileExists(t, filepath.Join(markdownDir, name))
+	}
+
+	// the hidden gen-docs command is not documented.
+	_, err = os.Stat(filepath.Join(markdownDir, "lenses-cli_gen-docs.md"))
+	assert.True(t, os.IsNotExist(err))
+
+	b, err := ioutil.ReadFile(filepath.Join(markdownDir, "lenses-cli_connections_create.md"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(b), "Create a connection")
+
+	_, err = test.ExecuteCommand(root, "gen-docs", "--dir", dir, "--format", "html")
+	assert.EqualError(t, err, "invalid format [html], expected man or markdown")
+}
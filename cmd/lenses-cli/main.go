@@ -2,15 +2,18 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/acl"
 	"github.com/landoop/lenses-go/pkg/alert"
 	"github.com/landoop/lenses-go/pkg/api"
 	"github.com/landoop/lenses-go/pkg/audit"
+	"github.com/landoop/lenses-go/pkg/broker"
 	config "github.com/landoop/lenses-go/pkg/configs"
 	"github.com/landoop/lenses-go/pkg/connection"
 	"github.com/landoop/lenses-go/pkg/connector"
@@ -19,17 +22,21 @@ import (
 	"github.com/landoop/lenses-go/pkg/elasticsearch"
 	"github.com/landoop/lenses-go/pkg/export"
 	imports "github.com/landoop/lenses-go/pkg/import"
+	"github.com/landoop/lenses-go/pkg/jsonschema"
 	"github.com/landoop/lenses-go/pkg/logs"
 	"github.com/landoop/lenses-go/pkg/management"
 	"github.com/landoop/lenses-go/pkg/policy"
 	"github.com/landoop/lenses-go/pkg/processor"
 	"github.com/landoop/lenses-go/pkg/quota"
+	"github.com/landoop/lenses-go/pkg/raw"
 	"github.com/landoop/lenses-go/pkg/schema"
 	"github.com/landoop/lenses-go/pkg/secret"
 	"github.com/landoop/lenses-go/pkg/shell"
 	"github.com/landoop/lenses-go/pkg/sql"
+	"github.com/landoop/lenses-go/pkg/status"
 	"github.com/landoop/lenses-go/pkg/topic"
 	"github.com/landoop/lenses-go/pkg/user"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -55,18 +62,69 @@ var (
 )
 
 func setup(cmd *cobra.Command, args []string) error {
+	// first of all, so the logs of the configuration loading respect the verbosity and the format too.
+	utils.SetupVerbosity(cmd)
+	utils.SetupColor(cmd)
+	if err := utils.SetupLogFormat(cmd, os.Stderr); err != nil {
+		return err
+	}
+
 	ok, err := config.Manager.Load()
+	if err == nil {
+		// the current context may define the output format, the --output flag has priority.
+		err = config.ApplyDefaultOutput(cmd)
+	}
 	// if command is "configure" and the configuration is invalid at this point, don't give a failure,
 	// let the configure command give a tutorial for user in order to create a configuration file.
 	// Note that if clientConfig is valid and we are inside the configure command
 	// then the configure will normally continue and save the valid configuration (that normally came from flags).
 	topLevelSubCmd := strings.Split(cmd.CommandPath(), " ")[1]
-	if name := topLevelSubCmd; name == "configure" || name == "version" || name == "context" || name == "contexts" || strings.Contains(cmd.CommandPath(), " secrets ") {
+	if name := topLevelSubCmd; name == "configure" || name == "version" || name == "completion" || name == "gen-docs" || name == "context" || name == "contexts" || name == "json-schema" || strings.Contains(cmd.CommandPath(), " secrets ") {
 		return nil
 	}
 
 	// it's not nil, if context does not exist then it would throw an error.
 	currentConfig := config.Manager.Config.GetCurrent()
+
+	// shell completion runs on every tab press, never prompt or print anything there,
+	// without a valid configuration the dynamic completions just suggest nothing.
+	if topLevelSubCmd == cobra.ShellCompRequestCmd || topLevelSubCmd == cobra.ShellCompNoDescRequestCmd {
+		if !ok {
+			return nil
+		}
+
+		if currentConfig.Timeout == "" {
+			currentConfig.Timeout = utils.CompletionTimeout.String()
+		}
+
+		// errors are ignored, the completion functions handle the missing client.
+		config.SetupClient()
+		return nil
+	}
+
+	// logout only needs the loaded configuration, the tokens may be already expired.
+	if cmd.Name() == "logout" {
+		return err
+	}
+
+	// config test connects on its own, in order to describe the connection failures.
+	if strings.HasSuffix(cmd.CommandPath(), " configs test") {
+		return err
+	}
+
+	// login --print-token is used by scripts, never prompt, fail instead.
+	if printToken, _ := cmd.Flags().GetBool("print-token"); printToken && cmd.Name() == "login" {
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			return fmt.Errorf("cannot retrieve credentials, please pass the --host, --user and --pass flags or use the '%s' command first", "configure")
+		}
+
+		return nil
+	}
+
 	for !ok {
 		if err != nil {
 			return err
@@ -76,6 +134,11 @@ func setup(cmd *cobra.Command, args []string) error {
 			fmt.Fprintf(cmd.OutOrStdout(), "%#+v\n", *currentConfig)
 		}
 
+		// i.e on CI, there is no one to answer the configure prompts.
+		if err = utils.RequirePrompt("the credentials", user.ConfigureNonInteractiveHint); err != nil {
+			return err
+		}
+
 		fmt.Fprintln(cmd.OutOrStderr(), "cannot retrieve credentials, please configure below")
 		configureCmd := user.NewConfigureCommand("")
 		// disable any flags passed on the parent command before execute.
@@ -94,8 +157,10 @@ func setup(cmd *cobra.Command, args []string) error {
 		if basicAuth, isBasicAuth := currentConfig.Authentication.(api.BasicAuthentication); isBasicAuth {
 			//  and fire any errors if host or user or pass are not there.
 			if currentConfig.Host == "" || basicAuth.Username == "" || basicAuth.Password == "" {
-				// return fmt.Errorf("cannot retrieve credentials, please setup the configuration using the '%s' command first", "configure")
-				//
+				if err := utils.RequirePrompt("the credentials", user.ConfigureNonInteractiveHint); err != nil {
+					return err
+				}
+
 				if err := user.NewConfigureCommand("").Execute(); err != nil {
 					return err
 				}
@@ -108,7 +173,29 @@ func setup(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	return config.SetupClient()
+	err = config.SetupClient()
+	// the saved credentials or token are rejected, i.e a changed password, configure them again,
+	// the network and the server failures are reported as they are.
+	if errors.Is(err, api.ErrAuth) {
+		if promptErr := utils.RequirePrompt("the credentials", user.ConfigureNonInteractiveHint); promptErr != nil {
+			return err
+		}
+
+		fmt.Fprintf(cmd.OutOrStderr(), "%v, please configure below\n", err)
+		configureCmd := user.NewConfigureCommand("")
+		configureCmd.DisableFlagParsing = true
+		if err = configureCmd.Execute(); err != nil {
+			return err
+		}
+
+		if _, err = config.Manager.Load(); err != nil {
+			return err
+		}
+
+		return config.SetupClient()
+	}
+
+	return err
 }
 
 func main() {
@@ -120,6 +207,8 @@ func main() {
 			BuildTime:            buildTime,
 			BuildVersion:         buildVersion,
 			ShowGoRuntimeVersion: true,
+			// bite prints an unset or invalid build time as 1970, see `buildVersionTmpl`.
+			Template: newBuildVersionTmpl(time.UnixDate, time.Local),
 		}
 	}
 
@@ -142,6 +231,9 @@ func main() {
 	//Audit
 	app.AddCommand(audit.NewGetAuditEntriesCommand())
 
+	//Brokers
+	app.AddCommand(broker.NewBrokersGroupCommand())
+
 	//Config
 	app.AddCommand(config.NewGetConfigsCommand())
 	app.AddCommand(config.NewGetModeCommand())
@@ -159,6 +251,9 @@ func main() {
 	//Import
 	app.AddCommand(imports.NewImportGroupCommand())
 
+	//JSON Schema
+	app.AddCommand(jsonschema.NewJSONSchemaCommand())
+
 	//Logs
 	app.AddCommand(logs.NewLogsCommandGroup())
 
@@ -170,6 +265,9 @@ func main() {
 	app.AddCommand(processor.NewGetProcessorsCommand())
 	app.AddCommand(processor.NewProcessorGroupCommand())
 
+	//Status
+	app.AddCommand(status.NewStatusCommand())
+
 	//Topics
 	app.AddCommand(topic.NewTopicsGroupCommand())
 	app.AddCommand(topic.NewTopicGroupCommand())
@@ -182,6 +280,9 @@ func main() {
 	app.AddCommand(quota.NewGetQuotasCommand())
 	app.AddCommand(quota.NewQuotaGroupCommand())
 
+	//Raw
+	app.AddCommand(raw.NewRawCommand())
+
 	//Schemas
 	app.AddCommand(schema.NewSchemasGroupCommand())
 	app.AddCommand(schema.NewSchemaGroupCommand())
@@ -194,14 +295,17 @@ func main() {
 
 	//SQL
 	app.AddCommand(sql.NewLiveLSQLCommand())
+	app.AddCommand(sql.NewLiveCommand())
 
 	//User
 	app.AddCommand(user.NewGetConfigurationContextsCommand())
 	app.AddCommand(user.NewConfigurationContextCommand())
 	app.AddCommand(user.NewConfigureCommand(""))
 	app.AddCommand(user.NewLoginCommand(app))
+	app.AddCommand(user.NewLogoutCommand())
 	app.AddCommand(user.NewGetLicenseInfoCommand())
 	app.AddCommand(user.NewUserGroupCommand())
+	app.AddCommand(user.NewWhoAmICommand())
 
 	//Management
 	app.AddCommand(management.NewGroupsCommand())
@@ -214,6 +318,25 @@ func main() {
 	// Connection Template
 	app.AddCommand(conntemplate.NewConnectionTemplateGroupCommand())
 
+	//Version
+	// replace the bite's version command, it can print only the client's build information.
+	rootCmd := bite.Build(app)
+	for _, c := range rootCmd.Commands() {
+		if c.Name() == "version" {
+			rootCmd.RemoveCommand(c)
+		}
+	}
+	rootCmd.AddCommand(newVersionCommand())
+	rootCmd.AddCommand(newCompletionCommand(rootCmd))
+	rootCmd.AddCommand(newGenDocsCommand(rootCmd))
+	utils.AddVerboseFlag(rootCmd)
+	utils.AddNoColorFlag(rootCmd)
+	utils.AddLogFormatFlag(rootCmd)
+	utils.AddColumnsFlag(rootCmd)
+	utils.AddSortByFlag(rootCmd)
+	utils.AddMaxRecordsFlag(rootCmd)
+	preferServerErrors(rootCmd)
+
 	if err := app.Run(os.Stdout, os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/spf13/cobra"
+)
+
+// serverVersionTimeout is the connection timeout of the `version` command's server call,
+// when the current context does not specify one, the command should not hang when offline.
+const serverVersionTimeout = "5s"
+
+const (
+	// unknownBuildTime is printed instead of an unset or invalid `buildTime`.
+	unknownBuildTime = "unknown"
+	// defaultTimeFormat is the layout of the build time of the `version` command.
+	defaultTimeFormat = "unixdate"
+)
+
+// timeFormats are the named layouts of the `version --time-format` flag, any other value is a Go time layout.
+var timeFormats = map[string]string{
+	"unixdate": time.UnixDate,
+	"rfc3339":  time.RFC3339,
+	"rfc1123":  time.RFC1123,
+	"rfc822":   time.RFC822,
+}
+
+// timeLayout returns the Go time layout of the "format", a named one of the `timeFormats` or a layout itself.
+func timeLayout(format string) string {
+	if layout, ok := timeFormats[strings.ToLower(strings.TrimSpace(format))]; ok {
+		return layout
+	}
+
+	return format
+}
+
+// timeLocation returns the location of the "zone", "local" for the system's one, "utc" or an IANA name, i.e "Europe/London".
+func timeLocation(zone string) (*time.Location, error) {
+	switch strings.ToLower(strings.TrimSpace(zone)) {
+	case "", "local":
+		return time.Local, nil
+	case "utc":
+		return time.UTC, nil
+	default:
+		loc, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone [%s], expected local, utc or a name like Europe/London", zone)
+		}
+		return loc, nil
+	}
+}
+
+// parseBuildTime parses the "value" of the `buildTime`, the build unix time in seconds,
+// it reports false if the value is not set or it's not a positive number.
+func parseBuildTime(value string) (time.Time, bool) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n <= 0 {
+		return time.Time{}, false
+	}
+
+	return time.Unix(n, 0), true
+}
+
+// formatBuildTime returns the "value" of the `buildTime` in the "layout" and in the "loc" time zone,
+// or the `unknownBuildTime` if it's not set or it's invalid.
+func formatBuildTime(value, layout string, loc *time.Location) string {
+	t, ok := parseBuildTime(value)
+	if !ok {
+		return unknownBuildTime
+	}
+
+	if loc == nil {
+		loc = time.Local
+	}
+
+	return t.In(loc).Format(layout)
+}
+
+// buildVersionTmpl renders the build information of the cli, like the bite's default template
+// but with an "unknown" build time if it's unset or invalid, instead of the unix epoch.
+type buildVersionTmpl struct {
+	layout   string
+	location *time.Location
+}
+
+func newBuildVersionTmpl(layout string, location *time.Location) buildVersionTmpl {
+	return buildVersionTmpl{layout: layout, location: location}
+}
+
+func (tmpl buildVersionTmpl) String() string {
+	buildTitle := ">>>> build"
+	tab := strings.Repeat(" ", len(buildTitle))
+
+	return fmt.Sprintf("%s %s\n", app.Name, buildVersion) +
+		fmt.Sprintf("%s\n", buildTitle) +
+		fmt.Sprintf("%s revision %s\n", tab, buildRevision) +
+		fmt.Sprintf("%s datetime %s\n", tab, formatBuildTime(buildTime, tmpl.layout, tmpl.location)) +
+		fmt.Sprintf("%s go       %s\n", tab, runtime.Version())
+}
+
+// versionInfo is the `version --output json` document, the build information of the cli
+// and the version of the connected server, if a valid context exists.
+type versionInfo struct {
+	ClientVersion string `json:"clientVersion"`
+	BuildRevision string `json:"buildRevision,omitempty"`
+	// BuildTime is the unix time of the build, in seconds, and BuildDatetime its human-readable form,
+	// they are omitted if the build time is unknown.
+	BuildTime     int64              `json:"buildTime,omitempty"`
+	BuildDatetime string             `json:"buildDatetime,omitempty"`
+	GoVersion     string             `json:"goVersion"`
+	ServerHost    string             `json:"serverHost,omitempty"`
+	ServerVersion *api.ServerVersion `json:"serverVersion,omitempty"`
+	// ServerError is the reason the server's version is missing, i.e the server is offline.
+	ServerError string `json:"serverError,omitempty"`
+}
+
+func newVersionInfo(layout string, loc *time.Location) versionInfo {
+	info := versionInfo{
+		ClientVersion: buildVersion,
+		BuildRevision: buildRevision,
+		GoVersion:     runtime.Version(),
+	}
+
+	if info.ClientVersion == "" {
+		info.ClientVersion = app.Version
+	}
+
+	if t, ok := parseBuildTime(buildTime); ok {
+		info.BuildTime = t.Unix()
+		info.BuildDatetime = formatBuildTime(buildTime, layout, loc)
+	}
+
+	return info
+}
+
+// newVersionCommand creates the `version` command, it prints the cli's build information
+// and the connected server's version if a valid context exists.
+func newVersionCommand() *cobra.Command {
+	var (
+		output          string
+		machineFriendly bool
+		timeFormat      string
+		timeZone        string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the current version of " + app.Name + " and of the connected Lenses server",
+		Example: `version
+version --output json
+version --time-format rfc3339 --time-zone utc`,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch strings.ToLower(output) {
+			case "", "text", "table":
+			case "json":
+				machineFriendly = true
+			default:
+				return fmt.Errorf("invalid output [%s], expected text or json", output)
+			}
+
+			loc, err := timeLocation(timeZone)
+			if err != nil {
+				return err
+			}
+
+			layout := timeLayout(timeFormat)
+			info := newVersionInfo(layout, loc)
+
+			// the server's version is optional, skip it if there is no valid context.
+			if ok, err := config.Manager.Load(); ok && err == nil {
+				currentConfig := *config.Manager.Config.GetCurrent()
+				if currentConfig.Timeout == "" {
+					currentConfig.Timeout = serverVersionTimeout
+				}
+
+				info.ServerHost = currentConfig.Host
+
+				client, err := api.OpenConnection(currentConfig)
+				if err == nil {
+					var serverVersion api.ServerVersion
+					if serverVersion, err = client.GetServerVersion(); err == nil {
+						info.ServerVersion = &serverVersion
+					}
+				}
+
+				if err != nil {
+					info.ServerError = api.DescribeConnectionError(currentConfig.Host, err).Error()
+				}
+			}
+
+			if info.ServerVersion != nil {
+				serverMajor, serverOk := info.ServerVersion.Major()
+				clientMajor, clientOk := api.MajorVersion(buildVersion)
+				if serverOk && clientOk && serverMajor != clientMajor {
+					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the server's major version [%d] differs from the client's [%d], some commands may not work as expected\n",
+						serverMajor, clientMajor)
+				}
+			}
+
+			if machineFriendly {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(info)
+			}
+
+			printVersionInfo(cmd, info, newBuildVersionTmpl(layout, loc))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", "", "Output format, text or json")
+	cmd.Flags().BoolVar(&machineFriendly, "machine-friendly", false, "Print the versions as JSON, same as --output json")
+	cmd.Flags().StringVar(&timeFormat, "time-format", defaultTimeFormat, "Layout of the build time, unixdate, rfc3339, rfc1123, rfc822 or a Go time layout")
+	cmd.Flags().StringVar(&timeZone, "time-zone", "local", "Time zone of the build time, local, utc or a name like Europe/London")
+
+	return cmd
+}
+
+// printVersionInfo prints the "info" as text, the build information through the "tmpl".
+func printVersionInfo(cmd *cobra.Command, info versionInfo, tmpl buildVersionTmpl) {
+	out := cmd.OutOrStdout()
+
+	if buildRevision != "" {
+		fmt.Fprint(out, tmpl.String())
+	} else {
+		fmt.Fprintf(out, "%s version %s\n", app.Name, app.Version)
+	}
+
+	if info.ServerError != "" {
+		fmt.Fprintf(out, "server unavailable: %s\n", info.ServerError)
+		return
+	}
+
+	if info.ServerVersion == nil {
+		return
+	}
+
+	fmt.Fprintf(out, "server %s %s\n", info.ServerHost, info.ServerVersion.Version)
+	if info.ServerVersion.Revision != "" {
+		fmt.Fprintf(out, "       revision %s\n", info.ServerVersion.Revision)
+	}
+	if info.ServerVersion.BuildTime != "" {
+		fmt.Fprintf(out, "       datetime %s\n", info.ServerVersion.BuildTime)
+	}
+}
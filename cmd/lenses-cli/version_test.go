@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionCommandJSON(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/version":
+			w.Write([]byte(`{"version": "4.0.1", "revision": "8a7c0e9", "buildTime": "2020-03-10T12:00:00Z"}`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "lenses-cli-version")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "lenses-cli.json")
+	assert.Nil(t, ioutil.WriteFile(configFile, []byte(fmt.Sprintf(
+		`{"currentContext": "master", "contexts": {"master": {"host": "%s", "token": "token"}}}`, srv.URL)), 0644))
+
+	os.Setenv("LENSES_CONFIG", configFile)
+	defer os.Unsetenv("LENSES_CONFIG")
+
+	config.Manager = config.NewEmptyConfigManager()
+	defer func() { config.Manager = nil }()
+
+	buildVersion, buildRevision, buildTime = "4.0.0", "b0f31e5", "1583841600"
+	defer func() { buildVersion, buildRevision, buildTime = "", "", "" }()
+
+	for _, args := range [][]string{{"--output", "json", "--time-zone", "utc"}, {"--machine-friendly", "--time-zone", "UTC"}} {
+		output, err := test.ExecuteCommand(newVersionCommand(), args...)
+		assert.Nil(t, err)
+
+		var info map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(output), &info), output)
+		assert.Equal(t, map[string]interface{}{
+			"clientVersion": "4.0.0",
+			"buildRevision": "b0f31e5",
+			"buildTime":     float64(1583841600),
+			"buildDatetime": "Tue Mar 10 12:00:00 UTC 2020",
+			"goVersion":     runtime.Version(),
+			"serverHost":    srv.URL,
+			"serverVersion": map[string]interface{}{
+				"version":   "4.0.1",
+				"revision":  "8a7c0e9",
+				"buildTime": "2020-03-10T12:00:00Z",
+			},
+		}, info)
+	}
+
+	// the server's version is omitted without a valid context.
+	os.Setenv("LENSES_CONFIG", filepath.Join(dir, "missing.json"))
+	config.Manager = config.NewEmptyConfigManager()
+
+	output, err := test.ExecuteCommand(newVersionCommand(), "--output", "json", "--time-zone", "utc")
+	assert.Nil(t, err)
+
+	var info versionInfo
+	assert.Nil(t, json.Unmarshal([]byte(output), &info))
+	assert.Equal(t, versionInfo{
+		ClientVersion: "4.0.0",
+		BuildRevision: "b0f31e5",
+		BuildTime:     1583841600,
+		BuildDatetime: "Tue Mar 10 12:00:00 UTC 2020",
+		GoVersion:     runtime.Version(),
+	}, info)
+	assert.Nil(t, info.ServerVersion)
+}
+
+func TestFormatBuildTime(t *testing.T) {
+	london, err := timeLocation("Europe/London")
+	assert.Nil(t, err)
+
+	tests := []struct {
+		value, format string
+		loc           *time.Location
+		expected      string
+	}{
+		{"", "unixdate", time.UTC, unknownBuildTime},
+		{"  ", "rfc3339", time.UTC, unknownBuildTime},
+		{"0", "unixdate", time.UTC, unknownBuildTime},
+		{"-1", "unixdate", time.UTC, unknownBuildTime},
+		{"yesterday", "unixdate", time.UTC, unknownBuildTime},
+		{"2020-03-10T12:00:00Z", "unixdate", time.UTC, unknownBuildTime},
+		{"1583841600", "unixdate", time.UTC, "Tue Mar 10 12:00:00 UTC 2020"},
+		{"1583841600", "RFC3339", time.UTC, "2020-03-10T12:00:00Z"},
+		{"1583841600", "rfc3339", london, "2020-03-10T12:00:00Z"},
+		{"1593777600", "rfc3339", london, "2020-07-03T13:00:00+01:00"},
+		{"1583841600", "2006-01-02", time.UTC, "2020-03-10"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, formatBuildTime(tt.value, timeLayout(tt.format), tt.loc), "%s %s", tt.value, tt.format)
+	}
+
+	_, err = timeLocation("Mars/Olympus")
+	assert.EqualError(t, err, "invalid time zone [Mars/Olympus], expected local, utc or a name like Europe/London")
+}
+
+func TestVersionCommandUnknownBuildTime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lenses-cli-version")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// no server.
+	os.Setenv("LENSES_CONFIG", filepath.Join(dir, "missing.json"))
+	defer os.Unsetenv("LENSES_CONFIG")
+
+	config.Manager = config.NewEmptyConfigManager()
+	defer func() { config.Manager = nil }()
+
+	buildVersion, buildRevision, buildTime = "4.0.0", "b0f31e5", ""
+	defer func() { buildVersion, buildRevision, buildTime = "", "", "" }()
+
+	output, err := test.ExecuteCommand(newVersionCommand())
+	assert.Nil(t, err)
+	assert.Contains(t, output, "revision b0f31e5\n")
+	assert.Contains(t, output, "datetime unknown\n")
+
+	buildTime = "1583841600"
+	output, err = test.ExecuteCommand(newVersionCommand(), "--time-format", "rfc3339", "--time-zone", "utc")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "datetime 2020-03-10T12:00:00Z\n")
+
+	_, err = test.ExecuteCommand(newVersionCommand(), "--time-zone", "Mars/Olympus")
+	assert.NotNil(t, err)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
@@ -34,11 +35,12 @@ func NewGetACLsCommand() *cobra.Command {
 				return acls[i].ResourceName < acls[j].ResourceName
 			})
 
-			return bite.PrintObject(cmd, acls)
+			return utils.PrintObject(cmd, acls)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+	utils.CanPage(cmd)
 
 	return cmd
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -47,7 +48,7 @@ func NewGetAlertsCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if sse {
 				handler := func(alert api.Alert) error {
-					return bite.PrintObject(cmd, alert) // keep json here?
+					return utils.PrintObject(cmd, alert) // keep json here?
 				}
 				return config.Client.GetAlertsLive(handler)
 			}
@@ -56,14 +57,14 @@ func NewGetAlertsCommand() *cobra.Command {
 				golog.Errorf("Failed to retrieve alerts. [%s]", err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, alerts)
+			return utils.PrintObject(cmd, alerts)
 		},
 	}
 
 	cmd.Flags().BoolVar(&sse, "live", false, "Enables real-time push alert notifications")
 	cmd.Flags().IntVar(&pageSize, "page-size", 25, "Size of items to be included in the list")
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -83,11 +84,11 @@ func NewGetAlertSettingsCommand() *cobra.Command {
 			}
 
 			// force json, may contains conditions that are easier to be seen in json format.
-			return bite.PrintJSON(cmd, settings)
+			return utils.PrintJSON(cmd, settings)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -119,7 +120,7 @@ func NewAlertSettingGroupCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, settings)
+			return utils.PrintObject(cmd, settings)
 		},
 	}
 
@@ -128,7 +129,7 @@ func NewAlertSettingGroupCommand() *cobra.Command {
 
 	root.Flags().BoolVar(&mustEnable, "enable", false, "--enable")
 
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
 	bite.CanBeSilent(root)
 
 	root.AddCommand(NewUpdateAlertSettingsCommand())
@@ -190,7 +191,7 @@ func NewGetAlertSettingConditionsCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, conds)
+			return utils.PrintObject(cmd, conds)
 		},
 	}
 
@@ -198,7 +199,7 @@ func NewGetAlertSettingConditionsCommand() *cobra.Command {
 	cmd.MarkFlagRequired("alert")
 
 	bite.CanBeSilent(cmd)
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -342,7 +343,7 @@ func NewGetAlertChannelsCommand() *cobra.Command {
 					golog.Errorf("Failed to retrieve alert channels. [%s]", err.Error())
 					return err
 				}
-				return bite.PrintObject(cmd, alertchannelsWithDetails.Values)
+				return utils.PrintObject(cmd, alertchannelsWithDetails.Values)
 			}
 
 			alertchannels, err := config.Client.GetAlertChannels(page, pageSize, sortField, sortOrder, templateName, channelName)
@@ -350,7 +351,7 @@ func NewGetAlertChannelsCommand() *cobra.Command {
 				golog.Errorf("Failed to retrieve alert channels. [%s]", err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, alertchannels.Values)
+			return utils.PrintObject(cmd, alertchannels.Values)
 		},
 	}
 
@@ -363,7 +364,7 @@ func NewGetAlertChannelsCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&details, "details", false, `--details`)
 
 	bite.CanBeSilent(cmd)
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	cmd.AddCommand(NewDeleteAlertChannelCommand())
 	cmd.AddCommand(NewCreateAlertChannelCommand())
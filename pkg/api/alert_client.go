@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -99,8 +100,13 @@ func constructQueryString(page int, pageSize int, sortField, sortOrder, template
 
 // GetAlertChannels handles the API call get the list of alert channels
 func (c *Client) GetAlertChannels(page int, pageSize int, sortField, sortOrder, templateName, channelName string) (response AlertChannelResponse, err error) {
+	return c.GetAlertChannelsContext(context.Background(), page, pageSize, sortField, sortOrder, templateName, channelName)
+}
+
+// GetAlertChannelsContext is like `GetAlertChannels` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertChannelsContext(ctx context.Context, page int, pageSize int, sortField, sortOrder, templateName, channelName string) (response AlertChannelResponse, err error) {
 	path := constructQueryString(page, pageSize, sortField, sortOrder, templateName, channelName)
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -111,8 +117,13 @@ func (c *Client) GetAlertChannels(page int, pageSize int, sortField, sortOrder,
 
 // GetAlertChannelsWithDetails handles the API call get the list of alert channels with details
 func (c *Client) GetAlertChannelsWithDetails(page int, pageSize int, sortField, sortOrder, templateName, channelName string) (response AlertChannelResponseWithDetails, err error) {
+	return c.GetAlertChannelsWithDetailsContext(context.Background(), page, pageSize, sortField, sortOrder, templateName, channelName)
+}
+
+// GetAlertChannelsWithDetailsContext is like `GetAlertChannelsWithDetails` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertChannelsWithDetailsContext(ctx context.Context, page int, pageSize int, sortField, sortOrder, templateName, channelName string) (response AlertChannelResponseWithDetails, err error) {
 	path := constructQueryString(page, pageSize, sortField, sortOrder, templateName, channelName)
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -123,8 +134,13 @@ func (c *Client) GetAlertChannelsWithDetails(page int, pageSize int, sortField,
 
 // DeleteAlertChannel handles the deletion of a channel
 func (c *Client) DeleteAlertChannel(channelID string) error {
+	return c.DeleteAlertChannelContext(context.Background(), channelID)
+}
+
+// DeleteAlertChannelContext is like `DeleteAlertChannel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteAlertChannelContext(ctx context.Context, channelID string) error {
 	path := fmt.Sprintf("%s/%s", pkg.AlertChannelsPath, channelID)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -133,6 +149,11 @@ func (c *Client) DeleteAlertChannel(channelID string) error {
 
 // CreateAlertChannel handles the creation of a channel
 func (c *Client) CreateAlertChannel(chnl AlertChannelPayload) error {
+	return c.CreateAlertChannelContext(context.Background(), chnl)
+}
+
+// CreateAlertChannelContext is like `CreateAlertChannel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateAlertChannelContext(ctx context.Context, chnl AlertChannelPayload) error {
 	var channel = AlertChannelPayload{
 		Name:           chnl.Name,
 		ConnectionName: chnl.ConnectionName,
@@ -143,7 +164,7 @@ func (c *Client) CreateAlertChannel(chnl AlertChannelPayload) error {
 	if err != nil {
 		return err
 	}
-	resp, err := c.Do(http.MethodPost, pkg.AlertChannelsPath, contentTypeJSON, payload)
+	resp, err := c.DoContext(ctx, http.MethodPost, pkg.AlertChannelsPath, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -152,6 +173,11 @@ func (c *Client) CreateAlertChannel(chnl AlertChannelPayload) error {
 
 // UpdateAlertChannel handles...take a guess
 func (c *Client) UpdateAlertChannel(chnl AlertChannelPayload, channelID string) error {
+	return c.UpdateAlertChannelContext(context.Background(), chnl, channelID)
+}
+
+// UpdateAlertChannelContext is like `UpdateAlertChannel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateAlertChannelContext(ctx context.Context, chnl AlertChannelPayload, channelID string) error {
 
 	var channel = AlertChannelPayload{
 		Name:           chnl.Name,
@@ -164,7 +190,7 @@ func (c *Client) UpdateAlertChannel(chnl AlertChannelPayload, channelID string)
 	if err != nil {
 		return err
 	}
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -173,10 +199,15 @@ func (c *Client) UpdateAlertChannel(chnl AlertChannelPayload, channelID string)
 
 // UpdateAlertSettings corresponds to `/api/v1/alerts/settings/{alert_setting_id}`
 func (c *Client) UpdateAlertSettings(alertSettings AlertSettingsPayload) error {
+	return c.UpdateAlertSettingsContext(context.Background(), alertSettings)
+}
+
+// UpdateAlertSettingsContext is like `UpdateAlertSettings` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateAlertSettingsContext(ctx context.Context, alertSettings AlertSettingsPayload) error {
 	path := fmt.Sprintf("%s/%s", pkg.AlertsSettingsPath, alertSettings.AlertID)
 
 	jsonPayload, err := json.Marshal(AlertSettingsPayload{Enable: alertSettings.Enable, Channels: alertSettings.Channels})
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, jsonPayload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, jsonPayload)
 
 	if err != nil {
 		return err
@@ -187,10 +218,15 @@ func (c *Client) UpdateAlertSettings(alertSettings AlertSettingsPayload) error {
 
 // UpdateAlertSettingsCondition corresponds to `/api/v1/alerts/settings/{alert_setting_id}/condition/{condition_id}`
 func (c *Client) UpdateAlertSettingsCondition(alertID, condition, conditionID string, channels []string) error {
+	return c.UpdateAlertSettingsConditionContext(context.Background(), alertID, condition, conditionID, channels)
+}
+
+// UpdateAlertSettingsConditionContext is like `UpdateAlertSettingsCondition` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateAlertSettingsConditionContext(ctx context.Context, alertID, condition, conditionID string, channels []string) error {
 	path := fmt.Sprintf("%s/%s/conditions/%s", pkg.AlertsSettingsPath, alertID, conditionID)
 
 	jsonPayload, err := json.Marshal(AlertSettingsConditionPayload{Condition: condition, Channels: channels})
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, jsonPayload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, jsonPayload)
 
 	if err != nil {
 		return err
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+const (
+	brokersPath      = "api/v1/kafka/brokers"
+	brokerConfigPath = brokersPath + "/%d/configs"
+)
+
+// RedactedConfigValue is the value of the sensitive broker configs, their actual values are never returned by the server.
+const RedactedConfigValue = "[hidden]"
+
+// Broker describes a kafka broker of the cluster, see `GetBrokers`.
+type Broker struct {
+	ID         int    `json:"id" yaml:"id" header:"ID,text"`
+	Host       string `json:"host" yaml:"host" header:"Host"`
+	Port       int    `json:"port" yaml:"port" header:"Port,text"`
+	Rack       string `json:"rack,omitempty" yaml:"rack,omitempty" header:"Rack"`
+	Controller bool   `json:"controller" yaml:"controller" header:"Controller"`
+}
+
+// GetBrokers returns the brokers of the kafka cluster.
+func (c *Client) GetBrokers() (brokers []Broker, err error) {
+	return c.GetBrokersContext(context.Background())
+}
+
+// GetBrokersContext is like `GetBrokers` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetBrokersContext(ctx context.Context) (brokers []Broker, err error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, brokersPath, "", nil)
+	if err != nil {
+		return
+	}
+
+	err = c.ReadJSON(resp, &brokers)
+	return
+}
+
+// BrokerConfigSource describes where the value of a broker config comes from.
+type BrokerConfigSource string
+
+// The available broker config sources, see `BrokerConfigEntry#IsDefault`.
+const (
+	BrokerConfigDefault       BrokerConfigSource = "DEFAULT_CONFIG"
+	BrokerConfigStatic        BrokerConfigSource = "STATIC_BROKER_CONFIG"
+	BrokerConfigDynamicBroker BrokerConfigSource = "DYNAMIC_BROKER_CONFIG"
+	BrokerConfigDynamicGlobal BrokerConfigSource = "DYNAMIC_DEFAULT_BROKER_CONFIG"
+)
+
+// BrokerConfigEntry describes a configuration key/value of a kafka broker, see `GetBrokerConfig`.
+type BrokerConfigEntry struct {
+	Name      string             `json:"name" yaml:"name" header:"Name"`
+	Value     string             `json:"value" yaml:"value" header:"Value"`
+	Source    BrokerConfigSource `json:"source" yaml:"source" header:"Source"`
+	Sensitive bool               `json:"sensitive" yaml:"sensitive" header:"Sensitive"`
+	ReadOnly  bool               `json:"readOnly" yaml:"readOnly" header:"Read Only"`
+}
+
+// IsDefault reports whether the config has its default value, i.e not overridden.
+func (e BrokerConfigEntry) IsDefault() bool {
+	return e.Source == BrokerConfigDefault
+}
+
+// GetBrokerConfig returns the configuration of a kafka broker, including the default values.
+// The values of the sensitive configs are replaced with the `RedactedConfigValue`.
+func (c *Client) GetBrokerConfig(brokerID int) (configs []BrokerConfigEntry, err error) {
+	return c.GetBrokerConfigContext(context.Background(), brokerID)
+}
+
+// GetBrokerConfigContext is like `GetBrokerConfig` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetBrokerConfigContext(ctx context.Context, brokerID int) (configs []BrokerConfigEntry, err error) {
+	path := fmt.Sprintf(brokerConfigPath, brokerID)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return
+	}
+
+	if err = c.ReadJSON(resp, &configs); err != nil {
+		return
+	}
+
+	for i := range configs {
+		if configs[i].Sensitive {
+			configs[i].Value = RedactedConfigValue
+		}
+	}
+
+	return
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const brokerConfigResponse = `[
+	{"name": "log.cleaner.threads", "value": "1", "source": "DEFAULT_CONFIG", "sensitive": false, "readOnly": false},
+	{"name": "compression.type", "value": "gzip", "source": "DYNAMIC_BROKER_CONFIG", "sensitive": false, "readOnly": false},
+	{"name": "ssl.keystore.password", "value": null, "source": "STATIC_BROKER_CONFIG", "sensitive": true, "readOnly": true}
+]`
+
+func TestGetBrokersAndConfig(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/" + brokersPath:
+			w.Write([]byte(`[{"id": 0, "host": "kafka-0", "port": 9092, "rack": "eu-west-1a", "controller": true}, {"id": 1, "host": "kafka-1", "port": 9092}]`))
+		case "/" + brokersPath + "/1/configs":
+			w.Write([]byte(brokerConfigResponse))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	brokers, err := client.GetBrokers()
+	assert.Nil(t, err)
+	assert.Equal(t, []Broker{
+		{ID: 0, Host: "kafka-0", Port: 9092, Rack: "eu-west-1a", Controller: true},
+		{ID: 1, Host: "kafka-1", Port: 9092},
+	}, brokers)
+
+	configs, err := client.GetBrokerConfig(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []BrokerConfigEntry{
+		{Name: "log.cleaner.threads", Value: "1", Source: BrokerConfigDefault},
+		{Name: "compression.type", Value: "gzip", Source: BrokerConfigDynamicBroker},
+		{Name: "ssl.keystore.password", Value: RedactedConfigValue, Source: BrokerConfigStatic, Sensitive: true, ReadOnly: true},
+	}, configs)
+	assert.True(t, configs[0].IsDefault())
+	assert.False(t, configs[1].IsDefault())
+
+	_, err = client.GetBrokerConfig(5)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,89 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultBulkConcurrency is the number of the concurrent requests of the bulk calls, i.e `CreateServiceAccounts`,
+// see `WithBulkConcurrency`.
+const DefaultBulkConcurrency = 4
+
+// WithBulkConcurrency sets the maximum number of the concurrent requests of the bulk calls, i.e `CreateServiceAccounts`.
+// Defaults to `DefaultBulkConcurrency`.
+func WithBulkConcurrency(n int) ConnectionOption {
+	return func(c *Client) {
+		c.bulkConcurrency = n
+	}
+}
+
+// BulkError is returned by the bulk calls, i.e `CreateServiceAccounts`, when some of their resources failed,
+// the rest of them are not rolled back.
+type BulkError struct {
+	// Kind is the kind of the resources, i.e "service account".
+	Kind string
+	// Total is the number of the resources of the call.
+	Total int
+	// Errors keeps the error of each failed resource by its name.
+	Errors map[string]error
+}
+
+// Error returns the errors of all the failed resources, sorted by their name.
+func (err BulkError) Error() string {
+	names := make([]string, 0, len(err.Errors))
+	for name := range err.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	causes := make([]string, 0, len(names))
+	for _, name := range names {
+		causes = append(causes, fmt.Sprintf("[%s]: %v", name, err.Errors[name]))
+	}
+
+	return fmt.Sprintf("failed to create %d of %d %ss: %s", len(err.Errors), err.Total, err.Kind, strings.Join(causes, ", "))
+}
+
+// bulk calls the "fn" for each of the "names" concurrently, up to the `WithBulkConcurrency` calls at a time,
+// it returns a `BulkError` of the failed ones, if any.
+func (c *Client) bulk(kind string, names []string, fn func(i int) error) error {
+	concurrency := c.bulkConcurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBulkConcurrency
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		errs    = make(map[string]error)
+		indexes = make(chan int)
+	)
+
+	for w := 0; w < concurrency && w < len(names); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				if err := fn(i); err != nil {
+					mu.Lock()
+					errs[names[i]] = err
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	for i := range names {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return BulkError{Kind: kind, Total: len(names), Errors: errs}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateServiceAccounts(t *testing.T) {
+	var (
+		mu                  sync.Mutex
+		inFlight, maxFlight int
+	)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+serviceAccountPath {
+			w.Write([]byte("[]"))
+			return
+		}
+
+		mu.Lock()
+		inFlight++
+		if inFlight > maxFlight {
+			maxFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+
+		var svcacc ServiceAccount
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&svcacc))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		if svcacc.Name == "svc-3" || svcacc.Name == "svc-7" {
+			w.Header().Set(contentTypeHeaderKey, contentTypeJSON)
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message": "already exists"}`))
+			return
+		}
+
+		fmt.Fprintf(w, `{"token": "token-%s"}`, svcacc.Name)
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithBulkConcurrency(3))
+	assert.Nil(t, err)
+
+	var svcaccs []ServiceAccount
+	for i := 0; i < 10; i++ {
+		svcaccs = append(svcaccs, ServiceAccount{Name: fmt.Sprintf("svc-%d", i), Owner: "admin", Groups: []string{"dev"}})
+	}
+
+	tokens, err := client.CreateServiceAccounts(svcaccs)
+	assert.True(t, maxFlight <= 3, "at most 3 concurrent requests expected, got %d", maxFlight)
+
+	bulkErr, ok := err.(BulkError)
+	if assert.True(t, ok, "expected a BulkError, got %T", err) {
+		assert.Equal(t, 10, bulkErr.Total)
+		assert.Len(t, bulkErr.Errors, 2)
+		assert.Contains(t, bulkErr.Errors, "svc-3")
+		assert.Contains(t, bulkErr.Errors, "svc-7")
+		assert.Equal(t, "failed to create 2 of 10 service accounts: [svc-3]: already exists, [svc-7]: already exists", err.Error())
+	}
+
+	assert.Len(t, tokens, 8)
+	assert.Equal(t, "token-svc-0", tokens["svc-0"].Token)
+	assert.NotContains(t, tokens, "svc-3")
+
+	tokens, err = client.CreateServiceAccounts(svcaccs[:2])
+	assert.Nil(t, err)
+	assert.Len(t, tokens, 2)
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -12,10 +13,10 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
-
-	"github.com/kataras/golog"
 )
 
 // User represents the user of the client.
@@ -28,18 +29,115 @@ type User struct {
 
 // Client is the lenses http client.
 // It contains the necessary API calls to communicate and develop via lenses.
+//
+// A Client is safe for concurrent use by multiple goroutines, the token refresh is single-flight.
+// Its exported fields should not be modified after the `OpenConnection`, use the `GetAccessToken` to read the current token.
 type Client struct {
 	Config     *ClientConfig
 	configFull *Config // not exported, used for `ConnectionOptions`.
 	// PersistentRequestModifier can be used to modify the *http.Request before send it to the backend.
+	//
+	// Deprecated: use the `WithRequestInterceptors` instead, they are called for every request too,
+	// after the `RequestOption`s of the call. It's still called, before them.
 	PersistentRequestModifier RequestOption
 
 	// Progress                  func(current, total int64)
 	// User is generated on `lenses#OpenConnection` function based on the `Config#Authentication`.
 	User User
 
+	// the settings of the connection options, they are shared by the copies of the client, see `loginClient`.
+	clientOptions
+
+	// tokenMu guards the `Config#Token`, `Config#TokenExpiry`, the `User` and the `loggedIn` which are replaced on token refresh,
+	// refreshMu makes the token refresh single-flight.
+	tokenMu   sync.RWMutex
+	refreshMu sync.Mutex
+	// loggedIn is the time of the last login of the client, see `relogin`.
+	loggedIn time.Time
+
+	requestCompressionRejected int32 // atomic, set when the server does not accept compressed requests.
+
+	// the last response received by `Client#Do`, see `Client#LastResponse`.
+	lastResponse   *http.Response
+	lastResponseMu sync.RWMutex
+
+	// the cached version of the server, see `WithVersionNegotiation` and `WithServerVersion`.
+	serverVersion   *ServerVersion
+	serverVersionMu sync.RWMutex
+	// the version is negotiated once, on the first call which depends on it, see `negotiateServerVersion`.
+	negotiateMu sync.Mutex
+	negotiated  bool
+
+	// atomic, set by the `Close`.
+	closed int32
+}
+
+// clientOptions are the settings of the `Client`, set by the `OpenConnection` and its `ConnectionOption`s.
+type clientOptions struct {
 	// the client is created on the `lenses#OpenConnection` function, it can be customized via options there.
 	client *http.Client
+	// the connection timeout of the client, see `Client#Timeout`.
+	timeout time.Duration
+	// see `WithHTTPClient`, the client is used as it is.
+	customClient bool
+
+	// see `WithTokenRefreshSkew` and `OnTokenRefresh`.
+	tokenRefreshSkew time.Duration
+	onTokenRefresh   func(ClientConfig)
+
+	// see `WithRequestCompression` and `WithoutResponseCompression`.
+	requestCompressionMinSize   int
+	responseCompressionDisabled bool
+
+	// see `WithRequestInterceptors` and `WithResponseInterceptors`.
+	requestInterceptors  []RequestInterceptor
+	responseInterceptors []ResponseInterceptor
+
+	// see `WithTracer`.
+	tracer Tracer
+	// see `WithMetricsCollector`.
+	metrics MetricsCollector
+	// see `WithBulkConcurrency`.
+	bulkConcurrency int
+	// see `WithLogger`.
+	logger Logger
+	// see `ClientConfig#RateLimit`, nil for no limit.
+	rateLimiter *rateLimiter
+	// the scheme://host:port and the path prefix of the `ClientConfig#Host`, see `ClientConfig#BasePath`.
+	origin, basePath string
+
+	// see `WithVersionNegotiation`.
+	negotiateVersion bool
+
+	// the ticket of the `KerberosAuthentication`, see `WithKerberosRenewal`.
+	kerberos        *kerberosSession
+	kerberosRenewal bool
+}
+
+// Timeout returns the connection establishment timeout that the client was built with,
+// the `ClientConfig#Timeout` or the timeout of the `UsingClient`'s HTTP Client, zero means no timeout.
+func (c *Client) Timeout() time.Duration {
+	return c.timeout
+}
+
+// LastResponse returns the raw HTTP response of the most recent call made by this client,
+// including the failed ones, i.e a 429 or a 404. It can be used after a typed call
+// to inspect the response status and headers, i.e rate-limit counters and request IDs.
+//
+// Note that the response body may already be consumed and closed by the typed call,
+// read only the status and the headers, use `Client#Do` for full access to the body.
+// It returns nil if no response was received yet.
+func (c *Client) LastResponse() *http.Response {
+	c.lastResponseMu.RLock()
+	resp := c.lastResponse
+	c.lastResponseMu.RUnlock()
+	return resp
+}
+
+func (c *Client) setLastResponse(resp *http.Response) {
+	c.lastResponseMu.Lock()
+	c.lastResponse = resp
+	c.lastResponseMu.Unlock()
 }
 
 var noOpBuffer = new(bytes.Buffer)
@@ -99,6 +197,10 @@ type ResourceError struct {
 	Method     string `json:"method" header:"Method"`
 	URI        string `json:"uri" header:"Target"`
 	Body       string `json:"message" header:"Message"`
+
+	// serverMessage is the message of the server's json error body, see `Message`.
+	serverMessage string
+	requestID     string
 }
 
 // String returns the detailed cause of the error.
@@ -143,6 +245,17 @@ func (err ResourceError) Code() int {
 	return err.StatusCode
 }
 
+// Message returns the descriptive message of the server's json error body,
+// it's empty if the server did not send one, i.e a plain text or an html error page.
+func (err ResourceError) Message() string {
+	return err.serverMessage
+}
+
+// RequestID returns the id of the failed request, if the server sent one, it helps the server's logs lookup.
+func (err ResourceError) RequestID() string {
+	return err.requestID
+}
+
 // NewResourceError is just a helper to create a new `ResourceError` to return from custom calls, it's "cli-compatible".
 func NewResourceError(statusCode int, uri, method, body string) ResourceError {
 	unescapedURI, _ := url.QueryUnescape(uri)
@@ -155,11 +268,27 @@ func NewResourceError(statusCode int, uri, method, body string) ResourceError {
 	}
 }
 
+// NotFoundError is returned by the calls which fetch a single resource by its name when the resource does not exist,
+// i.e `GetServiceAccount`.
+type NotFoundError struct {
+	// Kind describes the resource, i.e "service account".
+	Kind string
+	Name string
+}
+
+// Error implements the error.
+func (err NotFoundError) Error() string {
+	return fmt.Sprintf("%s [%s] not found", err.Kind, err.Name)
+}
+
 type jsonResourceError struct {
 	ErrorCode int    `json:"error_code"`
 	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
 }
 
+const requestIDHeaderKey = "X-Request-Id"
+
 // jsonResourceErrorV2 is defined for the Connections API
 type jsonResourceErrorV2 struct {
 	Fields    []map[string]string `json:"fields"`
@@ -169,54 +298,163 @@ type jsonResourceErrorV2 struct {
 // Do is the lower level of a client call, manually sends an HTTP request to the lenses box backend based on the `Client#Config`
 // and returns an HTTP response.
 func (c *Client) Do(method, path, contentType string, send []byte, options ...RequestOption) (*http.Response, error) {
+	return c.DoContext(context.Background(), method, path, contentType, send, options...)
+}
+
+// DoContext is like `Do` but the request is bound to the `ctx`,
+// if the `ctx` is canceled or its deadline is exceeded the request is aborted and the `ctx` error is returned.
+func (c *Client) DoContext(ctx context.Context, method, path, contentType string, send []byte, options ...RequestOption) (*http.Response, error) {
+	if c.isClosed() {
+		return nil, ErrClientClosed
+	}
+
 	if path[0] == '/' { // remove beginning slash, if any.
 		path = path[1:]
 	}
-	uri := c.Config.Host + "/" + path
 
-	golog.Debugf("Client#Do.req:\n\turi: %s:%s\n\tsend: %s", method, uri, string(send))
+	_, noMetrics := c.metrics.(NoopMetricsCollector)
+	if c.tracer == nil && (c.metrics == nil || noMetrics) {
+		return c.do(ctx, method, path, contentType, send, options, nil)
+	}
 
-	req, err := http.NewRequest(method, uri, acquireBuffer(send))
-	if err != nil {
-		return nil, err
+	var span Span
+	if c.tracer != nil {
+		ctx, span = c.startSpan(ctx, method, path)
 	}
-	// before sending requests here.
 
-	// set the token header.
-	if c.Config.Token != "" {
-		req.Header.Set(xKafkaLensesTokenHeaderKey, c.Config.Token)
+	start := time.Now()
+	stats := new(callStats)
+	resp, err := c.do(ctx, method, path, contentType, send, options, stats)
+
+	if span != nil {
+		endSpan(span, stats, err)
 	}
 
-	// set the content type if any.
-	if contentType != "" {
-		req.Header.Set(contentTypeHeaderKey, contentType)
+	if c.metrics != nil {
+		c.metrics.ObserveRequest(method, route(endpoint(path)), stats.statusCode, time.Since(start))
 	}
 
-	// response accept gzipped content.
-	req.Header.Add(acceptEncodingHeaderKey, gzipEncodingHeaderValue)
+	return resp, err
+}
 
-	if c.PersistentRequestModifier != nil {
-		if err := c.PersistentRequestModifier(req); err != nil {
+// callStats is filled by the `Client#do` for the tracing and the metrics of a call.
+type callStats struct {
+	statusCode int
+	// resent is the number of times the request was sent again, i.e uncompressed, see `WithRequestCompression`.
+	resent int
+}
+
+// requestURL returns the URL of the API "path", prefixed with the base path of the host, see `ClientConfig#BasePath`.
+func (c *Client) requestURL(path string) string {
+	return c.origin + c.basePath + "/" + strings.TrimPrefix(path, "/")
+}
+
+func (c *Client) do(ctx context.Context, method, path, contentType string, send []byte, options []RequestOption, stats *callStats) (*http.Response, error) {
+	uri := c.requestURL(path)
+
+	// the HTTP dumps are logged only on debug mode, the logger's debug level may be used for other messages too.
+	if c.Config.Debug {
+		c.Logger().Debugf("Client#Do.req:\n\turi: %s:%s\n\tsend: %s", method, uri, redactedBody{c: c, body: send})
+	}
+
+	// before sending requests here.
+	if err := c.refreshToken(); err != nil {
+		return nil, err
+	}
+
+	compress := c.shouldCompressRequest(send)
+	kerberosGeneration := c.kerberosGeneration()
+	token := c.token()
+	resp, err := c.sendRequest(ctx, method, uri, contentType, send, compress, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if compress && resp.StatusCode == http.StatusUnsupportedMediaType {
+		// the server does not accept compressed bodies, send it again as it is and don't try again.
+		c.Logger().Debugf("Client#Do: server does not accept gzip compressed requests, request compression is disabled")
+		resp.Body.Close()
+		atomic.StoreInt32(&c.requestCompressionRejected, 1)
+		compress = false
+		if stats != nil {
+			stats.resent++
+		}
+		if resp, err = c.sendRequest(ctx, method, uri, contentType, send, compress, options); err != nil {
 			return nil, err
 		}
 	}
 
-	for _, opt := range options {
-		if err = opt(req); err != nil {
+	// the client-side `RateLimit` throttles proactively, the server's Retry-After reactively.
+	for retries := 0; resp.StatusCode == http.StatusTooManyRequests && retries < maxRateLimitedRetries; retries++ {
+		wait := retryAfter(resp)
+		c.Logger().Warnf("Client#Do: too many requests, retrying [%s %s] in %s", method, uri, wait)
+		resp.Body.Close()
+		if err = sleepContext(ctx, wait); err != nil {
+			return nil, err
+		}
+		if stats != nil {
+			stats.resent++
+		}
+		if resp, err = c.sendRequest(ctx, method, uri, contentType, send, compress, options); err != nil {
 			return nil, err
 		}
 	}
 
-	// here will print all the headers, including the token (because it may be useful for debugging)
-	// --so bug reporters should be careful here to invalidate the token after that.
-	golog.Debugf("Client#Do.req.Headers: %#+v", req.Header)
+	// a SPNEGO protected proxy challenges the requests without a kerberos ticket, i.e of a token-only connection,
+	// acquire it and answer the challenge.
+	if kerberosGeneration == 0 && c.kerberos != nil && isNegotiateChallenge(resp) {
+		c.Logger().Debugf("Client#Do: [%s %s] challenged for SPNEGO negotiation, acquiring the kerberos ticket", method, uri)
+		resp.Body.Close()
+		if err = c.kerberos.renew(kerberosGeneration); err != nil {
+			return nil, fmt.Errorf("client: SPNEGO negotiation failure: [%w]", err)
+		}
+		if stats != nil {
+			stats.resent++
+		}
+		if resp, err = c.sendRequest(ctx, method, uri, contentType, send, compress, options); err != nil {
+			return nil, err
+		}
+	} else if c.shouldRenewKerberos(resp) {
+		// the kerberos ticket expired, renew it and send the request once more, see `WithKerberosRenewal`.
+		c.Logger().Debugf("Client#Do: [%s %s] challenged again for SPNEGO negotiation, renewing the kerberos ticket", method, uri)
+		if err = c.kerberos.renew(kerberosGeneration); err == errKerberosRenewalLimited {
+			// the rejection stands.
+			c.Logger().Warnf("Client#Do: [%s %s] rejected, %v", method, uri, err)
+		} else {
+			resp.Body.Close()
+			if err != nil {
+				return nil, fmt.Errorf("client: kerberos ticket renewal failure: [%w]", err)
+			}
+			if stats != nil {
+				stats.resent++
+			}
+			if resp, err = c.sendRequest(ctx, method, uri, contentType, send, compress, options); err != nil {
+				return nil, err
+			}
+		}
+	} else if c.shouldRelogin(resp) {
+		// the opaque token may have expired, login again and send the request once more, see `relogin`.
+		// If the login fails the rejection stands, it may be a permission error of a valid token.
+		if renewed, err := c.relogin(token); err != nil {
+			c.Logger().Warnf("Client#Do: [%s %s] rejected with status code %d, %v", method, uri, resp.StatusCode, err)
+		} else if renewed {
+			c.Logger().Debugf("Client#Do: [%s %s] rejected with status code %d, sending it again with the renewed token", method, uri, resp.StatusCode)
+			resp.Body.Close()
+			if stats != nil {
+				stats.resent++
+			}
+			if resp, err = c.sendRequest(ctx, method, uri, contentType, send, compress, options); err != nil {
+				return nil, err
+			}
+		}
+	}
 
-	// send the request and check the response for any connection & authorization errors here.
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	if stats != nil {
+		stats.statusCode = resp.StatusCode
 	}
 
+	c.setLastResponse(resp)
+
 	if !isAuthorized(resp) {
 		resp.Body.Close() // close the body here so we don't have leaks.
 		return nil, ErrCredentialsMissing
@@ -224,7 +462,8 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 
 	if !isOK(resp) {
 		defer resp.Body.Close()
-		var errBody string
+		var errBody, serverMessage string
+		requestID := resp.Header.Get(requestIDHeaderKey)
 
 		if cType := resp.Header.Get(contentTypeHeaderKey); strings.Contains(cType, contentTypeJSON) ||
 			strings.Contains(cType, contentTypeSchemaJSON) {
@@ -236,6 +475,9 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 				return nil, err
 			}
 			errBody = jsonErr.Message
+			if requestID == "" {
+				requestID = jsonErr.RequestID
+			}
 
 			// or it might be a V2 JSON Error message.
 			if jsonErr.Message == "" {
@@ -255,6 +497,8 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 				}
 				errBody = strings.TrimSuffix(errBody, ", ")
 			}
+
+			serverMessage = errBody
 		}
 
 		if errBody == "" {
@@ -271,23 +515,107 @@ func (c *Client) Do(method, path, contentType string, send []byte, options ...Re
 			errBody = fmt.Sprintf("Response returned status code %d", resp.StatusCode)
 		}
 
-		return nil, NewResourceError(resp.StatusCode, uri, method, errBody)
+		resErr := NewResourceError(resp.StatusCode, uri, method, errBody)
+		resErr.serverMessage = serverMessage
+		resErr.requestID = requestID
+		return nil, resErr
 	}
 
 	return resp, nil
 }
 
+// sendRequest builds and sends a single request of the `DoContext`, the body is gzip compressed if "compress" is true.
+func (c *Client) sendRequest(ctx context.Context, method, uri, contentType string, send []byte, compress bool, options []RequestOption) (*http.Response, error) {
+	var body io.Reader = acquireBuffer(send)
+	if compress {
+		compressed, err := gzipCompress(send)
+		if err != nil {
+			return nil, err
+		}
+		// a *bytes.Reader body sets the request's Content-Length to the compressed length.
+		body = bytes.NewReader(compressed)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// set the token header.
+	if token := c.token(); token != "" {
+		req.Header.Set(xKafkaLensesTokenHeaderKey, token)
+	}
+
+	// set the content type if any.
+	if contentType != "" {
+		req.Header.Set(contentTypeHeaderKey, contentType)
+	}
+
+	if compress {
+		req.Header.Set(contentEncodingHeaderKey, gzipEncodingHeaderValue)
+	}
+
+	// response accept gzipped content.
+	if !c.responseCompressionDisabled {
+		req.Header.Add(acceptEncodingHeaderKey, gzipEncodingHeaderValue)
+	}
+
+	if c.kerberos != nil {
+		if err := c.kerberos.setSPNEGOHeader(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.PersistentRequestModifier != nil {
+		if err := c.PersistentRequestModifier(req); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, opt := range options {
+		if err = opt(req); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, intercept := range c.requestInterceptors {
+		intercept(req)
+	}
+
+	// here will print all the headers, the token and the rest of the `RedactHeaders` are masked.
+	if c.Config.Debug {
+		c.Logger().Debugf("Client#Do.req.Headers: %s", redactedHeader{c, req.Header})
+	}
+
+	if err = c.rateLimiter.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	// send the request and check the response for any connection & authorization errors here.
+	resp, err := c.client.Do(req)
+	for _, intercept := range c.responseInterceptors {
+		intercept(req, resp, err)
+	}
+
+	return resp, err
+}
+
 type gzipReadCloser struct {
 	respReader io.ReadCloser
 	gzipReader io.ReadCloser
 }
 
 func (rc *gzipReadCloser) Close() error {
+	var err error
 	if rc.gzipReader != nil {
-		defer rc.gzipReader.Close()
+		err = rc.gzipReader.Close()
 	}
 
-	return rc.respReader.Close()
+	if respErr := rc.respReader.Close(); respErr != nil {
+		return respErr
+	}
+
+	return err
 }
 
 func (rc *gzipReadCloser) Read(p []byte) (n int, err error) {
@@ -305,9 +633,14 @@ func (c *Client) acquireResponseBodyStream(resp *http.Response) (io.ReadCloser,
 		err    error
 	)
 
-	if encoding := resp.Header.Get(contentEncodingHeaderKey); encoding == gzipEncodingHeaderValue {
+	if encoding := resp.Header.Get(contentEncodingHeaderKey); strings.EqualFold(strings.TrimSpace(encoding), gzipEncodingHeaderValue) {
 		reader, err = gzip.NewReader(resp.Body)
+		if err == io.EOF {
+			// empty body, i.e a 204 or a HEAD response, nothing to decompress.
+			return resp.Body, nil
+		}
 		if err != nil {
+			resp.Body.Close()
 			return nil, fmt.Errorf("client: failed to read gzip compressed content, trace: [%v]", err)
 		}
 		// we wrap the gzipReader and the underline response reader
@@ -375,8 +708,12 @@ func (c *Client) ReadResponseBody(resp *http.Response) ([]byte, error) {
 		    }
 	*/
 
+	// always close the body, even if the read failed, but don't hide the read error.
 	b, err := ioutil.ReadAll(reader)
-	if err = reader.Close(); err != nil {
+	if closeErr := reader.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
 		return nil, err
 	}
 
@@ -385,17 +722,15 @@ func (c *Client) ReadResponseBody(resp *http.Response) ([]byte, error) {
 	// }
 
 	if c.Config.Debug {
-		rawBodyString := string(b)
-
 		if strings.Contains(resp.Header.Get(contentTypeHeaderKey), "text/html") {
-			// If debug will print the full body through "rawBodyString", but the error here is the same content,
+			// The debug log below prints the full body, but the error here is the same content,
 			// so no need to duplicate it.
 			// The error should be minimal in this case in order to be resolved by callers: `lenses.ErrUnknownResponse`, same for !debug.
 			err = ErrUnknownResponse
 		}
 
 		// print both body and error, because both of them may be formated by the `readResponseBody`'s caller.
-		golog.Debugf("Client#Do.resp:\n\tbody: %s\n\tstatus code: %d\n\terror: [%v]", rawBodyString, resp.StatusCode, err)
+		c.Logger().Debugf("Client#Do.resp:\n\tbody: %s\n\tstatus code: %d\n\terror: [%v]", redactedBody{c: c, body: b, secret: isLoginResponse(resp)}, resp.StatusCode, err)
 	}
 
 	// return the body.
@@ -415,7 +750,7 @@ func (c *Client) ReadJSON(resp *http.Response, valuePtr interface{}) error {
 
 	if c.Config.Debug {
 		if syntaxErr, ok := err.(*json.SyntaxError); ok {
-			golog.Errorf("Client#ReadJSON: syntax error at offset [%d]: [%s]", syntaxErr.Offset, syntaxErr.Error())
+			c.Logger().Errorf("Client#ReadJSON: syntax error at offset [%d]: [%s]", syntaxErr.Offset, syntaxErr.Error())
 		}
 	}
 	return err
@@ -424,7 +759,7 @@ func (c *Client) ReadJSON(resp *http.Response, valuePtr interface{}) error {
 // GetAccessToken returns the access token that
 // generated from the `OpenConnection` or given by the configuration.
 func (c *Client) GetAccessToken() string {
-	return c.Config.Token
+	return c.token()
 }
 
 const logoutPath = "api/logout?token="
@@ -432,12 +767,18 @@ const logoutPath = "api/logout?token="
 // Logout invalidates the token and revoke its access.
 // A new Client, using `OpenConnection`, should be created in order to continue after this call.
 func (c *Client) Logout() error {
-	if c.Config.Token == "" {
+	return c.LogoutContext(context.Background())
+}
+
+// LogoutContext is like `Logout` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) LogoutContext(ctx context.Context) error {
+	token := c.token()
+	if token == "" {
 		return ErrCredentialsMissing
 	}
 
-	path := logoutPath + c.Config.Token
-	resp, err := c.Do(http.MethodGet, path, "", nil)
+	path := logoutPath + token
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -445,7 +786,9 @@ func (c *Client) Logout() error {
 	return resp.Body.Close()
 }
 
-//QueryFiltering used to add query params in an API request
+// QueryFiltering used to add query params in an API request
+// i.e the paging of the paged list calls, see `GetTopicsPage`, where the `Name` selects the items whose name contains it.
+// Pages start from 1 and the `PageSize` defaults to the `DefaultPageSize`.
 type QueryFiltering struct {
 	PageSize     int
 	Page         int
@@ -482,9 +825,14 @@ const licensePath = "api/license"
 
 // GetLicenseInfo returns the license information for the connected lenses box.
 func (c *Client) GetLicenseInfo() (LicenseInfo, error) {
+	return c.GetLicenseInfoContext(context.Background())
+}
+
+// GetLicenseInfoContext is like `GetLicenseInfo` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetLicenseInfoContext(ctx context.Context) (LicenseInfo, error) {
 	var lc LicenseInfo
 
-	resp, err := c.Do(http.MethodGet, licensePath, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, licensePath, "", nil)
 	if err != nil {
 		return lc, err
 	}
@@ -604,8 +952,8 @@ type BoxURLConfigProperty struct {
 	URL string `json:"url"`
 }
 
-func (c *Client) getBoxConfig(ptr interface{}) error {
-	resp, err := c.Do(http.MethodGet, configPath, "", nil, func(r *http.Request) error {
+func (c *Client) getBoxConfig(ctx context.Context, ptr interface{}) error {
+	resp, err := c.DoContext(ctx, http.MethodGet, configPath, "", nil, func(r *http.Request) error {
 		r.Header.Set("Accept", "application/json, text/plain")
 		return nil
 	})
@@ -624,14 +972,24 @@ func (c *Client) getBoxConfig(ptr interface{}) error {
 //
 // If you just need to retrieve the execution mode of the box use the `GetExecutionMode` instead.
 func (c *Client) GetConfig() (cfg BoxConfig, err error) {
-	err = c.getBoxConfig(&cfg)
+	return c.GetConfigContext(context.Background())
+}
+
+// GetConfigContext is like `GetConfig` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConfigContext(ctx context.Context) (cfg BoxConfig, err error) {
+	err = c.getBoxConfig(ctx, &cfg)
 	return
 }
 
 // GetConfigEntry reads the lenses back-end configuration and sets the value of a key, based on "keys", to the "outPtr".
 func (c *Client) GetConfigEntry(outPtr interface{}, keys ...string) error {
+	return c.GetConfigEntryContext(context.Background(), outPtr, keys...)
+}
+
+// GetConfigEntryContext is like `GetConfigEntry` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConfigEntryContext(ctx context.Context, outPtr interface{}, keys ...string) error {
 	config := make(map[string]interface{})
-	err := c.getBoxConfig(&config)
+	err := c.getBoxConfig(ctx, &config)
 	if err != nil || len(config) == 0 {
 		return fmt.Errorf("[%s]: cannot be extracted: unable to retrieve the config: [%v]", keys, err)
 	}
@@ -701,7 +1059,12 @@ func (c *Client) GetConfigEntry(outPtr interface{}, keys ...string) error {
 // GetExecutionMode returns the execution mode, if not error returned
 // then the possible values are: `ExecutionModeInProc`, `ExecutionModeConnect` or `ExecutionModeKubernetes`.
 func (c *Client) GetExecutionMode() (ExecutionMode, error) {
-	cfg, err := c.GetConfig()
+	return c.GetExecutionModeContext(context.Background())
+}
+
+// GetExecutionModeContext is like `GetExecutionMode` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetExecutionModeContext(ctx context.Context) (ExecutionMode, error) {
+	cfg, err := c.GetConfigContext(ctx)
 	if err != nil {
 		return ExecutionModeInvalid, err
 	}
@@ -722,7 +1085,12 @@ const connectClustersKey = "lenses.kafka.connect.clusters"
 
 // GetConnectClusters returns the `lenses.connect.clusters` key from the lenses configuration (`GetConfig`).
 func (c *Client) GetConnectClusters() (clusters []ConnectCluster, err error) {
-	err = c.GetConfigEntry(&clusters, connectClustersKey)
+	return c.GetConnectClustersContext(context.Background())
+}
+
+// GetConnectClustersContext is like `GetConnectClusters` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectClustersContext(ctx context.Context) (clusters []ConnectCluster, err error) {
+	err = c.GetConfigEntryContext(ctx, &clusters, connectClustersKey)
 	return
 }
 
@@ -749,13 +1117,18 @@ const validateLSQLPath = "api/sql/validation?sql="
 
 // ValidateLSQL validates but not executes a specific LSQL.
 func (c *Client) ValidateLSQL(sql string) (v LSQLValidation, err error) {
+	return c.ValidateLSQLContext(context.Background(), sql)
+}
+
+// ValidateLSQLContext is like `ValidateLSQL` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) ValidateLSQLContext(ctx context.Context, sql string) (v LSQLValidation, err error) {
 	if sql == "" {
 		err = errSQLEmpty
 		return
 	}
 
 	path := validateLSQLPath + url.QueryEscape(sql)
-	resp, respErr := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -786,7 +1159,12 @@ type LSQLRunningQuery struct {
 
 // GetRunningQueries returns a list of the current sql running queries.
 func (c *Client) GetRunningQueries() ([]LSQLRunningQuery, error) {
-	resp, err := c.Do(http.MethodGet, queriesPath, "", nil)
+	return c.GetRunningQueriesContext(context.Background())
+}
+
+// GetRunningQueriesContext is like `GetRunningQueries` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetRunningQueriesContext(ctx context.Context) ([]LSQLRunningQuery, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, queriesPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -799,8 +1177,13 @@ func (c *Client) GetRunningQueries() ([]LSQLRunningQuery, error) {
 // CancelQuery stops a running query based on its ID.
 // It returns true whether it was cancelled otherwise false or/and error.
 func (c *Client) CancelQuery(id int64) (bool, error) {
+	return c.CancelQueryContext(context.Background(), id)
+}
+
+// CancelQueryContext is like `CancelQuery` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CancelQueryContext(ctx context.Context, id int64) (bool, error) {
 	path := fmt.Sprintf(queriesPath+"/%d", id)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return false, err
 	}
@@ -1015,9 +1398,14 @@ const topicsPath = "api/topics"
 
 // GetTopics returns the list of topics.
 func (c *Client) GetTopics() (topics []Topic, err error) {
+	return c.GetTopicsContext(context.Background())
+}
+
+// GetTopicsContext is like `GetTopics` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicsContext(ctx context.Context) (topics []Topic, err error) {
 	// # List of topics
 	// GET /api/topics
-	resp, respErr := c.Do(http.MethodGet, topicsPath, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, topicsPath, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -1029,7 +1417,12 @@ func (c *Client) GetTopics() (topics []Topic, err error) {
 
 // GetTopicsNames returns the list of topics' names.
 func (c *Client) GetTopicsNames() ([]string, error) {
-	topics, err := c.GetTopics()
+	return c.GetTopicsNamesContext(context.Background())
+}
+
+// GetTopicsNamesContext is like `GetTopicsNames` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicsNamesContext(ctx context.Context) ([]string, error) {
+	topics, err := c.GetTopicsContext(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1042,11 +1435,81 @@ func (c *Client) GetTopicsNames() ([]string, error) {
 	return topicNames, nil
 }
 
+const topicsPagedPath = "api/v1/kafka/topics"
+
+// TopicsPage is a page of topics, see `GetTopicsPage`.
+type TopicsPage struct {
+	Page   `yaml:",inline"`
+	Values []Topic `json:"values" yaml:"values"`
+}
+
+// GetTopicsPage returns a single page of topics, optionally filtered by topic name.
+func (c *Client) GetTopicsPage(opts QueryFiltering) (TopicsPage, error) {
+	return c.GetTopicsPageContext(context.Background(), opts)
+}
+
+// GetTopicsPageContext is like `GetTopicsPage` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicsPageContext(ctx context.Context, opts QueryFiltering) (page TopicsPage, err error) {
+	opts = opts.withDefaults()
+
+	// servers without the paged endpoint return all the topics at once.
+	if _, older := c.serverMajorBefore(pagedListsMajor); older {
+		topics, topicsErr := c.GetTopicsContext(ctx)
+		if topicsErr != nil {
+			err = topicsErr
+			return
+		}
+
+		if opts.Name != "" {
+			c.Logger().Debugf("Client#GetTopicsPage: the server has no paged topics endpoint, filtering the topics by [%s] on the client side", opts.Name)
+		}
+
+		page = pageTopics(topics, opts)
+		return
+	}
+
+	// # Page of topics
+	// GET /api/v1/kafka/topics?page=1&pageSize=100&topicName=filter
+	resp, respErr := c.DoContext(ctx, http.MethodGet, opts.path(topicsPagedPath, "topicName"), "", nil)
+	if respErr != nil {
+		err = respErr
+		return
+	}
+
+	err = c.ReadJSON(resp, &page)
+	page.Number = opts.Page
+	return
+}
+
+// WalkTopics fetches the topics page by page, starting from the `opts.Page`,
+// and calls the `fn` with the topics of each page as soon as it arrives.
+// It stops on the first error of the `fn`.
+func (c *Client) WalkTopics(opts QueryFiltering, fn func(topics []Topic) error) error {
+	return c.WalkTopicsContext(context.Background(), opts, fn)
+}
+
+// WalkTopicsContext is like `WalkTopics` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) WalkTopicsContext(ctx context.Context, opts QueryFiltering, fn func(topics []Topic) error) error {
+	return WalkPages(opts, func(opts QueryFiltering) (Page, error) {
+		page, err := c.GetTopicsPageContext(ctx, opts)
+		if err != nil {
+			return page.Page, err
+		}
+
+		return page.Page, fn(page.Values)
+	})
+}
+
 const topicsAvailableConfigKeysPath = "api/configs/default/topics/keys"
 
 // GetAvailableTopicConfigKeys retrieves a list of available configs for topics.
 func (c *Client) GetAvailableTopicConfigKeys() ([]string, error) {
-	resp, err := c.Do(http.MethodGet, topicsAvailableConfigKeysPath, "", nil)
+	return c.GetAvailableTopicConfigKeysContext(context.Background())
+}
+
+// GetAvailableTopicConfigKeysContext is like `GetAvailableTopicConfigKeys` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAvailableTopicConfigKeysContext(ctx context.Context) ([]string, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, topicsAvailableConfigKeysPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1106,7 +1569,12 @@ const (
 
 // GetTopicsMetadata retrieves and returns all the topics' available metadata.
 func (c *Client) GetTopicsMetadata() ([]TopicMetadata, error) {
-	resp, err := c.Do(http.MethodGet, topicsMetadataPath, "", nil)
+	return c.GetTopicsMetadataContext(context.Background())
+}
+
+// GetTopicsMetadataContext is like `GetTopicsMetadata` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicsMetadataContext(ctx context.Context) ([]TopicMetadata, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, topicsMetadataPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -1119,6 +1587,11 @@ func (c *Client) GetTopicsMetadata() ([]TopicMetadata, error) {
 
 // GetTopicMetadata retrieves and returns a topic's metadata.
 func (c *Client) GetTopicMetadata(topicName string) (TopicMetadata, error) {
+	return c.GetTopicMetadataContext(context.Background(), topicName)
+}
+
+// GetTopicMetadataContext is like `GetTopicMetadata` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicMetadataContext(ctx context.Context, topicName string) (TopicMetadata, error) {
 	var meta TopicMetadata
 
 	if topicName == "" {
@@ -1126,7 +1599,7 @@ func (c *Client) GetTopicMetadata(topicName string) (TopicMetadata, error) {
 	}
 
 	path := fmt.Sprintf(topicMetadataPath, topicName)
-	resp, err := c.Do(http.MethodGet, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if err != nil {
 		return meta, err
 	}
@@ -1137,6 +1610,11 @@ func (c *Client) GetTopicMetadata(topicName string) (TopicMetadata, error) {
 
 // CreateOrUpdateTopicMetadata adds or updates an existing topic metadata.
 func (c *Client) CreateOrUpdateTopicMetadata(metadata TopicMetadata) error {
+	return c.CreateOrUpdateTopicMetadataContext(context.Background(), metadata)
+}
+
+// CreateOrUpdateTopicMetadataContext is like `CreateOrUpdateTopicMetadata` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateTopicMetadataContext(ctx context.Context, metadata TopicMetadata) error {
 	if metadata.TopicName == "" {
 		return errRequired("metadata.TopicName")
 	}
@@ -1153,7 +1631,7 @@ func (c *Client) CreateOrUpdateTopicMetadata(metadata TopicMetadata) error {
 		path += "&valueSchema" + string(metadata.ValueSchemaRaw)
 	}
 
-	resp, err := c.Do(http.MethodPost, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPost, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1163,12 +1641,17 @@ func (c *Client) CreateOrUpdateTopicMetadata(metadata TopicMetadata) error {
 
 // DeleteTopicMetadata removes an existing topic metadata.
 func (c *Client) DeleteTopicMetadata(topicName string) error {
+	return c.DeleteTopicMetadataContext(context.Background(), topicName)
+}
+
+// DeleteTopicMetadataContext is like `DeleteTopicMetadata` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteTopicMetadataContext(ctx context.Context, topicName string) error {
 	if topicName == "" {
 		return errRequired("topicName")
 	}
 
 	path := fmt.Sprintf(topicMetadataPath, topicName)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1193,6 +1676,11 @@ type CreateTopicPayload struct {
 //
 // Read more at: https://docs.lenses.io/dev/lenses-apis/rest-api/index.html#create-topic
 func (c *Client) CreateTopic(topicName string, replication, partitions int, configs KV) error {
+	return c.CreateTopicContext(context.Background(), topicName, replication, partitions, configs)
+}
+
+// CreateTopicContext is like `CreateTopic` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateTopicContext(ctx context.Context, topicName string, replication, partitions int, configs KV) error {
 	if topicName == "" {
 		return errRequired("topicName")
 	}
@@ -1210,7 +1698,7 @@ func (c *Client) CreateTopic(topicName string, replication, partitions int, conf
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPost, topicsPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPost, topicsPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -1228,12 +1716,17 @@ const (
 //
 // Read more at: https://docs.lenses.io/dev/lenses-apis/rest-api/index.html#delete-topic
 func (c *Client) DeleteTopic(topicName string) error {
+	return c.DeleteTopicContext(context.Background(), topicName)
+}
+
+// DeleteTopicContext is like `DeleteTopic` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteTopicContext(ctx context.Context, topicName string) error {
 	if topicName == "" {
 		return errRequired("topicName")
 	}
 
 	path := fmt.Sprintf(topicPath, topicName)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1247,6 +1740,11 @@ func (c *Client) DeleteTopic(topicName string) error {
 //
 // All input arguments are required.
 func (c *Client) DeleteTopicRecords(topicName string, fromPartition int, toOffset int64) error {
+	return c.DeleteTopicRecordsContext(context.Background(), topicName, fromPartition, toOffset)
+}
+
+// DeleteTopicRecordsContext is like `DeleteTopicRecords` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteTopicRecordsContext(ctx context.Context, topicName string, fromPartition int, toOffset int64) error {
 	if topicName == "" {
 		return errRequired("topicName")
 	}
@@ -1257,7 +1755,7 @@ func (c *Client) DeleteTopicRecords(topicName string, fromPartition int, toOffse
 		return NewResourceError(http.StatusBadRequest, c.Config.Host+"/"+path, "DELETE", "offset and partition should be positive numbers")
 	}
 
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1284,6 +1782,11 @@ type UpdateConfigs struct {
 //
 // Read more at: https://docs.lenses.io/dev/lenses-apis/rest-api/index.html#update-topic-configuration
 func (c *Client) UpdateTopic(topicName string, configsSlice []KV) error {
+	return c.UpdateTopicContext(context.Background(), topicName, configsSlice)
+}
+
+// UpdateTopicContext is like `UpdateTopic` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateTopicContext(ctx context.Context, topicName string, configsSlice []KV) error {
 	if topicName == "" {
 		return errRequired("topicName")
 	}
@@ -1303,7 +1806,7 @@ func (c *Client) UpdateTopic(topicName string, configsSlice []KV) error {
 	}
 
 	path := fmt.Sprintf(updateTopicConfigPath, topicName)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -1412,13 +1915,18 @@ type PartitionMessage struct {
 //
 // Read more at: https://docs.lenses.io/dev/lenses-apis/rest-api/index.html#get-topic-information
 func (c *Client) GetTopic(topicName string) (topic Topic, err error) {
+	return c.GetTopicContext(context.Background(), topicName)
+}
+
+// GetTopicContext is like `GetTopic` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicContext(ctx context.Context, topicName string) (topic Topic, err error) {
 	if topicName == "" {
 		err = errRequired("topicName")
 		return
 	}
 
 	path := fmt.Sprintf(topicPath, topicName)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -1456,6 +1964,11 @@ func (p *ProcessorStream) ProcessorAsRequest() CreateProcessorPayload {
 
 // CreateProcessor creates a new LSQL processor.
 func (c *Client) CreateProcessor(name string, sql string, runners int, clusterName, namespace, pipeline string) error {
+	return c.CreateProcessorContext(context.Background(), name, sql, runners, clusterName, namespace, pipeline)
+}
+
+// CreateProcessorContext is like `CreateProcessor` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateProcessorContext(ctx context.Context, name string, sql string, runners int, clusterName, namespace, pipeline string) error {
 	if name == "" {
 		return errRequired("name")
 	}
@@ -1486,7 +1999,7 @@ func (c *Client) CreateProcessor(name string, sql string, runners int, clusterNa
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPost, processorsPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPost, processorsPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -1556,9 +2069,14 @@ type (
 
 // GetProcessors returns a list of all available LSQL processors.
 func (c *Client) GetProcessors() (ProcessorsResult, error) {
+	return c.GetProcessorsContext(context.Background())
+}
+
+// GetProcessorsContext is like `GetProcessors` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetProcessorsContext(ctx context.Context) (ProcessorsResult, error) {
 	var res ProcessorsResult
 
-	resp, err := c.Do(http.MethodGet, processorsPath, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, processorsPath, "", nil)
 	if err != nil {
 		return res, err
 	}
@@ -1570,12 +2088,53 @@ func (c *Client) GetProcessors() (ProcessorsResult, error) {
 	return res, nil
 }
 
+// GetProcessorsInNamespace is like `GetProcessors` but it returns only the processors of a kubernetes namespace,
+// all of them if the "namespace" is empty. The servers which support it filter the processors,
+// the rest are filtered here.
+func (c *Client) GetProcessorsInNamespace(namespace string) (ProcessorsResult, error) {
+	return c.GetProcessorsInNamespaceContext(context.Background(), namespace)
+}
+
+// GetProcessorsInNamespaceContext is like `GetProcessorsInNamespace` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetProcessorsInNamespaceContext(ctx context.Context, namespace string) (ProcessorsResult, error) {
+	if namespace == "" {
+		return c.GetProcessorsContext(ctx)
+	}
+
+	var res ProcessorsResult
+
+	path := processorsPath + "?namespace=" + url.QueryEscape(namespace)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
+	if err != nil {
+		return res, err
+	}
+
+	if err = c.ReadJSON(resp, &res); err != nil {
+		return res, err
+	}
+
+	streams := res.Streams[:0]
+	for _, stream := range res.Streams {
+		if stream.Namespace == namespace {
+			streams = append(streams, stream)
+		}
+	}
+	res.Streams = streams
+
+	return res, nil
+}
+
 // GetProcessor returns a processor from Lenses for the given id
 func (c *Client) GetProcessor(processorID string) (ProcessorStream, error) {
+	return c.GetProcessorContext(context.Background(), processorID)
+}
+
+// GetProcessorContext is like `GetProcessor` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetProcessorContext(ctx context.Context, processorID string) (ProcessorStream, error) {
 	var res ProcessorStream
 
 	path := fmt.Sprintf(processorPath, processorID)
-	resp, err := c.Do(http.MethodGet, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if err != nil {
 		return res, err
 	}
@@ -1593,11 +2152,16 @@ func (c *Client) GetProcessor(processorID string) (ProcessorStream, error) {
 // Fill the id or name in any case.
 // Fill the clusterName and namespace when in KUBERNETES execution mode.
 func (c *Client) LookupProcessorIdentifier(id, name, clusterName, namespace string) (string, error) {
+	return c.LookupProcessorIdentifierContext(context.Background(), id, name, clusterName, namespace)
+}
+
+// LookupProcessorIdentifierContext is like `LookupProcessorIdentifier` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) LookupProcessorIdentifierContext(ctx context.Context, id, name, clusterName, namespace string) (string, error) {
 	if name == "" && id == "" {
 		return "", fmt.Errorf("LookupProcessorIdentifier: name or id are missing")
 	}
 
-	mode, err := c.GetExecutionMode()
+	mode, err := c.GetExecutionModeContext(ctx)
 	if err != nil {
 		return "", err // unable to determinate the lenses execution mode.
 	}
@@ -1613,7 +2177,7 @@ func (c *Client) LookupProcessorIdentifier(id, name, clusterName, namespace stri
 			identifier = id
 		} else if name != "" {
 			// get the id by looping over all available processors.
-			result, err := c.GetProcessors()
+			result, err := c.GetProcessorsContext(ctx)
 			if err != nil {
 				return "", err
 			}
@@ -1653,12 +2217,17 @@ const processorPausePath = processorPath + "/pause"
 // PauseProcessor pauses a processor.
 // See `LookupProcessorIdentifier`.
 func (c *Client) PauseProcessor(processorID string) error {
+	return c.PauseProcessorContext(context.Background(), processorID)
+}
+
+// PauseProcessorContext is like `PauseProcessor` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) PauseProcessorContext(ctx context.Context, processorID string) error {
 	if processorID == "" {
 		return errRequired("processorID")
 	}
 
 	path := fmt.Sprintf(processorPath+"/pause", processorID)
-	resp, err := c.Do(http.MethodPut, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1670,12 +2239,17 @@ const processorResumePath = processorPath + "/resume"
 // ResumeProcessor resumes a processor.
 // See `LookupProcessorIdentifier`.
 func (c *Client) ResumeProcessor(processorID string) error {
+	return c.ResumeProcessorContext(context.Background(), processorID)
+}
+
+// ResumeProcessorContext is like `ResumeProcessor` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) ResumeProcessorContext(ctx context.Context, processorID string) error {
 	if processorID == "" {
 		return errRequired("processorID")
 	}
 
 	path := fmt.Sprintf(processorResumePath, processorID)
-	resp, err := c.Do(http.MethodPut, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1687,6 +2261,11 @@ const processorUpdateRunnersPath = processorPath + "/scale/%d"
 // UpdateProcessorRunners scales a processor to "numberOfRunners".
 // See `LookupProcessorIdentifier`.
 func (c *Client) UpdateProcessorRunners(processorID string, numberOfRunners int) error {
+	return c.UpdateProcessorRunnersContext(context.Background(), processorID, numberOfRunners)
+}
+
+// UpdateProcessorRunnersContext is like `UpdateProcessorRunners` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateProcessorRunnersContext(ctx context.Context, processorID string, numberOfRunners int) error {
 	if processorID == "" {
 		return errRequired("processorID")
 	}
@@ -1696,7 +2275,7 @@ func (c *Client) UpdateProcessorRunners(processorID string, numberOfRunners int)
 	}
 
 	path := fmt.Sprintf(processorUpdateRunnersPath, processorID, numberOfRunners)
-	resp, err := c.Do(http.MethodPut, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1706,12 +2285,17 @@ func (c *Client) UpdateProcessorRunners(processorID string, numberOfRunners int)
 // DeleteProcessor removes a processor based on its name or the full id,
 // it depends on lenses execution mode, use the `LookupProcessorIdentifier`.
 func (c *Client) DeleteProcessor(processorNameOrID string) error {
+	return c.DeleteProcessorContext(context.Background(), processorNameOrID)
+}
+
+// DeleteProcessorContext is like `DeleteProcessor` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteProcessorContext(ctx context.Context, processorNameOrID string) error {
 	if processorNameOrID == "" {
 		return errRequired("processorNameOrID")
 	}
 
 	path := fmt.Sprintf(processorPath, processorNameOrID)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -1769,6 +2353,11 @@ const connectorsPath = "api/proxy-connect/%s/connectors"
 //
 // Visit https://docs.lenses.io/dev/lenses-apis/rest-api/index.html#connector-api
 func (c *Client) GetConnectors(clusterName string) (names []string, err error) {
+	return c.GetConnectorsContext(context.Background(), clusterName)
+}
+
+// GetConnectorsContext is like `GetConnectors` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorsContext(ctx context.Context, clusterName string) (names []string, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -1777,7 +2366,7 @@ func (c *Client) GetConnectors(clusterName string) (names []string, err error) {
 	// # List active connectors
 	// GET /api/proxy-connect/(string: clusterName)/connectors
 	path := fmt.Sprintf(connectorsPath, clusterName)
-	resp, respErr := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -1836,6 +2425,11 @@ func (c *CreateUpdateConnectorPayload) ApplyAndValidateName() error {
 //
 // Look `UpdateConnector` too.
 func (c *Client) CreateConnector(clusterName, name string, config ConnectorConfig) (connector Connector, err error) {
+	return c.CreateConnectorContext(context.Background(), clusterName, name, config)
+}
+
+// CreateConnectorContext is like `CreateConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateConnectorContext(ctx context.Context, clusterName, name string, config ConnectorConfig) (connector Connector, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -1857,7 +2451,7 @@ func (c *Client) CreateConnector(clusterName, name string, config ConnectorConfi
 	// # Create new connector
 	// POST /api/proxy-connect/(string: clusterName)/connectors [CONNECTOR_CONFIG]
 	path := fmt.Sprintf(connectorsPath, clusterName)
-	resp, respErr := c.Do(http.MethodPost, path, contentTypeJSON, send)
+	resp, respErr := c.DoContext(ctx, http.MethodPost, path, contentTypeJSON, send)
 	if respErr != nil {
 		err = respErr
 		return
@@ -1873,6 +2467,11 @@ func (c *Client) CreateConnector(clusterName, name string, config ConnectorConfi
 // It returns information about the connector after the change has been made
 // and an indicator if that connector was created or just configuration update.
 func (c *Client) UpdateConnector(clusterName, name string, config ConnectorConfig) (connector Connector, err error) {
+	return c.UpdateConnectorContext(context.Background(), clusterName, name, config)
+}
+
+// UpdateConnectorContext is like `UpdateConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateConnectorContext(ctx context.Context, clusterName, name string, config ConnectorConfig) (connector Connector, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -1892,7 +2491,7 @@ func (c *Client) UpdateConnector(clusterName, name string, config ConnectorConfi
 	// # Set connector config
 	// PUT /api/proxy-connect/(string: clusterName)/connectors/(string: name)/config
 	path := fmt.Sprintf(connectorPath+"/config", clusterName, name)
-	resp, respErr := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, respErr := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if respErr != nil {
 		err = respErr
 		return
@@ -1909,6 +2508,11 @@ const connectorPath = connectorsPath + "/%s"
 // GetConnector returns the information about the connector.
 // See `Connector` type
 func (c *Client) GetConnector(clusterName, name string) (connector Connector, err error) {
+	return c.GetConnectorContext(context.Background(), clusterName, name)
+}
+
+// GetConnectorContext is like `GetConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorContext(ctx context.Context, clusterName, name string) (connector Connector, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -1922,7 +2526,7 @@ func (c *Client) GetConnector(clusterName, name string) (connector Connector, er
 	// # Get information about a specific connector
 	// GET /api/proxy-connect/(string: clusterName)/connectors/(string: name)
 	path := fmt.Sprintf(connectorPath, clusterName, name)
-	resp, respErr := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -1935,6 +2539,11 @@ func (c *Client) GetConnector(clusterName, name string) (connector Connector, er
 
 // GetConnectorConfig returns the configuration for the connector.
 func (c *Client) GetConnectorConfig(clusterName, name string) (cfg ConnectorConfig, err error) {
+	return c.GetConnectorConfigContext(context.Background(), clusterName, name)
+}
+
+// GetConnectorConfigContext is like `GetConnectorConfig` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorConfigContext(ctx context.Context, clusterName, name string) (cfg ConnectorConfig, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -1948,7 +2557,7 @@ func (c *Client) GetConnectorConfig(clusterName, name string) (cfg ConnectorConf
 	// # Get connector config
 	// GET /api/proxy-connect/(string: clusterName)/connectors/(string: name)/config
 	path := fmt.Sprintf(connectorPath, clusterName, name)
-	resp, respErr := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2003,6 +2612,11 @@ type (
 // failed or paused, which worker it is assigned to, error information if it has failed,
 // and the state of all its tasks.
 func (c *Client) GetConnectorStatus(clusterName, name string) (cs ConnectorStatus, err error) {
+	return c.GetConnectorStatusContext(context.Background(), clusterName, name)
+}
+
+// GetConnectorStatusContext is like `GetConnectorStatus` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorStatusContext(ctx context.Context, clusterName, name string) (cs ConnectorStatus, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -2016,7 +2630,7 @@ func (c *Client) GetConnectorStatus(clusterName, name string) (cs ConnectorStatu
 	// # Get connector status
 	// GET /api/proxy-connect/(string: clusterName)/connectors/(string: name)/status
 	path := fmt.Sprintf(connectorPath+"/status", clusterName, name)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2029,6 +2643,11 @@ func (c *Client) GetConnectorStatus(clusterName, name string) (cs ConnectorStatu
 // PauseConnector pauses the connector and its tasks, which stops message processing until the connector is resumed.
 // This call asynchronous and the tasks will not transition to PAUSED state at the same time.
 func (c *Client) PauseConnector(clusterName, name string) error {
+	return c.PauseConnectorContext(context.Background(), clusterName, name)
+}
+
+// PauseConnectorContext is like `PauseConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) PauseConnectorContext(ctx context.Context, clusterName, name string) error {
 	if clusterName == "" {
 		return errRequired("clusterName")
 	}
@@ -2040,7 +2659,7 @@ func (c *Client) PauseConnector(clusterName, name string) error {
 	// # Pause a connector
 	// PUT /api/proxy-connect/(string: clusterName)/connectors/(string: name)/pause
 	path := fmt.Sprintf(connectorPath+"/pause", clusterName, name)
-	resp, err := c.Do(http.MethodPut, path, "", nil) // the success status is 202 Accepted.
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", nil) // the success status is 202 Accepted.
 	if err != nil {
 		return err
 	}
@@ -2051,6 +2670,11 @@ func (c *Client) PauseConnector(clusterName, name string) error {
 // ResumeConnector resumes a paused connector or do nothing if the connector is not paused.
 // This call asynchronous and the tasks will not transition to RUNNING state at the same time.
 func (c *Client) ResumeConnector(clusterName, name string) error {
+	return c.ResumeConnectorContext(context.Background(), clusterName, name)
+}
+
+// ResumeConnectorContext is like `ResumeConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) ResumeConnectorContext(ctx context.Context, clusterName, name string) error {
 	if clusterName == "" {
 		return errRequired("clusterName")
 	}
@@ -2062,7 +2686,7 @@ func (c *Client) ResumeConnector(clusterName, name string) error {
 	// # Resume a paused connector
 	// PUT /api/proxy-connect/(string: clusterName)/connectors/(string: name)/resume
 	path := fmt.Sprintf(connectorPath+"/resume", clusterName, name)
-	resp, err := c.Do(http.MethodPut, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -2073,6 +2697,11 @@ func (c *Client) ResumeConnector(clusterName, name string) error {
 // RestartConnector restarts the connector and its tasks.
 // It returns a 409 (Conflict) status code error if rebalance is in process.
 func (c *Client) RestartConnector(clusterName, name string) error {
+	return c.RestartConnectorContext(context.Background(), clusterName, name)
+}
+
+// RestartConnectorContext is like `RestartConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) RestartConnectorContext(ctx context.Context, clusterName, name string) error {
 	if clusterName == "" {
 		return errRequired("clusterName")
 	}
@@ -2084,7 +2713,7 @@ func (c *Client) RestartConnector(clusterName, name string) error {
 	// # Restart a connector
 	// POST /api/proxy-connect/(string: clusterName)/connectors/(string: name)/restart
 	path := fmt.Sprintf(connectorPath+"/restart", clusterName, name)
-	resp, err := c.Do(http.MethodPost, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPost, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -2095,6 +2724,11 @@ func (c *Client) RestartConnector(clusterName, name string) error {
 // DeleteConnector deletes a connector, halting all tasks and deleting its configuration.
 // It return a 409 (Conflict) status code error if rebalance is in process.
 func (c *Client) DeleteConnector(clusterName, name string) error {
+	return c.DeleteConnectorContext(context.Background(), clusterName, name)
+}
+
+// DeleteConnectorContext is like `DeleteConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteConnectorContext(ctx context.Context, clusterName, name string) error {
 	if clusterName == "" {
 		return errRequired("clusterName")
 	}
@@ -2106,7 +2740,7 @@ func (c *Client) DeleteConnector(clusterName, name string) error {
 	// # Remove a running connector
 	// DELETE /api/proxy-connect/(string: clusterName)/connectors/(string: name)
 	path := fmt.Sprintf(connectorPath, clusterName, name)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -2121,6 +2755,11 @@ const (
 
 // GetConnectorTasks returns a list of tasks currently running for the connector.
 func (c *Client) GetConnectorTasks(clusterName, name string) (m []map[string]interface{}, err error) {
+	return c.GetConnectorTasksContext(context.Background(), clusterName, name)
+}
+
+// GetConnectorTasksContext is like `GetConnectorTasks` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorTasksContext(ctx context.Context, clusterName, name string) (m []map[string]interface{}, err error) {
 	if clusterName == "" {
 		return nil, errRequired("clusterName")
 	}
@@ -2132,7 +2771,7 @@ func (c *Client) GetConnectorTasks(clusterName, name string) (m []map[string]int
 	// # Get list of connector tasks
 	// GET /api/proxy-connect/(string: clusterName)/connectors/(string: name)/tasks
 	path := fmt.Sprintf(tasksPath, clusterName, name)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2144,6 +2783,11 @@ func (c *Client) GetConnectorTasks(clusterName, name string) (m []map[string]int
 
 // GetConnectorTaskStatus returns a task’s status.
 func (c *Client) GetConnectorTaskStatus(clusterName, name string, taskID int) (cst ConnectorStatusTask, err error) {
+	return c.GetConnectorTaskStatusContext(context.Background(), clusterName, name, taskID)
+}
+
+// GetConnectorTaskStatusContext is like `GetConnectorTaskStatus` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorTaskStatusContext(ctx context.Context, clusterName, name string, taskID int) (cst ConnectorStatusTask, err error) {
 	if clusterName == "" {
 		err = errRequired("clusterName")
 		return
@@ -2157,7 +2801,7 @@ func (c *Client) GetConnectorTaskStatus(clusterName, name string, taskID int) (c
 	// # Get current status of a task
 	// GET /connectors/(string: name)/tasks/(int: taskid)/status
 	path := fmt.Sprintf(taskPath+"/status", clusterName, name, taskID)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2169,6 +2813,11 @@ func (c *Client) GetConnectorTaskStatus(clusterName, name string, taskID int) (c
 
 // RestartConnectorTask restarts an individual task.
 func (c *Client) RestartConnectorTask(clusterName, name string, taskID int) error {
+	return c.RestartConnectorTaskContext(context.Background(), clusterName, name, taskID)
+}
+
+// RestartConnectorTaskContext is like `RestartConnectorTask` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) RestartConnectorTaskContext(ctx context.Context, clusterName, name string, taskID int) error {
 	if clusterName == "" {
 		return errRequired("clusterName")
 	}
@@ -2180,7 +2829,7 @@ func (c *Client) RestartConnectorTask(clusterName, name string, taskID int) erro
 	// # Restart a connector task
 	// POST /api/proxy-connect/(string: clusterName)/connectors/(string: name)/tasks/(int: taskid)/restart
 	path := fmt.Sprintf(taskPath+"/restart", clusterName, name, taskID)
-	resp, err := c.Do(http.MethodPost, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPost, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -2205,6 +2854,11 @@ const pluginsPath = "api/proxy-connect/%s/connector-plugins"
 // which means it is possible to see inconsistent results,
 // especially during a rolling upgrade if you add new connector jars.
 func (c *Client) GetConnectorPlugins(clusterName string) (cp []ConnectorPlugin, err error) {
+	return c.GetConnectorPluginsContext(context.Background(), clusterName)
+}
+
+// GetConnectorPluginsContext is like `GetConnectorPlugins` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectorPluginsContext(ctx context.Context, clusterName string) (cp []ConnectorPlugin, err error) {
 	if clusterName == "" {
 		return nil, errRequired("clusterName")
 	}
@@ -2212,7 +2866,7 @@ func (c *Client) GetConnectorPlugins(clusterName string) (cp []ConnectorPlugin,
 	// # List available connector plugins
 	// GET /api/proxy-connect/(string: clusterName)/connector-plugins
 	path := fmt.Sprintf(pluginsPath, clusterName)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2233,9 +2887,14 @@ const subjectsPath = "api/proxy-sr/subjects"
 
 // GetSubjects returns a list of the available subjects(schemas).
 func (c *Client) GetSubjects() (subjects []string, err error) {
+	return c.GetSubjectsContext(context.Background())
+}
+
+// GetSubjectsContext is like `GetSubjects` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetSubjectsContext(ctx context.Context) (subjects []string, err error) {
 	// # List all available subjects
 	// GET /api/proxy-sr/subjects
-	resp, respErr := c.Do(http.MethodGet, subjectsPath, "", nil, schemaAPIOption)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, subjectsPath, "", nil, schemaAPIOption)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2249,6 +2908,11 @@ const subjectPath = subjectsPath + "/%s"
 
 // GetSubjectVersions returns all the versions of a subject(schema) based on its name.
 func (c *Client) GetSubjectVersions(subject string) (versions []int, err error) {
+	return c.GetSubjectVersionsContext(context.Background(), subject)
+}
+
+// GetSubjectVersionsContext is like `GetSubjectVersions` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetSubjectVersionsContext(ctx context.Context, subject string) (versions []int, err error) {
 	if subject == "" {
 		err = errRequired("subject")
 		return
@@ -2257,7 +2921,7 @@ func (c *Client) GetSubjectVersions(subject string) (versions []int, err error)
 	// # List all versions of a particular subject
 	// GET /api/proxy-sr/subjects/(string: subject)/versions
 	path := fmt.Sprintf(subjectPath, subject+"/versions")
-	resp, respErr := c.Do(http.MethodGet, path, "", nil, schemaAPIOption)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil, schemaAPIOption)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2271,6 +2935,11 @@ func (c *Client) GetSubjectVersions(subject string) (versions []int, err error)
 // It is recommended to use this API only when a topic needs to be recycled or in development environment.
 // Returns the versions of the schema deleted under this subject.
 func (c *Client) DeleteSubject(subject string) (versions []int, err error) {
+	return c.DeleteSubjectContext(context.Background(), subject)
+}
+
+// DeleteSubjectContext is like `DeleteSubject` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteSubjectContext(ctx context.Context, subject string) (versions []int, err error) {
 	if subject == "" {
 		err = errRequired("subject")
 		return
@@ -2278,7 +2947,7 @@ func (c *Client) DeleteSubject(subject string) (versions []int, err error) {
 
 	// DELETE /api/proxy-sr/subjects/(string: subject)
 	path := fmt.Sprintf(subjectPath, subject)
-	resp, respErr := c.Do(http.MethodDelete, path, "", nil, schemaAPIOption)
+	resp, respErr := c.DoContext(ctx, http.MethodDelete, path, "", nil, schemaAPIOption)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2297,10 +2966,15 @@ const schemaPath = "api/proxy-sr/schemas/ids/%d"
 // GetSchema returns the Auro schema string identified by the id.
 // id (int) – the globally unique identifier of the schema.
 func (c *Client) GetSchema(subjectID int) (string, error) {
+	return c.GetSchemaContext(context.Background(), subjectID)
+}
+
+// GetSchemaContext is like `GetSchema` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetSchemaContext(ctx context.Context, subjectID int) (string, error) {
 	// # Get the schema for a particular subject id
 	// GET /api/proxy-sr/schemas/ids/{int: id}
 	path := fmt.Sprintf(schemaPath, subjectID)
-	resp, err := c.Do(http.MethodGet, path, "", nil, schemaAPIOption)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil, schemaAPIOption)
 	if err != nil {
 		return "", err
 	}
@@ -2385,7 +3059,7 @@ func checkSchemaVersionID(versionID interface{}) error {
 // the version as integer and it will retrieve by a specific version.
 //
 // See `GetLatestSchema` and `GetSchemaAtVersion` instead.
-func (c *Client) getSubjectSchemaAtVersion(subject string, versionID interface{}) (s Schema, err error) {
+func (c *Client) getSubjectSchemaAtVersion(ctx context.Context, subject string, versionID interface{}) (s Schema, err error) {
 	if subject == "" {
 		err = errRequired("subject")
 		return
@@ -2398,7 +3072,7 @@ func (c *Client) getSubjectSchemaAtVersion(subject string, versionID interface{}
 	// # Get the schema at a particular version
 	// GET /api/proxy-sr/subjects/(string: subject)/versions/(versionId: "latest" | int)
 	path := fmt.Sprintf(subjectPath+"/versions/%v", subject, versionID)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil, schemaAPIOption)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil, schemaAPIOption)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2411,13 +3085,23 @@ func (c *Client) getSubjectSchemaAtVersion(subject string, versionID interface{}
 // GetLatestSchema returns the latest version of a schema.
 // See `GetSchemaAtVersion` to retrieve a subject schema by a specific version.
 func (c *Client) GetLatestSchema(subject string) (Schema, error) {
-	return c.getSubjectSchemaAtVersion(subject, SchemaLatestVersion)
+	return c.GetLatestSchemaContext(context.Background(), subject)
+}
+
+// GetLatestSchemaContext is like `GetLatestSchema` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetLatestSchemaContext(ctx context.Context, subject string) (Schema, error) {
+	return c.getSubjectSchemaAtVersion(ctx, subject, SchemaLatestVersion)
 }
 
 // GetSchemaAtVersion returns a specific version of a schema.
 // See `GetLatestSchema` to retrieve the latest schema.
 func (c *Client) GetSchemaAtVersion(subject string, versionID int) (Schema, error) {
-	return c.getSubjectSchemaAtVersion(subject, versionID)
+	return c.GetSchemaAtVersionContext(context.Background(), subject, versionID)
+}
+
+// GetSchemaAtVersionContext is like `GetSchemaAtVersion` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetSchemaAtVersionContext(ctx context.Context, subject string, versionID int) (Schema, error) {
+	return c.getSubjectSchemaAtVersion(ctx, subject, versionID)
 }
 
 type idOnlyJSON struct {
@@ -2429,6 +3113,11 @@ type idOnlyJSON struct {
 // this schema from the schemas resource and is different from
 // the schema’s version which is associated with that name.
 func (c *Client) RegisterSchema(subject string, avroSchema string) (int, error) {
+	return c.RegisterSchemaContext(context.Background(), subject, avroSchema)
+}
+
+// RegisterSchemaContext is like `RegisterSchema` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) RegisterSchemaContext(ctx context.Context, subject string, avroSchema string) (int, error) {
 	if subject == "" {
 		return 0, errRequired("subject")
 	}
@@ -2449,7 +3138,7 @@ func (c *Client) RegisterSchema(subject string, avroSchema string) (int, error)
 	// POST /api/proxy-sr/subjects/(string: subject)/versions
 
 	path := fmt.Sprintf(subjectPath+"/versions", subject)
-	resp, err := c.Do(http.MethodPost, path, contentTypeSchemaJSON, send, schemaAPIOption)
+	resp, err := c.DoContext(ctx, http.MethodPost, path, contentTypeSchemaJSON, send, schemaAPIOption)
 	if err != nil {
 		return 0, err
 	}
@@ -2461,7 +3150,7 @@ func (c *Client) RegisterSchema(subject string, avroSchema string) (int, error)
 
 // deleteSubjectSchemaVersion deletes a specific version of the schema registered under this subject.
 // It's being used in `DeleteSchemaVersion` and `DeleteLatestSchemaVersion`.
-func (c *Client) deleteSubjectSchemaVersion(subject string, versionID interface{}) (int, error) {
+func (c *Client) deleteSubjectSchemaVersion(ctx context.Context, subject string, versionID interface{}) (int, error) {
 	if subject == "" {
 		return 0, errRequired("subject")
 	}
@@ -2473,7 +3162,7 @@ func (c *Client) deleteSubjectSchemaVersion(subject string, versionID interface{
 	// # Delete a particular version of a subject
 	// DELETE /api/proxy-sr/subjects/(string: subject)/versions/(versionId: version)
 	path := fmt.Sprintf(subjectPath+"/versions/%v", subject, versionID)
-	resp, err := c.Do(http.MethodDelete, path, contentTypeSchemaJSON, nil, schemaAPIOption)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeSchemaJSON, nil, schemaAPIOption)
 	if err != nil {
 		return 0, err
 	}
@@ -2497,7 +3186,12 @@ func (c *Client) deleteSubjectSchemaVersion(subject string, versionID interface{
 //
 // See `DeleteLatestSubjectVersion` too.
 func (c *Client) DeleteSubjectVersion(subject string, versionID int) (int, error) {
-	return c.deleteSubjectSchemaVersion(subject, versionID)
+	return c.DeleteSubjectVersionContext(context.Background(), subject, versionID)
+}
+
+// DeleteSubjectVersionContext is like `DeleteSubjectVersion` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteSubjectVersionContext(ctx context.Context, subject string, versionID int) (int, error) {
+	return c.deleteSubjectSchemaVersion(ctx, subject, versionID)
 }
 
 // DeleteLatestSubjectVersion deletes the latest version of the schema registered under this subject.
@@ -2511,7 +3205,12 @@ func (c *Client) DeleteSubjectVersion(subject string, versionID int) (int, error
 //
 // See `DeleteSubjectVersion` too.
 func (c *Client) DeleteLatestSubjectVersion(subject string) (int, error) {
-	return c.deleteSubjectSchemaVersion(subject, SchemaLatestVersion)
+	return c.DeleteLatestSubjectVersionContext(context.Background(), subject)
+}
+
+// DeleteLatestSubjectVersionContext is like `DeleteLatestSubjectVersion` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteLatestSubjectVersionContext(ctx context.Context, subject string) (int, error) {
+	return c.deleteSubjectSchemaVersion(ctx, subject, SchemaLatestVersion)
 }
 
 // CompatibilityLevel describes the valid compatibility levels' type, it's just a string.
@@ -2583,6 +3282,11 @@ const compatibilityLevelPath = "api/proxy-sr/config"
 // If the master is not available, the client will get an error code indicating
 // that the forwarding has failed.
 func (c *Client) UpdateGlobalCompatibilityLevel(level CompatibilityLevel) error {
+	return c.UpdateGlobalCompatibilityLevelContext(context.Background(), level)
+}
+
+// UpdateGlobalCompatibilityLevelContext is like `UpdateGlobalCompatibilityLevel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateGlobalCompatibilityLevelContext(ctx context.Context, level CompatibilityLevel) error {
 	lv := compatibilityPutOnlyJSON{
 		Compatibility: string(level),
 	}
@@ -2594,7 +3298,7 @@ func (c *Client) UpdateGlobalCompatibilityLevel(level CompatibilityLevel) error
 
 	// # Update global compatibility level
 	// PUT /api/proxy-sr/config
-	resp, err := c.Do(http.MethodPut, compatibilityLevelPath, contentTypeSchemaJSON, send, schemaAPIOption)
+	resp, err := c.DoContext(ctx, http.MethodPut, compatibilityLevelPath, contentTypeSchemaJSON, send, schemaAPIOption)
 	if err != nil {
 		return err
 	}
@@ -2605,9 +3309,14 @@ func (c *Client) UpdateGlobalCompatibilityLevel(level CompatibilityLevel) error
 // GetGlobalCompatibilityLevel returns the global compatibility level,
 // "NONE", "FULL", "FORWARD" or "BACKWARD", as described at the `CompatibilityLevel` type.
 func (c *Client) GetGlobalCompatibilityLevel() (level CompatibilityLevel, err error) {
+	return c.GetGlobalCompatibilityLevelContext(context.Background())
+}
+
+// GetGlobalCompatibilityLevelContext is like `GetGlobalCompatibilityLevel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetGlobalCompatibilityLevelContext(ctx context.Context) (level CompatibilityLevel, err error) {
 	// # Get global compatibility level
 	// GET /api/proxy-sr/config
-	resp, respErr := c.Do(http.MethodGet, compatibilityLevelPath, "", nil, schemaAPIOption)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, compatibilityLevelPath, "", nil, schemaAPIOption)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2623,6 +3332,11 @@ const subjectCompatibilityLevelPath = compatibilityLevelPath + "/%s"
 
 // UpdateSubjectCompatibilityLevel modifies a specific subject(schema)'s compatibility level.
 func (c *Client) UpdateSubjectCompatibilityLevel(subject string, level CompatibilityLevel) error {
+	return c.UpdateSubjectCompatibilityLevelContext(context.Background(), subject, level)
+}
+
+// UpdateSubjectCompatibilityLevelContext is like `UpdateSubjectCompatibilityLevel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateSubjectCompatibilityLevelContext(ctx context.Context, subject string, level CompatibilityLevel) error {
 	if subject == "" {
 		return errRequired("subject")
 	}
@@ -2643,7 +3357,7 @@ func (c *Client) UpdateSubjectCompatibilityLevel(subject string, level Compatibi
 	// # Change compatibility level of a subject
 	// PUT /api/proxy-sr/config/(string: subject)
 	path := fmt.Sprintf(subjectCompatibilityLevelPath, subject)
-	resp, err := c.Do(http.MethodPut, path, contentTypeSchemaJSON, send, schemaAPIOption)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeSchemaJSON, send, schemaAPIOption)
 	if err != nil {
 		return err
 	}
@@ -2653,6 +3367,11 @@ func (c *Client) UpdateSubjectCompatibilityLevel(subject string, level Compatibi
 
 // GetSubjectCompatibilityLevel returns the compatibility level of a specific subject(schema) name.
 func (c *Client) GetSubjectCompatibilityLevel(subject string) (level CompatibilityLevel, err error) {
+	return c.GetSubjectCompatibilityLevelContext(context.Background(), subject)
+}
+
+// GetSubjectCompatibilityLevelContext is like `GetSubjectCompatibilityLevel` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetSubjectCompatibilityLevelContext(ctx context.Context, subject string) (level CompatibilityLevel, err error) {
 	if subject == "" {
 		err = errRequired("subject")
 		return
@@ -2661,7 +3380,7 @@ func (c *Client) GetSubjectCompatibilityLevel(subject string) (level Compatibili
 	// # Get compatibility level of a subject
 	// GET /api/proxy-sr/config/(string: subject)
 	path := fmt.Sprintf(subjectCompatibilityLevelPath, subject)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil, schemaAPIOption)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil, schemaAPIOption)
 	if respErr != nil {
 		err = respErr
 		return
@@ -2861,6 +3580,11 @@ const aclPath = "api/acl"
 //
 // Note that on the "host" input argument you should use IP addresses as domain names are not supported at the moment by Apache Kafka.
 func (c *Client) CreateOrUpdateACL(acl ACL) error {
+	return c.CreateOrUpdateACLContext(context.Background(), acl)
+}
+
+// CreateOrUpdateACLContext is like `CreateOrUpdateACL` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateACLContext(ctx context.Context, acl ACL) error {
 	if err := acl.Validate(); err != nil {
 		return err
 	}
@@ -2870,7 +3594,7 @@ func (c *Client) CreateOrUpdateACL(acl ACL) error {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPut, aclPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, aclPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -2887,7 +3611,12 @@ func (c *Client) CreateOrUpdateACL(acl ACL) error {
 
 // GetACLs returns all the available Apache Kafka Access Control Lists.
 func (c *Client) GetACLs() ([]ACL, error) {
-	resp, err := c.Do(http.MethodGet, aclPath, "", nil)
+	return c.GetACLsContext(context.Background())
+}
+
+// GetACLsContext is like `GetACLs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetACLsContext(ctx context.Context) ([]ACL, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, aclPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -2905,6 +3634,11 @@ func (c *Client) GetACLs() ([]ACL, error) {
 
 // DeleteACL deletes an existing Apache Kafka Access Control List.
 func (c *Client) DeleteACL(acl ACL) error {
+	return c.DeleteACLContext(context.Background(), acl)
+}
+
+// DeleteACLContext is like `DeleteACL` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteACLContext(ctx context.Context, acl ACL) error {
 	if err := acl.Validate(); err != nil {
 		return err
 	}
@@ -2914,7 +3648,7 @@ func (c *Client) DeleteACL(acl ACL) error {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodDelete, aclPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, aclPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3037,7 +3771,12 @@ const quotasPath = "api/quotas"
 
 // GetQuotas returns a list of all available quotas.
 func (c *Client) GetQuotas() ([]Quota, error) {
-	resp, err := c.Do(http.MethodGet, quotasPath, "", nil)
+	return c.GetQuotasContext(context.Background())
+}
+
+// GetQuotasContext is like `GetQuotas` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetQuotasContext(ctx context.Context) ([]Quota, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, quotasPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -3053,12 +3792,17 @@ const quotasPathAllUsers = quotasPath + "/users"
 // CreateOrUpdateQuotaForAllUsers sets the default quota for all users.
 // Read more at: https://docs.lenses.io/using-lenses/user-guide/quotas.html.
 func (c *Client) CreateOrUpdateQuotaForAllUsers(config QuotaConfig) error {
+	return c.CreateOrUpdateQuotaForAllUsersContext(context.Background(), config)
+}
+
+// CreateOrUpdateQuotaForAllUsersContext is like `CreateOrUpdateQuotaForAllUsers` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateQuotaForAllUsersContext(ctx context.Context, config QuotaConfig) error {
 	send, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPut, quotasPathAllUsers, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, quotasPathAllUsers, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3095,12 +3839,17 @@ func marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove []string) ([]byt
 //
 // if "propertiesToRemove" is not passed or empty then the client will send all the available keys to be removed, see `DefaultQuotaConfigPropertiesToRemove` for more.
 func (c *Client) DeleteQuotaForAllUsers(propertiesToRemove ...string) error {
+	return c.DeleteQuotaForAllUsersContext(context.Background(), propertiesToRemove...)
+}
+
+// DeleteQuotaForAllUsersContext is like `DeleteQuotaForAllUsers` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteQuotaForAllUsersContext(ctx context.Context, propertiesToRemove ...string) error {
 	send, err := marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodDelete, quotasPathAllUsers, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, quotasPathAllUsers, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3114,13 +3863,18 @@ const quotasPathUser = quotasPathAllUsers + "/%s"
 // CreateOrUpdateQuotaForUser sets a quota for a user.
 // Read more at: https://docs.lenses.io/using-lenses/user-guide/quotas.html.
 func (c *Client) CreateOrUpdateQuotaForUser(user string, config QuotaConfig) error {
+	return c.CreateOrUpdateQuotaForUserContext(context.Background(), user, config)
+}
+
+// CreateOrUpdateQuotaForUserContext is like `CreateOrUpdateQuotaForUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateQuotaForUserContext(ctx context.Context, user string, config QuotaConfig) error {
 	send, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathUser, user)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3131,13 +3885,18 @@ func (c *Client) CreateOrUpdateQuotaForUser(user string, config QuotaConfig) err
 // DeleteQuotaForUser deletes a quota for a user.
 // if "propertiesToRemove" is not passed or empty then the client will send all the available keys to be removed, see `DefaultQuotaConfigPropertiesToRemove` for more.
 func (c *Client) DeleteQuotaForUser(user string, propertiesToRemove ...string) error {
+	return c.DeleteQuotaForUserContext(context.Background(), user, propertiesToRemove...)
+}
+
+// DeleteQuotaForUserContext is like `DeleteQuotaForUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteQuotaForUserContext(ctx context.Context, user string, propertiesToRemove ...string) error {
 	send, err := marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathUser, user)
-	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3151,13 +3910,18 @@ const quotasPathUserAllClients = quotasPathUser + "/clients"
 // CreateOrUpdateQuotaForUserAllClients sets a quota for a user for all clients.
 // Read more at: https://docs.lenses.io/using-lenses/user-guide/quotas.html.
 func (c *Client) CreateOrUpdateQuotaForUserAllClients(user string, config QuotaConfig) error {
+	return c.CreateOrUpdateQuotaForUserAllClientsContext(context.Background(), user, config)
+}
+
+// CreateOrUpdateQuotaForUserAllClientsContext is like `CreateOrUpdateQuotaForUserAllClients` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateQuotaForUserAllClientsContext(ctx context.Context, user string, config QuotaConfig) error {
 	send, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathUserAllClients, user)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3169,13 +3933,18 @@ func (c *Client) CreateOrUpdateQuotaForUserAllClients(user string, config QuotaC
 //
 // if "propertiesToRemove" is not passed or empty then the client will send all the available keys to be removed, see `DefaultQuotaConfigPropertiesToRemove` for more.
 func (c *Client) DeleteQuotaForUserAllClients(user string, propertiesToRemove ...string) error {
+	return c.DeleteQuotaForUserAllClientsContext(context.Background(), user, propertiesToRemove...)
+}
+
+// DeleteQuotaForUserAllClientsContext is like `DeleteQuotaForUserAllClients` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteQuotaForUserAllClientsContext(ctx context.Context, user string, propertiesToRemove ...string) error {
 	send, err := marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathUserAllClients, user)
-	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3189,13 +3958,18 @@ const quotasPathUserClient = quotasPathUserAllClients + "/%s"
 // CreateOrUpdateQuotaForUserClient sets the quota for a user/client pair.
 // Read more at: https://docs.lenses.io/using-lenses/user-guide/quotas.html.
 func (c *Client) CreateOrUpdateQuotaForUserClient(user, clientID string, config QuotaConfig) error {
+	return c.CreateOrUpdateQuotaForUserClientContext(context.Background(), user, clientID, config)
+}
+
+// CreateOrUpdateQuotaForUserClientContext is like `CreateOrUpdateQuotaForUserClient` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateQuotaForUserClientContext(ctx context.Context, user, clientID string, config QuotaConfig) error {
 	send, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathUserClient, user, clientID)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3207,13 +3981,18 @@ func (c *Client) CreateOrUpdateQuotaForUserClient(user, clientID string, config
 //
 // if "propertiesToRemove" is not passed or empty then the client will send all the available keys to be removed, see `DefaultQuotaConfigPropertiesToRemove` for more.
 func (c *Client) DeleteQuotaForUserClient(user, clientID string, propertiesToRemove ...string) error {
+	return c.DeleteQuotaForUserClientContext(context.Background(), user, clientID, propertiesToRemove...)
+}
+
+// DeleteQuotaForUserClientContext is like `DeleteQuotaForUserClient` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteQuotaForUserClientContext(ctx context.Context, user, clientID string, propertiesToRemove ...string) error {
 	send, err := marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathUserClient, user, clientID)
-	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3227,12 +4006,17 @@ const quotasPathAllClients = quotasPath + "/clients"
 // CreateOrUpdateQuotaForAllClients sets the default quota for all clients.
 // Read more at: https://docs.lenses.io/using-lenses/user-guide/quotas.html.
 func (c *Client) CreateOrUpdateQuotaForAllClients(config QuotaConfig) error {
+	return c.CreateOrUpdateQuotaForAllClientsContext(context.Background(), config)
+}
+
+// CreateOrUpdateQuotaForAllClientsContext is like `CreateOrUpdateQuotaForAllClients` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateQuotaForAllClientsContext(ctx context.Context, config QuotaConfig) error {
 	send, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPut, quotasPathAllClients, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, quotasPathAllClients, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3244,12 +4028,17 @@ func (c *Client) CreateOrUpdateQuotaForAllClients(config QuotaConfig) error {
 //
 // if "propertiesToRemove" is not passed or empty then the client will send all the available keys to be removed, see `DefaultQuotaConfigPropertiesToRemove` for more.
 func (c *Client) DeleteQuotaForAllClients(propertiesToRemove ...string) error {
+	return c.DeleteQuotaForAllClientsContext(context.Background(), propertiesToRemove...)
+}
+
+// DeleteQuotaForAllClientsContext is like `DeleteQuotaForAllClients` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteQuotaForAllClientsContext(ctx context.Context, propertiesToRemove ...string) error {
 	send, err := marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodDelete, quotasPathAllClients, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, quotasPathAllClients, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3263,13 +4052,18 @@ const quotasPathClient = quotasPathAllClients + "/%s"
 // CreateOrUpdateQuotaForClient sets the quota for a specific client.
 // Read more at: https://docs.lenses.io/using-lenses/user-guide/quotas.html.
 func (c *Client) CreateOrUpdateQuotaForClient(clientID string, config QuotaConfig) error {
+	return c.CreateOrUpdateQuotaForClientContext(context.Background(), clientID, config)
+}
+
+// CreateOrUpdateQuotaForClientContext is like `CreateOrUpdateQuotaForClient` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateQuotaForClientContext(ctx context.Context, clientID string, config QuotaConfig) error {
 	send, err := json.Marshal(config)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathClient, clientID)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3281,13 +4075,18 @@ func (c *Client) CreateOrUpdateQuotaForClient(clientID string, config QuotaConfi
 //
 // if "propertiesToRemove" is not passed or empty then the client will send all the available keys to be removed, see `DefaultQuotaConfigPropertiesToRemove` for more.
 func (c *Client) DeleteQuotaForClient(clientID string, propertiesToRemove ...string) error {
+	return c.DeleteQuotaForClientContext(context.Background(), clientID, propertiesToRemove...)
+}
+
+// DeleteQuotaForClientContext is like `DeleteQuotaForClient` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteQuotaForClientContext(ctx context.Context, clientID string, propertiesToRemove ...string) error {
 	send, err := marshalQuotaConfigPropertiesToBeRemoved(propertiesToRemove)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(quotasPathClient, clientID)
-	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3357,7 +4156,12 @@ const (
 //
 // Alert notifications are the result of an `AlertSetting` Condition being met on an `AlertSetting`.
 func (c *Client) GetAlertSettings() (AlertSettings, error) {
-	resp, err := c.Do(http.MethodGet, alertSettingsPath, "", nil)
+	return c.GetAlertSettingsContext(context.Background())
+}
+
+// GetAlertSettingsContext is like `GetAlertSettings` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertSettingsContext(ctx context.Context) (AlertSettings, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, alertSettingsPath, "", nil)
 	if err != nil {
 		return AlertSettings{}, err
 	}
@@ -3369,8 +4173,13 @@ func (c *Client) GetAlertSettings() (AlertSettings, error) {
 
 // GetAlertSetting returns a specific alert setting based on its "id".
 func (c *Client) GetAlertSetting(id int) (setting AlertSetting, err error) {
+	return c.GetAlertSettingContext(context.Background(), id)
+}
+
+// GetAlertSettingContext is like `GetAlertSetting` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertSettingContext(ctx context.Context, id int) (setting AlertSetting, err error) {
 	path := fmt.Sprintf(alertSettingPath, id)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -3382,9 +4191,14 @@ func (c *Client) GetAlertSetting(id int) (setting AlertSetting, err error) {
 
 // EnableAlertSetting enables a specific alert setting based on its "id".
 func (c *Client) EnableAlertSetting(id int, enable bool) error {
+	return c.EnableAlertSettingContext(context.Background(), id, enable)
+}
+
+// EnableAlertSettingContext is like `EnableAlertSetting` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) EnableAlertSettingContext(ctx context.Context, id int, enable bool) error {
 	path := fmt.Sprintf(alertSettingPath, id)
 	payload := strconv.FormatBool(enable)
-	resp, err := c.Do(http.MethodPut, path, "", []byte(payload))
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", []byte(payload))
 	if err != nil {
 		return err
 	}
@@ -3397,8 +4211,13 @@ type AlertSettingConditions map[string]string
 
 // GetAlertSettingConditions returns alert setting's conditions as a map of strings.
 func (c *Client) GetAlertSettingConditions(id int) (AlertSettingConditions, error) {
+	return c.GetAlertSettingConditionsContext(context.Background(), id)
+}
+
+// GetAlertSettingConditionsContext is like `GetAlertSettingConditions` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertSettingConditionsContext(ctx context.Context, id int) (AlertSettingConditions, error) {
 	path := fmt.Sprintf(alertSettingConditionsPath, id)
-	resp, err := c.Do(http.MethodGet, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -3435,6 +4254,11 @@ type (
 
 // RegisterAlert registers an Alert, returns an error on failure.
 func (c *Client) RegisterAlert(alert Alert) error {
+	return c.RegisterAlertContext(context.Background(), alert)
+}
+
+// RegisterAlertContext is like `RegisterAlert` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) RegisterAlertContext(ctx context.Context, alert Alert) error {
 	if alert.Severity == "" {
 		return errRequired("Severity")
 	}
@@ -3446,7 +4270,7 @@ func (c *Client) RegisterAlert(alert Alert) error {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPost, alertsPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPost, alertsPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3456,10 +4280,15 @@ func (c *Client) RegisterAlert(alert Alert) error {
 
 // GetAlerts returns the registered alerts.
 func (c *Client) GetAlerts(pageSize int) (alerts []Alert, err error) {
+	return c.GetAlertsContext(context.Background(), pageSize)
+}
+
+// GetAlertsContext is like `GetAlerts` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertsContext(ctx context.Context, pageSize int) (alerts []Alert, err error) {
 	path := fmt.Sprintf("%s?pageSize=%d", alertsPath, pageSize)
 
 	var results AlertResult
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -3473,8 +4302,13 @@ func (c *Client) GetAlerts(pageSize int) (alerts []Alert, err error) {
 
 // CreateOrUpdateAlertSettingCondition sets a condition(expression text) for a specific alert setting.
 func (c *Client) CreateOrUpdateAlertSettingCondition(alertSettingID int, condition string) error {
+	return c.CreateOrUpdateAlertSettingConditionContext(context.Background(), alertSettingID, condition)
+}
+
+// CreateOrUpdateAlertSettingConditionContext is like `CreateOrUpdateAlertSettingCondition` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateOrUpdateAlertSettingConditionContext(ctx context.Context, alertSettingID int, condition string) error {
 	path := fmt.Sprintf(alertSettingConditionsPath, alertSettingID)
-	resp, err := c.Do(http.MethodPost, path, "text/plain", []byte(condition))
+	resp, err := c.DoContext(ctx, http.MethodPost, path, "text/plain", []byte(condition))
 	if err != nil {
 		return err
 	}
@@ -3484,8 +4318,13 @@ func (c *Client) CreateOrUpdateAlertSettingCondition(alertSettingID int, conditi
 
 // DeleteAlertSettingCondition deletes a condition from an alert setting.
 func (c *Client) DeleteAlertSettingCondition(alertSettingID int, conditionUUID string) error {
+	return c.DeleteAlertSettingConditionContext(context.Background(), alertSettingID, conditionUUID)
+}
+
+// DeleteAlertSettingConditionContext is like `DeleteAlertSettingCondition` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteAlertSettingConditionContext(ctx context.Context, alertSettingID int, conditionUUID string) error {
 	path := fmt.Sprintf(alertSettingConditionPath, alertSettingID, conditionUUID)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -3502,7 +4341,12 @@ type AlertHandler func(Alert) error
 
 // GetAlertsLive receives alert notifications in real-time from the server via a Send Server Event endpoint.
 func (c *Client) GetAlertsLive(handler AlertHandler) error {
-	resp, err := c.Do(http.MethodGet, alertsPathSSE, contentTypeJSON, nil, func(r *http.Request) error {
+	return c.GetAlertsLiveContext(context.Background(), handler)
+}
+
+// GetAlertsLiveContext is like `GetAlertsLive` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAlertsLiveContext(ctx context.Context, handler AlertHandler) error {
+	resp, err := c.DoContext(ctx, http.MethodGet, alertsPathSSE, contentTypeJSON, nil, func(r *http.Request) error {
 		r.Header.Add(acceptHeaderKey, "application/json, text/event-stream")
 		return nil
 	}, schemaAPIOption)
@@ -3573,7 +4417,12 @@ const defaultProcessorsLogsFollowLines = 100
 
 // GetProcessorsLogs retrieves the LSQL processor logs if in kubernetes mode.
 func (c *Client) GetProcessorsLogs(clusterName, ns, podName string, follow bool, lines int, handler func(level string, log string) error) error {
-	if mode, _ := c.GetExecutionMode(); mode != ExecutionModeKubernetes {
+	return c.GetProcessorsLogsContext(context.Background(), clusterName, ns, podName, follow, lines, handler)
+}
+
+// GetProcessorsLogsContext is like `GetProcessorsLogs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetProcessorsLogsContext(ctx context.Context, clusterName, ns, podName string, follow bool, lines int, handler func(level string, log string) error) error {
+	if mode, _ := c.GetExecutionModeContext(ctx); mode != ExecutionModeKubernetes {
 		return fmt.Errorf("unable to retrieve logs, execution mode is not KUBERNETES")
 	}
 
@@ -3586,7 +4435,7 @@ func (c *Client) GetProcessorsLogs(clusterName, ns, podName string, follow bool,
 		path += "?follow=true&lines=" + fmt.Sprintf("%d", lines)
 	}
 
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil, func(r *http.Request) error {
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil, func(r *http.Request) error {
 		r.Header.Add(acceptHeaderKey, "application/json, text/event-stream")
 		return nil
 	})
@@ -3673,7 +4522,12 @@ const (
 // GetDynamicClusterConfigs returns the dynamic updated configurations for a kafka cluster.
 // Retrieves only the ones added/updated dynamically.
 func (c *Client) GetDynamicClusterConfigs() (configs BrokerConfig, err error) {
-	resp, respErr := c.Do(http.MethodGet, brokersConfigsPath, "", nil)
+	return c.GetDynamicClusterConfigsContext(context.Background())
+}
+
+// GetDynamicClusterConfigsContext is like `GetDynamicClusterConfigs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetDynamicClusterConfigsContext(ctx context.Context) (configs BrokerConfig, err error) {
+	resp, respErr := c.DoContext(ctx, http.MethodGet, brokersConfigsPath, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -3686,8 +4540,13 @@ func (c *Client) GetDynamicClusterConfigs() (configs BrokerConfig, err error) {
 // GetDynamicBrokerConfigs returns the dynamic updated configurations for a kafka broker.
 // Retrieves only the ones added/updated dynamically.
 func (c *Client) GetDynamicBrokerConfigs(brokerID int) (config BrokerConfig, err error) {
+	return c.GetDynamicBrokerConfigsContext(context.Background(), brokerID)
+}
+
+// GetDynamicBrokerConfigsContext is like `GetDynamicBrokerConfigs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetDynamicBrokerConfigsContext(ctx context.Context, brokerID int) (config BrokerConfig, err error) {
 	path := fmt.Sprintf(brokerConfigsPath, brokerID)
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -3699,12 +4558,17 @@ func (c *Client) GetDynamicBrokerConfigs(brokerID int) (config BrokerConfig, err
 
 // UpdateDynamicClusterConfigs adds or updates cluster configuration dynamically.
 func (c *Client) UpdateDynamicClusterConfigs(toAddOrUpdate BrokerConfig) error {
+	return c.UpdateDynamicClusterConfigsContext(context.Background(), toAddOrUpdate)
+}
+
+// UpdateDynamicClusterConfigsContext is like `UpdateDynamicClusterConfigs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateDynamicClusterConfigsContext(ctx context.Context, toAddOrUpdate BrokerConfig) error {
 	send, err := json.Marshal(toAddOrUpdate)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPut, brokersConfigsPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, brokersConfigsPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3714,13 +4578,18 @@ func (c *Client) UpdateDynamicClusterConfigs(toAddOrUpdate BrokerConfig) error {
 
 // UpdateDynamicBrokerConfigs adds or updates broker configuration dynamically.
 func (c *Client) UpdateDynamicBrokerConfigs(brokerID int, toAddOrUpdate BrokerConfig) error {
+	return c.UpdateDynamicBrokerConfigsContext(context.Background(), brokerID, toAddOrUpdate)
+}
+
+// UpdateDynamicBrokerConfigsContext is like `UpdateDynamicBrokerConfigs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateDynamicBrokerConfigsContext(ctx context.Context, brokerID int, toAddOrUpdate BrokerConfig) error {
 	send, err := json.Marshal(toAddOrUpdate)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(brokerConfigsPath, brokerID)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3731,12 +4600,17 @@ func (c *Client) UpdateDynamicBrokerConfigs(brokerID int, toAddOrUpdate BrokerCo
 // DeleteDynamicClusterConfigs deletes cluster configuration(s) dynamically.
 // It reverts the configuration to its default value.
 func (c *Client) DeleteDynamicClusterConfigs(configKeysToBeReset ...string) error {
+	return c.DeleteDynamicClusterConfigsContext(context.Background(), configKeysToBeReset...)
+}
+
+// DeleteDynamicClusterConfigsContext is like `DeleteDynamicClusterConfigs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteDynamicClusterConfigsContext(ctx context.Context, configKeysToBeReset ...string) error {
 	send, err := json.Marshal(configKeysToBeReset)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodDelete, brokersConfigsPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, brokersConfigsPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3747,13 +4621,18 @@ func (c *Client) DeleteDynamicClusterConfigs(configKeysToBeReset ...string) erro
 // DeleteDynamicBrokerConfigs deletes a configuration for a broker.
 // Deleting a configuration dynamically reverts it to its default value.
 func (c *Client) DeleteDynamicBrokerConfigs(brokerID int, configKeysToBeReseted ...string) error {
+	return c.DeleteDynamicBrokerConfigsContext(context.Background(), brokerID, configKeysToBeReseted...)
+}
+
+// DeleteDynamicBrokerConfigsContext is like `DeleteDynamicBrokerConfigs` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteDynamicBrokerConfigsContext(ctx context.Context, brokerID int, configKeysToBeReseted ...string) error {
 	send, err := json.Marshal(configKeysToBeReseted)
 	if err != nil {
 		return err
 	}
 
 	path := fmt.Sprintf(brokerConfigsPath, brokerID)
-	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -3810,7 +4689,12 @@ const auditPath = "api/audit"
 // Retrives the last N audit entries created.
 // See `GetAuditEntriesLive` for real-time notifications.
 func (c *Client) GetAuditEntries() (entries []AuditEntry, err error) {
-	resp, err := c.Do(http.MethodGet, auditPath, "", nil)
+	return c.GetAuditEntriesContext(context.Background())
+}
+
+// GetAuditEntriesContext is like `GetAuditEntries` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAuditEntriesContext(ctx context.Context) (entries []AuditEntry, err error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, auditPath, "", nil)
 	if err != nil {
 		return nil, nil
 	}
@@ -3819,6 +4703,100 @@ func (c *Client) GetAuditEntries() (entries []AuditEntry, err error) {
 	return
 }
 
+const auditPagedPath = "api/v1/audit"
+
+// AuditOptions describes the paging and the filters of the `GetAuditEntriesPage` call.
+type AuditOptions struct {
+	QueryFiltering
+	// From and To select the entries of a time range, inclusive, optional.
+	From, To time.Time
+	// User selects the entries of a user, optional.
+	User string
+	// Types selects the entries of the given types, all types if empty.
+	Types []AuditEntryType
+}
+
+func (opts AuditOptions) path() string {
+	path := opts.QueryFiltering.path(auditPagedPath, "search")
+
+	v := url.Values{}
+	if !opts.From.IsZero() {
+		v.Add("from", strconv.FormatInt(toMillis(opts.From), 10))
+	}
+	if !opts.To.IsZero() {
+		v.Add("to", strconv.FormatInt(toMillis(opts.To), 10))
+	}
+	if opts.User != "" {
+		v.Add("user", opts.User)
+	}
+	for _, typ := range opts.Types {
+		v.Add("type", string(typ))
+	}
+
+	if len(v) == 0 {
+		return path
+	}
+
+	return path + "&" + v.Encode()
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// AuditEntriesPage is a page of audit entries, see `GetAuditEntriesPage`.
+type AuditEntriesPage struct {
+	Page   `yaml:",inline"`
+	Values []AuditEntry `json:"values" yaml:"values"`
+}
+
+// GetAuditEntriesPage returns a single page of audit entries, filtered by time range, user and entry types.
+// See `WalkAuditEntries` to fetch all the pages.
+func (c *Client) GetAuditEntriesPage(opts AuditOptions) (page AuditEntriesPage, err error) {
+	return c.GetAuditEntriesPageContext(context.Background(), opts)
+}
+
+// GetAuditEntriesPageContext is like `GetAuditEntriesPage` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAuditEntriesPageContext(ctx context.Context, opts AuditOptions) (page AuditEntriesPage, err error) {
+	if err = c.requireMajor("paged audit entries", pagedListsMajor); err != nil {
+		return
+	}
+
+	opts.QueryFiltering = opts.QueryFiltering.withDefaults()
+
+	// # Page of audit entries
+	// GET /api/v1/audit?page=1&pageSize=100&from=1577836800000&to=1580515200000&user=admin&type=TOPIC
+	resp, respErr := c.DoContext(ctx, http.MethodGet, opts.path(), "", nil)
+	if respErr != nil {
+		err = respErr
+		return
+	}
+
+	err = c.ReadJSON(resp, &page)
+	page.Number = opts.Page
+	return
+}
+
+// WalkAuditEntries fetches the audit entries page by page, starting from the `opts.Page`,
+// and calls the `fn` with the entries of each page as soon as it arrives, so large time ranges are not buffered.
+// It stops on the first error of the `fn`.
+func (c *Client) WalkAuditEntries(opts AuditOptions, fn func(entries []AuditEntry) error) error {
+	return c.WalkAuditEntriesContext(context.Background(), opts, fn)
+}
+
+// WalkAuditEntriesContext is like `WalkAuditEntries` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) WalkAuditEntriesContext(ctx context.Context, opts AuditOptions, fn func(entries []AuditEntry) error) error {
+	return WalkPages(opts.QueryFiltering, func(listOpts QueryFiltering) (Page, error) {
+		opts.QueryFiltering = listOpts
+		page, err := c.GetAuditEntriesPageContext(ctx, opts)
+		if err != nil {
+			return page.Page, err
+		}
+
+		return page.Page, fn(page.Values)
+	})
+}
+
 // AuditEntryHandler is the type of the function, the listener which is
 // the input parameter of the `GetAuditEntriesLive` API call.
 type AuditEntryHandler func(AuditEntry) error
@@ -3827,11 +4805,16 @@ const auditPathSSE = "api/sse/audit"
 
 // GetAuditEntriesLive returns the live audit notifications, see `GetAuditEntries` too.
 func (c *Client) GetAuditEntriesLive(handler AuditEntryHandler) error {
+	return c.GetAuditEntriesLiveContext(context.Background(), handler)
+}
+
+// GetAuditEntriesLiveContext is like `GetAuditEntriesLive` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetAuditEntriesLiveContext(ctx context.Context, handler AuditEntryHandler) error {
 	if handler == nil {
 		return errRequired("handler")
 	}
 
-	resp, err := c.Do(http.MethodGet, auditPathSSE, contentTypeJSON, nil, func(r *http.Request) error {
+	resp, err := c.DoContext(ctx, http.MethodGet, auditPathSSE, contentTypeJSON, nil, func(r *http.Request) error {
 		r.Header.Add(acceptHeaderKey, "application/json, text/event-stream")
 		return nil
 	})
@@ -3909,7 +4892,12 @@ const (
 
 // GetLogsInfo returns the latest (512) INFO log lines.
 func (c *Client) GetLogsInfo() ([]LogLine, error) {
-	resp, err := c.Do(http.MethodGet, logsInfoPath, "", nil)
+	return c.GetLogsInfoContext(context.Background())
+}
+
+// GetLogsInfoContext is like `GetLogsInfo` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetLogsInfoContext(ctx context.Context) ([]LogLine, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, logsInfoPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -3920,7 +4908,12 @@ func (c *Client) GetLogsInfo() ([]LogLine, error) {
 
 // GetLogsMetrics returns the latest (512) METRICS log lines.
 func (c *Client) GetLogsMetrics() ([]LogLine, error) {
-	resp, err := c.Do(http.MethodGet, logsMetricsPath, "", nil)
+	return c.GetLogsMetricsContext(context.Background())
+}
+
+// GetLogsMetricsContext is like `GetLogsMetrics` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetLogsMetricsContext(ctx context.Context) ([]LogLine, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, logsMetricsPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -3940,6 +4933,27 @@ type UserProfile struct {
 	Transformers []string `json:"transformers" header:"Transformers"`
 }
 
+const currentUserPath = "api/auth"
+
+// GetCurrentUser returns the authenticated user of the client's token, its name and its permissions.
+// It works for any authentication method, including just a `Token`.
+func (c *Client) GetCurrentUser() (User, error) {
+	return c.GetCurrentUserContext(context.Background())
+}
+
+// GetCurrentUserContext is like `GetCurrentUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetCurrentUserContext(ctx context.Context) (User, error) {
+	var user User
+
+	resp, err := c.DoContext(ctx, http.MethodGet, currentUserPath, "", nil)
+	if err != nil {
+		return user, err
+	}
+
+	err = c.ReadJSON(resp, &user)
+	return user, err
+}
+
 const (
 	userProfilePath         = "api/user/profile"
 	userProfilePropertyPath = userProfilePath + "/%s/%s"
@@ -3947,9 +4961,14 @@ const (
 
 // GetUserProfile returns the user-specific favourites.
 func (c *Client) GetUserProfile() (UserProfile, error) {
+	return c.GetUserProfileContext(context.Background())
+}
+
+// GetUserProfileContext is like `GetUserProfile` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetUserProfileContext(ctx context.Context) (UserProfile, error) {
 	var profile UserProfile
 
-	resp, err := c.Do(http.MethodGet, userProfilePath, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, userProfilePath, "", nil)
 	if err != nil {
 		return profile, err
 	}
@@ -3960,8 +4979,13 @@ func (c *Client) GetUserProfile() (UserProfile, error) {
 
 // CreateUserProfilePropertyValue adds a "value" to the user profile "property" entries.
 func (c *Client) CreateUserProfilePropertyValue(property, value string) error {
+	return c.CreateUserProfilePropertyValueContext(context.Background(), property, value)
+}
+
+// CreateUserProfilePropertyValueContext is like `CreateUserProfilePropertyValue` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateUserProfilePropertyValueContext(ctx context.Context, property, value string) error {
 	path := fmt.Sprintf(userProfilePropertyPath, property, value)
-	resp, err := c.Do(http.MethodPut, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -3971,8 +4995,13 @@ func (c *Client) CreateUserProfilePropertyValue(property, value string) error {
 
 // DeleteUserProfilePropertyValue removes the "value" from the user profile "property" entries.
 func (c *Client) DeleteUserProfilePropertyValue(property, value string) error {
+	return c.DeleteUserProfilePropertyValueContext(context.Background(), property, value)
+}
+
+// DeleteUserProfilePropertyValueContext is like `DeleteUserProfilePropertyValue` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteUserProfilePropertyValueContext(ctx context.Context, property, value string) error {
 	path := fmt.Sprintf(userProfilePropertyPath, property, value)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
@@ -4003,7 +5032,12 @@ type ConnectorInfoUI struct {
 
 // GetSupportedConnectors returns the list of the supported Kafka Connectors.
 func (c *Client) GetSupportedConnectors() ([]ConnectorInfoUI, error) {
-	resp, err := c.Do(http.MethodGet, staticSupportedConnectorsPath, "", nil)
+	return c.GetSupportedConnectorsContext(context.Background())
+}
+
+// GetSupportedConnectorsContext is like `GetSupportedConnectors` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetSupportedConnectorsContext(ctx context.Context) ([]ConnectorInfoUI, error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, staticSupportedConnectorsPath, "", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -4025,9 +5059,14 @@ type TopicExtract struct {
 
 // GetTopicExtract returns a TopicExtract for an id
 func (c *Client) GetTopicExtract(id string) ([]TopicExtract, error) {
+	return c.GetTopicExtractContext(context.Background(), id)
+}
+
+// GetTopicExtractContext is like `GetTopicExtract` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetTopicExtractContext(ctx context.Context, id string) ([]TopicExtract, error) {
 	var topics []TopicExtract
 
-	resp, err := c.Do(http.MethodGet, topicExtractPath+id, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, topicExtractPath+id, "", nil)
 	if err != nil {
 		return topics, err
 	}
@@ -4071,6 +5110,11 @@ type SQLValidationResponse struct {
 
 // ValidateSQL valids a Lenses sql statement
 func (c *Client) ValidateSQL(sql string, caret int) (SQLValidationResponse, error) {
+	return c.ValidateSQLContext(context.Background(), sql, caret)
+}
+
+// ValidateSQLContext is like `ValidateSQL` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) ValidateSQLContext(ctx context.Context, sql string, caret int) (SQLValidationResponse, error) {
 
 	var response SQLValidationResponse
 
@@ -4081,7 +5125,7 @@ func (c *Client) ValidateSQL(sql string, caret int) (SQLValidationResponse, erro
 		return response, err
 	}
 
-	resp, err := c.Do(http.MethodPost, sqlValidationPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPost, sqlValidationPath, contentTypeJSON, send)
 	if err != nil {
 		return response, err
 	}
@@ -4155,6 +5199,40 @@ type DataImpactType struct {
 	ImpactType string `json:"type" yaml:"type" header:"Type"`
 }
 
+// The available data policy redaction types, the `Obfuscation` field of a data policy.
+const (
+	RedactionAll      = "All"
+	RedactionNone     = "None"
+	RedactionEmail    = "Email"
+	RedactionInitials = "Initials"
+	RedactionFirst1   = "First-1"
+	RedactionFirst2   = "First-2"
+	RedactionFirst3   = "First-3"
+	RedactionFirst4   = "First-4"
+	RedactionLast1    = "Last-1"
+	RedactionLast2    = "Last-2"
+	RedactionLast3    = "Last-3"
+	RedactionLast4    = "Last-4"
+)
+
+// DataPolicyRedactions are the available data policy redaction types, see `ValidateRedaction`.
+var DataPolicyRedactions = []string{
+	RedactionAll, RedactionNone, RedactionEmail, RedactionInitials,
+	RedactionFirst1, RedactionFirst2, RedactionFirst3, RedactionFirst4,
+	RedactionLast1, RedactionLast2, RedactionLast3, RedactionLast4,
+}
+
+// ValidateRedaction returns an error if the "redaction" is not one of the `DataPolicyRedactions`.
+func ValidateRedaction(redaction string) error {
+	for _, r := range DataPolicyRedactions {
+		if r == redaction {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid redaction type [%s], available types: %s", redaction, strings.Join(DataPolicyRedactions, ", "))
+}
+
 // DataPolicyRequest is a Lenses data policy as a request
 type DataPolicyRequest struct {
 	Name            string   `json:"name" yaml:"name" header:"Name,text"`
@@ -4168,6 +5246,22 @@ type DataPolicyRequest struct {
 	LastUpdatedUser string   `json:"lastUpdatedUser" yaml:"lastUpdatedUser" header:"Updated By,text"`
 }
 
+// Validate checks that the policy's required fields are set and its redaction type is valid.
+func (p DataPolicyRequest) Validate() error {
+	switch {
+	case p.Name == "":
+		return errRequired("name")
+	case p.Category == "":
+		return errRequired("category")
+	case p.ImpactType == "":
+		return errRequired("impactType")
+	case len(p.Fields) == 0:
+		return errRequired("fields")
+	}
+
+	return ValidateRedaction(p.Obfuscation)
+}
+
 // DataPolicyUpdateRequest is a data policy as an update
 type DataPolicyUpdateRequest struct {
 	ID          string   `json:"id" yaml:"id"`
@@ -4210,10 +5304,15 @@ func (c *Client) PolicyForPrint(p DataPolicy) DataPolicyTablePrint {
 
 // GetPolicies retrieves data policies from Lenses
 func (c *Client) GetPolicies() ([]DataPolicy, error) {
+	return c.GetPoliciesContext(context.Background())
+}
+
+// GetPoliciesContext is like `GetPolicies` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetPoliciesContext(ctx context.Context) ([]DataPolicy, error) {
 
 	var response []DataPolicy
 
-	resp, err := c.Do(http.MethodGet, policyPath, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, policyPath, "", nil)
 	if err != nil {
 		return response, err
 	}
@@ -4227,11 +5326,16 @@ func (c *Client) GetPolicies() ([]DataPolicy, error) {
 
 // GetPolicy retrieves the specified policy
 func (c *Client) GetPolicy(id string) (DataPolicy, error) {
+	return c.GetPolicyContext(context.Background(), id)
+}
+
+// GetPolicyContext is like `GetPolicy` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetPolicyContext(ctx context.Context, id string) (DataPolicy, error) {
 	var response DataPolicy
 
 	path := fmt.Sprintf("%s/%s", policyPath, id)
 
-	resp, err := c.Do(http.MethodGet, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if err != nil {
 		return response, err
 	}
@@ -4245,9 +5349,14 @@ func (c *Client) GetPolicy(id string) (DataPolicy, error) {
 
 // GetPolicyCategory retrieves the data policy categories
 func (c *Client) GetPolicyCategory() ([]string, error) {
+	return c.GetPolicyCategoryContext(context.Background())
+}
+
+// GetPolicyCategoryContext is like `GetPolicyCategory` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetPolicyCategoryContext(ctx context.Context) ([]string, error) {
 	var response []string
 
-	resp, err := c.Do(http.MethodGet, "/api/protection/static/category", "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, "/api/protection/static/category", "", nil)
 	if err != nil {
 		return response, err
 	}
@@ -4261,10 +5370,15 @@ func (c *Client) GetPolicyCategory() ([]string, error) {
 
 // GetPolicyObfuscation retrieves the data policy obfuscation types
 func (c *Client) GetPolicyObfuscation() ([]DataObfuscationType, error) {
+	return c.GetPolicyObfuscationContext(context.Background())
+}
+
+// GetPolicyObfuscationContext is like `GetPolicyObfuscation` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetPolicyObfuscationContext(ctx context.Context) ([]DataObfuscationType, error) {
 	var response []string
 	var redactions []DataObfuscationType
 
-	resp, err := c.Do(http.MethodGet, "/api/protection/static/obfuscation", "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, "/api/protection/static/obfuscation", "", nil)
 	if err != nil {
 		return redactions, err
 	}
@@ -4282,10 +5396,15 @@ func (c *Client) GetPolicyObfuscation() ([]DataObfuscationType, error) {
 
 // GetPolicyImpacts retrieves the data policy impacts
 func (c *Client) GetPolicyImpacts() ([]DataImpactType, error) {
+	return c.GetPolicyImpactsContext(context.Background())
+}
+
+// GetPolicyImpactsContext is like `GetPolicyImpacts` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetPolicyImpactsContext(ctx context.Context) ([]DataImpactType, error) {
 	var response []string
 	var impactTypes []DataImpactType
 
-	resp, err := c.Do(http.MethodGet, "/api/protection/static/impacts", "", nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, "/api/protection/static/impacts", "", nil)
 	if err != nil {
 		return impactTypes, err
 	}
@@ -4303,13 +5422,18 @@ func (c *Client) GetPolicyImpacts() ([]DataImpactType, error) {
 
 // CreatePolicy create a data policy
 func (c *Client) CreatePolicy(policy DataPolicyRequest) error {
+	return c.CreatePolicyContext(context.Background(), policy)
+}
+
+// CreatePolicyContext is like `CreatePolicy` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreatePolicyContext(ctx context.Context, policy DataPolicyRequest) error {
 
 	send, err := json.Marshal(policy)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPost, policyPath, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPost, policyPath, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -4319,6 +5443,11 @@ func (c *Client) CreatePolicy(policy DataPolicyRequest) error {
 
 // UpdatePolicy updates a policy
 func (c *Client) UpdatePolicy(policy DataPolicyUpdateRequest) error {
+	return c.UpdatePolicyContext(context.Background(), policy)
+}
+
+// UpdatePolicyContext is like `UpdatePolicy` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdatePolicyContext(ctx context.Context, policy DataPolicyUpdateRequest) error {
 
 	path := fmt.Sprintf("%s/%s", policyPath, policy.ID)
 
@@ -4327,7 +5456,7 @@ func (c *Client) UpdatePolicy(policy DataPolicyUpdateRequest) error {
 		return err
 	}
 
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, send)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, send)
 	if err != nil {
 		return err
 	}
@@ -4337,8 +5466,13 @@ func (c *Client) UpdatePolicy(policy DataPolicyUpdateRequest) error {
 
 // DeletePolicy deletes a policy
 func (c *Client) DeletePolicy(id string) error {
+	return c.DeletePolicyContext(context.Background(), id)
+}
+
+// DeletePolicyContext is like `DeletePolicy` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeletePolicyContext(ctx context.Context, id string) error {
 	path := fmt.Sprintf("%s/%s", policyPath, id)
-	resp, err := c.Do(http.MethodDelete, path, "", nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, "", nil)
 	if err != nil {
 		return err
 	}
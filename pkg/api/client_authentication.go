@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"gopkg.in/jcmturner/gokrb5.v5/client"
 	"gopkg.in/jcmturner/gokrb5.v5/config"
@@ -43,10 +44,18 @@ type BasicAuthentication struct {
 	Password string `json:"password,omitempty" yaml:"Password" survey:"password"`
 }
 
-var errUnknownPath = func(c *Client, relPath string) error {
+var errUnknownPath = func(c *Client, relPath string, cause error) error {
+	if cause != nil {
+		// keep the cause, i.e a network error or the `ErrCredentialsMissing`, see `DescribeConnectionError`.
+		return fmt.Errorf("could not connect to Lenses (URL: %s): %w", c.Config.Host+"/"+relPath, cause)
+	}
+
 	return fmt.Errorf("could not connect to Lenses (URL: %s)", c.Config.Host+"/"+relPath)
 }
 
+// loginPath is the endpoint which exchanges the basic authentication for a token, its response is the raw token.
+const loginPath = "api/login"
+
 // Auth implements the `Authentication` for the `BasicAuthentication`.
 func (auth BasicAuthentication) Auth(c *Client) error {
 	// auth by raw username/password.
@@ -56,14 +65,13 @@ func (auth BasicAuthentication) Auth(c *Client) error {
 
 	// retrieve token.
 	userAuthJSON := fmt.Sprintf(`{"user":"%s", "password": "%s"}`, auth.Username, auth.Password)
-	loginPath := "api/login"
 	resp, err := c.Do(http.MethodPost, loginPath, contentTypeJSON, []byte(userAuthJSON))
 	if resp == nil || (resp.StatusCode == http.StatusNotFound) {
-		return errUnknownPath(c, loginPath)
+		return errUnknownPath(c, loginPath, err)
 	}
 
 	if err != nil {
-		return fmt.Errorf("%s or kerberos authentication is required", err.Error())
+		return fmt.Errorf("%w or kerberos authentication is required", err)
 	}
 
 	tokenBytes, err := c.ReadResponseBody(resp)
@@ -95,11 +103,67 @@ func (auth BasicAuthentication) Auth(c *Client) error {
 }
 
 // KerberosAuthentication can be used as alternative option of the `BasicAuthentication` for a more secure way to connect to the lenses backend box.
+//
+// The `Method` acquires the ticket, a `KerberosWithKeytab` for service accounts,
+// a `KerberosFromCCache` to use the ticket of an existing `kinit` or a `KerberosWithPassword`.
 type KerberosAuthentication struct {
+	// ConfFile is the krb5.conf path, if empty then the KRB5_CONFIG environment variable or the `DefaultKerberosConfFile` is used.
 	ConfFile string                       `json:"confFile" yaml:"ConfFile" survey:"-"` // keep those, useful for marshal.
 	Method   KerberosAuthenticationMethod `json:"-" yaml:"-" survey:"-"`
 }
 
+const (
+	// DefaultKerberosConfFile is the krb5.conf path when neither the `KerberosAuthentication#ConfFile`
+	// nor the KRB5_CONFIG environment variable are set.
+	DefaultKerberosConfFile = "/etc/krb5.conf"
+	// KerberosConfEnv is the environment variable of the krb5.conf path, see `KerberosAuthentication#ConfPath`.
+	KerberosConfEnv = "KRB5_CONFIG"
+	// KerberosCCacheEnv is the environment variable of the credential cache, see `KerberosFromCCache#Path`.
+	KerberosCCacheEnv = "KRB5CCNAME"
+)
+
+// ConfPath returns the krb5.conf path, the `ConfFile` or the KRB5_CONFIG environment variable or the `DefaultKerberosConfFile`.
+func (auth KerberosAuthentication) ConfPath() string {
+	if auth.ConfFile != "" {
+		return auth.ConfFile
+	}
+
+	if env := os.Getenv(KerberosConfEnv); env != "" {
+		// it may be a list of files, the first one is the main one.
+		return strings.Split(env, string(os.PathListSeparator))[0]
+	}
+
+	return DefaultKerberosConfFile
+}
+
+// Validate returns a descriptive error if the kerberos authentication misses the required fields of its method,
+// either a keytab and a principal (username), a credential cache or a username and password.
+func (auth KerberosAuthentication) Validate() error {
+	switch method := auth.Method.(type) {
+	case nil:
+		return fmt.Errorf("kerberos authentication: method is required, with password, keytab or ccache")
+	case KerberosWithPassword:
+		if method.Username == "" || method.Password == "" {
+			return fmt.Errorf("kerberos authentication: username and password are both required")
+		}
+	case KerberosWithKeytab:
+		if method.Username == "" || method.KeytabFile == "" {
+			return fmt.Errorf("kerberos authentication: username and keytab file are both required")
+		}
+	case KerberosFromCCache:
+		path, err := method.Path()
+		if err != nil {
+			return fmt.Errorf("kerberos authentication: %v", err)
+		}
+
+		if path == "" {
+			return fmt.Errorf("kerberos authentication: ccache file is required, set it or the %s environment variable", KerberosCCacheEnv)
+		}
+	}
+
+	return nil
+}
+
 // WithPassword reports whether the kerberos authentication is with username, password (and realm).
 func (auth KerberosAuthentication) WithPassword() (KerberosWithPassword, bool) {
 	method, isWithPassword := auth.Method.(KerberosWithPassword)
@@ -124,52 +188,79 @@ func (auth KerberosAuthentication) Auth(c *Client) error {
 		return fmt.Errorf("kerberos failure: authentication method is nil")
 	}
 
-	absPath, err := filepath.Abs(auth.ConfFile)
+	ticket, err := auth.acquireTicket()
 	if err != nil {
-		return fmt.Errorf("kerberos failure: unable to retrieve absolute file location for '%s': %v", auth.ConfFile, err)
+		return err
+	}
+
+	c.setKerberosTicket(ticket, auth.ticketAcquirer())
+
+	authPath := "api/auth"
+	resp, err := c.Do(http.MethodGet, authPath, contentTypeJSON, nil)
+	if resp == nil || (resp.StatusCode == http.StatusNotFound) {
+		return errUnknownPath(c, authPath, err)
+	}
+
+	if err != nil {
+		return fmt.Errorf("kerberos failure: unable to send SPNEGO header: %v", err)
+	}
+
+	if err = c.ReadJSON(resp, &c.User); err != nil {
+		return err
+	}
+
+	c.Config.Token = c.User.Token // update the config's one as well for any case.
+	return nil
+}
+
+// acquireTicket loads the krb5.conf and acquires the ticket granting ticket of the `Method`,
+// the service tickets are acquired by the client on the SPNEGO requests, see `WithKerberosRenewal` too.
+func (auth KerberosAuthentication) acquireTicket() (*client.Client, error) {
+	confFile := auth.ConfPath()
+	absPath, err := filepath.Abs(confFile)
+	if err != nil {
+		return nil, fmt.Errorf("kerberos failure: unable to retrieve absolute file location for '%s': %v", confFile, err)
 	}
 	f, err := os.Open(absPath)
 	f.Close()
 	if err != nil {
-		return fmt.Errorf("kerberos failure: unable to find conf file '%s': %v", absPath, err)
+		return nil, fmt.Errorf("kerberos failure: unable to find conf file '%s': %v", absPath, err)
 	}
 
-	kerberosConfig, err := config.Load(auth.ConfFile)
+	kerberosConfig, err := config.Load(absPath)
 	if err != nil {
-		return fmt.Errorf("kerberos failure: invalid configuration: %v", err)
+		return nil, fmt.Errorf("kerberos failure: invalid configuration: %v", err)
 	}
 
 	kc, err := auth.Method.NewClient()
 	if err != nil {
-		return fmt.Errorf("kerberos failure: %v", err)
+		return nil, fmt.Errorf("kerberos failure: %v", err)
 	}
 
 	kerberosClient := kc.WithConfig(kerberosConfig)
 
-	if err = kerberosClient.Login(); err != nil {
-		return fmt.Errorf("kerberos failure: login: %v", err)
+	if _, fromCCache := auth.FromCCache(); fromCCache {
+		// the ccache has no password or keytab to login with, its ticket granting ticket is used instead.
+		if ok, err := kerberosClient.IsConfigured(); !ok {
+			return nil, fmt.Errorf("kerberos failure: ccache: %v", err)
+		}
+	} else if err = kerberosClient.Login(); err != nil {
+		return nil, fmt.Errorf("kerberos failure: login: %v", err)
 	}
 
-	c.PersistentRequestModifier = func(r *http.Request) error {
-		return kerberosClient.SetSPNEGOHeader(r, fmt.Sprintf("%s/%s", "HTTP", r.URL.Hostname()))
-	}
-
-	authPath := "api/auth"
-	resp, err := c.Do(http.MethodGet, authPath, contentTypeJSON, nil)
-	if resp == nil || (resp.StatusCode == http.StatusNotFound) {
-		return errUnknownPath(c, authPath)
-	}
+	return kerberosClient, nil
+}
 
-	if err != nil {
-		return fmt.Errorf("kerberos failure: unable to send SPNEGO header: %v", err)
-	}
+// ticketAcquirer returns the `acquireTicket` of the `kerberosSession`, see `WithKerberosRenewal` and `Client#do`.
+func (auth KerberosAuthentication) ticketAcquirer() func() (kerberosTicket, error) {
+	return func() (kerberosTicket, error) {
+		ticket, err := auth.acquireTicket()
+		if err != nil {
+			return nil, err
+		}
 
-	if err = c.ReadJSON(resp, &c.User); err != nil {
-		return err
+		return ticket, nil
 	}
-
-	c.Config.Token = c.User.Token // update the config's one as well for any case.
-	return nil
 }
 
 // KerberosAuthenticationMethod is the interface which all available kerberos authentication methods are implement.
@@ -204,14 +295,32 @@ func (m KerberosWithPassword) NewClient() (client.Client, error) {
 		return emptyClient, fmt.Errorf("with password: 'Username' and 'Password' are both required")
 	}
 
-	c := client.NewClientWithPassword(m.Username, m.Realm, m.Password)
+	username, realm := splitPrincipal(m.Username, m.Realm)
+	c := client.NewClientWithPassword(username, realm, m.Password)
 	return c, nil
 }
 
-// KerberosWithKeytab is a `KerberosAuthenticationMethod` using a username and a keytab file path and optionally a realm.
+// splitPrincipal accepts the "username" in its principal form, i.e "svc-lenses@EXAMPLE.COM",
+// the realm of the principal is used if the "realm" is empty.
+func splitPrincipal(username, realm string) (string, string) {
+	idx := strings.LastIndexByte(username, '@')
+	if idx == -1 {
+		return username, realm
+	}
+
+	if realm == "" {
+		realm = username[idx+1:]
+	}
+
+	return username[:idx], realm
+}
+
+// KerberosWithKeytab is a `KerberosAuthenticationMethod` using a username and a keytab file path and optionally a realm,
+// the way to run as a service account without a prompt.
 //
 // The `KerberosAuthentication` calls its `NewClient`.
 type KerberosWithKeytab struct {
+	// Username is the principal of the keytab, i.e "svc-lenses" or "svc-lenses@EXAMPLE.COM".
 	Username string `json:"username" yaml:"Username" survey:"username"`
 
 	// Realm is optional, if empty then default is used.
@@ -232,7 +341,8 @@ func (m KerberosWithKeytab) NewClient() (client.Client, error) {
 		return emptyClient, fmt.Errorf("with keytab: unable to load keytab file '%s': %v", m.KeytabFile, err)
 	}
 
-	c := client.NewClientWithKeytab(m.Username, m.Realm, kt)
+	username, realm := splitPrincipal(m.Username, m.Realm)
+	c := client.NewClientWithKeytab(username, realm, kt)
 	return c, nil
 }
 
@@ -240,16 +350,45 @@ func (m KerberosWithKeytab) NewClient() (client.Client, error) {
 //
 // The `KerberosAuthentication` calls its `NewClient`.
 type KerberosFromCCache struct {
-	// CCacheFile should be filled with the ccache file path.
+	// CCacheFile is the ccache file path, if empty then the KRB5CCNAME environment variable is used,
+	// i.e the credential cache of a previous `kinit`.
 	CCacheFile string `json:"ccacheFile" yaml:"CCacheFile" survey:"ccache"`
 }
 
+// Path returns the ccache file path, the `CCacheFile` or the KRB5CCNAME environment variable, without its "FILE:" prefix.
+// It fails if the credential cache is not a file, i.e a "KEYRING:" or a "DIR:" one.
+func (m KerberosFromCCache) Path() (string, error) {
+	path := m.CCacheFile
+	if path == "" {
+		path = os.Getenv(KerberosCCacheEnv)
+	}
+
+	if idx := strings.IndexByte(path, ':'); idx > 0 && filepath.VolumeName(path) == "" {
+		if typ := path[:idx]; typ != "FILE" {
+			return "", fmt.Errorf("unsupported ccache [%s], only FILE credential caches are supported", path)
+		}
+
+		path = path[idx+1:]
+	}
+
+	return path, nil
+}
+
 // NewClient implements the `KerberosAuthenticationMethod` for the `KerberosFromCCache`.
 func (m KerberosFromCCache) NewClient() (client.Client, error) {
+	path, err := m.Path()
+	if err != nil {
+		return emptyClient, fmt.Errorf("from ccache: %v", err)
+	}
+
+	if path == "" {
+		return emptyClient, fmt.Errorf("from ccache: 'CCacheFile' or the %s environment variable is required", KerberosCCacheEnv)
+	}
+
 	// load from ccache.
-	cc, err := credentials.LoadCCache(m.CCacheFile)
+	cc, err := credentials.LoadCCache(path)
 	if err != nil {
-		return emptyClient, fmt.Errorf("from ccache: unable to load ccache file '%s': %v", m.CCacheFile, err)
+		return emptyClient, fmt.Errorf("from ccache: unable to load ccache file '%s': %v", path, err)
 	}
 
 	c, err := client.NewClientFromCCache(cc)
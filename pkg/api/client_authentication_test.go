@@ -0,0 +1,96 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setEnv(t *testing.T, key, value string) func() {
+	prev, had := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		if had {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestKerberosAuthenticationValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        KerberosAuthenticationMethod
+		ccacheEnv     string
+		expectedErr   string
+		expectedValid bool
+	}{
+		{name: "no method", expectedErr: "kerberos authentication: method is required, with password, keytab or ccache"},
+		{name: "keytab and principal", method: KerberosWithKeytab{Username: "svc-lenses@EXAMPLE.COM", KeytabFile: "/tmp/svc.keytab"}, expectedValid: true},
+		{name: "keytab without principal", method: KerberosWithKeytab{KeytabFile: "/tmp/svc.keytab"}, expectedErr: "kerberos authentication: username and keytab file are both required"},
+		{name: "principal without keytab", method: KerberosWithKeytab{Username: "svc-lenses"}, expectedErr: "kerberos authentication: username and keytab file are both required"},
+		{name: "ccache file", method: KerberosFromCCache{CCacheFile: "/tmp/krb5cc_1000"}, expectedValid: true},
+		{name: "ccache from env", method: KerberosFromCCache{}, ccacheEnv: "FILE:/tmp/krb5cc_1000", expectedValid: true},
+		{name: "ccache missing", method: KerberosFromCCache{}, expectedErr: "kerberos authentication: ccache file is required, set it or the KRB5CCNAME environment variable"},
+		{name: "ccache not a file", method: KerberosFromCCache{}, ccacheEnv: "KEYRING:persistent:1000", expectedErr: "kerberos authentication: unsupported ccache [KEYRING:persistent:1000], only FILE credential caches are supported"},
+		{name: "password", method: KerberosWithPassword{Username: "user", Password: "pass"}, expectedValid: true},
+		// the password may be prompted, so the config is still valid.
+		{name: "password missing", method: KerberosWithPassword{Username: "user"}, expectedErr: "kerberos authentication: username and password are both required", expectedValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer setEnv(t, KerberosCCacheEnv, tt.ccacheEnv)()
+
+			cfg := ClientConfig{Host: "http://localhost:3030", Authentication: KerberosAuthentication{Method: tt.method}}
+			if tt.expectedErr == "" {
+				assert.Nil(t, cfg.Validate())
+			} else {
+				assert.EqualError(t, cfg.Validate(), tt.expectedErr)
+			}
+
+			assert.Equal(t, tt.expectedValid, cfg.IsValid())
+		})
+	}
+}
+
+func TestKerberosDefaultPaths(t *testing.T) {
+	defer setEnv(t, KerberosConfEnv, "")()
+	defer setEnv(t, KerberosCCacheEnv, "FILE:/tmp/krb5cc_1000")()
+
+	auth := KerberosAuthentication{}
+	assert.Equal(t, DefaultKerberosConfFile, auth.ConfPath())
+
+	os.Setenv(KerberosConfEnv, "/opt/krb5.conf"+string(os.PathListSeparator)+"/etc/krb5.conf")
+	assert.Equal(t, "/opt/krb5.conf", auth.ConfPath())
+
+	auth.ConfFile = "/etc/lenses/krb5.conf"
+	assert.Equal(t, "/etc/lenses/krb5.conf", auth.ConfPath())
+
+	path, err := KerberosFromCCache{}.Path()
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/krb5cc_1000", path)
+
+	path, err = KerberosFromCCache{CCacheFile: "/tmp/other"}.Path()
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/other", path)
+}
+
+func TestSplitPrincipal(t *testing.T) {
+	username, realm := splitPrincipal("svc-lenses@EXAMPLE.COM", "")
+	assert.Equal(t, "svc-lenses", username)
+	assert.Equal(t, "EXAMPLE.COM", realm)
+
+	username, realm = splitPrincipal("svc-lenses@EXAMPLE.COM", "OTHER.COM")
+	assert.Equal(t, "svc-lenses", username)
+	assert.Equal(t, "OTHER.COM", realm)
+
+	username, realm = splitPrincipal("svc-lenses", "")
+	assert.Equal(t, "svc-lenses", username)
+	assert.Equal(t, "", realm)
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientLastResponse(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", r.URL.Path)
+		w.Header().Set("X-RateLimit-Remaining", "41")
+		if r.URL.Path == "/api/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+	assert.Nil(t, client.LastResponse())
+
+	var v map[string]interface{}
+	resp, err := client.Do(http.MethodGet, "api/found", "", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, client.ReadJSON(resp, &v))
+
+	last := client.LastResponse()
+	assert.Equal(t, http.StatusOK, last.StatusCode)
+	assert.Equal(t, "/api/found", last.Header.Get("X-Request-Id"))
+	assert.Equal(t, "41", last.Header.Get("X-RateLimit-Remaining"))
+
+	// failed calls are captured too.
+	_, err = client.Do(http.MethodGet, "api/missing", "", nil)
+	assert.NotNil(t, err)
+
+	last = client.LastResponse()
+	assert.Equal(t, http.StatusNotFound, last.StatusCode)
+	assert.Equal(t, "/api/missing", last.Header.Get("X-Request-Id"))
+}
+
+func TestClientContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// hang until the test ends.
+		<-release
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+	defer close(release)
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	calls := map[string]func(ctx context.Context) error{
+		"GetConnections": func(ctx context.Context) error {
+			_, err := client.GetConnectionsContext(ctx)
+			return err
+		},
+		"GetGroups": func(ctx context.Context) error {
+			_, err := client.GetGroupsContext(ctx)
+			return err
+		},
+		"CreateTopic": func(ctx context.Context) error {
+			return client.CreateTopicContext(ctx, "payments", 1, 1, nil)
+		},
+		"UpdateConnector": func(ctx context.Context) error {
+			_, err := client.UpdateConnectorContext(ctx, "dev", "sink", nil)
+			return err
+		},
+		"DeleteServiceAccount": func(ctx context.Context) error {
+			return client.DeleteServiceAccountContext(ctx, "svc")
+		},
+	}
+
+	for name, call := range calls {
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+
+		start := time.Now()
+		err = call(ctx)
+
+		assert.True(t, errors.Is(err, context.Canceled), "%s: expected context canceled error but got: %v", name, err)
+		assert.True(t, time.Since(start) < 5*time.Second, "%s: call did not return promptly", name)
+	}
+}
+
+func TestDataPolicyRequestValidate(t *testing.T) {
+	policy := DataPolicyRequest{
+		Name:        "pii",
+		Category:    "Address",
+		ImpactType:  "HIGH",
+		Obfuscation: RedactionFirst1,
+		Fields:      []string{"address"},
+	}
+	assert.Nil(t, policy.Validate())
+
+	for _, redaction := range DataPolicyRedactions {
+		assert.Nil(t, ValidateRedaction(redaction))
+	}
+
+	policy.Obfuscation = "first-1"
+	assert.NotNil(t, policy.Validate())
+
+	policy.Obfuscation = ""
+	assert.NotNil(t, policy.Validate())
+
+	policy.Obfuscation = RedactionEmail
+	policy.Fields = nil
+	assert.Equal(t, errRequired("fields"), policy.Validate())
+}
+
+func TestResourceErrorServerMessage(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/json":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error_code": 409, "message": "Topic [payments] already exists", "requestId": "req-1"}`))
+		case "/api/v1/json-header":
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Request-Id", "req-2")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "validation failed", "fields": [{"name": "is required"}]}`))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("<html>oops</html>"))
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	_, err = client.Do(http.MethodPost, "api/v1/json", "", nil)
+	resErr, ok := err.(ResourceError)
+	if assert.True(t, ok) {
+		assert.Equal(t, http.StatusConflict, resErr.Code())
+		assert.Equal(t, "Topic [payments] already exists", resErr.Message())
+		assert.Equal(t, "req-1", resErr.RequestID())
+	}
+
+	_, err = client.Do(http.MethodPost, "api/v1/json-header", "", nil)
+	resErr, ok = err.(ResourceError)
+	if assert.True(t, ok) {
+		assert.Equal(t, "validation failed name:is required", resErr.Message())
+		assert.Equal(t, "req-2", resErr.RequestID())
+	}
+
+	_, err = client.Do(http.MethodPost, "api/v1/html", "", nil)
+	resErr, ok = err.(ResourceError)
+	if assert.True(t, ok) {
+		assert.Equal(t, "", resErr.Message())
+		assert.Equal(t, "", resErr.RequestID())
+		assert.Equal(t, "<html>oops</html>", resErr.Body)
+	}
+}
+
+func TestOpenConnectionTimeout(t *testing.T) {
+	client, err := OpenConnection(ClientConfig{Host: "http://domain.com", Token: "secret", Timeout: "15s"})
+	assert.Nil(t, err)
+	assert.Equal(t, 15*time.Second, client.Timeout())
+
+	_, err = OpenConnection(ClientConfig{Host: "http://domain.com", Token: "secret", Timeout: "15"})
+	assert.NotNil(t, err)
+}
+
+func TestServiceAccountEqual(t *testing.T) {
+	svcacc := ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"dev", "ops"}}
+
+	assert.True(t, svcacc.Equal(ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"ops", "dev"}}))
+	assert.False(t, svcacc.Equal(ServiceAccount{Name: "ingestion", Owner: "other", Groups: []string{"dev", "ops"}}))
+	assert.False(t, svcacc.Equal(ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"dev"}}))
+	assert.False(t, svcacc.Equal(ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"dev", "dev"}}))
+}
+
+func TestGetProcessorsInNamespace(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/streams", r.URL.Path)
+		assert.Equal(t, "team-a", r.URL.Query().Get("namespace"))
+
+		// a server which ignores the filter.
+		w.Write([]byte(`{"streams": [{"id": "1", "name": "p1", "namespace": "team-a"}, {"id": "2", "name": "p2", "namespace": "team-b"}]}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	processors, err := client.GetProcessorsInNamespace("team-a")
+	assert.Nil(t, err)
+	if assert.Len(t, processors.Streams, 1) {
+		assert.Equal(t, "p1", processors.Streams[0].Name)
+	}
+}
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrClientClosed fires on the calls of a `Client` after its `Close`.
+var ErrClientClosed = errors.New("client: closed")
+
+// Close releases the resources of the client, the idle connections of its HTTP client and the kerberos ticket,
+// which stops its background renewal. The client is unusable after Close, its calls fail with the `ErrClientClosed`,
+// a new one should be created using the `OpenConnection`.
+//
+// It's safe to call it more than once and concurrently, only the first call releases the resources.
+func (c *Client) Close() error {
+	if !atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
+		return nil
+	}
+
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+
+	if c.kerberos != nil {
+		c.kerberos.destroy()
+	}
+
+	return nil
+}
+
+func (c *Client) isClosed() bool {
+	return atomic.LoadInt32(&c.closed) == 1
+}
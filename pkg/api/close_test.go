@@ -0,0 +1,86 @@
+package api
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type destroyableTicket struct {
+	fakeKerberosTicket
+	destroyed *int32
+}
+
+func (t destroyableTicket) Destroy() { atomic.AddInt32(t.destroyed, 1) }
+
+func TestClientClose(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		states = make(map[net.Conn]http.ConnState)
+	)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		mu.Lock()
+		states[conn] = state
+		mu.Unlock()
+	}
+	server.Start()
+	defer server.Close()
+
+	connState := func(state http.ConnState) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, s := range states {
+			if s != state {
+				return false
+			}
+		}
+		return len(states) > 0
+	}
+
+	waitConnState := func(state http.ConnState) {
+		for deadline := time.Now().Add(2 * time.Second); !connState(state); time.Sleep(5 * time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the connections to be %s", state)
+			}
+		}
+	}
+
+	c, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var destroyed int32
+	c.setKerberosTicket(destroyableTicket{fakeKerberosTicket: 1, destroyed: &destroyed}, nil)
+
+	_, err = c.GetTopics()
+	assert.Nil(t, err)
+	waitConnState(http.StateIdle)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, c.Close())
+		}()
+	}
+	wg.Wait()
+	assert.Nil(t, c.Close())
+
+	waitConnState(http.StateClosed)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&destroyed))
+
+	_, err = c.GetTopics()
+	assert.Equal(t, ErrClientClosed, err)
+}
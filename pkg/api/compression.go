@@ -0,0 +1,49 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"sync/atomic"
+)
+
+// DefaultRequestCompressionMinSize is a good request body size, in bytes, to start compressing from,
+// i.e the large processors and connectors of the cli imports, see `WithRequestCompression`.
+const DefaultRequestCompressionMinSize = 64 * 1024
+
+// WithRequestCompression makes the client gzip compress the request bodies of "minSize" bytes or more,
+// with a "Content-Encoding: gzip" header. Zero or negative "minSize" disables the compression, the default.
+//
+// If the server does not support compressed requests, it replies with a 415 status code,
+// the request is sent again uncompressed and the client stops compressing its requests.
+func WithRequestCompression(minSize int) ConnectionOption {
+	return func(c *Client) {
+		c.requestCompressionMinSize = minSize
+	}
+}
+
+// WithoutResponseCompression stops the client from asking for gzip compressed responses,
+// by default the client sends an "Accept-Encoding: gzip" header and decompresses the responses transparently.
+func WithoutResponseCompression() ConnectionOption {
+	return func(c *Client) {
+		c.responseCompressionDisabled = true
+	}
+}
+
+func (c *Client) shouldCompressRequest(send []byte) bool {
+	return c.requestCompressionMinSize > 0 && len(send) >= c.requestCompressionMinSize &&
+		atomic.LoadInt32(&c.requestCompressionRejected) == 0
+}
+
+func gzipCompress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(b); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newGzipServer echoes the "/api/echo" request bodies, it decompresses the gzip requests
+// (or rejects them if not "acceptsGzip") and compresses the responses when the client accepts it.
+func newGzipServer(t *testing.T, acceptsGzip bool, compressedRequests *int) *httptest.Server {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/echo" {
+			w.Write([]byte("[]"))
+			return
+		}
+
+		raw, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(len(raw)), r.ContentLength)
+
+		body := raw
+		if r.Header.Get(contentEncodingHeaderKey) == gzipEncodingHeaderValue {
+			*compressedRequests++
+			if !acceptsGzip {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+
+			gr, err := gzip.NewReader(bytes.NewReader(raw))
+			assert.Nil(t, err)
+			body, err = ioutil.ReadAll(gr)
+			assert.Nil(t, err)
+		}
+
+		if !strings.Contains(r.Header.Get(acceptEncodingHeaderKey), gzipEncodingHeaderValue) {
+			w.Write(body)
+			return
+		}
+
+		w.Header().Set(contentEncodingHeaderKey, gzipEncodingHeaderValue)
+		gw := gzip.NewWriter(w)
+		gw.Write(body)
+		gw.Close()
+	})
+
+	return httptest.NewServer(h)
+}
+
+func echo(t *testing.T, client *Client, send []byte) []byte {
+	resp, err := client.Do(http.MethodPost, "api/echo", contentTypeJSON, send)
+	assert.Nil(t, err)
+
+	b, err := client.ReadResponseBody(resp)
+	assert.Nil(t, err)
+	return b
+}
+
+func TestRequestCompression(t *testing.T) {
+	var compressed int
+	server := newGzipServer(t, true, &compressed)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithRequestCompression(1024))
+	assert.Nil(t, err)
+
+	small := []byte(`{"name": "small"}`)
+	assert.Equal(t, small, echo(t, client, small))
+	assert.Equal(t, 0, compressed)
+
+	large := []byte(`[` + strings.Repeat(`{"name": "large"},`, 1000) + `{}]`)
+	assert.Equal(t, large, echo(t, client, large))
+	assert.Equal(t, 1, compressed)
+	assert.Equal(t, gzipEncodingHeaderValue, client.LastResponse().Header.Get(contentEncodingHeaderKey))
+}
+
+func TestRequestCompressionUnsupported(t *testing.T) {
+	var compressed int
+	server := newGzipServer(t, false, &compressed)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithRequestCompression(1))
+	assert.Nil(t, err)
+
+	send := []byte(`{"name": "rejected"}`)
+	// sent again uncompressed.
+	assert.Equal(t, send, echo(t, client, send))
+	assert.Equal(t, 1, compressed)
+
+	// and not compressed anymore.
+	assert.Equal(t, send, echo(t, client, send))
+	assert.Equal(t, 1, compressed)
+}
+
+func TestWithoutResponseCompression(t *testing.T) {
+	var compressed int
+	server := newGzipServer(t, true, &compressed)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithoutResponseCompression())
+	assert.Nil(t, err)
+
+	send := []byte(`{"name": "plain"}`)
+	assert.Equal(t, send, echo(t, client, send))
+	assert.Empty(t, client.LastResponse().Header.Get(contentEncodingHeaderKey))
+}
+
+func TestReadEmptyGzipResponse(t *testing.T) {
+	client := &Client{Config: &ClientConfig{}}
+	resp := &http.Response{
+		Header: http.Header{contentEncodingHeaderKey: []string{gzipEncodingHeaderValue}},
+		Body:   ioutil.NopCloser(bytes.NewReader(nil)),
+	}
+
+	b, err := client.ReadResponseBody(resp)
+	assert.Nil(t, err)
+	assert.Empty(t, b)
+}
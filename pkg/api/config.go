@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
+	"net/url"
 	"os"
 	"os/user"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 )
 
 const (
@@ -68,12 +71,17 @@ type (
 		// If not empty, overrides any `Authentication` settings.
 		//
 		// If `Token` is expired then all the calls will result on 403 forbidden error HTTP code
-		// and a manual renewal will be demanded.
+		// and a manual renewal will be demanded, unless the `Authentication` is set too, see `Client#relogin`.
 		//
 		// For general-purpose usecase the recommendation is to let this field empty and
 		// fill the `Authentication` field instead.
 		Token string `json:"token,omitempty" yaml:"Token,omitempty" survey:"-"`
 
+		// TokenExpiry is the expiry of the `Token`, nil if unknown.
+		// The client renews the token before that, using the `Authentication`,
+		// see `WithTokenRefreshSkew` and `OnTokenRefresh` too.
+		TokenExpiry *time.Time `json:"tokenExpiry,omitempty" yaml:"TokenExpiry,omitempty" survey:"-"`
+
 		// Timeout specifies the timeout for connection establishment.
 		//
 		// Empty timeout value means no timeout.
@@ -98,6 +106,24 @@ type (
 		//
 		// Defaults to false.
 		Debug bool `json:"debug,omitempty" yaml:"Debug,omitempty" survey:"debug"`
+
+		// DefaultOutput is the output format of the cli commands, "table", "json", "yaml" or "ndjson",
+		// when this context is the active one and the --output flag is not passed.
+		//
+		// Defaults to empty, the cli's default output.
+		DefaultOutput string `json:"defaultOutput,omitempty" yaml:"DefaultOutput,omitempty" survey:"-"`
+
+		// RateLimit throttles the requests of the client, i.e on the import of many resources,
+		// the responses of 429 Too Many Requests are retried after their Retry-After regardless.
+		//
+		// Defaults to nil, no limit.
+		RateLimit *RateLimit `json:"rateLimit,omitempty" yaml:"RateLimit,omitempty" survey:"-"`
+
+		// Transport tunes the idle (keep-alive) connections of the HTTP transport that the client constructs,
+		// i.e for long-running services, they are ignored when a custom HTTP client is supplied.
+		//
+		// Defaults to nil, the defaults of the `http.DefaultTransport`.
+		Transport *TransportOptions `json:"transport,omitempty" yaml:"Transport,omitempty" survey:"-"`
 	}
 )
 
@@ -125,12 +151,96 @@ func (c *ClientConfig) IsValid() bool {
 
 	c.FormatHost()
 
+	if auth, ok := c.Authentication.(KerberosAuthentication); ok {
+		// a keytab without its principal or a missing ccache can't acquire a ticket,
+		// the password may be prompted so it is not checked here.
+		if _, withPassword := auth.WithPassword(); !withPassword && auth.Validate() != nil {
+			return false
+		}
+	}
+
 	return c.Host != "" && (c.Token != "" || c.Authentication != nil)
 }
 
+// Validate is like `IsValid` but it returns a descriptive error of the first invalid field,
+// including the authentication's required fields and the `Timeout` format.
+// Unlike the `IsValid` it doesn't modify the configuration, the `Host` is not formatted.
+func (c *ClientConfig) Validate() error {
+	if c.Host == "" {
+		return fmt.Errorf("host is required")
+	}
+
+	if c.Timeout != "" {
+		if _, err := time.ParseDuration(c.Timeout); err != nil {
+			return fmt.Errorf("invalid timeout [%s]: %v", c.Timeout, err)
+		}
+	}
+
+	switch strings.ToLower(c.DefaultOutput) {
+	case "", "table", "json", "yaml", "ndjson":
+	default:
+		return fmt.Errorf("invalid default output [%s], expected table, json, yaml or ndjson", c.DefaultOutput)
+	}
+
+	if c.RateLimit != nil {
+		if err := c.RateLimit.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Transport != nil {
+		if err := c.Transport.Validate(); err != nil {
+			return err
+		}
+	}
+
+	switch auth := c.Authentication.(type) {
+	case nil:
+		if c.Token == "" {
+			return fmt.Errorf("token or authentication is required")
+		}
+	case BasicAuthentication:
+		if auth.Username == "" || auth.Password == "" {
+			return fmt.Errorf("basic authentication: username and password are both required")
+		}
+	case KerberosAuthentication:
+		if err := auth.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // DefaultContextKey is used to set an empty client configuration when no custom context available.
 var DefaultContextKey = "master"
 
+// NewConfiguration returns a `Config` of a single context, the "cfg", named after the `DefaultContextKey`
+// and set as the `CurrentContext`, for the applications which build their configuration in code instead of a file.
+//
+// The "cfg" is validated, see `ClientConfig#Validate`, and its `Host` is formatted.
+// Use the `Config#GetCurrent` to pass it to the `OpenConnection`.
+func NewConfiguration(cfg ClientConfig) (*Config, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %v", err)
+	}
+
+	cfg.FormatHost()
+	return &Config{
+		CurrentContext: DefaultContextKey,
+		Contexts:       map[string]*ClientConfig{DefaultContextKey: &cfg},
+	}, nil
+}
+
+// NewBasicAuthConfiguration is a shortcut of the `NewConfiguration`
+// for a "host" with the `BasicAuthentication` of the "username" and "password".
+func NewBasicAuthConfiguration(host, username, password string) (*Config, error) {
+	return NewConfiguration(ClientConfig{
+		Host:           host,
+		Authentication: BasicAuthentication{Username: username, Password: password},
+	})
+}
+
 // GetCurrent returns the specific current client configuration based on the `CurrentContext`.
 func (c *Config) GetCurrent() *ClientConfig {
 	if c.Contexts == nil {
@@ -214,6 +324,33 @@ func (c *Config) RemoveContext(contextName string) bool {
 	return false
 }
 
+// RenameContext moves the `oldName` context to the `newName`,
+// the `CurrentContext` follows the rename if it was the `oldName`.
+// It fails if the `oldName` does not exist or the `newName` is empty or already taken.
+func (c *Config) RenameContext(oldName, newName string) error {
+	cfg, ok := c.Contexts[oldName]
+	if !ok {
+		return fmt.Errorf("context [%s] does not exist", oldName)
+	}
+
+	if newName == "" {
+		return fmt.Errorf("new context name is required")
+	}
+
+	if _, exists := c.Contexts[newName]; exists {
+		return fmt.Errorf("context [%s] already exists", newName)
+	}
+
+	delete(c.Contexts, oldName)
+	c.Contexts[newName] = cfg
+
+	if c.CurrentContext == oldName {
+		c.SetCurrent(newName)
+	}
+
+	return nil
+}
+
 // Clone will returns a deep clone of the this `Config`.
 func (c *Config) Clone() Config {
 	clone := Config{CurrentContext: c.CurrentContext}
@@ -255,6 +392,7 @@ func (c *ClientConfig) Fill(other ClientConfig) bool {
 
 	if v := other.Token; v != "" && v != c.Token {
 		c.Token = v
+		c.TokenExpiry = other.TokenExpiry
 	}
 
 	if v := other.Timeout; v != "" && v != c.Timeout {
@@ -270,49 +408,77 @@ func (c *ClientConfig) Fill(other ClientConfig) bool {
 		c.Insecure = v
 	}
 
+	if v := other.DefaultOutput; v != "" {
+		c.DefaultOutput = v
+	}
+
+	if v := other.RateLimit; v != nil {
+		c.RateLimit = v
+	}
+
+	if v := other.Transport; v != nil {
+		c.Transport = v
+	}
+
 	return c.IsValid()
 }
 
 // FormatHost will try to make sure that the schema:host:port pattern is followed on the `Host` field.
+// The scheme defaults to https for the port 443 and to http otherwise, the port defaults to 443 for https and to 80 otherwise,
+// IPv6 literals, i.e "[::1]:9991", and path prefixes, i.e "https://gateway.com/lenses", are kept as they are.
 func (c *ClientConfig) FormatHost() {
 	if len(c.Host) == 0 {
 		return
 	}
 
-	// remove last slash, so the API can append the path with ease.
-	if c.Host[len(c.Host)-1] == '/' {
-		c.Host = c.Host[0 : len(c.Host)-1]
+	raw := c.Host
+	hasSchema := strings.Contains(raw, "://")
+	if !hasSchema {
+		// a bare IPv6 literal, i.e "::1", needs its brackets to be parsed as a host.
+		if ip := net.ParseIP(strings.TrimSuffix(raw, "/")); ip != nil && strings.Contains(raw, ":") {
+			raw = "[" + strings.TrimSuffix(raw, "/") + "]"
+		}
+		raw = "http://" + raw
 	}
 
-	portIdx := strings.LastIndexByte(c.Host, ':')
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		// not a URL, just remove the last slash, so the API can append the path with ease.
+		c.Host = strings.TrimRight(c.Host, "/")
+		return
+	}
 
-	schemaIdx := strings.Index(c.Host, "://")
-	hasSchema := schemaIdx >= 0
-	hasPort := portIdx > schemaIdx+1
+	port := u.Port()
 
-	var port = "80"
-	if hasPort {
-		port = c.Host[portIdx+1:]
+	// find the schema based on the port.
+	if !hasSchema && port == "443" {
+		u.Scheme = "https"
 	}
 
-	// find the schema based on the port.
-	if !hasSchema {
-		if port == "443" {
-			c.Host = "https://" + c.Host
-		} else {
-			c.Host = "http://" + c.Host
-		}
-	} else if !hasPort {
-		// has schema but not port.
-		if strings.HasPrefix(c.Host, "https://") {
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
 			port = "443"
 		}
 	}
 
-	// finally, append the port part if it wasn't there.
-	if !hasPort {
-		c.Host += ":" + port
+	u.Host = net.JoinHostPort(u.Hostname(), port)
+	// remove the last slash of the path prefix, so the API can append the path with ease.
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = strings.TrimRight(u.RawPath, "/")
+
+	c.Host = u.String()
+}
+
+// BasePath returns the path prefix of the `Host`, i.e "/lenses" for "https://gateway.com/lenses",
+// the prefix of every request path, it's empty when the Lenses is served on the root of the host.
+func (c *ClientConfig) BasePath() string {
+	u, err := url.Parse(c.Host)
+	if err != nil || u.Host == "" {
+		return ""
 	}
+
+	return strings.TrimRight(u.EscapedPath(), "/")
 }
 
 // IsBasicAuth reports whether the authentication is basic.
@@ -327,6 +493,42 @@ func (c *ClientConfig) IsKerberosAuth() (KerberosAuthentication, bool) {
 	return auth, isKerberosAuth
 }
 
+// plainClientConfig is the `ClientConfig` without its methods, so it's printed without calling its `String` and `GoString` again.
+type plainClientConfig ClientConfig
+
+// masked returns a copy of the configuration with its `Token` and passwords replaced by the `RedactedValue`.
+func (c ClientConfig) masked() plainClientConfig {
+	if c.Token != "" {
+		c.Token = RedactedValue
+	}
+
+	switch auth := c.Authentication.(type) {
+	case BasicAuthentication:
+		if auth.Password != "" {
+			auth.Password = RedactedValue
+		}
+		c.Authentication = auth
+	case KerberosAuthentication:
+		if method, ok := auth.WithPassword(); ok && method.Password != "" {
+			method.Password = RedactedValue
+			auth.Method = method
+		}
+		c.Authentication = auth
+	}
+
+	return plainClientConfig(c)
+}
+
+// String returns the fields of the configuration, like the %+v does, with the `Token` and the passwords masked.
+func (c ClientConfig) String() string {
+	return fmt.Sprintf("%+v", c.masked())
+}
+
+// GoString is like the `String` but for the %#v, so the debug output of the configuration is safe to print.
+func (c ClientConfig) GoString() string {
+	return "api.ClientConfig" + strings.TrimPrefix(fmt.Sprintf("%#+v", c.masked()), "api.plainClientConfig")
+}
+
 // UnmarshalFunc is the most standard way to declare a Decoder/Unmarshaler to read the configurations and more.
 // See `ReadConfig` and `ReadConfigFromFile` for more.
 type UnmarshalFunc func(in []byte, outPtr *Config) error
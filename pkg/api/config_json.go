@@ -52,6 +52,12 @@ func ConfigMarshalJSON(c Config) ([]byte, error) {
 // ConfigUnmarshalJSON parses the JSON-encoded `Config` and stores the result
 // in the `Config` pointed to by "c".
 func ConfigUnmarshalJSON(b []byte, c *Config) error {
+	return configUnmarshalJSON(b, c, true)
+}
+
+// configUnmarshalJSON is the `ConfigUnmarshalJSON`, the contexts without authentication
+// are accepted if not "requireAuth", i.e the overrides of the `LoadMerged`.
+func configUnmarshalJSON(b []byte, c *Config, requireAuth bool) error {
 	var keys map[string]json.RawMessage
 	err := json.Unmarshal(b, &keys)
 	if err != nil {
@@ -89,7 +95,7 @@ func ConfigUnmarshalJSON(b []byte, c *Config) error {
 
 			for k, v := range contextsJSON {
 				var clientConfig ClientConfig
-				if err := ClientConfigUnmarshalJSON(v, &clientConfig); err != nil {
+				if err := clientConfigUnmarshalJSON(v, &clientConfig, requireAuth); err != nil {
 					return err // exit on first failure.
 				}
 
@@ -111,7 +117,8 @@ func ClientConfigMarshalJSON(c ClientConfig) ([]byte, error) {
 	}
 
 	if c.Authentication == nil {
-		return nil, nil
+		// token-only context.
+		return b, nil
 	}
 
 	var (
@@ -135,8 +142,9 @@ func ClientConfigMarshalJSON(c ClientConfig) ([]byte, error) {
 	}
 
 	content = append(append(commaSep, []byte(fmt.Sprintf(`"%s":`, authenticationKey))...), content...)
-	// b = bytes.Replace(b, bracketRightB, append(content, commaSep...), 1)
-	b = bytes.Replace(b, bracketRightB, append(content, bracketRightB...), 1)
+	// before the last bracket, the nested objects, i.e the `RateLimit`, have their own.
+	idx := bytes.LastIndex(b, bracketRightB)
+	b = append(b[:idx], append(content, b[idx:]...)...)
 	return b, nil
 }
 
@@ -181,6 +189,10 @@ func kerberosAuthenticationMarshalJSON(auth KerberosAuthentication) ([]byte, err
 // ClientConfigUnmarshalJSON parses the JSON-encoded `ClientConfig` and stores the result
 // in the `ClientConfig` pointed to by "c".
 func ClientConfigUnmarshalJSON(b []byte, c *ClientConfig) error {
+	return clientConfigUnmarshalJSON(b, c, true)
+}
+
+func clientConfigUnmarshalJSON(b []byte, c *ClientConfig, requireAuth bool) error {
 	// first unmarshal the known types.
 	if err := json.Unmarshal(b, c); err != nil {
 		return err
@@ -236,6 +248,11 @@ func ClientConfigUnmarshalJSON(b []byte, c *ClientConfig) error {
 		return nil
 	}
 
+	// a token is enough to connect.
+	if c.Token != "" || !requireAuth {
+		return nil
+	}
+
 	return fmt.Errorf("json: unknown or missing authentication key")
 }
 
@@ -124,3 +124,18 @@ func TestKerberosAuthenticationJSON_FromCCache(t *testing.T) {
 
 	testKerberosAuthenticationJSON(t, expectedAuthStr, testKerberosMethodFromCCacheField)
 }
+
+func TestKerberosAuthenticationJSON_WithKeytabPrincipal(t *testing.T) {
+	expectedAuthStr := fmt.Sprintf(`"%s":{"username":"%s","realm":"","keytabFile":"%s"}`,
+		kerberosWithKeytabMethodKeyJSON,
+		"svc-lenses@EXAMPLE.COM",
+		testKerberosKeytabField,
+	)
+
+	testKerberosAuthenticationJSON(t, expectedAuthStr, KerberosWithKeytab{Username: "svc-lenses@EXAMPLE.COM", KeytabFile: testKerberosKeytabField})
+}
+
+func TestKerberosAuthenticationJSON_FromCCacheEnv(t *testing.T) {
+	expectedAuthStr := fmt.Sprintf(`"%s":{"ccacheFile":""}`, kerberosFromCCacheMethodKeyJSON)
+	testKerberosAuthenticationJSON(t, expectedAuthStr, KerberosFromCCache{})
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"os"
+)
+
+// LoadMerged reads the configuration files of the "paths" in order and merges them into one,
+// i.e a shared base configuration and the user's overrides.
+//
+// The contexts of a file are added to the ones of the previous files or, if they already exist,
+// their fields are overridden through the `ClientConfig#Fill`, so the later files win per field, see `Config#Merge`.
+// The `CurrentContext` is the last non-empty one.
+//
+// Unlike the `TryReadConfigFromFile`, a context may not define its authentication, it can override only some fields of a previous one,
+// call the `Config#IsValid` to check the final result.
+// It fails if any of the files does not exist or is not a JSON or a YAML configuration.
+//
+// The passwords are read as they are, see `LoadMergedWith` to decrypt the ones of the files saved by the cli.
+func LoadMerged(paths ...string) (*Config, error) {
+	return LoadMergedWith(nil, paths...)
+}
+
+// LoadMergedWith is like `LoadMerged` but it calls the "layer", if not nil, with the configuration of each file
+// before its merge, i.e to decrypt the passwords of its contexts, which are encrypted with the hosts of that file.
+func LoadMergedWith(layer func(c *Config), paths ...string) (*Config, error) {
+	merged := &Config{Contexts: make(map[string]*ClientConfig)}
+
+	for _, path := range paths {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("configuration file [%s] not found", path)
+		}
+
+		c, err := readConfigLayer(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if layer != nil {
+			layer(c)
+		}
+
+		merged.Merge(*c)
+	}
+
+	return merged, nil
+}
+
+// readConfigLayer reads a configuration file of the `LoadMerged`, its contexts may be partial.
+func readConfigLayer(path string) (*Config, error) {
+	unmarshalers := []UnmarshalFunc{
+		func(b []byte, c *Config) error { return configUnmarshalJSON(b, c, false) },
+		func(b []byte, c *Config) error { return configUnmarshalYAML(b, c, false) },
+	}
+
+	for _, unmarshaler := range unmarshalers {
+		c := new(Config)
+		if err := ReadConfigFromFile(path, unmarshaler, c); err == nil {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("configuration file [%s] is not formatted to a compatible document: JSON, YAML", path)
+}
+
+// Merge overlays the "other" configuration onto "c", i.e the defaults of one source and a partial configuration of another.
+// The contexts of the "other" are added or, if they already exist, their fields are overridden through the `ClientConfig#Fill`,
+// the `CurrentContext` of the "other" is used when it's not empty. The "other"'s contexts are copied, see `Config#Clone`.
+func (c *Config) Merge(other Config) {
+	if c.Contexts == nil {
+		c.Contexts = make(map[string]*ClientConfig)
+	}
+
+	for name, cfg := range other.Contexts {
+		if cfg == nil {
+			continue
+		}
+
+		if existing, ok := c.Contexts[name]; ok && existing != nil {
+			existing.Fill(*cfg)
+			continue
+		}
+
+		clone := *cfg
+		c.Contexts[name] = &clone
+	}
+
+	if other.CurrentContext != "" {
+		c.CurrentContext = other.CurrentContext
+	}
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadMerged(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lenses-config-merge")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	base := filepath.Join(dir, "base.yml")
+	assert.Nil(t, ioutil.WriteFile(base, []byte(`CurrentContext: master
+Contexts:
+  master:
+    Host: https://shared.lenses.io
+    Timeout: 15s
+    Basic:
+      Username: shared
+      Password: shared-pass
+  dev:
+    Host: https://dev.lenses.io
+    Token: dev-token
+`), 0600))
+
+	// partial contexts, without authentication, and no current context.
+	user := filepath.Join(dir, "user.json")
+	assert.Nil(t, ioutil.WriteFile(user, []byte(`{
+  "contexts": {
+    "master": {"timeout": "1m", "debug": true},
+    "local": {"host": "http://localhost:3030", "token": "local-token"}
+  }
+}`), 0600))
+
+	c, err := LoadMerged(base, user)
+	assert.Nil(t, err)
+
+	assert.Equal(t, "master", c.CurrentContext)
+	assert.Len(t, c.Contexts, 3)
+
+	master := c.Contexts["master"]
+	assert.Equal(t, "https://shared.lenses.io:443", master.Host)
+	assert.Equal(t, "1m", master.Timeout)
+	assert.True(t, master.Debug)
+	assert.Equal(t, BasicAuthentication{Username: "shared", Password: "shared-pass"}, master.Authentication)
+
+	assert.Equal(t, "dev-token", c.Contexts["dev"].Token)
+	assert.Equal(t, "local-token", c.Contexts["local"].Token)
+	assert.True(t, c.IsValid())
+
+	// the last non-empty current context wins.
+	current := filepath.Join(dir, "current.yml")
+	assert.Nil(t, ioutil.WriteFile(current, []byte("CurrentContext: dev\n"), 0600))
+	c, err = LoadMerged(base, current, user)
+	assert.Nil(t, err)
+	assert.Equal(t, "dev", c.CurrentContext)
+
+	_, err = LoadMerged(base, filepath.Join(dir, "missing.yml"))
+	assert.EqualError(t, err, "configuration file ["+filepath.Join(dir, "missing.yml")+"] not found")
+}
+
+func TestConfigMerge(t *testing.T) {
+	c := Config{
+		CurrentContext: "master",
+		Contexts: map[string]*ClientConfig{
+			"master": {Host: "https://lenses.io:443", Timeout: "15s", Authentication: BasicAuthentication{Username: "user", Password: "pass"}},
+			"dev":    {Host: "https://dev.lenses.io:443", Token: "dev-token"},
+		},
+	}
+
+	other := Config{
+		Contexts: map[string]*ClientConfig{
+			// per field update, the rest are kept.
+			"master": {Timeout: "1m", Debug: true},
+			// added.
+			"local": {Host: "http://localhost:3030", Token: "local-token"},
+		},
+	}
+
+	c.Merge(other)
+
+	assert.Equal(t, "master", c.CurrentContext, "an empty current context should not override the existing one")
+	assert.Len(t, c.Contexts, 3)
+	assert.Equal(t, &ClientConfig{
+		Host:           "https://lenses.io:443",
+		Timeout:        "1m",
+		Debug:          true,
+		Authentication: BasicAuthentication{Username: "user", Password: "pass"},
+	}, c.Contexts["master"])
+	assert.Equal(t, &ClientConfig{Host: "https://dev.lenses.io:443", Token: "dev-token"}, c.Contexts["dev"])
+	assert.Equal(t, &ClientConfig{Host: "http://localhost:3030", Token: "local-token"}, c.Contexts["local"])
+
+	// the added contexts are copies.
+	other.Contexts["local"].Token = "changed"
+	assert.Equal(t, "local-token", c.Contexts["local"].Token)
+
+	// the current context of the other wins.
+	c.Merge(Config{CurrentContext: "dev"})
+	assert.Equal(t, "dev", c.CurrentContext)
+
+	// on an empty configuration.
+	var empty Config
+	empty.Merge(Config{CurrentContext: "local", Contexts: map[string]*ClientConfig{"local": {Host: "http://localhost:3030", Token: "t"}}})
+	assert.Equal(t, "local", empty.CurrentContext)
+	assert.True(t, empty.IsValid())
+}
@@ -229,3 +229,247 @@ Contexts:
 		t.Fatalf("expected result yaml to be written as:\n'%s'\nbut:\n'%s'", expected, got)
 	}
 }
+
+func TestRenameContext(t *testing.T) {
+	c := Config{
+		CurrentContext: "master",
+		Contexts: map[string]*ClientConfig{
+			"master": {Host: testHostField},
+			"dev":    {Host: "https://dev.landoop.com"},
+		},
+	}
+
+	if err := c.RenameContext("dev", "master"); err == nil {
+		t.Fatalf("expected an error when renaming to an existing context")
+	}
+
+	if err := c.RenameContext("missing", "other"); err == nil {
+		t.Fatalf("expected an error when renaming a missing context")
+	}
+
+	if err := c.RenameContext("dev", "staging"); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ContextExists("dev") || c.Contexts["staging"].Host != "https://dev.landoop.com" {
+		t.Fatalf("expected [dev] to be moved to [staging] but got: %#+v", c.Contexts)
+	}
+
+	if c.CurrentContext != "master" {
+		t.Fatalf("expected current context to remain [master] but got [%s]", c.CurrentContext)
+	}
+
+	// rename the current one.
+	if err := c.RenameContext("master", "prod"); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.CurrentContext != "prod" || c.GetCurrent().Host != testHostField {
+		t.Fatalf("expected current context to follow the rename to [prod] but got [%s]", c.CurrentContext)
+	}
+
+	if len(c.Contexts) != 2 {
+		t.Fatalf("expected 2 contexts but got %d", len(c.Contexts))
+	}
+}
+
+func TestTokenOnlyContext(t *testing.T) {
+	expected := Config{
+		CurrentContext: testCurrentContextField,
+		Contexts: map[string]*ClientConfig{
+			testCurrentContextField: {Host: testHostField, Token: "secret", Timeout: testTimeoutField, DefaultOutput: "json"},
+		},
+	}
+
+	yamlContents, err := ConfigMarshalYAML(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jsonContents, err := ConfigMarshalJSON(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fromYAML, fromJSON Config
+	if err = ConfigUnmarshalYAML(yamlContents, &fromYAML); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ConfigUnmarshalJSON(jsonContents, &fromJSON); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(expected, fromYAML) {
+		t.Fatalf("expected yaml configuration to be read as:\n%#+v\nbut got:\n%#+v", expected, fromYAML)
+	}
+
+	if !reflect.DeepEqual(expected, fromJSON) {
+		t.Fatalf("expected json configuration to be read as:\n%#+v\nbut got:\n%#+v", expected, fromJSON)
+	}
+}
+
+func TestNewConfiguration(t *testing.T) {
+	var logins int
+	server := newTokenServer(t, &logins, "token")
+	defer server.Close()
+
+	c, err := NewBasicAuthConfiguration(server.URL+"/", "user", "pass")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, got := DefaultContextKey, c.CurrentContext; expected != got {
+		t.Fatalf("expected current context to be: '%s' but got: '%s'", expected, got)
+	}
+
+	current := c.GetCurrent()
+	if expected, got := server.URL, current.Host; expected != got {
+		t.Fatalf("expected host to be formatted as: '%s' but got: '%s'", expected, got)
+	}
+
+	client, err := OpenConnection(*current)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, got := "token", client.Config.Token; expected != got || logins != 1 {
+		t.Fatalf("expected to login once and get the token: '%s' but got: '%s' after %d logins", expected, got, logins)
+	}
+
+	if _, err = NewConfiguration(ClientConfig{Host: server.URL}); err == nil || err.Error() != "invalid configuration: token or authentication is required" {
+		t.Fatalf("expected a missing authentication error but got: %v", err)
+	}
+
+	if _, err = NewConfiguration(ClientConfig{Host: "localhost:443", Token: "secret", Timeout: "1 minute"}); err == nil {
+		t.Fatal("expected an invalid timeout error")
+	}
+}
+
+func TestClientConfigValidateKeepsHost(t *testing.T) {
+	c := ClientConfig{Host: "lenses.example.com/", Token: "secret"}
+	if err := c.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected, got := "lenses.example.com/", c.Host; expected != got {
+		t.Fatalf("expected the host to stay: '%s' but got: '%s'", expected, got)
+	}
+}
+
+func TestClientConfigFormatMasksSecrets(t *testing.T) {
+	configs := []ClientConfig{
+		{Host: testHostField, Token: "secret-token", Timeout: testTimeoutField, Debug: true, Authentication: testBasicAuthenticationField},
+		{Host: testHostField, Token: "secret-token", Authentication: KerberosAuthentication{
+			ConfFile: testKerberosConfFileField,
+			Method:   KerberosWithPassword{Username: testUsernameField, Password: testPasswordField},
+		}},
+	}
+
+	for _, cfg := range configs {
+		for _, format := range []string{"%v", "%+v", "%#v", "%#+v", "%s"} {
+			got := fmt.Sprintf(format, cfg)
+			if strings.Contains(got, "secret-token") || strings.Contains(got, testPasswordField) {
+				t.Fatalf("expected the token and the password to be masked on %s but got: %s", format, got)
+			}
+
+			if !strings.Contains(got, RedactedValue) || !strings.Contains(got, testHostField) || !strings.Contains(got, testUsernameField) {
+				t.Fatalf("expected the rest of the fields to be visible on %s but got: %s", format, got)
+			}
+		}
+
+		if got := fmt.Sprintf("%#+v", cfg); !strings.HasPrefix(got, "api.ClientConfig{Host:\""+testHostField+"\"") {
+			t.Fatalf("expected the go syntax of the configuration but got: %s", got)
+		}
+	}
+
+	// the configuration itself is not modified.
+	if configs[0].Token != "secret-token" || configs[0].Authentication.(BasicAuthentication).Password != testPasswordField {
+		t.Fatalf("expected the configuration to keep its secrets but got: %#+v", configs[0].masked())
+	}
+}
+
+func TestFormatHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		expected string
+	}{
+		{"domain.com", "http://domain.com:80"},
+		{"domain.com/", "http://domain.com:80"},
+		{"domain.com:443", "https://domain.com:443"},
+		{"domain.com:9991", "http://domain.com:9991"},
+		{"http://domain.com", "http://domain.com:80"},
+		{"https://domain.com", "https://domain.com:443"},
+		{"http://domain.com:443", "http://domain.com:443"},
+		{"https://domain.com:9991/", "https://domain.com:9991"},
+		// IPv6.
+		{"[::1]:9991", "http://[::1]:9991"},
+		{"::1", "http://[::1]:80"},
+		{"http://[::1]", "http://[::1]:80"},
+		{"https://[2001:db8::1]", "https://[2001:db8::1]:443"},
+		{"https://[2001:db8::1]:9991/", "https://[2001:db8::1]:9991"},
+		// path prefixes.
+		{"https://gateway.com/lenses", "https://gateway.com:443/lenses"},
+		{"https://gateway.com/lenses/", "https://gateway.com:443/lenses"},
+		{"gateway.com:8080/lenses", "http://gateway.com:8080/lenses"},
+		{"http://[::1]:9991/lenses/", "http://[::1]:9991/lenses"},
+	}
+
+	for _, tt := range tests {
+		c := ClientConfig{Host: tt.host}
+		c.FormatHost()
+		if c.Host != tt.expected {
+			t.Fatalf("expected host [%s] to be formatted as: '%s' but got: '%s'", tt.host, tt.expected, c.Host)
+		}
+
+		// formatting again doesn't change it.
+		c.FormatHost()
+		if c.Host != tt.expected {
+			t.Fatalf("expected formatted host [%s] to stay the same but got: '%s'", tt.expected, c.Host)
+		}
+	}
+}
+
+func TestFormatHostDefaultPorts(t *testing.T) {
+	// the scheme is inferred from the port and the port from the scheme.
+	expected := map[string]map[string]string{
+		"": {
+			"":      "http://lenses.io:80",
+			":":     "http://lenses.io:80",
+			":80":   "http://lenses.io:80",
+			":443":  "https://lenses.io:443",
+			":9991": "http://lenses.io:9991",
+		},
+		"http://": {
+			"":      "http://lenses.io:80",
+			":":     "http://lenses.io:80",
+			":80":   "http://lenses.io:80",
+			":443":  "http://lenses.io:443",
+			":9991": "http://lenses.io:9991",
+		},
+		"https://": {
+			"":      "https://lenses.io:443",
+			":":     "https://lenses.io:443",
+			":80":   "https://lenses.io:80",
+			":443":  "https://lenses.io:443",
+			":9991": "https://lenses.io:9991",
+		},
+		"HTTPS://": {
+			"":      "https://lenses.io:443",
+			":9991": "https://lenses.io:9991",
+		},
+	}
+
+	for scheme, ports := range expected {
+		for port, formatted := range ports {
+			for _, suffix := range []string{"", "/"} {
+				host := scheme + "lenses.io" + port + suffix
+				c := ClientConfig{Host: host}
+				c.FormatHost()
+				if c.Host != formatted {
+					t.Fatalf("expected host [%s] to be formatted as: '%s' but got: '%s'", host, formatted, c.Host)
+				}
+			}
+		}
+	}
+}
@@ -54,15 +54,16 @@ func ConfigMarshalYAML(c Config) ([]byte, error) {
 
 // ClientConfigMarshalYAML retruns the yaml string as bytes of the given `ClientConfig` structure.
 func ClientConfigMarshalYAML(c ClientConfig) ([]byte, error) {
-	if c.Authentication == nil {
-		return nil, nil
-	}
-
 	b, err := yaml.Marshal(c)
 	if err != nil {
 		return nil, err
 	}
 
+	if c.Authentication == nil {
+		// token-only context.
+		return b, nil
+	}
+
 	var (
 		authenticationKey string
 		content           []byte
@@ -131,6 +132,12 @@ func kerberosAuthenticationMarshalYAML(auth KerberosAuthentication) ([]byte, err
 // ConfigUnmarshalYAML parses the YAML-encoded `Config` and stores the result
 // in the `Config` pointed to by "c".
 func ConfigUnmarshalYAML(b []byte, c *Config) error {
+	return configUnmarshalYAML(b, c, true)
+}
+
+// configUnmarshalYAML is the `ConfigUnmarshalYAML`, the contexts without authentication
+// are accepted if not "requireAuth", i.e the overrides of the `LoadMerged`.
+func configUnmarshalYAML(b []byte, c *Config, requireAuth bool) error {
 	var tree yaml.MapSlice
 	err := yaml.Unmarshal(b, &tree)
 	if err != nil {
@@ -256,8 +263,8 @@ func ConfigUnmarshalYAML(b []byte, c *Config) error {
 					clientConfig.Authentication = BasicAuthentication{Username: username, Password: password}
 				}
 
-				if clientConfig.Authentication == nil {
-					// don't allow empty auth ofc.
+				if requireAuth && clientConfig.Authentication == nil && clientConfig.Token == "" {
+					// don't allow empty auth ofc, a token is enough though.
 					return fmt.Errorf("yaml: unknown or missing authentication key for context [%s]", contextKey)
 				}
 
@@ -150,3 +150,27 @@ func TestKerberosAuthenticationYAML_FromCCache(t *testing.T) {
 
 	testKerberosAuthenticationYAML(t, expectedAuthStr, testKerberosMethodFromCCacheField)
 }
+
+func TestKerberosAuthenticationYAML_WithKeytabPrincipal(t *testing.T) {
+	expectedAuthStr := fmt.Sprintf(`
+      %s:
+        Username: %s
+        Realm: ""
+        KeytabFile: %s`,
+		kerberosWithKeytabMethodKeyYAML,
+		"svc-lenses@EXAMPLE.COM",
+		testKerberosKeytabField,
+	)
+
+	testKerberosAuthenticationYAML(t, expectedAuthStr, KerberosWithKeytab{Username: "svc-lenses@EXAMPLE.COM", KeytabFile: testKerberosKeytabField})
+}
+
+func TestKerberosAuthenticationYAML_FromCCacheEnv(t *testing.T) {
+	expectedAuthStr := fmt.Sprintf(`
+      %s:
+        CCacheFile: ""`,
+		kerberosFromCCacheMethodKeyYAML,
+	)
+
+	testKerberosAuthenticationYAML(t, expectedAuthStr, KerberosFromCCache{})
+}
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/landoop/lenses-go/pkg"
 )
@@ -38,13 +40,30 @@ type Connection struct {
 	ModifiedBy      string             `json:"modifiedBy" yaml:"modifiedBy" header:"Modified By,text"`
 	ModifiedAt      int64              `json:"modifiedAt" yaml:"modifiedAt" header:"Modified At,text"`
 	Tags            []string           `json:"tags" yaml:"tags" header:"Tags,text"`
+	// Status is the last-known health of the connection, it's set only by the `export connections --with-status`
+	// and it's ignored on create and update.
+	Status *ConnectionStatus `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// ConnectionStatus is the health of a connection, see `GetConnectionStatus`.
+type ConnectionStatus struct {
+	Name string `json:"name" yaml:"name" header:"Name,text"`
+	// State is the health of the connection, i.e "UP" or "DOWN".
+	State string `json:"state" yaml:"state" header:"State,text"`
+	// Error is the reason of an unhealthy state, empty otherwise.
+	Error string `json:"error,omitempty" yaml:"error,omitempty" header:"Error,text"`
 }
 
 // GetConnections returns all connections
 func (c *Client) GetConnections() (response []ConnectionList, err error) {
+	return c.GetConnectionsContext(context.Background())
+}
+
+// GetConnectionsContext is like `GetConnections` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectionsContext(ctx context.Context) (response []ConnectionList, err error) {
 	path := fmt.Sprintf("api/%s", pkg.ConnectionsAPIPath)
 
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -58,9 +77,14 @@ func (c *Client) GetConnections() (response []ConnectionList, err error) {
 
 // GetConnection returns a specific connection
 func (c *Client) GetConnection(name string) (response Connection, err error) {
+	return c.GetConnectionContext(context.Background(), name)
+}
+
+// GetConnectionContext is like `GetConnection` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectionContext(ctx context.Context, name string) (response Connection, err error) {
 	path := fmt.Sprintf("api/%s/%s", pkg.ConnectionsAPIPath, name)
 
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -156,6 +180,11 @@ func updateConnectionPayload(connectionName string, configKeyValueArray []Connec
 
 // CreateConnection creates a new Lenses connection
 func (c *Client) CreateConnection(connectionName string, templateName string, configString string, configArray []ConnectionConfig, tags []string) (err error) {
+	return c.CreateConnectionContext(context.Background(), connectionName, templateName, configString, configArray, tags)
+}
+
+// CreateConnectionContext is like `CreateConnection` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateConnectionContext(ctx context.Context, connectionName string, templateName string, configString string, configArray []ConnectionConfig, tags []string) (err error) {
 	if len(configString) != 0 {
 		if configArray, err = parseConnectionConfigurationValues(configString); err != nil {
 			return
@@ -169,7 +198,7 @@ func (c *Client) CreateConnection(connectionName string, templateName string, co
 
 	path := fmt.Sprintf("api/%s", pkg.ConnectionsAPIPath)
 
-	resp, err := c.Do(http.MethodPost, path, contentTypeJSON, jsonPayload)
+	resp, err := c.DoContext(ctx, http.MethodPost, path, contentTypeJSON, jsonPayload)
 	if err != nil {
 		return
 	}
@@ -178,8 +207,44 @@ func (c *Client) CreateConnection(connectionName string, templateName string, co
 	return
 }
 
+// CreateConnectionFromTemplate creates a new Lenses connection of the "templateName", i.e "Kafka",
+// the "props" are validated against the properties of the template before sent, see `ConnectionTemplate#ValidateProperties`.
+func (c *Client) CreateConnectionFromTemplate(connectionName, templateName string, props map[string]interface{}, tags []string) error {
+	return c.CreateConnectionFromTemplateContext(context.Background(), connectionName, templateName, props, tags)
+}
+
+// CreateConnectionFromTemplateContext is like `CreateConnectionFromTemplate` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateConnectionFromTemplateContext(ctx context.Context, connectionName, templateName string, props map[string]interface{}, tags []string) error {
+	template, err := c.GetConnectionTemplateContext(ctx, templateName)
+	if err != nil {
+		return err
+	}
+
+	if err = template.ValidateProperties(props); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	configArray := make([]ConnectionConfig, 0, len(keys))
+	for _, key := range keys {
+		configArray = append(configArray, ConnectionConfig{Key: key, Value: props[key]})
+	}
+
+	return c.CreateConnectionContext(ctx, connectionName, template.Name, "", configArray, tags)
+}
+
 // UpdateConnection updates a Lenses connection
 func (c *Client) UpdateConnection(connectionName string, newName string, configString string, configArray []ConnectionConfig, tags []string) (err error) {
+	return c.UpdateConnectionContext(context.Background(), connectionName, newName, configString, configArray, tags)
+}
+
+// UpdateConnectionContext is like `UpdateConnection` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateConnectionContext(ctx context.Context, connectionName string, newName string, configString string, configArray []ConnectionConfig, tags []string) (err error) {
 	if connectionName == "" {
 		return errRequired("Required argument --connectionName not given")
 	}
@@ -197,7 +262,7 @@ func (c *Client) UpdateConnection(connectionName string, newName string, configS
 
 	path := fmt.Sprintf("api/%s/%s", pkg.ConnectionsAPIPath, connectionName)
 
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, jsonPayload)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, jsonPayload)
 	if err != nil {
 		return
 	}
@@ -206,15 +271,42 @@ func (c *Client) UpdateConnection(connectionName string, newName string, configS
 	return
 }
 
+// CreateConnectionFrom creates a new Lenses connection, i.e one of the `export connections` files,
+// the server-generated fields of the "connection", i.e the `CreatedAt` and the `Status`, are not sent.
+func (c *Client) CreateConnectionFrom(connection Connection) error {
+	return c.CreateConnectionFromContext(context.Background(), connection)
+}
+
+// CreateConnectionFromContext is like `CreateConnectionFrom` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateConnectionFromContext(ctx context.Context, connection Connection) error {
+	return c.CreateConnectionContext(ctx, connection.Name, connection.TemplateName, "", connection.Configuration, connection.Tags)
+}
+
+// UpdateConnectionFrom updates the Lenses connection of the "name" to the "connection",
+// it's renamed if their names differ, see `CreateConnectionFrom`.
+func (c *Client) UpdateConnectionFrom(name string, connection Connection) error {
+	return c.UpdateConnectionFromContext(context.Background(), name, connection)
+}
+
+// UpdateConnectionFromContext is like `UpdateConnectionFrom` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateConnectionFromContext(ctx context.Context, name string, connection Connection) error {
+	return c.UpdateConnectionContext(ctx, name, connection.Name, "", connection.Configuration, connection.Tags)
+}
+
 // DeleteConnection deletes a new Lenses connection
 func (c *Client) DeleteConnection(connectionName string) (err error) {
+	return c.DeleteConnectionContext(context.Background(), connectionName)
+}
+
+// DeleteConnectionContext is like `DeleteConnection` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteConnectionContext(ctx context.Context, connectionName string) (err error) {
 	if connectionName == "" {
 		return errRequired("Required argument connectionName not given")
 	}
 
 	path := fmt.Sprintf("api/%s/%s", pkg.ConnectionsAPIPath, connectionName)
 
-	resp, err := c.Do(http.MethodDelete, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -222,3 +314,31 @@ func (c *Client) DeleteConnection(connectionName string) (err error) {
 
 	return
 }
+
+// GetConnectionStatus returns the health of a connection, its state and any error.
+func (c *Client) GetConnectionStatus(name string) (status ConnectionStatus, err error) {
+	return c.GetConnectionStatusContext(context.Background(), name)
+}
+
+// GetConnectionStatusContext is like `GetConnectionStatus` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectionStatusContext(ctx context.Context, name string) (status ConnectionStatus, err error) {
+	if name == "" {
+		err = errRequired("name")
+		return
+	}
+
+	path := fmt.Sprintf("api/%s/%s/status", pkg.ConnectionsAPIPath, name)
+
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
+	if err != nil {
+		return
+	}
+
+	if err = c.ReadJSON(resp, &status); err != nil {
+		return
+	}
+
+	// the server may not include the name.
+	status.Name = name
+	return
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionPayloads(t *testing.T) {
+	var requests []string
+	var bodies []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		b, err := ioutil.ReadAll(r.Body)
+		assert.Nil(t, err)
+		if len(b) > 0 {
+			var body map[string]interface{}
+			assert.Nil(t, json.Unmarshal(b, &body))
+			bodies = append(bodies, body)
+		}
+	}))
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	connection := Connection{
+		Name:          "slack",
+		TemplateName:  "Slack",
+		Configuration: []ConnectionConfig{{Key: "webhookUrl", Value: "https://hooks.slack.com/"}},
+		Tags:          []string{"alerts"},
+		CreatedBy:     "admin",
+		CreatedAt:     1580392100854,
+		Status:        &ConnectionStatus{Name: "slack", State: "UP"},
+	}
+
+	assert.Nil(t, client.CreateConnectionFrom(connection))
+
+	connection.Name = "slack-alerts"
+	assert.Nil(t, client.UpdateConnectionFrom("slack", connection))
+	assert.Nil(t, client.DeleteConnection("slack-alerts"))
+
+	assert.Equal(t, []string{
+		"POST /api/v1/connection/connections",
+		"PUT /api/v1/connection/connections/slack",
+		"DELETE /api/v1/connection/connections/slack-alerts",
+	}, requests)
+
+	configuration := []interface{}{map[string]interface{}{"key": "webhookUrl", "value": "https://hooks.slack.com/"}}
+	assert.Equal(t, []map[string]interface{}{
+		{"name": "slack", "templateName": "Slack", "configuration": configuration, "tags": []interface{}{"alerts"}},
+		{"name": "slack-alerts", "configuration": configuration, "tags": []interface{}{"alerts"}},
+	}, bodies)
+
+	err = client.CreateConnectionFrom(Connection{Name: "slack", TemplateName: "Slack"})
+	assert.EqualError(t, err, "client: required argument missing")
+}
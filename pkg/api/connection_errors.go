@@ -0,0 +1,75 @@
+package api
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// The classes of the `OpenConnection` failures, check them with the `errors.Is`,
+// i.e re-login only on `ErrAuth`, see `ConnectionError`.
+var (
+	// ErrAuth fires when the server rejects the credentials or the token, or they can't be used or renewed.
+	ErrAuth = errors.New("authentication failed")
+	// ErrUnreachable fires when the server can't be reached, i.e unresolved host, refused connection or timeout.
+	ErrUnreachable = errors.New("server unreachable")
+	// ErrServer fires when the server fails or responds unexpectedly, i.e a 5xx status code or a missing login endpoint.
+	ErrServer = errors.New("server error")
+)
+
+// ConnectionError is the error of a failed `OpenConnection`, its `Class` is one of the `ErrAuth`,
+// `ErrUnreachable` and `ErrServer`. Its message is the message of the cause, which is kept as well,
+// so both the `errors.Is(err, ErrAuth)` and the `errors.Is(err, ErrCredentialsMissing)` match.
+type ConnectionError struct {
+	Class error
+	Err   error
+}
+
+// Error implements the error, it returns the message of the cause.
+func (e *ConnectionError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause.
+func (e *ConnectionError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether the "target" is the class of the error.
+func (e *ConnectionError) Is(target error) bool {
+	return target == e.Class
+}
+
+// classifyConnectionError wraps the "err" of a connection into a `ConnectionError`,
+// the errors which are not network or server errors are "fallback" errors, i.e `ErrAuth` for a failed login.
+func classifyConnectionError(err error, fallback error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		netErr net.Error
+		urlErr *url.Error
+		opErr  *net.OpError
+		resErr ResourceError
+	)
+
+	class := fallback
+	switch {
+	case errors.Is(err, ErrCredentialsMissing), errors.Is(err, ErrTokenExpired):
+		class = ErrAuth
+	case errors.As(err, &resErr):
+		switch {
+		case resErr.StatusCode == http.StatusUnauthorized || resErr.StatusCode == http.StatusForbidden:
+			class = ErrAuth
+		case resErr.StatusCode == http.StatusNotFound || resErr.StatusCode >= http.StatusInternalServerError:
+			// a missing login endpoint means that the host is not a Lenses server or not a supported one.
+			class = ErrServer
+		}
+	case errors.As(err, &netErr), errors.As(err, &urlErr), errors.As(err, &opErr):
+		class = ErrUnreachable
+	}
+
+	return &ConnectionError{Class: class, Err: err}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenConnectionErrors(t *testing.T) {
+	classes := []error{ErrAuth, ErrUnreachable, ErrServer}
+
+	tests := []struct {
+		name          string
+		status        int
+		closed        bool
+		expectedClass error
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, expectedClass: ErrAuth},
+		{name: "forbidden", status: http.StatusForbidden, expectedClass: ErrAuth},
+		{name: "internal server error", status: http.StatusInternalServerError, expectedClass: ErrServer},
+		{name: "missing login endpoint", status: http.StatusNotFound, expectedClass: ErrServer},
+		{name: "unreachable", closed: true, expectedClass: ErrUnreachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/"+loginPath, r.URL.Path)
+				w.WriteHeader(tt.status)
+			}))
+			if tt.closed {
+				server.Close()
+			} else {
+				defer server.Close()
+			}
+
+			_, err := OpenConnection(ClientConfig{Host: server.URL}, WithBasicAuth("user", "pass"))
+
+			var connErr *ConnectionError
+			if assert.True(t, errors.As(err, &connErr), "%v", err) {
+				assert.Equal(t, tt.expectedClass, connErr.Class)
+			}
+
+			for _, class := range classes {
+				assert.Equal(t, class == tt.expectedClass, errors.Is(err, class), "%v is %v", err, class)
+			}
+		})
+	}
+}
+
+func TestOpenConnectionErrorsKeepTheCause(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := OpenConnection(ClientConfig{Host: server.URL}, WithBasicAuth("user", "pass"))
+	assert.True(t, errors.Is(err, ErrAuth))
+	assert.True(t, errors.Is(err, ErrCredentialsMissing))
+	assert.EqualError(t, err, "client: auth failure: [could not connect to Lenses (URL: "+server.URL+"/api/login): credentials missing or invalid]")
+	assert.EqualError(t, DescribeConnectionError(server.URL, err), "authentication to ["+server.URL+"] failed: credentials missing or invalid")
+}
@@ -1,8 +1,13 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/landoop/lenses-go/pkg"
 )
@@ -39,7 +44,7 @@ type ConnectionTemplateConfig struct {
 
 // ConnectionTemplate type
 type ConnectionTemplate struct {
-	Name     string                     `json:"	name" yaml:"name" header:"Name,text"`
+	Name     string                     `json:"name" yaml:"name" header:"Name,text"`
 	Version  string                     `json:"version" yaml:"version" header:"Version,text"`
 	BuiltIn  bool                       `json:"builtIn" yaml:"buildIn" header:"BuiltIn,text"`
 	Enabled  bool                       `json:"enabled" yaml:"enabled" header:"Enabled,text"`
@@ -51,9 +56,14 @@ type ConnectionTemplate struct {
 
 // GetConnectionTemplates returns all connections
 func (c *Client) GetConnectionTemplates() (response []ConnectionTemplate, err error) {
+	return c.GetConnectionTemplatesContext(context.Background())
+}
+
+// GetConnectionTemplatesContext is like `GetConnectionTemplates` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectionTemplatesContext(ctx context.Context) (response []ConnectionTemplate, err error) {
 	path := fmt.Sprintf("api/%s", pkg.ConnectionTemplatesAPIPath)
 
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -64,3 +74,149 @@ func (c *Client) GetConnectionTemplates() (response []ConnectionTemplate, err er
 
 	return
 }
+
+// GetConnectionTemplate returns the connection template of the "name", i.e "Kafka" or "Elasticsearch".
+func (c *Client) GetConnectionTemplate(name string) (ConnectionTemplate, error) {
+	return c.GetConnectionTemplateContext(context.Background(), name)
+}
+
+// GetConnectionTemplateContext is like `GetConnectionTemplate` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConnectionTemplateContext(ctx context.Context, name string) (ConnectionTemplate, error) {
+	templates, err := c.GetConnectionTemplatesContext(ctx)
+	if err != nil {
+		return ConnectionTemplate{}, err
+	}
+
+	for _, template := range templates {
+		if template.Name == name {
+			return template, nil
+		}
+	}
+
+	return ConnectionTemplate{}, fmt.Errorf("connection template [%s] not found", name)
+}
+
+// Property returns the configuration of the property "key" of the template.
+func (t ConnectionTemplate) Property(key string) (ConnectionTemplateConfig, bool) {
+	for _, cfg := range t.Config {
+		if cfg.Key == key {
+			return cfg, true
+		}
+	}
+
+	return ConnectionTemplateConfig{}, false
+}
+
+// RequiredProperties returns the keys of the required properties of the template.
+func (t ConnectionTemplate) RequiredProperties() []string {
+	return t.properties(true)
+}
+
+// OptionalProperties returns the keys of the optional properties of the template.
+func (t ConnectionTemplate) OptionalProperties() []string {
+	return t.properties(false)
+}
+
+func (t ConnectionTemplate) properties(required bool) []string {
+	keys := make([]string, 0, len(t.Config))
+	for _, cfg := range t.Config {
+		if cfg.Required == required {
+			keys = append(keys, cfg.Key)
+		}
+	}
+
+	return keys
+}
+
+// ValidateProperties returns an error if the "props" miss a required property of the template,
+// they contain a property which is unknown to the template or a value does not match the type of its property.
+func (t ConnectionTemplate) ValidateProperties(props map[string]interface{}) error {
+	var missing []string
+	for _, key := range t.RequiredProperties() {
+		if value, ok := props[key]; !ok || value == nil || value == "" {
+			missing = append(missing, key)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required properties of the connection template [%s]: %s", t.Name, strings.Join(missing, ", "))
+	}
+
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var unknown []string
+	for _, key := range keys {
+		cfg, ok := t.Property(key)
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+
+		if err := cfg.validateValue(props[key]); err != nil {
+			return fmt.Errorf("invalid property [%s] of the connection template [%s]: %v", key, t.Name, err)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown properties of the connection template [%s]: %s", t.Name, strings.Join(unknown, ", "))
+	}
+
+	return nil
+}
+
+func (cfg ConnectionTemplateConfig) validateValue(value interface{}) error {
+	switch strings.ToLower(cfg.Type.Name) {
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a boolean but got [%v]", value)
+		}
+	case "number", "int", "long":
+		switch v := value.(type) {
+		case int, int32, int64, float32, float64:
+		case json.Number:
+			if _, err := v.Float64(); err != nil {
+				return fmt.Errorf("expected a number but got [%v]", value)
+			}
+		default:
+			return fmt.Errorf("expected a number but got [%v]", value)
+		}
+	case "array", "list":
+		switch value.(type) {
+		case []string, []interface{}:
+		default:
+			return fmt.Errorf("expected a list but got [%v]", value)
+		}
+	}
+
+	return nil
+}
+
+// ParseValue parses the string "value", i.e of a command-line flag, based on the type of the property,
+// the lists are comma separated.
+func (cfg ConnectionTemplateConfig) ParseValue(value string) (interface{}, error) {
+	switch strings.ToLower(cfg.Type.Name) {
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value [%s] of the property [%s], expected true or false", value, cfg.Key)
+		}
+		return b, nil
+	case "number", "int", "long":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid value [%s] of the property [%s], expected a number", value, cfg.Key)
+		}
+		return json.Number(value), nil
+	case "array", "list":
+		values := strings.Split(value, ",")
+		for i := range values {
+			values[i] = strings.TrimSpace(values[i])
+		}
+		return values, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testConnectionTemplate = ConnectionTemplate{
+	Name: "Kafka",
+	Config: []ConnectionTemplateConfig{
+		{Key: "kafkaBootstrapServers", Required: true, Type: ConnectionTemplateConfigType{Name: "List"}},
+		{Key: "protocol", Required: true, Type: ConnectionTemplateConfigType{Name: "String"}},
+		{Key: "sslKeyPassword", Type: ConnectionTemplateConfigType{Name: "Secret"}},
+		{Key: "metricsPort", Type: ConnectionTemplateConfigType{Name: "Int"}},
+		{Key: "metricsSsl", Type: ConnectionTemplateConfigType{Name: "Boolean"}},
+	},
+}
+
+func TestConnectionTemplateValidateProperties(t *testing.T) {
+	assert.Equal(t, []string{"kafkaBootstrapServers", "protocol"}, testConnectionTemplate.RequiredProperties())
+	assert.Equal(t, []string{"sslKeyPassword", "metricsPort", "metricsSsl"}, testConnectionTemplate.OptionalProperties())
+
+	tests := []struct {
+		props map[string]interface{}
+		err   string
+	}{
+		{map[string]interface{}{"kafkaBootstrapServers": []string{"PLAINTEXT://broker:9092"}, "protocol": "PLAINTEXT"}, ""},
+		{map[string]interface{}{"kafkaBootstrapServers": []interface{}{"PLAINTEXT://broker:9092"}, "protocol": "PLAINTEXT",
+			"metricsPort": json.Number("9581"), "metricsSsl": false}, ""},
+		{map[string]interface{}{}, "missing required properties of the connection template [Kafka]: kafkaBootstrapServers, protocol"},
+		{map[string]interface{}{"kafkaBootstrapServers": []string{"broker:9092"}, "protocol": ""},
+			"missing required properties of the connection template [Kafka]: protocol"},
+		{map[string]interface{}{"kafkaBootstrapServers": []string{"broker:9092"}, "protocol": "PLAINTEXT", "port": 1, "host": "h"},
+			"unknown properties of the connection template [Kafka]: host, port"},
+		{map[string]interface{}{"kafkaBootstrapServers": "broker:9092", "protocol": "PLAINTEXT"},
+			"invalid property [kafkaBootstrapServers] of the connection template [Kafka]: expected a list but got [broker:9092]"},
+		{map[string]interface{}{"kafkaBootstrapServers": []string{"broker:9092"}, "protocol": "PLAINTEXT", "metricsSsl": "yes"},
+			"invalid property [metricsSsl] of the connection template [Kafka]: expected a boolean but got [yes]"},
+	}
+
+	for _, tt := range tests {
+		err := testConnectionTemplate.ValidateProperties(tt.props)
+		if tt.err == "" {
+			assert.Nil(t, err)
+			continue
+		}
+
+		assert.EqualError(t, err, tt.err)
+	}
+}
+
+func TestConnectionTemplateConfigParseValue(t *testing.T) {
+	cfg, _ := testConnectionTemplate.Property("kafkaBootstrapServers")
+	v, err := cfg.ParseValue("broker-1:9092, broker-2:9092")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"broker-1:9092", "broker-2:9092"}, v)
+
+	cfg, _ = testConnectionTemplate.Property("metricsPort")
+	v, err = cfg.ParseValue("9581")
+	assert.Nil(t, err)
+	assert.Equal(t, json.Number("9581"), v)
+	_, err = cfg.ParseValue("port")
+	assert.EqualError(t, err, "invalid value [port] of the property [metricsPort], expected a number")
+
+	cfg, _ = testConnectionTemplate.Property("metricsSsl")
+	v, err = cfg.ParseValue("true")
+	assert.Nil(t, err)
+	assert.Equal(t, true, v)
+}
+
+func TestCreateConnectionFromTemplate(t *testing.T) {
+	var created CreateConnectionPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connection-templates":
+			json.NewEncoder(w).Encode([]ConnectionTemplate{testConnectionTemplate})
+		case "/api/v1/connection/connections":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&created))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	err = client.CreateConnectionFromTemplate("kafka", "Kafka", map[string]interface{}{"protocol": "PLAINTEXT"}, nil)
+	assert.EqualError(t, err, "missing required properties of the connection template [Kafka]: kafkaBootstrapServers")
+	assert.Empty(t, created.Name, "no connection should be created with missing properties")
+
+	err = client.CreateConnectionFromTemplate("kafka", "Kafka", map[string]interface{}{
+		"protocol":              "PLAINTEXT",
+		"kafkaBootstrapServers": []string{"PLAINTEXT://broker:9092"},
+	}, []string{"prod"})
+	assert.Nil(t, err)
+	assert.Equal(t, "kafka", created.Name)
+	assert.Equal(t, "Kafka", created.TemplateName)
+	assert.Equal(t, []string{"prod"}, created.Tags)
+	assert.Equal(t, []ConnectionConfig{
+		{Key: "kafkaBootstrapServers", Value: []interface{}{"PLAINTEXT://broker:9092"}},
+		{Key: "protocol", Value: "PLAINTEXT"},
+	}, created.Configuration)
+
+	err = client.CreateConnectionFromTemplate("es", "Elasticsearch", nil, nil)
+	assert.EqualError(t, err, "connection template [Elasticsearch] not found")
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,6 +27,11 @@ type MultipleTopicOffsets struct {
 // UpdateSingleTopicOffset handles the API call to update
 // a signle partition of a topic.
 func (c *Client) UpdateSingleTopicOffset(groupID, topic, partitionID, offsetType string, offset int) error {
+	return c.UpdateSingleTopicOffsetContext(context.Background(), groupID, topic, partitionID, offsetType, offset)
+}
+
+// UpdateSingleTopicOffsetContext is like `UpdateSingleTopicOffset` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateSingleTopicOffsetContext(ctx context.Context, groupID, topic, partitionID, offsetType string, offset int) error {
 	if offsetType == "" {
 		return errRequired("field `type` is missing")
 	}
@@ -34,7 +40,7 @@ func (c *Client) UpdateSingleTopicOffset(groupID, topic, partitionID, offsetType
 	singleTopic := SingleTopicOffset{Type: offsetType, Offset: offset}
 	payload, err := json.Marshal(singleTopic)
 
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -45,14 +51,41 @@ func (c *Client) UpdateSingleTopicOffset(groupID, topic, partitionID, offsetType
 // UpdateMultipleTopicsOffset handles the Lenses API call to update
 // all partitions of multiple topics of a consumer group.
 func (c *Client) UpdateMultipleTopicsOffset(groupID, offsetType, target string, topics []string) error {
+	return c.UpdateMultipleTopicsOffsetContext(context.Background(), groupID, offsetType, target, topics)
+}
+
+// UpdateMultipleTopicsOffsetContext is like `UpdateMultipleTopicsOffset` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateMultipleTopicsOffsetContext(ctx context.Context, groupID, offsetType, target string, topics []string) error {
 	path := fmt.Sprintf("%s/%s/offsets", pkg.ConsumersGroupPath, groupID)
 	multipleTopics := MultipleTopicOffsets{Type: offsetType, Target: target, Topics: topics}
 	payload, err := json.Marshal(multipleTopics)
 
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// ConsumerGroup describes a consumer group, see `GetConsumerGroups`.
+type ConsumerGroup struct {
+	ID    string `json:"id" header:"ID"`
+	State string `json:"state" header:"State"`
+}
+
+// GetConsumerGroups returns the consumer groups of the kafka cluster.
+func (c *Client) GetConsumerGroups() (groups []ConsumerGroup, err error) {
+	return c.GetConsumerGroupsContext(context.Background())
+}
+
+// GetConsumerGroupsContext is like `GetConsumerGroups` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetConsumerGroupsContext(ctx context.Context) (groups []ConsumerGroup, err error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, pkg.ConsumersGroupPath, "", nil)
+	if err != nil {
+		return
+	}
+
+	err = c.ReadJSON(resp, &groups)
+	return
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -36,6 +37,11 @@ type Index struct {
 
 // GetIndexes returns the list of elasticsearch indexes.
 func (c *Client) GetIndexes(connectionName string, includeSystemIndexes bool) (indexes []Index, err error) {
+	return c.GetIndexesContext(context.Background(), connectionName, includeSystemIndexes)
+}
+
+// GetIndexesContext is like `GetIndexes` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetIndexesContext(ctx context.Context, connectionName string, includeSystemIndexes bool) (indexes []Index, err error) {
 	// # List of indexes
 	// GET /api/elastic/indexes?connectionName=$x&includeSystemIndexes=$y
 	url, err := url.Parse(pkg.ElasticsearchIndexesPath)
@@ -48,7 +54,7 @@ func (c *Client) GetIndexes(connectionName string, includeSystemIndexes bool) (i
 	}
 	url.RawQuery = q.Encode()
 
-	resp, respErr := c.Do(http.MethodGet, url.String(), "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, url.String(), "", nil)
 	if respErr != nil {
 		err = respErr
 		return
@@ -61,11 +67,16 @@ func (c *Client) GetIndexes(connectionName string, includeSystemIndexes bool) (i
 
 // GetIndex fetches stuff about an index
 func (c *Client) GetIndex(connectionName string, indexName string) (index Index, err error) {
+	return c.GetIndexContext(context.Background(), connectionName, indexName)
+}
+
+// GetIndexContext is like `GetIndex` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetIndexContext(ctx context.Context, connectionName string, indexName string) (index Index, err error) {
 	// List of indexes
 	// GET /api/elastic/indexes/connectionName/indexName
 	path := fmt.Sprintf("%s/%s/%s", pkg.ElasticsearchIndexesPath, connectionName, indexName)
 
-	resp, respErr := c.Do(http.MethodGet, path, "", nil)
+	resp, respErr := c.DoContext(ctx, http.MethodGet, path, "", nil)
 	if respErr != nil {
 		err = respErr
 		return
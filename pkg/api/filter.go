@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"strings"
+)
+
+// MatchesFilter reports whether the `name` contains the `filter`, case insensitive.
+// An empty filter matches everything.
+func MatchesFilter(name, filter string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(filter))
+}
+
+// FilterConnectors returns the names of the connectors of the `clusterName` that contain the `filter`.
+// The connectors endpoint has no filter parameter, so the names are filtered on the client side, see `MatchesFilter`.
+func (c *Client) FilterConnectors(clusterName, filter string) ([]string, error) {
+	return c.FilterConnectorsContext(context.Background(), clusterName, filter)
+}
+
+// FilterConnectorsContext is like `FilterConnectors` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) FilterConnectorsContext(ctx context.Context, clusterName, filter string) ([]string, error) {
+	names, err := c.GetConnectorsContext(ctx, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != "" {
+		c.Logger().Debugf("Client#FilterConnectors: filtering the connectors of [%s] by [%s] on the client side", clusterName, filter)
+	}
+
+	filtered := names[:0]
+	for _, name := range names {
+		if MatchesFilter(name, filter) {
+			filtered = append(filtered, name)
+		}
+	}
+
+	return filtered, nil
+}
+
+// FilterProcessors returns the processors whose name contains the `filter`.
+// The processors endpoint has no filter parameter, so the processors are filtered on the client side, see `MatchesFilter`.
+func (c *Client) FilterProcessors(filter string) (ProcessorsResult, error) {
+	return c.FilterProcessorsContext(context.Background(), filter)
+}
+
+// FilterProcessorsContext is like `FilterProcessors` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) FilterProcessorsContext(ctx context.Context, filter string) (ProcessorsResult, error) {
+	res, err := c.GetProcessorsContext(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	if filter != "" {
+		c.Logger().Debugf("Client#FilterProcessors: filtering the processors by [%s] on the client side", filter)
+	}
+
+	filtered := res.Streams[:0]
+	for _, stream := range res.Streams {
+		if MatchesFilter(stream.Name, filter) {
+			filtered = append(filtered, stream)
+		}
+	}
+
+	res.Streams = filtered
+	return res, nil
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesFilter(t *testing.T) {
+	assert.True(t, MatchesFilter("FOO-sink", "foo"))
+	assert.True(t, MatchesFilter("bar", ""))
+	assert.False(t, MatchesFilter("bar", "foo"))
+}
+
+func TestFilterConnectors(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.URL.RawQuery)
+		w.Write([]byte(`["foo-sink", "bar-source", "FOO-SINK-2"]`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	logger := new(capturingLogger)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithLogger(logger))
+	assert.Nil(t, err)
+
+	names, err := client.FilterConnectors("dev", "sink")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"foo-sink", "FOO-SINK-2"}, names)
+	assert.True(t, logger.contains("debug: Client#FilterConnectors: filtering the connectors of [dev] by [sink] on the client side"))
+}
+
+func TestFilterProcessors(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "", r.URL.RawQuery)
+		w.Write([]byte(`{"streams": [{"name": "orders"}, {"name": "payments"}]}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	logger := new(capturingLogger)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithLogger(logger))
+	assert.Nil(t, err)
+
+	res, err := client.FilterProcessors("pay")
+	assert.Nil(t, err)
+	assert.Len(t, res.Streams, 1)
+	assert.Equal(t, "payments", res.Streams[0].Name)
+	assert.True(t, logger.contains("debug: Client#FilterProcessors: filtering the processors by [pay] on the client side"))
+}
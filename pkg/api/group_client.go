@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -29,7 +30,12 @@ type Group struct {
 
 //GetGroups returns the list of groups
 func (c *Client) GetGroups() (groups []Group, err error) {
-	resp, err := c.Do(http.MethodGet, groupPath, contentTypeJSON, nil)
+	return c.GetGroupsContext(context.Background())
+}
+
+// GetGroupsContext is like `GetGroups` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetGroupsContext(ctx context.Context) (groups []Group, err error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, groupPath, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -39,13 +45,18 @@ func (c *Client) GetGroups() (groups []Group, err error) {
 
 //GetGroup returns the group by the provided name
 func (c *Client) GetGroup(name string) (group Group, err error) {
+	return c.GetGroupContext(context.Background(), name)
+}
+
+// GetGroupContext is like `GetGroup` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetGroupContext(ctx context.Context, name string) (group Group, err error) {
 	if name == "" {
 		err = errRequired("name")
 		return
 	}
 
 	path := fmt.Sprintf("%s/%s", groupPath, name)
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -56,6 +67,11 @@ func (c *Client) GetGroup(name string) (group Group, err error) {
 
 //CreateGroup creates a group
 func (c *Client) CreateGroup(group *Group) error {
+	return c.CreateGroupContext(context.Background(), group)
+}
+
+// CreateGroupContext is like `CreateGroup` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateGroupContext(ctx context.Context, group *Group) error {
 	if group.Name == "" {
 		return errRequired("name")
 	}
@@ -65,7 +81,7 @@ func (c *Client) CreateGroup(group *Group) error {
 		return err
 	}
 
-	_, err = c.Do(http.MethodPost, groupPath, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPost, groupPath, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -74,12 +90,17 @@ func (c *Client) CreateGroup(group *Group) error {
 
 //DeleteGroup deletes a group
 func (c *Client) DeleteGroup(name string) error {
+	return c.DeleteGroupContext(context.Background(), name)
+}
+
+// DeleteGroupContext is like `DeleteGroup` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteGroupContext(ctx context.Context, name string) error {
 	if name == "" {
 		return errRequired("name")
 	}
 
 	path := fmt.Sprintf("%s/%s", groupPath, name)
-	_, err := c.Do(http.MethodDelete, path, contentTypeJSON, nil)
+	_, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, nil)
 	if err != nil {
 		return err
 	}
@@ -88,6 +109,11 @@ func (c *Client) DeleteGroup(name string) error {
 
 //UpdateGroup updates a group
 func (c *Client) UpdateGroup(group *Group) error {
+	return c.UpdateGroupContext(context.Background(), group)
+}
+
+// UpdateGroupContext is like `UpdateGroup` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateGroupContext(ctx context.Context, group *Group) error {
 	if group.Name == "" {
 		return errRequired("name")
 	}
@@ -98,7 +124,7 @@ func (c *Client) UpdateGroup(group *Group) error {
 	}
 
 	path := fmt.Sprintf("%s/%s", groupPath, group.Name)
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -107,6 +133,11 @@ func (c *Client) UpdateGroup(group *Group) error {
 
 //CloneGroup clones a group
 func (c *Client) CloneGroup(currentName string, newName string) error {
+	return c.CloneGroupContext(context.Background(), currentName, newName)
+}
+
+// CloneGroupContext is like `CloneGroup` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CloneGroupContext(ctx context.Context, currentName string, newName string) error {
 	if currentName == "" {
 		return errRequired("name")
 	}
@@ -115,7 +146,7 @@ func (c *Client) CloneGroup(currentName string, newName string) error {
 	}
 
 	path := fmt.Sprintf("%s/%s/clone/%s", groupPath, currentName, newName)
-	_, err := c.Do(http.MethodPost, path, contentTypeJSON, nil)
+	_, err := c.DoContext(ctx, http.MethodPost, path, contentTypeJSON, nil)
 	if err != nil {
 		return err
 	}
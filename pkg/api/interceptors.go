@@ -0,0 +1,31 @@
+package api
+
+import "net/http"
+
+// RequestInterceptor is called for every outgoing request of the client, right before it's sent,
+// after the token and the rest of the client's headers are set, so it can read or override them,
+// i.e to add a correlation or a tracing header.
+type RequestInterceptor func(req *http.Request)
+
+// ResponseInterceptor is called after every request of the client, with its response or the error if it failed,
+// i.e to log or measure the calls. The response's body should not be read, it belongs to the caller.
+type ResponseInterceptor func(req *http.Request, resp *http.Response, err error)
+
+// WithRequestInterceptors registers interceptors which are called, in order, for every request of the client.
+// They replace the deprecated `Client#PersistentRequestModifier`.
+//
+// See `RequestInterceptor` and `WithResponseInterceptors` too.
+func WithRequestInterceptors(interceptors ...RequestInterceptor) ConnectionOption {
+	return func(c *Client) {
+		c.requestInterceptors = append(c.requestInterceptors, interceptors...)
+	}
+}
+
+// WithResponseInterceptors registers interceptors which are called, in order, after every request of the client.
+//
+// See `ResponseInterceptor` and `WithRequestInterceptors` too.
+func WithResponseInterceptors(interceptors ...ResponseInterceptor) ConnectionOption {
+	return func(c *Client) {
+		c.responseInterceptors = append(c.responseInterceptors, interceptors...)
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptors(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/topics" {
+			assert.Equal(t, "abc", r.Header.Get("X-Correlation-Id"))
+			assert.Equal(t, "overridden", r.Header.Get(xKafkaLensesTokenHeaderKey))
+		}
+		w.Write([]byte("[]"))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	var (
+		seenToken string
+		calls     []string
+	)
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"},
+		WithRequestInterceptors(func(req *http.Request) {
+			if req.URL.Path != "/api/topics" {
+				return
+			}
+			seenToken = req.Header.Get(xKafkaLensesTokenHeaderKey)
+			req.Header.Set("X-Correlation-Id", "abc")
+			req.Header.Set(xKafkaLensesTokenHeaderKey, "overridden")
+		}),
+		WithResponseInterceptors(func(req *http.Request, resp *http.Response, err error) {
+			assert.Nil(t, err)
+			calls = append(calls, req.URL.Path+" "+resp.Status)
+		}))
+	assert.Nil(t, err)
+
+	_, err = client.GetTopicsNames()
+	assert.Nil(t, err)
+	assert.Equal(t, "secret", seenToken, "the interceptor should run after the token header is set")
+	assert.Contains(t, calls, "/api/topics 200 OK")
+}
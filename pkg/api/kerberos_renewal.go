@@ -0,0 +1,160 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// kerberosRenewalInterval is the minimum interval between two renewals of the kerberos ticket,
+// so a server which rejects every ticket does not make the client acquire one per request.
+const kerberosRenewalInterval = 30 * time.Second
+
+// errKerberosRenewalLimited is returned by the renewals within the `kerberosRenewalInterval` of the previous one.
+var errKerberosRenewalLimited = errors.New("the kerberos ticket was renewed less than " + kerberosRenewalInterval.String() + " ago")
+
+// WithKerberosRenewal makes the client re-acquire its kerberos ticket, from the keytab, the ccache or the password,
+// when a request is rejected with a 401 status code and a "WWW-Authenticate: Negotiate" challenge, i.e the ticket
+// expired on a long-running process, and send that request once more. The 403 status codes are permission errors
+// and they are not retried. Concurrent rejected requests share a single renewal and the ticket is renewed
+// at most once every 30 seconds, the rejections in between are returned as they are.
+// It's a no-op for the other authentication methods, see `KerberosAuthentication`.
+func WithKerberosRenewal() ConnectionOption {
+	return func(c *Client) {
+		c.kerberosRenewal = true
+	}
+}
+
+// kerberosTicket sets the SPNEGO header of the requests, it's the `*client.Client` of the gokrb5,
+// which acquires the service tickets based on its ticket granting ticket.
+type kerberosTicket interface {
+	SetSPNEGOHeader(r *http.Request, spn string) error
+}
+
+// kerberosSession keeps the current ticket of the `KerberosAuthentication` and renews it, see `WithKerberosRenewal`.
+type kerberosSession struct {
+	mu      sync.Mutex
+	ticket  kerberosTicket
+	acquire func() (kerberosTicket, error)
+	// generation is increased on every new ticket,
+	// so the requests which were rejected before a renewal do not renew it again.
+	generation uint64
+	// renewal is the in-flight renewal, if any.
+	renewal *kerberosRenewal
+	// renewed is the time of the last renewal and interval the minimum time between two of them,
+	// see `kerberosRenewalInterval`.
+	renewed  time.Time
+	interval time.Duration
+}
+
+type kerberosRenewal struct {
+	done chan struct{}
+	err  error
+}
+
+// setKerberosTicket sets the kerberos "ticket" of the client and the way to "acquire" a new one,
+// its SPNEGO header is added to all requests. A nil "ticket" is acquired on the first SPNEGO challenge.
+func (c *Client) setKerberosTicket(ticket kerberosTicket, acquire func() (kerberosTicket, error)) {
+	if c.kerberos == nil {
+		c.kerberos = &kerberosSession{interval: kerberosRenewalInterval}
+	}
+
+	s := c.kerberos
+	s.mu.Lock()
+	s.acquire = acquire
+	if ticket != nil {
+		s.ticket = ticket
+		s.generation++
+	}
+	s.mu.Unlock()
+}
+
+// kerberosGeneration returns the generation of the current kerberos ticket,
+// zero if the client does not use kerberos or its ticket is not acquired yet.
+func (c *Client) kerberosGeneration() uint64 {
+	if c.kerberos == nil {
+		return 0
+	}
+
+	_, generation := c.kerberos.current()
+	return generation
+}
+
+// shouldRenewKerberos reports whether the "resp" is the rejection of an expired kerberos ticket, a new SPNEGO challenge,
+// see `WithKerberosRenewal` and `isNegotiateChallenge`.
+func (c *Client) shouldRenewKerberos(resp *http.Response) bool {
+	return c.kerberosRenewal && c.kerberos != nil && isNegotiateChallenge(resp)
+}
+
+func (s *kerberosSession) current() (kerberosTicket, uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.ticket, s.generation
+}
+
+func (s *kerberosSession) setSPNEGOHeader(r *http.Request) error {
+	ticket, _ := s.current()
+	if ticket == nil {
+		// not acquired yet, see `isNegotiateChallenge`.
+		return nil
+	}
+
+	return ticket.SetSPNEGOHeader(r, fmt.Sprintf("%s/%s", "HTTP", r.URL.Hostname()))
+}
+
+// destroy destroys the current ticket, it stops the background renewal of its ticket granting ticket, see `Client#Close`.
+func (s *kerberosSession) destroy() {
+	s.mu.Lock()
+	ticket := s.ticket
+	s.ticket = nil
+	s.mu.Unlock()
+
+	if d, ok := ticket.(interface{ Destroy() }); ok {
+		d.Destroy()
+	}
+}
+
+// renew acquires a new ticket if the current one is still of the "generation" that the request was rejected with,
+// the concurrent callers wait for the same renewal and share its result.
+// It returns the `errKerberosRenewalLimited` if the ticket was renewed within the interval.
+func (s *kerberosSession) renew(generation uint64) error {
+	s.mu.Lock()
+	if s.generation != generation {
+		// already renewed by another request.
+		s.mu.Unlock()
+		return nil
+	}
+
+	if s.renewal == nil && !s.renewed.IsZero() && time.Since(s.renewed) < s.interval {
+		s.mu.Unlock()
+		return errKerberosRenewalLimited
+	}
+
+	if call := s.renewal; call != nil {
+		s.mu.Unlock()
+		<-call.done
+		return call.err
+	}
+
+	call := &kerberosRenewal{done: make(chan struct{})}
+	s.renewal = call
+	acquire := s.acquire
+	s.mu.Unlock()
+
+	ticket, err := acquire()
+
+	s.mu.Lock()
+	if err == nil {
+		s.ticket = ticket
+		s.generation++
+	}
+	s.renewed = time.Now()
+	s.renewal = nil
+	s.mu.Unlock()
+
+	call.err = err
+	close(call.done)
+	return err
+}
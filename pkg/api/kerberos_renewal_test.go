@@ -0,0 +1,185 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeKerberosTicket int32
+
+func (t fakeKerberosTicket) SetSPNEGOHeader(r *http.Request, spn string) error {
+	r.Header.Set("Authorization", fmt.Sprintf("Negotiate %s-%d", spn, t))
+	return nil
+}
+
+// newSPNEGOServer accepts only the requests with the "valid" ticket, the rest are rejected as expired,
+// a negative "valid" rejects all of them as forbidden.
+func newSPNEGOServer(t *testing.T, valid *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(valid) < 0 {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		expected := fmt.Sprintf("Negotiate HTTP/127.0.0.1-%d", atomic.LoadInt32(valid))
+		if r.Header.Get("Authorization") != expected {
+			w.Header().Set("WWW-Authenticate", "Negotiate")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte("ok"))
+	}))
+}
+
+func newKerberosTestClient(t *testing.T, host string, acquired *int32, options ...ConnectionOption) *Client {
+	c, err := OpenConnection(ClientConfig{Host: host, Token: "secret"}, options...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.setKerberosTicket(fakeKerberosTicket(1), func() (kerberosTicket, error) {
+		return fakeKerberosTicket(atomic.AddInt32(acquired, 1) + 1), nil
+	})
+	// renewed on every expiration, see `TestKerberosRenewalInterval`.
+	c.kerberos.interval = 0
+
+	return c
+}
+
+func TestKerberosRenewal(t *testing.T) {
+	valid := int32(1)
+	server := newSPNEGOServer(t, &valid)
+	defer server.Close()
+
+	var acquired int32
+	c := newKerberosTestClient(t, server.URL, &acquired, WithKerberosRenewal())
+
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&acquired))
+
+	// the ticket expires, the next ticket is the valid one.
+	atomic.StoreInt32(&valid, 2)
+	resp, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	if assert.Nil(t, err) {
+		b, _ := c.ReadResponseBody(resp)
+		assert.Equal(t, "ok", string(b))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&acquired))
+
+	// concurrent requests with the expired ticket renew it once.
+	atomic.StoreInt32(&valid, 3)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), atomic.LoadInt32(&acquired))
+}
+
+func TestKerberosInterceptors(t *testing.T) {
+	valid := int32(1)
+	server := newSPNEGOServer(t, &valid)
+	defer server.Close()
+
+	// the interceptors see the SPNEGO header, the kerberos session does not replace them.
+	var header string
+	var acquired int32
+	c := newKerberosTestClient(t, server.URL, &acquired, WithRequestInterceptors(func(req *http.Request) {
+		header = req.Header.Get("Authorization")
+	}))
+
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "Negotiate HTTP/127.0.0.1-1", header)
+}
+
+func TestKerberosRenewalDisabled(t *testing.T) {
+	valid := int32(2)
+	server := newSPNEGOServer(t, &valid)
+	defer server.Close()
+
+	var acquired int32
+	c := newKerberosTestClient(t, server.URL, &acquired)
+
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Equal(t, ErrCredentialsMissing, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&acquired))
+}
+
+func TestKerberosRenewalForbidden(t *testing.T) {
+	valid := int32(-1)
+	server := newSPNEGOServer(t, &valid)
+	defer server.Close()
+
+	var acquired int32
+	c := newKerberosTestClient(t, server.URL, &acquired, WithKerberosRenewal())
+
+	// a permission error, not an expired ticket.
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	var resErr ResourceError
+	if assert.True(t, errors.As(err, &resErr), "%v", err) {
+		assert.Equal(t, http.StatusForbidden, resErr.StatusCode)
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&acquired))
+}
+
+func TestKerberosRenewalInterval(t *testing.T) {
+	valid := int32(2)
+	server := newSPNEGOServer(t, &valid)
+	defer server.Close()
+
+	var acquired int32
+	c := newKerberosTestClient(t, server.URL, &acquired, WithKerberosRenewal())
+	c.kerberos.interval = kerberosRenewalInterval
+
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&acquired))
+
+	// rejected again right after the renewal, the rejection is returned.
+	atomic.StoreInt32(&valid, 3)
+	_, err = c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Equal(t, ErrCredentialsMissing, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&acquired))
+
+	// renewed once the interval passed.
+	c.kerberos.mu.Lock()
+	c.kerberos.renewed = time.Now().Add(-kerberosRenewalInterval)
+	c.kerberos.mu.Unlock()
+	_, err = c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&acquired))
+}
+
+func TestKerberosRenewalFailure(t *testing.T) {
+	valid := int32(2)
+	server := newSPNEGOServer(t, &valid)
+	defer server.Close()
+
+	c, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithKerberosRenewal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.setKerberosTicket(fakeKerberosTicket(1), func() (kerberosTicket, error) {
+		return nil, fmt.Errorf("with keytab: unable to load keytab file")
+	})
+
+	_, err = c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.EqualError(t, err, "client: kerberos ticket renewal failure: [with keytab: unable to load keytab file]")
+}
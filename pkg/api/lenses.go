@@ -5,15 +5,14 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
 	"time"
-
-	"github.com/kataras/golog"
 )
 
 // ConnectionOption describes an optional runtime configurator that can be passed on `OpenConnection`.
 // Custom `ConnectionOption` can be used as well, it's just a type of `func(*lenses.Client)`.
 //
-// Look `UsingClient` and `UsingToken` for use-cases.
+// Look `UsingClient`, `WithHTTPClient`, `UsingToken`, `WithBasicAuth` and `WithTimeout` for use-cases.
 type ConnectionOption func(*Client)
 
 func getTimeout(httpClient *http.Client, timeoutStr string) time.Duration {
@@ -26,7 +25,7 @@ func getTimeout(httpClient *http.Client, timeoutStr string) time.Duration {
 	return httpClient.Timeout
 }
 
-func getTransportLayer(httpClient *http.Client, timeout time.Duration, insecure bool) (t http.RoundTripper) {
+func getTransportLayer(httpClient *http.Client, timeout time.Duration, insecure bool, options *TransportOptions) (t http.RoundTripper) {
 	if t := httpClient.Transport; t != nil {
 		return t
 	}
@@ -36,6 +35,8 @@ func getTransportLayer(httpClient *http.Client, timeout time.Duration, insecure
 		TLSNextProto: make(map[string]func(authority string, c *tls.Conn) http.RoundTripper),
 	}
 
+	options.apply(httpTransport)
+
 	if insecure {
 		httpTransport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
 	}
@@ -50,24 +51,57 @@ func getTransportLayer(httpClient *http.Client, timeout time.Duration, insecure
 }
 
 // UsingClient modifies the underline HTTP Client that lenses is using for contact with the backend server.
+// Its transport and timeout are set from the configuration, after all the options, see `WithHTTPClient` too.
 func UsingClient(httpClient *http.Client) ConnectionOption {
+	return usingClient(httpClient, false)
+}
+
+// WithHTTPClient sets the HTTP Client that lenses is using for contact with the backend server, i.e a shared one
+// with connection pooling and tracing. Unlike the `UsingClient` the "httpClient" is used as it is,
+// its transport and timeout are not modified, so the configuration's timeout and insecure are ignored with a warning.
+// The authentication header is still set per request.
+func WithHTTPClient(httpClient *http.Client) ConnectionOption {
+	return usingClient(httpClient, true)
+}
+
+func usingClient(httpClient *http.Client, asIs bool) ConnectionOption {
 	return func(c *Client) {
 		if httpClient == nil {
 			return
 		}
 
-		// config's timeout has priority if the httpClient passed has smaller or not-seted timeout.
-		timeout := getTimeout(httpClient, c.Config.Timeout)
+		c.client = httpClient
+		c.customClient = asIs
+	}
+}
+
+// configureClient sets the transport and the timeout of the HTTP Client from the configuration,
+// once all the options are applied, so the options which modify the configuration, i.e the `WithTimeout`,
+// are respected regardless of their order. The HTTP Client of the `WithHTTPClient` is used as it is.
+func (c *Client) configureClient() {
+	// if client is not set-ed by any option, set it to a new one,
+	// a good idea could be to use the `http.DefaultClient`
+	// but this has some limitations so we start with a new, to be clear and simple.
+	if c.client == nil {
+		c.client = &http.Client{}
+	}
 
-		transport := getTransportLayer(httpClient, timeout, c.Config.Insecure)
-		httpClient.Transport = transport
+	if c.customClient {
+		if c.Config.Timeout != "" || c.Config.Insecure || c.Config.Transport != nil {
+			c.Logger().Warnf("The configured timeout, insecure and transport are ignored, the HTTP client of the WithHTTPClient is used as it is")
+		}
 
-		c.client = httpClient
+		c.timeout = c.client.Timeout
+		return
 	}
+
+	// config's timeout has priority if the httpClient passed has smaller or not-seted timeout.
+	c.timeout = getTimeout(c.client, c.Config.Timeout)
+	c.client.Transport = getTransportLayer(c.client, c.timeout, c.Config.Insecure, c.Config.Transport)
 }
 
-// UsingToken can specify a custom token that can by-pass the "user" and "password".
-// It may be useful for testing purposes.
+// UsingToken can specify a custom token that can by-pass the "user" and "password",
+// it overrides the configuration's token.
 func UsingToken(tok string) ConnectionOption {
 	return func(c *Client) {
 		if tok == "" {
@@ -75,6 +109,7 @@ func UsingToken(tok string) ConnectionOption {
 		}
 
 		c.Config.Token = tok
+		c.Config.TokenExpiry = nil // parsed from the new token.
 	}
 }
 
@@ -91,15 +126,42 @@ func WithContext(contextName string) ConnectionOption {
 	}
 }
 
+// WithBasicAuth overrides the configuration's authentication with the `BasicAuthentication` of the "username" and "password".
+// Any configuration's token is dropped, so the client logins with these credentials instead.
+func WithBasicAuth(username, password string) ConnectionOption {
+	return func(c *Client) {
+		c.Config.Authentication = BasicAuthentication{Username: username, Password: password}
+		c.Config.Token = ""
+		c.Config.TokenExpiry = nil
+	}
+}
+
+// WithTimeout overrides the configuration's timeout.
+func WithTimeout(timeout time.Duration) ConnectionOption {
+	return func(c *Client) {
+		c.Config.Timeout = timeout.String()
+	}
+}
+
+// WithDebug overrides the configuration's debug mode.
+func WithDebug(debug bool) ConnectionOption {
+	return func(c *Client) {
+		c.Config.Debug = debug
+	}
+}
+
 // OpenConnection creates & returns a new Landoop's Lenses API bridge interface
 // based on the passed `ClientConfig` and the (optional) options.
 // OpenConnection authenticates the user and returns a valid ready-to-use `*lenses.Client`.
-// If failed to communicate with the server then it returns a nil client and a non-nil error.
+// If failed to communicate with the server then it returns a nil client and a non-nil error,
+// the authentication and the network failures are `ConnectionError`s, see `ErrAuth`, `ErrUnreachable` and `ErrServer`.
 //
 // Usage:
 // auth := lenses.BasicAuthentication{Username: "user", Password: "pass"}
 // config := lenses.ClientConfig{Host: "domain.com", Authentication: auth, Timeout: "15s"}
 // client, err := lenses.OpenConnection(config) // or (config, lenses.UsingClient/UsingToken)
+// or without a configuration:
+// client, err := lenses.OpenConnection(lenses.ClientConfig{Host: "domain.com"}, lenses.WithBasicAuth("user", "pass"), lenses.WithTimeout(15*time.Second))
 // if err != nil { panic(err) }
 // client.DeleteTopic("topicName")
 //
@@ -116,6 +178,8 @@ func OpenConnection(cfg ClientConfig, options ...ConnectionOption) (*Client, err
 	}
 
 	c := &Client{configFull: full, Config: clientConfig}
+	c.tokenRefreshSkew = DefaultTokenRefreshSkew
+	c.metrics = NoopMetricsCollector{}
 	for _, opt := range options {
 		opt(c)
 	}
@@ -124,18 +188,47 @@ func OpenConnection(cfg ClientConfig, options ...ConnectionOption) (*Client, err
 		return nil, fmt.Errorf("invalid configuration: Token or Authentication missing")
 	}
 
-	// if client is not set-ed by any option, set it to a new one,
-	// a good idea could be to use the `http.DefaultClient`
-	// but this has some limitations so we start with a new, to be clear and simple.
-	if c.client == nil {
-		httpClient := &http.Client{}
-		UsingClient(httpClient)(c)
+	if clientConfig.Timeout != "" {
+		if _, err := time.ParseDuration(clientConfig.Timeout); err != nil {
+			return nil, fmt.Errorf("invalid configuration: timeout [%s] is not a valid duration, i.e 30s or 1m", clientConfig.Timeout)
+		}
+	}
+
+	if clientConfig.RateLimit != nil {
+		if err := clientConfig.RateLimit.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %v", err)
+		}
+	}
+
+	if clientConfig.Transport != nil {
+		if err := clientConfig.Transport.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid configuration: %v", err)
+		}
+	}
+
+	c.rateLimiter = newRateLimiter(clientConfig.RateLimit)
+	c.basePath = clientConfig.BasePath()
+	c.origin = strings.TrimSuffix(clientConfig.Host, c.basePath)
+
+	c.configureClient()
+
+	if auth, ok := clientConfig.Authentication.(KerberosAuthentication); ok && auth.Method != nil {
+		// the ticket is acquired on login or, for a token-only connection, on the first SPNEGO challenge.
+		c.setKerberosTicket(nil, auth.ticketAcquirer())
 	}
 
 	// i.e `UsingToken`.
 	if clientConfig.Token != "" {
-		golog.Debugf("Connecting using just the token: [%s]", clientConfig.Token)
+		c.Logger().Debugf("Connecting using just the token: [%s]", RedactedValue)
 		// User will be empty but it does its job.
+		if clientConfig.TokenExpiry == nil {
+			clientConfig.TokenExpiry = ParseTokenExpiry(clientConfig.Token)
+		}
+
+		if err := c.refreshToken(); err != nil {
+			return nil, classifyConnectionError(err, ErrAuth)
+		}
+
 		return c, nil
 	}
 
@@ -143,18 +236,15 @@ func OpenConnection(cfg ClientConfig, options ...ConnectionOption) (*Client, err
 		return nil, fmt.Errorf("client: auth failure: authenticator missing")
 	}
 
-	if err := clientConfig.Authentication.Auth(c); err != nil {
-		return nil, fmt.Errorf("client: auth failure: [%v]", err)
-	}
-
-	if c.User.Token == "" { // this should never happen.
-		return nil, fmt.Errorf("client: login failure: token is undefined")
+	if err := c.authenticate(); err != nil {
+		return nil, classifyConnectionError(fmt.Errorf("client: auth failure: [%w]", err), ErrAuth)
 	}
 
 	if clientConfig.Debug {
-		golog.SetLevel("debug")
-		golog.Debugf("Connected on [%s] with token: [%s]\nUser details: [%#+v]",
-			c.Config.Host, c.User.Token, c.User)
+		user := c.User
+		user.Token = RedactedValue
+		c.Logger().Debugf("Connected on [%s] with token: [%s]\nUser details: [%#+v]",
+			c.Config.Host, RedactedValue, user)
 	}
 
 	return c, nil
@@ -0,0 +1,151 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConnectionOptionsOverrideConfig(t *testing.T) {
+	var logins []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			var auth struct{ User, Password string }
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&auth))
+			logins = append(logins, auth.User+":"+auth.Password)
+			w.Write([]byte("login-token"))
+		case "/api/auth":
+			w.Write([]byte(`{"token": "` + r.Header.Get(xKafkaLensesTokenHeaderKey) + `", "user": "user"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	f, teardown := makeTestFile(t, "lenses-cli.yml")
+	defer teardown()
+
+	content := "CurrentContext: master\nContexts:\n  master:\n    Host: " + server.URL + "\n    Token: file-token\n    Timeout: 5s\n    Debug: true\n"
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+
+	var c Config
+	assert.Nil(t, TryReadConfigFromFile(f.Name(), &c))
+
+	// the options win over the file.
+	client, err := OpenConnection(*c.GetCurrent(), WithBasicAuth("user", "pass"), WithTimeout(time.Minute), WithDebug(false))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"user:pass"}, logins)
+	assert.Equal(t, "login-token", client.Config.Token)
+	assert.Equal(t, "1m0s", client.Config.Timeout)
+	assert.Equal(t, time.Minute, client.Timeout())
+	assert.False(t, client.Config.Debug)
+
+	// regardless of the order of the options.
+	client, err = OpenConnection(*c.GetCurrent(), UsingClient(&http.Client{}), WithTimeout(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, client.Timeout())
+
+	// the last option wins.
+	client, err = OpenConnection(*c.GetCurrent(), WithBasicAuth("user", "pass"), UsingToken("option-token"))
+	assert.Nil(t, err)
+	assert.Len(t, logins, 1, "the token should be used instead of a login")
+	assert.Equal(t, "option-token", client.Config.Token)
+	assert.Equal(t, "5s", client.Config.Timeout)
+
+	// nothing from a file.
+	client, err = OpenConnection(ClientConfig{Host: server.URL}, UsingToken("option-token"))
+	assert.Nil(t, err)
+	assert.Equal(t, "option-token", client.Config.Token)
+}
+
+type recordingTransport struct {
+	calls []string
+}
+
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.calls = append(t.calls, r.Method+" "+r.URL.Path+" "+r.Header.Get(xKafkaLensesTokenHeaderKey))
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"version": "4.0.0"}`))
+	}))
+	defer server.Close()
+
+	transport := new(recordingTransport)
+	httpClient := &http.Client{Transport: transport, Timeout: 3 * time.Second}
+
+	logger := new(capturingLogger)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Timeout: "1m", Insecure: true},
+		WithLogger(logger), WithHTTPClient(httpClient))
+	assert.Nil(t, err)
+
+	_, err = client.GetServerVersion()
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"GET /api/version secret"}, transport.calls)
+	// used verbatim.
+	assert.Equal(t, transport, httpClient.Transport)
+	assert.Equal(t, 3*time.Second, httpClient.Timeout)
+	assert.Equal(t, 3*time.Second, client.Timeout())
+	assert.True(t, logger.contains("warn: The configured timeout, insecure and transport are ignored"))
+
+	// no warning without timeout and insecure.
+	logger = new(capturingLogger)
+	_, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithLogger(logger), WithHTTPClient(httpClient))
+	assert.Nil(t, err)
+	assert.False(t, logger.contains("warn:"))
+}
+
+func TestBasePathPrefix(t *testing.T) {
+	var paths []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		switch r.URL.Path {
+		case "/lenses/api/login":
+			w.Write([]byte("login-token"))
+		case "/lenses/api/auth":
+			w.Write([]byte(`{"token": "login-token", "user": "user"}`))
+		case "/lenses/api/topics", "/lenses/api/v1/kafka/topics":
+			w.Write([]byte("[]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL + "/lenses/"}, WithBasicAuth("user", "pass"))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.Equal(t, "/lenses", client.Config.BasePath())
+
+	for _, path := range []string{"api/topics", "/api/v1/kafka/topics"} {
+		resp, err := client.Do(http.MethodGet, path, "", nil)
+		if assert.Nil(t, err, path) {
+			resp.Body.Close()
+		}
+	}
+
+	assert.Equal(t, []string{"/lenses/api/login", "/lenses/api/auth", "/lenses/api/topics", "/lenses/api/v1/kafka/topics"}, paths)
+
+	// without a prefix.
+	paths = nil
+	client, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+	assert.Equal(t, "", client.Config.BasePath())
+
+	_, err = client.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"/api/topics"}, paths)
+}
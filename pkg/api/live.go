@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+
+	"github.com/landoop/lenses-go/pkg/websocket"
+)
+
+// LiveSubscribe runs the `query` as a continuous (live) query over the Lenses websocket,
+// authenticated with the client's token, and streams the decoded messages to the returned channel
+// until the `ctx` is done or the subscription is over.
+//
+// See `websocket.Subscribe` for the reconnection and the close behavior.
+func (c *Client) LiveSubscribe(ctx context.Context, query string) (<-chan websocket.LiveMessage, error) {
+	return c.subscribe(ctx, query, true)
+}
+
+// subscribe runs the `query` over the Lenses websocket, a snapshot (not live) query
+// is never reconnected, its records would be sent again.
+func (c *Client) subscribe(ctx context.Context, query string, live bool) (<-chan websocket.LiveMessage, error) {
+	if c.isClosed() {
+		return nil, ErrClientClosed
+	}
+
+	config := websocket.LiveConfiguration{
+		Host:  c.Config.Host,
+		Debug: c.Config.Debug,
+		Message: websocket.Message{
+			Token: c.token(),
+			SQL:   query,
+			Live:  live,
+		},
+	}
+
+	if !live {
+		config.NoReconnect = true
+	}
+
+	if c.Config.Insecure {
+		config.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return websocket.Subscribe(ctx, config)
+}
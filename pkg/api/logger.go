@@ -0,0 +1,186 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/kataras/golog"
+)
+
+// Logger is the destination of the messages of the `Client` and of the cli's import and export commands,
+// so the applications which embed the client can capture them or disable them, see `NopLogger`.
+//
+// The default one writes to the `golog` package's logger, see `SetLogger` and `WithLogger`.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+type gologLogger struct{}
+
+func (gologLogger) Debugf(format string, args ...interface{}) {
+	// don't format the debug messages for nothing, i.e the redacted HTTP dumps.
+	if golog.Default.Level >= golog.DebugLevel {
+		golog.Debugf(format, args...)
+	}
+}
+func (gologLogger) Infof(format string, args ...interface{})  { golog.Infof(format, args...) }
+func (gologLogger) Warnf(format string, args ...interface{})  { golog.Warnf(format, args...) }
+func (gologLogger) Errorf(format string, args ...interface{}) { golog.Errorf(format, args...) }
+
+type nopLogger struct{}
+
+func (nopLogger) Debugf(format string, args ...interface{}) {}
+func (nopLogger) Infof(format string, args ...interface{})  {}
+func (nopLogger) Warnf(format string, args ...interface{})  {}
+func (nopLogger) Errorf(format string, args ...interface{}) {}
+
+// NopLogger is a `Logger` which discards all the messages.
+var NopLogger Logger = nopLogger{}
+
+var (
+	defaultLogger   Logger = gologLogger{}
+	defaultLoggerMu sync.RWMutex
+)
+
+// SetLogger sets the package-level `Logger`,
+// the one of the clients without a `WithLogger` and of the code without a client.
+// A nil "logger" restores the default `golog` one.
+func SetLogger(logger Logger) {
+	if logger == nil {
+		logger = gologLogger{}
+	}
+
+	defaultLoggerMu.Lock()
+	defaultLogger = logger
+	defaultLoggerMu.Unlock()
+}
+
+// DefaultLogger returns the package-level `Logger`, see `SetLogger`.
+func DefaultLogger() Logger {
+	defaultLoggerMu.RLock()
+	logger := defaultLogger
+	defaultLoggerMu.RUnlock()
+	return logger
+}
+
+// WithLogger sets the `Logger` of the client, instead of the package-level one of the `SetLogger`.
+func WithLogger(logger Logger) ConnectionOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// Logger returns the `Logger` of the client, see `WithLogger`.
+// It is safe to call on a nil client, it returns the `DefaultLogger` then.
+func (c *Client) Logger() Logger {
+	if c == nil || c.logger == nil {
+		return DefaultLogger()
+	}
+
+	return c.logger
+}
+
+// Fields are the structured context of a log message, i.e the resource's name and the action on it.
+type Fields map[string]interface{}
+
+// FieldLogger is a `Logger` which supports structured fields, see `LoggerWithFields`.
+type FieldLogger interface {
+	Logger
+	// WithFields returns a `Logger` which adds the "fields" to all of its messages.
+	WithFields(fields Fields) Logger
+}
+
+// LoggerWithFields returns the "logger" with the "fields" if it is a `FieldLogger`,
+// otherwise the "logger" itself, its messages should describe the fields as well.
+func LoggerWithFields(logger Logger, fields Fields) Logger {
+	if fieldLogger, ok := logger.(FieldLogger); ok {
+		return fieldLogger.WithFields(fields)
+	}
+
+	return logger
+}
+
+// JSONLogger is a `FieldLogger` which writes one JSON object per message,
+// with the "level", "time", "message" and the optional "fields".
+//
+// It respects the level of the `golog` package's logger, i.e the debug messages
+// are written only when the `ClientConfig#Debug` is enabled.
+type JSONLogger struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	fields Fields
+}
+
+var _ FieldLogger = (*JSONLogger)(nil)
+
+// NewJSONLogger returns a new `JSONLogger` which writes to "w".
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{w: w, mu: new(sync.Mutex)}
+}
+
+type jsonLogEntry struct {
+	Level   string    `json:"level"`
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+	Fields  Fields    `json:"fields,omitempty"`
+}
+
+// Log writes the "message" of the "level", regardless of the `golog` level.
+func (l *JSONLogger) Log(level golog.Level, t time.Time, message string) {
+	b, err := json.Marshal(jsonLogEntry{Level: golog.Levels[level].Name, Time: t, Message: message, Fields: l.fields})
+	if err != nil {
+		// the fields may not be serializable, keep the message at least.
+		b, _ = json.Marshal(jsonLogEntry{Level: golog.Levels[level].Name, Time: t, Message: message})
+	}
+
+	l.mu.Lock()
+	l.w.Write(append(b, '\n'))
+	l.mu.Unlock()
+}
+
+func (l *JSONLogger) logf(level golog.Level, format string, args ...interface{}) {
+	if golog.Default.Level < level {
+		return
+	}
+
+	l.Log(level, time.Now(), fmt.Sprintf(format, args...))
+}
+
+// Debugf implements the `Logger`.
+func (l *JSONLogger) Debugf(format string, args ...interface{}) {
+	l.logf(golog.DebugLevel, format, args...)
+}
+
+// Infof implements the `Logger`.
+func (l *JSONLogger) Infof(format string, args ...interface{}) {
+	l.logf(golog.InfoLevel, format, args...)
+}
+
+// Warnf implements the `Logger`.
+func (l *JSONLogger) Warnf(format string, args ...interface{}) {
+	l.logf(golog.WarnLevel, format, args...)
+}
+
+// Errorf implements the `Logger`.
+func (l *JSONLogger) Errorf(format string, args ...interface{}) {
+	l.logf(golog.ErrorLevel, format, args...)
+}
+
+// WithFields implements the `FieldLogger`, the "fields" are added to the existing ones.
+func (l *JSONLogger) WithFields(fields Fields) Logger {
+	merged := make(Fields, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+
+	return &JSONLogger{w: l.w, mu: l.mu, fields: merged}
+}
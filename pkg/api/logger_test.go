@@ -0,0 +1,98 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type capturingLogger struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (l *capturingLogger) log(level, format string, args ...interface{}) {
+	l.mu.Lock()
+	l.messages = append(l.messages, level+": "+fmt.Sprintf(format, args...))
+	l.mu.Unlock()
+}
+
+func (l *capturingLogger) Debugf(format string, args ...interface{}) { l.log("debug", format, args...) }
+func (l *capturingLogger) Infof(format string, args ...interface{})  { l.log("info", format, args...) }
+func (l *capturingLogger) Warnf(format string, args ...interface{})  { l.log("warn", format, args...) }
+func (l *capturingLogger) Errorf(format string, args ...interface{}) { l.log("error", format, args...) }
+
+func (l *capturingLogger) contains(prefix string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, msg := range l.messages {
+		if strings.HasPrefix(msg, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestWithLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[]"))
+	}))
+	defer server.Close()
+
+	logger := new(capturingLogger)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Debug: true}, WithLogger(logger))
+	assert.Nil(t, err)
+	assert.Equal(t, logger, client.Logger())
+
+	_, err = client.GetTopics()
+	assert.Nil(t, err)
+	assert.True(t, logger.contains("debug: Client#Do.req:"), "the requests should be logged to the client's logger")
+
+	// the package-level logger is used by the clients without one.
+	defaultLogger := new(capturingLogger)
+	SetLogger(defaultLogger)
+	defer SetLogger(nil)
+
+	client, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Debug: true})
+	assert.Nil(t, err)
+	_, err = client.GetTopics()
+	assert.Nil(t, err)
+	assert.True(t, defaultLogger.contains("debug: Client#Do.req:"))
+
+	var nilClient *Client
+	assert.Equal(t, defaultLogger, nilClient.Logger())
+}
+
+func TestJSONLogger(t *testing.T) {
+	var b bytes.Buffer
+	logger := NewJSONLogger(&b)
+
+	logger.Debugf("hidden %d", 1) // the default level is info.
+	LoggerWithFields(logger, Fields{"name": "orders"}).Infof("created topic [%s]", "orders")
+	logger.Errorf("failed")
+
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		var entry map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(lines[0]), &entry))
+		assert.Equal(t, "info", entry["level"])
+		assert.Equal(t, "created topic [orders]", entry["message"])
+		assert.Equal(t, map[string]interface{}{"name": "orders"}, entry["fields"])
+
+		entry = nil
+		assert.Nil(t, json.Unmarshal([]byte(lines[1]), &entry))
+		assert.Equal(t, "error", entry["level"])
+		assert.NotContains(t, entry, "fields")
+	}
+
+	// the fields are ignored by the loggers which don't support them.
+	assert.Equal(t, NopLogger, LoggerWithFields(NopLogger, Fields{"name": "orders"}))
+}
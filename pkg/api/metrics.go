@@ -0,0 +1,31 @@
+package api
+
+import "time"
+
+// MetricsCollector observes the calls of the client, i.e to count them and measure their latency, see `WithMetricsCollector`.
+//
+// The "github.com/landoop/lenses-go/pkg/api/promcollector" package provides a Prometheus implementation.
+type MetricsCollector interface {
+	// ObserveRequest is called after each call of the client with its method, its endpoint (the route of the path without the query
+	// and with its values replaced by their placeholders, i.e "/api/topics" or "/api/topics/{name}", so the endpoints are a bounded set),
+	// the status code of its response, zero if no response was received, i.e on a network error, and its duration.
+	ObserveRequest(method, endpoint string, status int, dur time.Duration)
+}
+
+// NoopMetricsCollector is a `MetricsCollector` which does nothing, it's the default one.
+type NoopMetricsCollector struct{}
+
+// ObserveRequest implements the `MetricsCollector`, it does nothing.
+func (NoopMetricsCollector) ObserveRequest(method, endpoint string, status int, dur time.Duration) {}
+
+// WithMetricsCollector sets the `MetricsCollector` which observes each call of the client.
+// Defaults to the `NoopMetricsCollector`.
+func WithMetricsCollector(collector MetricsCollector) ConnectionOption {
+	return func(c *Client) {
+		if collector == nil {
+			collector = NoopMetricsCollector{}
+		}
+
+		c.metrics = collector
+	}
+}
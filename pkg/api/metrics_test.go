@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type observedRequest struct {
+	method, endpoint string
+	status           int
+}
+
+type recordingCollector struct {
+	requests []observedRequest
+}
+
+func (c *recordingCollector) ObserveRequest(method, endpoint string, status int, dur time.Duration) {
+	c.requests = append(c.requests, observedRequest{method, endpoint, status})
+}
+
+func TestMetricsCollector(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/topics" {
+			w.Write([]byte("[]"))
+			return
+		}
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	collector := new(recordingCollector)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithMetricsCollector(collector))
+	assert.Nil(t, err)
+
+	_, err = client.GetTopicsNames()
+	assert.Nil(t, err)
+	_, err = client.Do(http.MethodDelete, "api/topics/unknown?force=true", "", nil)
+	assert.NotNil(t, err)
+
+	assert.Equal(t, []observedRequest{
+		{http.MethodGet, "/api/topics", http.StatusOK},
+		// the route, not the name of the topic.
+		{http.MethodDelete, "/api/topics/{name}", http.StatusNotFound},
+	}, collector.requests)
+
+	// network error, no response.
+	server.Close()
+	collector.requests = nil
+	_, err = client.GetTopicsNames()
+	assert.NotNil(t, err)
+	assert.Equal(t, []observedRequest{{http.MethodGet, "/api/topics", 0}}, collector.requests)
+}
+
+func TestRoute(t *testing.T) {
+	tests := map[string]string{
+		"/api/topics":                                           "/api/topics",
+		"/api/topics/payments":                                  "/api/topics/{name}",
+		"/api/topics/payments/0/10":                             "/api/topics/{name}/{partition}/{offset}",
+		"/api/streams/running":                                  "/api/streams/{id}",
+		"/api/streams/p1/scale/3":                               "/api/streams/{id}/scale/{runners}",
+		"/api/proxy-connect/dev/connectors":                     "/api/proxy-connect/{cluster}/connectors",
+		"/api/proxy-connect/dev/connectors/orders/status":       "/api/proxy-connect/{cluster}/connectors/{name}/status",
+		"/api/proxy-connect/dev/connectors/orders/tasks/0":      "/api/proxy-connect/{cluster}/connectors/{name}/tasks/{task}",
+		"/api/proxy-sr/subjects/payments-value/versions/latest": "/api/proxy-sr/subjects/{subject}/versions/{version}",
+		"/api/v1/serviceaccount/ingestion/revoke":               "/api/v1/serviceaccount/{name}/revoke",
+		"/api/version":                                          "/api/version",
+		"/api/topology/":                                        "/api/topology/",
+		// unknown, the segments which are not fixed segments of the routes are replaced.
+		"/api/v1/kafka/topics/payments/partitions/3": "/api/v1/kafka/topics/{param}/partitions/{param}",
+		"/api/v3/topics/payments":                    "/api/{param}/topics/{param}",
+	}
+
+	for endpoint, expected := range tests {
+		assert.Equal(t, expected, route(endpoint), endpoint)
+	}
+}
@@ -0,0 +1,101 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size of the paged list calls when `QueryFiltering#PageSize` is not set.
+const DefaultPageSize = 100
+
+func (opts QueryFiltering) withDefaults() QueryFiltering {
+	if opts.Page <= 0 {
+		opts.Page = 1
+	}
+
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+
+	return opts
+}
+
+// path returns the `path` with the paging query, the `Name` is sent as the `nameKey` query parameter.
+// The `SortBy` and the `SortingOrder` are not sent, the paged endpoints have no sort parameters.
+func (opts QueryFiltering) path(path, nameKey string) string {
+	opts = opts.withDefaults()
+
+	v := url.Values{}
+	v.Add("page", strconv.Itoa(opts.Page))
+	v.Add("pageSize", strconv.Itoa(opts.PageSize))
+	if opts.Name != "" {
+		v.Add(nameKey, opts.Name)
+	}
+
+	return fmt.Sprintf("%s?%s", path, v.Encode())
+}
+
+// Bounds returns the paging information of a list of "total" items which is paged on the client side,
+// i.e of the endpoints without paging, and the [start, end) range of the items of the `Page`.
+// The range is empty if the page is after the last one.
+func (opts QueryFiltering) Bounds(total int) (page Page, start, end int) {
+	opts = opts.withDefaults()
+
+	page = Page{Number: opts.Page, TotalCount: total}
+	page.PagesAmount = (total + opts.PageSize - 1) / opts.PageSize
+
+	start = (opts.Page - 1) * opts.PageSize
+	if start > total {
+		start = total
+	}
+
+	end = start + opts.PageSize
+	if end > total {
+		end = total
+	}
+
+	return
+}
+
+// Page describes the paging information of a paged list response.
+type Page struct {
+	// Number is the requested page, it's not part of the response.
+	Number      int `json:"-" yaml:"-"`
+	PagesAmount int `json:"pagesAmount" yaml:"pagesAmount"`
+	TotalCount  int `json:"totalCount" yaml:"totalCount"`
+}
+
+// HasMore reports whether there are pages after this one.
+func (p Page) HasMore() bool {
+	return p.Number < p.PagesAmount
+}
+
+// NextPage returns the number of the next page or 0 if this is the last one.
+func (p Page) NextPage() int {
+	if !p.HasMore() {
+		return 0
+	}
+
+	return p.Number + 1
+}
+
+// WalkPages calls the `fetch` for each page, starting from the `opts.Page`, until there are no more pages
+// or `fetch` returns an error. The `fetch` receives the options of the page to fetch
+// and should return the paging information of the fetched page.
+func WalkPages(opts QueryFiltering, fetch func(opts QueryFiltering) (Page, error)) error {
+	opts = opts.withDefaults()
+
+	for {
+		page, err := fetch(opts)
+		if err != nil {
+			return err
+		}
+
+		if !page.HasMore() {
+			return nil
+		}
+
+		opts.Page = page.NextPage()
+	}
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWalkTopics(t *testing.T) {
+	topics := []string{"a", "b", "c", "d", "e"}
+	var requestedPages []int
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+topicsPagedPath, r.URL.Path)
+		assert.Equal(t, "x", r.URL.Query().Get("topicName"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		requestedPages = append(requestedPages, page)
+
+		start, end := (page-1)*pageSize, page*pageSize
+		if end > len(topics) {
+			end = len(topics)
+		}
+
+		var values []Topic
+		for _, name := range topics[start:end] {
+			values = append(values, Topic{TopicName: name})
+		}
+
+		pagesAmount := (len(topics) + pageSize - 1) / pageSize
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"pagesAmount": pagesAmount,
+			"totalCount":  len(topics),
+			"values":      values,
+		})
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	var got []string
+	err = client.WalkTopics(QueryFiltering{PageSize: 2, Name: "x"}, func(page []Topic) error {
+		for _, topic := range page {
+			got = append(got, topic.TopicName)
+		}
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, topics, got)
+	assert.Equal(t, []int{1, 2, 3}, requestedPages)
+
+	// stops on the first error.
+	requestedPages = nil
+	errStop := errors.New("stop")
+	err = client.WalkTopics(QueryFiltering{PageSize: 2, Name: "x"}, func(page []Topic) error {
+		return errStop
+	})
+
+	assert.Equal(t, errStop, err)
+	assert.Equal(t, []int{1}, requestedPages)
+}
+
+func TestGetAuditEntriesPageQuery(t *testing.T) {
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+auditPagedPath, r.URL.Path)
+
+		query := r.URL.Query()
+		assert.Equal(t, "2", query.Get("page"))
+		assert.Equal(t, "10", query.Get("pageSize"))
+		assert.Equal(t, "1577836800000", query.Get("from"))
+		assert.Equal(t, "1580515200000", query.Get("to"))
+		assert.Equal(t, "admin", query.Get("user"))
+		assert.Equal(t, []string{"TOPIC", "ACL"}, query["type"])
+
+		w.Write([]byte(`{"pagesAmount": 3, "totalCount": 21, "values": [{"type": "TOPIC", "change": "ADD", "userId": "admin", "timestamp": 1577836800001}]}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	page, err := client.GetAuditEntriesPage(AuditOptions{
+		QueryFiltering: QueryFiltering{Page: 2, PageSize: 10},
+		From:           from,
+		To:             to,
+		User:           "admin",
+		Types:          []AuditEntryType{AuditEntryTopic, AuditEntryACL},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, page.Number)
+	assert.True(t, page.HasMore())
+	assert.Equal(t, []AuditEntry{{Type: AuditEntryTopic, Change: "ADD", UserID: "admin", Timestamp: 1577836800001}}, page.Values)
+
+	// no filters, no filter params.
+	assert.Equal(t, auditPagedPath+"?page=1&pageSize=100", AuditOptions{}.path())
+}
+
+func TestPageHasMore(t *testing.T) {
+	assert.True(t, Page{Number: 1, PagesAmount: 2}.HasMore())
+	assert.Equal(t, 2, Page{Number: 1, PagesAmount: 2}.NextPage())
+	assert.False(t, Page{Number: 2, PagesAmount: 2}.HasMore())
+	assert.Equal(t, 0, Page{Number: 2, PagesAmount: 2}.NextPage())
+	assert.False(t, Page{Number: 1, PagesAmount: 0}.HasMore())
+}
+
+func TestQueryFilteringBounds(t *testing.T) {
+	page, start, end := QueryFiltering{Page: 2, PageSize: 2}.Bounds(5)
+	assert.Equal(t, Page{Number: 2, PagesAmount: 3, TotalCount: 5}, page)
+	assert.Equal(t, []int{2, 4}, []int{start, end})
+
+	// the last page is not full.
+	_, start, end = QueryFiltering{Page: 3, PageSize: 2}.Bounds(5)
+	assert.Equal(t, []int{4, 5}, []int{start, end})
+
+	// after the last page.
+	page, start, end = QueryFiltering{Page: 4, PageSize: 2}.Bounds(5)
+	assert.False(t, page.HasMore())
+	assert.Equal(t, []int{5, 5}, []int{start, end})
+
+	// the defaults.
+	page, start, end = QueryFiltering{}.Bounds(150)
+	assert.Equal(t, Page{Number: 1, PagesAmount: 2, TotalCount: 150}, page)
+	assert.Equal(t, []int{0, DefaultPageSize}, []int{start, end})
+}
@@ -0,0 +1,314 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/landoop/lenses-go/pkg/websocket"
+)
+
+// DefaultPeekMax is the maximum amount of records of a `PeekTopic` when `PeekOptions#Max` is not set.
+const DefaultPeekMax = 20
+
+// Deserializer describes how the key or the value of a peeked record is decoded, see `PeekOptions`.
+type Deserializer string
+
+// The available deserializers, the `DeserializerAuto` selects one based on the topic's key and value types.
+const (
+	DeserializerAuto   Deserializer = ""
+	DeserializerString Deserializer = "string"
+	DeserializerJSON   Deserializer = "json"
+	DeserializerAvro   Deserializer = "avro"
+	DeserializerBytes  Deserializer = "bytes"
+)
+
+// ParseDeserializer returns the deserializer of the "name", an empty name is the `DeserializerAuto`.
+func ParseDeserializer(name string) (Deserializer, error) {
+	switch d := Deserializer(strings.ToLower(name)); d {
+	case DeserializerAuto, DeserializerString, DeserializerJSON, DeserializerAvro, DeserializerBytes:
+		return d, nil
+	}
+
+	return DeserializerAuto, fmt.Errorf("unknown deserializer [%s], available: string, json, avro, bytes", name)
+}
+
+// SelectDeserializer returns the "requested" deserializer,
+// or the one that matches the topic's key or value "topicType" when the "requested" is the `DeserializerAuto`.
+func SelectDeserializer(requested Deserializer, topicType string) Deserializer {
+	if requested != DeserializerAuto {
+		return requested
+	}
+
+	switch strings.ToUpper(topicType) {
+	case "AVRO":
+		return DeserializerAvro
+	case "JSON":
+		return DeserializerJSON
+	case "BYTES", "BINARY":
+		return DeserializerBytes
+	}
+
+	// STRING, INT, LONG and the unknown types are shown as they were sent.
+	return DeserializerString
+}
+
+// PeekOptions describes the records to sample with the `PeekTopic`.
+type PeekOptions struct {
+	// Partitions selects the partitions to read from, all of them if empty.
+	Partitions []int
+	// FromOffset reads the records starting from this offset of each partition.
+	FromOffset int64
+	// FromTimestamp reads the records created from this time, optional.
+	FromTimestamp time.Time
+	// FromLatest reads the last `Max` records of each partition instead of the first ones.
+	FromLatest bool
+	// Max is the maximum amount of records to read, of each partition with the `FromLatest`,
+	// defaults to `DefaultPeekMax`.
+	Max int
+	// KeyDeserializer and ValueDeserializer decode the records' keys and values,
+	// they default to the topic's key and value types, see `SelectDeserializer`.
+	KeyDeserializer, ValueDeserializer Deserializer
+}
+
+// PeekRecord is a decoded record of a topic, see `PeekTopic`.
+type PeekRecord struct {
+	Partition int         `json:"partition" yaml:"partition" header:"Partition,text"`
+	Offset    int64       `json:"offset" yaml:"offset" header:"Offset,text"`
+	Timestamp int64       `json:"timestamp" yaml:"timestamp" header:"Date,timestamp(ms|utc|02 Jan 2006 15:04)"`
+	Key       interface{} `json:"key" yaml:"key" header:"Key"`
+	Value     interface{} `json:"value" yaml:"value" header:"Value"`
+	// Note is set when the key or the value could not be decoded and they are shown as base64.
+	Note string `json:"note,omitempty" yaml:"note,omitempty" header:"Note"`
+}
+
+// PeekTopic returns a sample of the decoded records of a topic, see `PeekTopicContext`.
+func (c *Client) PeekTopic(topicName string, opts PeekOptions) ([]PeekRecord, error) {
+	var records []PeekRecord
+	err := c.PeekTopicContext(context.Background(), topicName, opts, func(record PeekRecord) error {
+		records = append(records, record)
+		return nil
+	})
+
+	return records, err
+}
+
+// PeekTopicContext reads a sample of the records of a topic, without the need of a query,
+// and calls the "fn" with each decoded record as soon as it arrives, until the `opts.Max` records are read,
+// the "ctx" is done or the "fn" returns an error.
+func (c *Client) PeekTopicContext(ctx context.Context, topicName string, opts PeekOptions, fn func(PeekRecord) error) error {
+	topic, err := c.GetTopicContext(ctx, topicName)
+	if err != nil {
+		return err
+	}
+
+	opts.KeyDeserializer = SelectDeserializer(opts.KeyDeserializer, topic.KeyType)
+	opts.ValueDeserializer = SelectDeserializer(opts.ValueDeserializer, topic.ValueType)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages, err := c.subscribe(ctx, peekQuery(topic, opts), false)
+	if err != nil {
+		return err
+	}
+
+	for msg := range messages {
+		if msg.Err != nil {
+			return msg.Err
+		}
+
+		if msg.Type != websocket.RecordMessageResponse {
+			continue
+		}
+
+		if err = fn(newPeekRecord(msg.Data, opts)); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Err()
+}
+
+// peekQuery returns the sql query which reads the records of the "opts".
+func peekQuery(topic Topic, opts PeekOptions) string {
+	if opts.Max <= 0 {
+		opts.Max = DefaultPeekMax
+	}
+
+	var conditions []string
+
+	if len(opts.Partitions) > 0 {
+		partitions := make([]string, len(opts.Partitions))
+		for i, p := range opts.Partitions {
+			partitions[i] = fmt.Sprintf("%d", p)
+		}
+		conditions = append(conditions, fmt.Sprintf("_meta.partition IN (%s)", strings.Join(partitions, ", ")))
+	}
+
+	if opts.FromOffset > 0 {
+		conditions = append(conditions, fmt.Sprintf("_meta.offset >= %d", opts.FromOffset))
+	}
+
+	if !opts.FromTimestamp.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("_meta.timestamp >= %d", toMillis(opts.FromTimestamp)))
+	}
+
+	limit := opts.Max
+	if opts.FromLatest {
+		if latest, records := latestOffsetsCondition(topic, opts); latest != "" {
+			conditions = append(conditions, latest)
+			limit = records
+		}
+	}
+
+	query := "SELECT * FROM " + quoteIdentifier(topic.TopicName)
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	return fmt.Sprintf("%s LIMIT %d", query, limit)
+}
+
+// quoteIdentifier quotes a topic name of a query with backticks, the backticks of the name are doubled.
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// latestOffsetsCondition selects the last `opts.Max` records of each of the selected partitions,
+// it returns the amount of the selected records too, the limit of the query.
+func latestOffsetsCondition(topic Topic, opts PeekOptions) (string, int) {
+	selected := func(partition int) bool {
+		if len(opts.Partitions) == 0 {
+			return true
+		}
+
+		for _, p := range opts.Partitions {
+			if p == partition {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var (
+		partitions []string
+		records    int
+	)
+	for _, p := range topic.MessagesPerPartition {
+		if !selected(p.Partition) {
+			continue
+		}
+
+		from := p.End - int64(opts.Max)
+		if from < p.Begin {
+			from = p.Begin
+		}
+
+		partitions = append(partitions, fmt.Sprintf("(_meta.partition = %d AND _meta.offset >= %d)", p.Partition, from))
+		records += int(p.End - from)
+	}
+
+	if len(partitions) == 0 || records == 0 {
+		return "", 0
+	}
+
+	return "(" + strings.Join(partitions, " OR ") + ")", records
+}
+
+func newPeekRecord(data websocket.Data, opts PeekOptions) PeekRecord {
+	record := PeekRecord{
+		Partition: data.Metadata.Partition,
+		Offset:    int64(data.Metadata.Offset),
+		Timestamp: int64(data.Metadata.Timestamp),
+	}
+
+	var keyDecoded, valueDecoded bool
+	record.Key, keyDecoded = decodePeekPayload(data.Key, opts.KeyDeserializer)
+	record.Value, valueDecoded = decodePeekPayload(data.Value, opts.ValueDeserializer)
+
+	switch {
+	case !keyDecoded && !valueDecoded:
+		record.Note = "the key and the value are binary, shown as base64"
+	case !keyDecoded:
+		record.Note = "the key is binary, shown as base64"
+	case !valueDecoded:
+		record.Note = "the value is binary, shown as base64"
+	}
+
+	return record
+}
+
+// decodePeekPayload decodes a record's key or value based on the deserializer,
+// it reports false if the payload could not be decoded and it's returned as base64.
+func decodePeekPayload(raw json.RawMessage, d Deserializer) (interface{}, bool) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, true
+	}
+
+	// the server sends the payloads which are not objects as strings.
+	var (
+		text     string
+		isString = json.Unmarshal(raw, &text) == nil
+	)
+
+	switch d {
+	case DeserializerBytes:
+		if isString {
+			return base64.StdEncoding.EncodeToString([]byte(text)), true
+		}
+		return base64.StdEncoding.EncodeToString(raw), true
+	case DeserializerJSON, DeserializerAvro:
+		if !isString {
+			var v interface{}
+			if err := json.Unmarshal(raw, &v); err == nil {
+				return v, true
+			}
+			return base64.StdEncoding.EncodeToString(raw), false
+		}
+
+		// a json document sent as text.
+		var v interface{}
+		if err := json.Unmarshal([]byte(text), &v); err == nil {
+			return v, true
+		}
+
+		if isPrintable(text) {
+			return text, true
+		}
+
+		return base64.StdEncoding.EncodeToString([]byte(text)), false
+	}
+
+	if !isString {
+		return string(raw), true
+	}
+
+	if isPrintable(text) {
+		return text, true
+	}
+
+	return base64.StdEncoding.EncodeToString([]byte(text)), false
+}
+
+func isPrintable(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+
+	for _, r := range s {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/landoop/lenses-go/pkg/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeekQueryFromTimestamp(t *testing.T) {
+	topic := Topic{TopicName: "payments"}
+	from := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	query := peekQuery(topic, PeekOptions{FromTimestamp: from, Partitions: []int{0, 2}, Max: 5})
+	assert.Equal(t, "SELECT * FROM `payments` WHERE _meta.partition IN (0, 2) AND _meta.timestamp >= 1577872800000 LIMIT 5", query)
+
+	query = peekQuery(topic, PeekOptions{FromOffset: 42})
+	assert.Equal(t, "SELECT * FROM `payments` WHERE _meta.offset >= 42 LIMIT 20", query)
+}
+
+func TestPeekQueryEscapesTopicName(t *testing.T) {
+	query := peekQuery(Topic{TopicName: "pay`ments` WHERE 1=1 --"}, PeekOptions{Max: 1})
+	assert.Equal(t, "SELECT * FROM `pay``ments`` WHERE 1=1 --` LIMIT 1", query)
+}
+
+func TestPeekQueryFromLatest(t *testing.T) {
+	topic := Topic{
+		TopicName: "payments",
+		MessagesPerPartition: []PartitionMessage{
+			{Partition: 0, Begin: 0, End: 100},
+			{Partition: 1, Begin: 95, End: 98},
+		},
+	}
+
+	query := peekQuery(topic, PeekOptions{FromLatest: true, Max: 10})
+	assert.Equal(t, "SELECT * FROM `payments` WHERE ((_meta.partition = 0 AND _meta.offset >= 90) OR (_meta.partition = 1 AND _meta.offset >= 95)) LIMIT 13", query)
+
+	// the limit is of each partition.
+	topic.MessagesPerPartition = append(topic.MessagesPerPartition, PartitionMessage{Partition: 2, Begin: 0, End: 50})
+	query = peekQuery(topic, PeekOptions{FromLatest: true, Max: 10, Partitions: []int{0, 2}})
+	assert.Equal(t, "SELECT * FROM `payments` WHERE _meta.partition IN (0, 2) AND ((_meta.partition = 0 AND _meta.offset >= 90) OR (_meta.partition = 2 AND _meta.offset >= 40)) LIMIT 20", query)
+
+	query = peekQuery(topic, PeekOptions{FromLatest: true, Max: 10, Partitions: []int{1}})
+	assert.Equal(t, "SELECT * FROM `payments` WHERE _meta.partition IN (1) AND ((_meta.partition = 1 AND _meta.offset >= 95)) LIMIT 3", query)
+}
+
+func TestSelectDeserializer(t *testing.T) {
+	assert.Equal(t, DeserializerAvro, SelectDeserializer(DeserializerAuto, "AVRO"))
+	assert.Equal(t, DeserializerJSON, SelectDeserializer(DeserializerAuto, "json"))
+	assert.Equal(t, DeserializerString, SelectDeserializer(DeserializerAuto, "STRING"))
+	assert.Equal(t, DeserializerString, SelectDeserializer(DeserializerAuto, "LONG"))
+	assert.Equal(t, DeserializerBytes, SelectDeserializer(DeserializerAuto, "BYTES"))
+	// the requested one wins over the topic's type.
+	assert.Equal(t, DeserializerString, SelectDeserializer(DeserializerString, "AVRO"))
+
+	_, err := ParseDeserializer("protobuf")
+	assert.NotNil(t, err)
+	d, err := ParseDeserializer("AVRO")
+	assert.Nil(t, err)
+	assert.Equal(t, DeserializerAvro, d)
+}
+
+func TestDecodePeekPayload(t *testing.T) {
+	object := json.RawMessage(`{"amount": 10}`)
+
+	v, ok := decodePeekPayload(object, DeserializerAvro)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"amount": float64(10)}, v)
+
+	v, ok = decodePeekPayload(object, DeserializerString)
+	assert.True(t, ok)
+	assert.Equal(t, `{"amount": 10}`, v)
+
+	v, ok = decodePeekPayload(json.RawMessage(`"{\"amount\": 10}"`), DeserializerJSON)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]interface{}{"amount": float64(10)}, v)
+
+	v, ok = decodePeekPayload(json.RawMessage(`"key-1"`), DeserializerString)
+	assert.True(t, ok)
+	assert.Equal(t, "key-1", v)
+
+	binary := "\x00\x01\x02"
+	raw, _ := json.Marshal(binary)
+	v, ok = decodePeekPayload(raw, DeserializerString)
+	assert.False(t, ok)
+	assert.Equal(t, base64.StdEncoding.EncodeToString([]byte(binary)), v)
+
+	v, ok = decodePeekPayload(json.RawMessage(`null`), DeserializerAvro)
+	assert.True(t, ok)
+	assert.Nil(t, v)
+}
+
+func TestPeekTopic(t *testing.T) {
+	upgrader := gorilla.Upgrader{}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/topics/payments":
+			w.Write([]byte(`{"topicName": "payments", "keyType": "STRING", "valueType": "AVRO"}`))
+		case "/api/ws/v2/sql/execute":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer conn.Close()
+
+			var msg websocket.Message
+			assert.Nil(t, conn.ReadJSON(&msg))
+			assert.Equal(t, "SELECT * FROM `payments` LIMIT 2", msg.SQL)
+			assert.False(t, msg.Live)
+
+			conn.WriteJSON(websocket.LiveResponse{Type: websocket.RecordMessageResponse, Data: websocket.Data{
+				Key:      json.RawMessage(`"k1"`),
+				Value:    json.RawMessage(`{"amount": 1}`),
+				Metadata: websocket.MetaData{Partition: 0, Offset: 7},
+			}})
+			conn.WriteJSON(websocket.LiveResponse{Type: websocket.RecordMessageResponse, Data: websocket.Data{
+				Key:   json.RawMessage(`"k2"`),
+				Value: json.RawMessage(`"\u0000\u0001"`),
+			}})
+			conn.WriteJSON(websocket.LiveResponse{Type: websocket.EndResponse})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	records, err := client.PeekTopic("payments", PeekOptions{Max: 2})
+	assert.Nil(t, err)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, PeekRecord{Offset: 7, Key: "k1", Value: map[string]interface{}{"amount": float64(1)}}, records[0])
+		assert.Equal(t, "k2", records[1].Key)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0, 1}), records[1].Value)
+		assert.Equal(t, "the value is binary, shown as base64", records[1].Note)
+	}
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// PingResult describes the result of a `Client#Ping`.
+type PingResult struct {
+	Host    string        `json:"host" yaml:"host" header:"Host"`
+	User    string        `json:"user,omitempty" yaml:"user,omitempty" header:"User"`
+	Latency time.Duration `json:"latency" yaml:"latency" header:"Latency"`
+	// Version is the server's version, empty if it's not available to the user.
+	Version string `json:"version,omitempty" yaml:"version,omitempty" header:"Version"`
+}
+
+// Ping checks that the server is reachable and the client's credentials are accepted,
+// it returns the latency of the round trip, the authenticated user and the server's version.
+//
+// The returned error is described by the `DescribeConnectionError`.
+func (c *Client) Ping() (PingResult, error) {
+	return c.PingContext(context.Background())
+}
+
+// PingContext is like `Ping` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) PingContext(ctx context.Context) (PingResult, error) {
+	result := PingResult{Host: c.Config.Host}
+
+	start := time.Now()
+	user, err := c.GetCurrentUserContext(ctx)
+	if err != nil {
+		return result, DescribeConnectionError(c.Config.Host, err)
+	}
+
+	result.Latency = time.Since(start)
+	result.User = user.Name
+
+	// the version is optional, it may not be available to the user.
+	if version, err := c.GetServerVersionContext(ctx); err == nil {
+		result.Version = version.Version
+	}
+
+	return result, nil
+}
+
+// TestConnection opens a connection based on the "cfg" and pings the server, see `Client#Ping`.
+// Unlike the `OpenConnection`, the returned errors are described for the end-user,
+// i.e unresolved host, timeout or invalid credentials, see `DescribeConnectionError`.
+func TestConnection(cfg ClientConfig, options ...ConnectionOption) (PingResult, error) {
+	client, err := OpenConnection(cfg, options...)
+	if err != nil {
+		return PingResult{Host: cfg.Host}, DescribeConnectionError(cfg.Host, err)
+	}
+
+	return client.Ping()
+}
+
+// DescribeConnectionError returns an error which describes the cause of a failed connection to the "host",
+// i.e unresolved host, timeout, refused connection or invalid credentials,
+// if the cause is unknown then it returns the "err" as it's.
+func DescribeConnectionError(host string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		dnsErr *net.DNSError
+		netErr net.Error
+		opErr  *net.OpError
+	)
+
+	switch {
+	case errors.Is(err, ErrCredentialsMissing):
+		return fmt.Errorf("authentication to [%s] failed: %v", host, ErrCredentialsMissing)
+	case errors.As(err, &dnsErr):
+		return fmt.Errorf("unable to resolve the host [%s]: %v", dnsErr.Name, dnsErr.Err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Errorf("connection to [%s] timed out, please check the host and the timeout", host)
+	case errors.As(err, &opErr):
+		return fmt.Errorf("unable to connect to [%s]: %v", host, opErr.Err)
+	}
+
+	return err
+}
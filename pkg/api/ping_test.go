@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestConnection(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(xKafkaLensesTokenHeaderKey) != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/auth":
+			w.Write([]byte(`{"token": "secret", "user": "admin"}`))
+		case "/api/config":
+			w.Write([]byte(`{"lenses.version": "3.1.0"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	// success.
+	result, err := TestConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+	assert.Equal(t, "admin", result.User)
+	assert.Equal(t, "3.1.0", result.Version)
+	assert.True(t, result.Latency > 0)
+
+	// auth failure.
+	_, err = TestConnection(ClientConfig{Host: server.URL, Token: "invalid"})
+	assert.NotNil(t, err)
+	assert.Equal(t, "authentication to ["+server.URL+"] failed: credentials missing or invalid", err.Error())
+
+	_, err = TestConnection(ClientConfig{Host: server.URL, Authentication: BasicAuthentication{Username: "user", Password: "invalid"}})
+	assert.NotNil(t, err)
+	assert.Equal(t, "authentication to ["+server.URL+"] failed: credentials missing or invalid", err.Error())
+}
+
+func TestTestConnectionUnreachableHost(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	host := server.URL
+	// nothing listens there now.
+	server.Close()
+
+	_, err := TestConnection(ClientConfig{Host: host, Token: "secret"})
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "unable to connect to ["+host+"]: "), err.Error())
+
+	_, err = TestConnection(ClientConfig{Host: host, Authentication: BasicAuthentication{Username: "user", Password: "pass"}})
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "unable to connect to ["+host+"]: "), err.Error())
+}
@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const topicMessagesPath = "api/v1/kafka/topics/%s/messages"
+
+// Encoding describes how the value of a produced record is encoded, see `ProduceOptions`.
+type Encoding string
+
+// The available value encodings, the `EncodingAuto` lets the server encode the values based on the topic's value type.
+const (
+	EncodingAuto   Encoding = ""
+	EncodingString Encoding = "string"
+	EncodingJSON   Encoding = "json"
+	EncodingAvro   Encoding = "avro"
+)
+
+// ParseEncoding returns the encoding of the "name", an empty name is the `EncodingAuto`.
+func ParseEncoding(name string) (Encoding, error) {
+	switch e := Encoding(strings.ToLower(name)); e {
+	case EncodingAuto, EncodingString, EncodingJSON, EncodingAvro:
+		return e, nil
+	}
+
+	return EncodingAuto, fmt.Errorf("unknown encoding [%s], available: string, json, avro", name)
+}
+
+// ProduceRecord is a record to produce to a topic, see `ProduceToTopic`.
+type ProduceRecord struct {
+	Key string `json:"key,omitempty" yaml:"key,omitempty"`
+	// Value is the json value of the record, a json string for the string encoding.
+	Value   json.RawMessage   `json:"value" yaml:"value"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Partition is the partition to produce the record to, the default partitioner selects it when nil.
+	Partition *int `json:"partition,omitempty" yaml:"partition,omitempty"`
+}
+
+// ProduceOptions describes the encoding of the values of a `ProduceToTopicWithOptions` call.
+type ProduceOptions struct {
+	ValueEncoding Encoding
+	// ValueSubject is the schema registry subject of the avro encoding, defaults to "<topic>-value".
+	ValueSubject string
+}
+
+// ProduceResult describes where a produced record was written to, or why it failed.
+type ProduceResult struct {
+	Record    int    `json:"record" yaml:"record" header:"Record,text"`
+	Partition int    `json:"partition" yaml:"partition" header:"Partition,text"`
+	Offset    int64  `json:"offset" yaml:"offset" header:"Offset,text"`
+	Error     string `json:"error,omitempty" yaml:"error,omitempty" header:"Error"`
+}
+
+type producePayload struct {
+	ValueType     string          `json:"valueType,omitempty"`
+	ValueSchemaID int             `json:"valueSchemaId,omitempty"`
+	Records       []ProduceRecord `json:"records"`
+}
+
+// ProduceToTopic produces the "records" to a topic, the server encodes the values based on the topic's value type.
+// See `ProduceToTopicWithOptions` to select the encoding.
+func (c *Client) ProduceToTopic(topicName string, records []ProduceRecord) ([]ProduceResult, error) {
+	return c.ProduceToTopicContext(context.Background(), topicName, records)
+}
+
+// ProduceToTopicContext is like `ProduceToTopic` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) ProduceToTopicContext(ctx context.Context, topicName string, records []ProduceRecord) ([]ProduceResult, error) {
+	return c.ProduceToTopicWithOptionsContext(ctx, topicName, ProduceOptions{}, records)
+}
+
+// ProduceToTopicWithOptions produces the "records" to a topic and returns the partition and the offset of each record.
+// The avro encoding looks up the latest schema of the `opts.ValueSubject` on the schema registry,
+// the records' values are encoded with that schema by the server.
+func (c *Client) ProduceToTopicWithOptions(topicName string, opts ProduceOptions, records []ProduceRecord) ([]ProduceResult, error) {
+	return c.ProduceToTopicWithOptionsContext(context.Background(), topicName, opts, records)
+}
+
+// ProduceToTopicWithOptionsContext is like `ProduceToTopicWithOptions` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) ProduceToTopicWithOptionsContext(ctx context.Context, topicName string, opts ProduceOptions, records []ProduceRecord) ([]ProduceResult, error) {
+	if topicName == "" {
+		return nil, errRequired("topicName")
+	}
+
+	if len(records) == 0 {
+		return nil, errRequired("records")
+	}
+
+	payload := producePayload{
+		ValueType: strings.ToUpper(string(opts.ValueEncoding)),
+		Records:   make([]ProduceRecord, len(records)),
+	}
+
+	for i, record := range records {
+		value, err := encodeValue(record.Value, opts.ValueEncoding)
+		if err != nil {
+			return nil, fmt.Errorf("record [%d]: %v", i, err)
+		}
+
+		record.Value = value
+		payload.Records[i] = record
+	}
+
+	if opts.ValueEncoding == EncodingAvro {
+		subject := opts.ValueSubject
+		if subject == "" {
+			subject = topicName + "-value"
+		}
+
+		schema, err := c.GetLatestSchemaContext(ctx, subject)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find the avro schema of the subject [%s]: %w", subject, err)
+		}
+
+		payload.ValueSchemaID = schema.ID
+	}
+
+	send, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.DoContext(ctx, http.MethodPost, fmt.Sprintf(topicMessagesPath, url.PathEscape(topicName)), contentTypeJSON, send)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []ProduceResult
+	if err = c.ReadJSON(resp, &results); err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Record = i
+	}
+
+	return results, nil
+}
+
+// encodeValue checks that the "value" matches the "encoding",
+// the string encoding accepts any text, it's sent as a json string.
+func encodeValue(value json.RawMessage, encoding Encoding) (json.RawMessage, error) {
+	if len(value) == 0 {
+		return json.RawMessage("null"), nil
+	}
+
+	if encoding == EncodingString {
+		var s string
+		if json.Unmarshal(value, &s) == nil {
+			return value, nil
+		}
+
+		return json.Marshal(string(value))
+	}
+
+	if !json.Valid(value) {
+		return nil, fmt.Errorf("the value is not valid json")
+	}
+
+	if encoding == EncodingAvro && !strings.HasPrefix(strings.TrimSpace(string(value)), "{") {
+		return nil, fmt.Errorf("the avro value should be a json object")
+	}
+
+	return value, nil
+}
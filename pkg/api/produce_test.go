@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduceToTopicAvroLooksUpSubject(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/proxy-sr/subjects/payments-value/versions/latest":
+			w.Write([]byte(`{"id": 12, "subject": "payments-value", "version": 3, "schema": "{}"}`))
+		case "/api/v1/kafka/topics/payments/messages":
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"valueType": "AVRO", "valueSchemaId": 12, "records": [{"key": "k1", "value": {"amount": 1}}]}`, string(b))
+			w.Write([]byte(`[{"partition": 1, "offset": 42}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	records := []ProduceRecord{{Key: "k1", Value: json.RawMessage(`{"amount": 1}`)}}
+	results, err := client.ProduceToTopicWithOptions("payments", ProduceOptions{ValueEncoding: EncodingAvro}, records)
+	assert.Nil(t, err)
+	assert.Equal(t, []ProduceResult{{Record: 0, Partition: 1, Offset: 42}}, results)
+
+	// unknown subject.
+	_, err = client.ProduceToTopicWithOptions("payments", ProduceOptions{ValueEncoding: EncodingAvro, ValueSubject: "missing"}, records)
+	assert.NotNil(t, err)
+}
+
+func TestProduceToTopicEscapesName(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/kafka/topics/team%2Fpayments%20eu/messages", r.URL.EscapedPath())
+		w.Write([]byte(`[{"partition": 0, "offset": 1}]`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	_, err = client.ProduceToTopic("team/payments eu", []ProduceRecord{{Value: json.RawMessage(`1`)}})
+	assert.Nil(t, err)
+}
+
+func TestEncodeValue(t *testing.T) {
+	v, err := encodeValue(json.RawMessage(`plain text`), EncodingString)
+	assert.Nil(t, err)
+	assert.Equal(t, `"plain text"`, string(v))
+
+	v, err = encodeValue(json.RawMessage(`"already a string"`), EncodingString)
+	assert.Nil(t, err)
+	assert.Equal(t, `"already a string"`, string(v))
+
+	_, err = encodeValue(json.RawMessage(`{invalid`), EncodingJSON)
+	assert.NotNil(t, err)
+
+	_, err = encodeValue(json.RawMessage(`"text"`), EncodingAvro)
+	assert.NotNil(t, err)
+
+	_, err = ParseEncoding("protobuf")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,74 @@
+// Package promcollector provides a Prometheus `api.MetricsCollector`, it's a separate package
+// so the users of the client who don't need it do not depend on Prometheus.
+//
+// Usage:
+// collector, err := promcollector.New(prometheus.DefaultRegisterer, "")
+// if err != nil { panic(err) }
+// client, err := api.OpenConnection(config, api.WithMetricsCollector(collector))
+package promcollector
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNamespace is the namespace of the metrics if no other is given to `New`.
+const DefaultNamespace = "lenses_client"
+
+// Collector is an `api.MetricsCollector` which records the calls of the client to Prometheus metrics:
+// the "requests_total" counter and the "request_duration_seconds" histogram,
+// labeled by the "method" and the "endpoint", the counter by the status "code" too,
+// it is "error" if no response was received.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+}
+
+var _ api.MetricsCollector = (*Collector)(nil)
+
+// New returns a new `Collector` and registers its metrics to the "registerer",
+// under the "namespace" or the `DefaultNamespace` if it's empty.
+func New(registerer prometheus.Registerer, namespace string) (*Collector, error) {
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	c := &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Total number of the requests to the Lenses API.",
+		}, []string{"method", "endpoint", "code"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests to the Lenses API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "endpoint"}),
+	}
+
+	if err := registerer.Register(c.requests); err != nil {
+		return nil, err
+	}
+
+	if err := registerer.Register(c.duration); err != nil {
+		registerer.Unregister(c.requests)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// ObserveRequest implements the `api.MetricsCollector`.
+func (c *Collector) ObserveRequest(method, endpoint string, status int, dur time.Duration) {
+	code := "error"
+	if status > 0 {
+		code = strconv.Itoa(status)
+	}
+
+	c.requests.WithLabelValues(method, endpoint, code).Inc()
+	c.duration.WithLabelValues(method, endpoint).Observe(dur.Seconds())
+}
@@ -0,0 +1,33 @@
+package promcollector
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	c, err := New(registry, "")
+	assert.Nil(t, err)
+
+	c.ObserveRequest("GET", "/api/topics", 200, time.Second)
+	c.ObserveRequest("GET", "/api/topics", 200, time.Second)
+	c.ObserveRequest("GET", "/api/topics", 0, time.Second)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(c.requests.WithLabelValues("GET", "/api/topics", "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(c.requests.WithLabelValues("GET", "/api/topics", "error")))
+
+	families, err := registry.Gather()
+	assert.Nil(t, err)
+	assert.Len(t, families, 2)
+	assert.Equal(t, "lenses_client_request_duration_seconds", families[0].GetName())
+	assert.Equal(t, uint64(3), families[0].GetMetric()[0].GetHistogram().GetSampleCount())
+
+	// registered twice.
+	_, err = New(registry, "")
+	assert.NotNil(t, err)
+}
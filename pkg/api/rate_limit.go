@@ -0,0 +1,139 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RateLimit is the client-side rate limit of the requests, see `ClientConfig#RateLimit`.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of the requests, zero or negative means no limit.
+	RequestsPerSecond float64 `json:"requestsPerSecond" yaml:"RequestsPerSecond"`
+	// Burst is the number of requests that can be sent at once, before the rate applies.
+	//
+	// Defaults to 1.
+	Burst int `json:"burst,omitempty" yaml:"Burst,omitempty"`
+}
+
+// Validate returns an error if the rate or the burst are invalid.
+func (r RateLimit) Validate() error {
+	if r.RequestsPerSecond < 0 {
+		return fmt.Errorf("invalid rate limit [%v], expected the requests per second to be positive", r.RequestsPerSecond)
+	}
+
+	if r.Burst < 0 {
+		return fmt.Errorf("invalid rate limit burst [%d], expected a positive number", r.Burst)
+	}
+
+	return nil
+}
+
+// rateLimiter is a token bucket of the `RateLimit`, a nil one does not limit.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // between two tokens.
+	burst    float64
+	tokens   float64
+	last     time.Time
+}
+
+func newRateLimiter(limit *RateLimit) *rateLimiter {
+	if limit == nil || limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+
+	burst := limit.Burst
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / limit.RequestsPerSecond),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// reserve takes a token and returns the time to wait until it's available.
+func (l *rateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	// the tokens go negative for the waiting requests, so they are spaced by the interval.
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-l.tokens * float64(l.interval))
+}
+
+// wait blocks until a request can be sent, or the "ctx" is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	return sleepContext(ctx, l.reserve())
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+const (
+	// maxRateLimitedRetries is the number of times a 429 Too Many Requests response is retried.
+	maxRateLimitedRetries = 3
+	// maxRetryAfter caps the waiting of a Retry-After header.
+	maxRetryAfter = time.Minute
+	// defaultRetryAfter is the waiting of a 429 Too Many Requests response without a valid Retry-After header.
+	defaultRetryAfter = time.Second
+)
+
+// retryAfter returns the waiting of a 429 Too Many Requests "resp", its Retry-After header
+// in seconds or as an HTTP date, capped to the `maxRetryAfter`.
+func retryAfter(resp *http.Response) time.Duration {
+	d := defaultRetryAfter
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			d = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(value); err == nil {
+			d = time.Until(date)
+		}
+	}
+
+	if d < 0 {
+		d = 0
+	}
+
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+
+	return d
+}
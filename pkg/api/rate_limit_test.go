@@ -0,0 +1,125 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []time.Time
+	)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, time.Now())
+		mu.Unlock()
+		w.Write([]byte("{}"))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	const interval = 50 * time.Millisecond
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret", RateLimit: &RateLimit{RequestsPerSecond: 20, Burst: 2}})
+	assert.Nil(t, err)
+
+	for i := 0; i < 6; i++ {
+		resp, err := client.Do(http.MethodGet, "api/topics", "", nil)
+		assert.Nil(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Len(t, received, 6)
+	// the burst is sent at once, the rest of the requests are spaced by the rate.
+	assert.True(t, received[1].Sub(received[0]) < interval, "burst: %s", received[1].Sub(received[0]))
+	for i := 2; i < len(received); i++ {
+		gap := received[i].Sub(received[i-1])
+		assert.True(t, gap >= interval-5*time.Millisecond, "request %d was sent after %s, expected at least %s", i, gap, interval)
+	}
+
+	// no limit by default.
+	client, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+	assert.Nil(t, client.rateLimiter)
+
+	_, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret", RateLimit: &RateLimit{RequestsPerSecond: -1}})
+	assert.EqualError(t, err, "invalid configuration: invalid rate limit [-1], expected the requests per second to be positive")
+}
+
+func TestRateLimitRetryAfter(t *testing.T) {
+	var requests int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"version": "4.0.0"}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	version, err := client.GetServerVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, "4.0.0", version.Version)
+	assert.Equal(t, 3, requests)
+
+	// gives up after the `maxRateLimitedRetries`.
+	requests = -10
+	_, err = client.GetServerVersion()
+	assert.NotNil(t, err)
+	assert.Equal(t, -10+1+maxRateLimitedRetries, requests)
+}
+
+func TestRetryAfter(t *testing.T) {
+	newResponse := func(value string) *http.Response {
+		resp := &http.Response{Header: make(http.Header)}
+		if value != "" {
+			resp.Header.Set("Retry-After", value)
+		}
+		return resp
+	}
+
+	assert.Equal(t, defaultRetryAfter, retryAfter(newResponse("")))
+	assert.Equal(t, defaultRetryAfter, retryAfter(newResponse("soon")))
+	assert.Equal(t, 5*time.Second, retryAfter(newResponse("5")))
+	assert.Equal(t, maxRetryAfter, retryAfter(newResponse("3600")))
+	assert.Equal(t, time.Duration(0), retryAfter(newResponse(time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))))
+
+	d := retryAfter(newResponse(time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat)))
+	assert.True(t, d > 8*time.Second && d <= 10*time.Second, "%s", d)
+}
+
+func TestRateLimitConfigJSON(t *testing.T) {
+	cfg := ClientConfig{
+		Host:           "https://lenses:9991",
+		Authentication: BasicAuthentication{Username: "admin", Password: "admin"},
+		RateLimit:      &RateLimit{RequestsPerSecond: 10, Burst: 5},
+	}
+
+	b, err := ClientConfigMarshalJSON(cfg)
+	assert.Nil(t, err)
+
+	var got ClientConfig
+	assert.Nil(t, ClientConfigUnmarshalJSON(b, &got))
+	assert.Equal(t, cfg, got)
+
+	full := Config{CurrentContext: "master", Contexts: map[string]*ClientConfig{"master": &cfg}}
+	b, err = ConfigMarshalYAML(full)
+	assert.Nil(t, err)
+
+	var gotFull Config
+	assert.Nil(t, ConfigUnmarshalYAML(b, &gotFull))
+	assert.Equal(t, full, gotFull)
+}
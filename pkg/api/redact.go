@@ -0,0 +1,210 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// RedactedValue replaces the sensitive values, i.e the tokens and the passwords, on the debug logs of the client.
+const RedactedValue = "[REDACTED]"
+
+var (
+	redactedHeaders = []string{xKafkaLensesTokenHeaderKey, "Authorization"}
+	// secretFields are the, normalized, parts of the field names which hold secrets, see `IsSecretField` and `RedactFields`.
+	secretFields = []string{
+		"password",
+		"passwd",
+		"secret",
+		"token",
+		"credential",
+		"apikey",
+		"accesskey",
+		"privatekey",
+		"keytab",
+		"jaasconfig",
+	}
+	redactionMu sync.RWMutex
+)
+
+// RedactHeaders adds request header names, case-insensitive, whose values are masked on the debug logs.
+// The "X-Kafka-Lenses-Token" and "Authorization" headers are always masked.
+func RedactHeaders(names ...string) {
+	redactionMu.Lock()
+	redactedHeaders = append(redactedHeaders, names...)
+	redactionMu.Unlock()
+}
+
+// RedactFields adds field names whose values are secrets, see `IsSecretField`. They are masked on the debug logs
+// of the request and response bodies and replaced by placeholders on the exported files with the --redact-secrets.
+func RedactFields(names ...string) {
+	redactionMu.Lock()
+	for _, name := range names {
+		secretFields = append(secretFields, normalizeField(name))
+	}
+	redactionMu.Unlock()
+}
+
+func isRedacted(list []string, name string) bool {
+	redactionMu.RLock()
+	defer redactionMu.RUnlock()
+
+	for _, s := range list {
+		if strings.EqualFold(s, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// secrets returns the known sensitive values of the client, they are masked wherever they appear on the debug logs,
+// i.e the token of a login response which is not a JSON document.
+func (c *Client) secrets() (secrets []string) {
+	c.tokenMu.RLock()
+	tokens := []string{c.Config.Token, c.User.Token}
+	c.tokenMu.RUnlock()
+
+	for _, s := range tokens {
+		if s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+
+	switch auth := c.Config.Authentication.(type) {
+	case BasicAuthentication:
+		secrets = append(secrets, auth.Password)
+	case KerberosAuthentication:
+		if method, ok := auth.Method.(KerberosWithPassword); ok {
+			secrets = append(secrets, method.Password)
+		}
+	}
+
+	return
+}
+
+func (c *Client) redactSecrets(s string) string {
+	for _, secret := range c.secrets() {
+		if secret != "" {
+			s = strings.Replace(s, secret, RedactedValue, -1)
+		}
+	}
+
+	return s
+}
+
+// redactedHeader prints the request headers on the debug logs, with the `RedactHeaders` masked.
+type redactedHeader struct {
+	c      *Client
+	header http.Header
+}
+
+func (r redactedHeader) String() string {
+	masked := make(http.Header, len(r.header))
+	for key, values := range r.header {
+		if isRedacted(redactedHeaders, key) {
+			values = []string{RedactedValue}
+		}
+		masked[key] = values
+	}
+
+	return r.c.redactSecrets(fmt.Sprintf("%#+v", masked))
+}
+
+// redactedBody prints a request or response body on the debug logs, with the `IsSecretField` fields masked.
+type redactedBody struct {
+	c    *Client
+	body []byte
+	// secret masks the whole body, i.e the login response.
+	secret bool
+}
+
+func (r redactedBody) String() string {
+	if r.secret {
+		return RedactedValue
+	}
+
+	s := string(r.body)
+
+	decoder := json.NewDecoder(bytes.NewReader(r.body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err == nil {
+		if b, err := json.Marshal(redactJSON(v)); err == nil {
+			s = string(b)
+		}
+	}
+
+	return r.c.redactSecrets(s)
+}
+
+func redactJSON(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if IsSecretField(key) {
+				value[key] = RedactedValue
+				continue
+			}
+			value[key] = redactJSON(field)
+		}
+	case []interface{}:
+		for i, elem := range value {
+			value[i] = redactJSON(elem)
+		}
+	}
+
+	return v
+}
+
+// isLoginResponse reports whether the "resp" is of the `loginPath`, its body is the raw token.
+func isLoginResponse(resp *http.Response) bool {
+	return resp.Request != nil && resp.Request.URL != nil && strings.HasSuffix(resp.Request.URL.Path, "/"+loginPath)
+}
+
+// IsSecretField reports whether a field, i.e of a request body or of the configuration of a connection, a connector or a topic,
+// holds a secret by its name, case-insensitive and regardless of the separators:
+// passwords, keys, tokens, credentials, keytabs and JAAS configurations,
+// i.e "password", "ssl.key.password", "secretAccessKey", "client_secret" and "apiKey", plus the `RedactFields`.
+func IsSecretField(name string) bool {
+	normalized := normalizeField(name)
+
+	redactionMu.RLock()
+	defer redactionMu.RUnlock()
+
+	for _, secret := range secretFields {
+		if strings.Contains(normalized, secret) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeField(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '_' || r == '-' || r == ' ' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// SecretPlaceholder returns the `${VAR}` placeholder which replaces the value of a secret "field" of a "resource"
+// on the exported files, i.e "${SLACK_WEBHOOK_PASSWORD}" for the "webhook.password" of the "slack" connection.
+// The files with placeholders can't be imported as they are, the variables must be set on import.
+func SecretPlaceholder(resource, field string) string {
+	name := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, resource+"_"+field)
+
+	return "${" + name + "}"
+}
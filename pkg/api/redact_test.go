@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kataras/golog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugRedaction(t *testing.T) {
+	const token = "a5a1a9f1-secret-token"
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			w.Write([]byte(token))
+		case "/api/auth":
+			w.Write([]byte(`{"token": "` + token + `", "user": "user"}`))
+		default:
+			w.Header().Set("Authorization", "Bearer "+token)
+			w.Write([]byte(`[{"name": "svc", "client_secret": "s3cr3t", "nested": {"Password": "hunter2", "apiKey": "k3y"}}]`))
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	// the debug mode sets the golog's level too.
+	defer golog.SetLevel("info")
+
+	RedactFields("apiKey")
+	logger := new(capturingLogger)
+	client, err := OpenConnection(ClientConfig{
+		Host:           server.URL,
+		Authentication: BasicAuthentication{Username: "user", Password: "login-pass"},
+		Debug:          true,
+	}, WithLogger(logger))
+	assert.Nil(t, err)
+
+	_, err = client.Do(http.MethodPost, "api/v1/serviceaccount", contentTypeJSON, []byte(`{"name": "svc", "token": "`+token+`"}`))
+	assert.Nil(t, err)
+
+	resp, err := client.Do(http.MethodGet, "api/v1/serviceaccount", "", nil)
+	assert.Nil(t, err)
+	_, err = client.ReadResponseBody(resp)
+	assert.Nil(t, err)
+
+	output := strings.Join(logger.messages, "\n")
+	assert.Contains(t, output, RedactedValue)
+	for _, secret := range []string{token, "login-pass", "s3cr3t", "hunter2", "k3y"} {
+		assert.NotContains(t, output, secret)
+	}
+	// the rest of the body is still there.
+	assert.Contains(t, output, `"name":"svc"`)
+}
+
+func TestIsSecretField(t *testing.T) {
+	for _, name := range []string{"password", "ssl.key.password", "sslKeystorePassword", "secretAccessKey", "accessKey",
+		"apiKey", "API_KEY", "token", "client_secret", "credentials", "sasl.jaas.config", "keytab", "private-key"} {
+		assert.True(t, IsSecretField(name), name)
+	}
+
+	for _, name := range []string{"username", "webhookUrl", "kafkaBootstrapServers", "key.serializer", "region", "protocol"} {
+		assert.False(t, IsSecretField(name), name)
+	}
+
+	assert.Equal(t, "${SLACK_WEBHOOK_PASSWORD}", SecretPlaceholder("slack", "webhook.password"))
+	assert.Equal(t, "${MY_KAFKA_SSLKEYPASSWORD}", SecretPlaceholder("my-kafka", "sslKeyPassword"))
+}
@@ -0,0 +1,177 @@
+package api
+
+import "strings"
+
+// routes are the templates of the parameterized endpoints of the client, their "{...}" segments match any value.
+// The `route` reports the calls under them, so the names of the topics, connectors, processors and the rest
+// do not become labels of the metrics, see `MetricsCollector`.
+var routes = []string{
+	"/api/v1/kafka/brokers/{id}/configs",
+	"/api/v1/kafka/topics/{name}/messages",
+	"/api/metadata/topics/{name}",
+	"/api/topics/{name}",
+	"/api/topics/{name}/{partition}/{offset}",
+	"/api/configs/topics/{name}",
+	"/api/configs/brokers/{id}",
+	"/api/streams/{id}",
+	"/api/streams/{id}/pause",
+	"/api/streams/{id}/resume",
+	"/api/streams/{id}/scale/{runners}",
+	"/api/proxy-connect/{cluster}/connectors",
+	"/api/proxy-connect/{cluster}/connector-plugins",
+	"/api/proxy-connect/{cluster}/connectors/{name}",
+	"/api/proxy-connect/{cluster}/connectors/{name}/config",
+	"/api/proxy-connect/{cluster}/connectors/{name}/status",
+	"/api/proxy-connect/{cluster}/connectors/{name}/pause",
+	"/api/proxy-connect/{cluster}/connectors/{name}/resume",
+	"/api/proxy-connect/{cluster}/connectors/{name}/restart",
+	"/api/proxy-connect/{cluster}/connectors/{name}/tasks",
+	"/api/proxy-connect/{cluster}/connectors/{name}/tasks/{task}",
+	"/api/proxy-connect/{cluster}/connectors/{name}/tasks/{task}/status",
+	"/api/proxy-connect/{cluster}/connectors/{name}/tasks/{task}/restart",
+	"/api/proxy-sr/subjects/{subject}",
+	"/api/proxy-sr/subjects/{subject}/versions",
+	"/api/proxy-sr/subjects/{subject}/versions/{version}",
+	"/api/proxy-sr/schemas/ids/{id}",
+	"/api/proxy-sr/config/{subject}",
+	"/api/alerts/settings/{id}",
+	"/api/alerts/settings/{id}/condition",
+	"/api/alerts/settings/{id}/condition/{condition}",
+	"/api/v1/alert/channels/{id}",
+	"/api/v1/alert/settings/{id}",
+	"/api/v1/alert/settings/{id}/conditions/{condition}",
+	"/api/user/profile/{property}/{value}",
+	"/api/protection/policy/{id}",
+	"/api/v1/group/{name}",
+	"/api/v1/group/{name}/clone/{newName}",
+	"/api/v1/serviceaccount/{name}",
+	"/api/v1/serviceaccount/{name}/revoke",
+	"/api/v1/user/{name}",
+	"/api/v1/user/{name}/password",
+	"/api/v1/connection/connections/{name}",
+	"/api/v1/connection/connections/{name}/status",
+	"/api/consumers/{group}/offsets",
+	"/api/consumers/{group}/offsets/topics/{topic}/partitions/{partition}",
+	"/api/elastic/indexes/{connection}/{index}",
+	"/api/sse/k8/logs/{cluster}/{namespace}/{name}",
+}
+
+// staticRoutes are the endpoints of the client without parameters, their segments are fixed segments of the unknown endpoints too.
+var staticRoutes = []string{
+	"/api/login",
+	"/api/logout",
+	"/api/auth",
+	"/api/version",
+	"/api/license",
+	"/api/config",
+	"/api/user/profile",
+	"/api/sql/validation",
+	"/api/sql/queries",
+	"/api/v1/sql/presentation",
+	"/api/ws/v2/sql/execute",
+	"/api/topics",
+	"/api/v1/kafka/topics",
+	"/api/v1/kafka/brokers",
+	"/api/configs/default/topics/keys",
+	"/api/configs/brokers",
+	"/api/metadata/topics",
+	"/api/topology",
+	"/api/streams",
+	"/api/proxy-sr/subjects",
+	"/api/proxy-sr/config",
+	"/api/acl",
+	"/api/quotas",
+	"/api/alerts",
+	"/api/alerts/settings",
+	"/api/v1/alert/channels",
+	"/api/v1/alert/settings",
+	"/api/audit",
+	"/api/v1/audit",
+	"/api/sse/alerts",
+	"/api/sse/audit",
+	"/api/logs/INFO",
+	"/api/logs/METRICS",
+	"/api/static/supported-connectors",
+	"/api/protection/policy",
+	"/api/protection/static/category",
+	"/api/protection/static/impacts",
+	"/api/protection/static/obfuscation",
+	"/api/v1/group",
+	"/api/v1/serviceaccount",
+	"/api/v1/user",
+	"/api/v1/connection/connections",
+	"/api/v1/connection/connection-templates",
+	"/api/consumers",
+	"/api/elastic/indexes",
+}
+
+// routeParam is the segment of the endpoints which match none of the `routes`, for the values of their paths.
+const routeParam = "{param}"
+
+var (
+	routeSegments [][]string
+	// routeLiterals are the fixed segments of the `routes`, the rest of the segments of an unknown endpoint are values.
+	routeLiterals = make(map[string]bool)
+)
+
+func init() {
+	routes = append(routes, staticRoutes...)
+	for _, r := range routes {
+		segments := strings.Split(strings.TrimPrefix(r, "/"), "/")
+		routeSegments = append(routeSegments, segments)
+		for _, segment := range segments {
+			if !isRouteParam(segment) {
+				routeLiterals[segment] = true
+			}
+		}
+	}
+}
+
+func isRouteParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// route returns the template of the "endpoint", see `endpoint`, i.e "/api/topics/{name}" for "/api/topics/payments".
+// The most specific of the `routes` wins, the fixed segments over the values. The segments of an unknown endpoint
+// which are not fixed segments of any of the `routes` are replaced by the `routeParam`, so the values are never reported as they are.
+func route(endpoint string) string {
+	segments := strings.Split(strings.TrimPrefix(endpoint, "/"), "/")
+
+	best, bestLiterals := -1, -1
+	for i, template := range routeSegments {
+		if len(template) != len(segments) {
+			continue
+		}
+
+		literals := 0
+		for j, segment := range template {
+			if isRouteParam(segment) {
+				continue
+			}
+			if segment != segments[j] {
+				literals = -1
+				break
+			}
+			literals++
+		}
+
+		if literals > bestLiterals {
+			best, bestLiterals = i, literals
+		}
+	}
+
+	if best >= 0 {
+		return routes[best]
+	}
+
+	normalized := make([]string, len(segments))
+	for i, segment := range segments {
+		if segment == "" || routeLiterals[segment] {
+			normalized[i] = segment
+		} else {
+			normalized[i] = routeParam
+		}
+	}
+
+	return "/" + strings.Join(normalized, "/")
+}
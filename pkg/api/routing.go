@@ -0,0 +1,139 @@
+package api
+
+import "fmt"
+
+// pagedListsMajor is the first major version of the Lenses servers which expose the paged list endpoints,
+// i.e the `topicsPagedPath` and the `auditPagedPath`.
+const pagedListsMajor = 4
+
+// WithVersionNegotiation makes the client fetch the version of the server once, on the first call whose endpoint
+// differs between the Lenses versions, so these calls are routed to the endpoints of the connected server
+// and the rest of the calls cost no extra request,
+// i.e the `GetTopicsPage` falls back to the full list of topics on servers without the paged endpoint.
+// A failed negotiation does not fail the connection, the calls are routed to the endpoints of the latest version.
+func WithVersionNegotiation() ConnectionOption {
+	return func(c *Client) {
+		c.negotiateVersion = true
+	}
+}
+
+// WithServerVersion routes the calls to the endpoints of the given server "version", i.e "3.2.1",
+// without asking the server for it, see `WithVersionNegotiation`.
+func WithServerVersion(version string) ConnectionOption {
+	return func(c *Client) {
+		c.setServerVersion(ServerVersion{Version: version})
+	}
+}
+
+// UnsupportedFeatureError is returned by the calls which are not supported by the version of the connected server,
+// see `WithVersionNegotiation`.
+type UnsupportedFeatureError struct {
+	// Feature describes the unsupported call, i.e "paged audit entries".
+	Feature string
+	// MinMajor is the first major version of the servers which support the feature.
+	MinMajor int
+	// ServerVersion is the version of the connected server.
+	ServerVersion string
+}
+
+// Error implements the error.
+func (err UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s require Lenses %d or newer, the connected server is [%s]", err.Feature, err.MinMajor, err.ServerVersion)
+}
+
+// NegotiatedVersion returns the cached version of the connected server
+// and false if it's not known, i.e not negotiated yet, see `WithVersionNegotiation` and `WithServerVersion`.
+func (c *Client) NegotiatedVersion() (ServerVersion, bool) {
+	c.serverVersionMu.RLock()
+	defer c.serverVersionMu.RUnlock()
+
+	if c.serverVersion == nil {
+		return ServerVersion{}, false
+	}
+
+	return *c.serverVersion, true
+}
+
+func (c *Client) setServerVersion(version ServerVersion) {
+	c.serverVersionMu.Lock()
+	c.serverVersion = &version
+	c.serverVersionMu.Unlock()
+}
+
+// negotiateServerVersion fetches and caches the version of the server, once, even if it fails,
+// if the `WithVersionNegotiation` is set and the version is not pinned by the `WithServerVersion`.
+func (c *Client) negotiateServerVersion() {
+	if !c.negotiateVersion {
+		return
+	}
+
+	c.negotiateMu.Lock()
+	defer c.negotiateMu.Unlock()
+
+	if c.negotiated {
+		return
+	}
+	c.negotiated = true
+
+	if _, ok := c.NegotiatedVersion(); ok {
+		return
+	}
+
+	version, err := c.GetServerVersion()
+	if err != nil {
+		c.Logger().Debugf("Unable to negotiate the server version, the endpoints of the latest version are used: %v", err)
+		return
+	}
+
+	c.Logger().Debugf("Connected to Lenses [%s]", version.Version)
+	c.setServerVersion(version)
+}
+
+// serverMajorBefore reports whether the major version of the connected server is known and older than the "major".
+// Servers of unknown versions, i.e development builds, are treated as the latest ones.
+func (c *Client) serverMajorBefore(major int) (ServerVersion, bool) {
+	c.negotiateServerVersion()
+
+	version, ok := c.NegotiatedVersion()
+	if !ok {
+		return version, false
+	}
+
+	serverMajor, ok := version.Major()
+	return version, ok && serverMajor < major
+}
+
+// requireMajor returns an `UnsupportedFeatureError` if the connected server is known to be older than the "major".
+func (c *Client) requireMajor(feature string, major int) error {
+	if version, older := c.serverMajorBefore(major); older {
+		return UnsupportedFeatureError{Feature: feature, MinMajor: major, ServerVersion: version.Version}
+	}
+
+	return nil
+}
+
+// pageTopics returns the page of the "topics", filtered by name, of the servers without the paged topics endpoint.
+func pageTopics(topics []Topic, opts QueryFiltering) TopicsPage {
+	opts = opts.withDefaults()
+
+	if opts.Name != "" {
+		filtered := topics[:0]
+		for _, topic := range topics {
+			if MatchesFilter(topic.TopicName, opts.Name) {
+				filtered = append(filtered, topic)
+			}
+		}
+		topics = filtered
+	}
+
+	var start, end int
+	page := TopicsPage{}
+	page.Page, start, end = opts.Bounds(len(topics))
+	if start == end {
+		page.Values = []Topic{}
+		return page
+	}
+
+	page.Values = topics[start:end]
+	return page
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newVersionedServer returns a fake server which advertises the "version" and records the requested paths.
+func newVersionedServer(t *testing.T, version string, paths *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*paths = append(*paths, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/" + serverVersionPath:
+			json.NewEncoder(w).Encode(ServerVersion{Version: version})
+		case "/" + topicsPath:
+			json.NewEncoder(w).Encode([]Topic{{TopicName: "payments"}, {TopicName: "orders"}, {TopicName: "Payments-DLQ"}})
+		case "/" + topicsPagedPath:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"pagesAmount": 1,
+				"totalCount":  1,
+				"values":      []Topic{{TopicName: "payments"}},
+			})
+		case "/" + auditPagedPath:
+			json.NewEncoder(w).Encode(map[string]interface{}{"pagesAmount": 1, "totalCount": 0, "values": []AuditEntry{}})
+		default:
+			assert.Fail(t, "unexpected path", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestVersionNegotiationRouting(t *testing.T) {
+	tests := []struct {
+		version       string
+		expectedPaths []string
+		expectedPage  TopicsPage
+		auditErr      error
+	}{
+		{
+			version:       "4.0.2",
+			expectedPaths: []string{"/" + serverVersionPath, "/" + topicsPagedPath, "/" + auditPagedPath},
+			expectedPage: TopicsPage{
+				Page:   Page{Number: 1, PagesAmount: 1, TotalCount: 1},
+				Values: []Topic{{TopicName: "payments"}},
+			},
+		},
+		{
+			version:       "3.2.1",
+			expectedPaths: []string{"/" + serverVersionPath, "/" + topicsPath},
+			expectedPage: TopicsPage{
+				Page: Page{Number: 2, PagesAmount: 2, TotalCount: 2},
+				// filtered on the client side, case insensitive like the server.
+				Values: []Topic{{TopicName: "Payments-DLQ"}},
+			},
+			auditErr: UnsupportedFeatureError{Feature: "paged audit entries", MinMajor: 4, ServerVersion: "3.2.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			var paths []string
+			server := newVersionedServer(t, tt.version, &paths)
+			defer server.Close()
+
+			logger := new(capturingLogger)
+			client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithVersionNegotiation(), WithLogger(logger))
+			assert.Nil(t, err)
+
+			// negotiated on the first versioned call.
+			_, ok := client.NegotiatedVersion()
+			assert.False(t, ok)
+			assert.Empty(t, paths)
+
+			opts := QueryFiltering{Name: "payments"}
+			if tt.expectedPage.Number == 2 {
+				opts.Page, opts.PageSize = 2, 1
+			}
+
+			page, err := client.GetTopicsPage(opts)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expectedPage, page)
+
+			version, ok := client.NegotiatedVersion()
+			assert.True(t, ok)
+			assert.Equal(t, tt.version, version.Version)
+
+			_, err = client.GetAuditEntriesPage(AuditOptions{})
+			assert.Equal(t, tt.auditErr, err)
+
+			// the version is fetched once.
+			assert.Equal(t, tt.expectedPaths, paths)
+
+			clientSide := logger.contains("debug: Client#GetTopicsPage: the server has no paged topics endpoint")
+			assert.Equal(t, tt.auditErr != nil, clientSide, "the client side filtering should be logged on the older servers only")
+		})
+	}
+}
+
+func TestWithServerVersion(t *testing.T) {
+	var paths []string
+	server := newVersionedServer(t, "4.0.0", &paths)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithServerVersion("3.0.0"), WithVersionNegotiation())
+	assert.Nil(t, err)
+
+	_, err = client.GetAuditEntriesPage(AuditOptions{})
+	assert.EqualError(t, err, "paged audit entries require Lenses 4 or newer, the connected server is [3.0.0]")
+	assert.Empty(t, paths)
+}
+
+func TestVersionNegotiationFailure(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/"+topicsPagedPath {
+			json.NewEncoder(w).Encode(map[string]interface{}{"pagesAmount": 1, "totalCount": 0, "values": []Topic{}})
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithVersionNegotiation())
+	assert.Nil(t, err)
+
+	// unknown versions are routed to the latest endpoints.
+	_, err = client.GetTopicsPage(QueryFiltering{})
+	assert.Nil(t, err)
+	assert.Equal(t, "/"+topicsPagedPath, paths[len(paths)-1])
+
+	_, ok := client.NegotiatedVersion()
+	assert.False(t, ok)
+
+	// the failed negotiation is not repeated.
+	paths = nil
+	_, err = client.GetTopicsPage(QueryFiltering{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"/" + topicsPagedPath}, paths)
+}
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 )
 
 const serviceAccountPath = "api/v1/serviceaccount"
@@ -15,6 +17,33 @@ type ServiceAccount struct {
 	Groups []string `json:"groups" yaml:"groups" header:"Groups"`
 }
 
+//Equal reports whether the service account has the same owner and groups as the "other",
+// the order of the groups does not matter. It's used to skip the needless updates, i.e on import.
+func (s ServiceAccount) Equal(other ServiceAccount) bool {
+	return s.Name == other.Name && s.Owner == other.Owner && sameStringSet(s.Groups, other.Groups)
+}
+
+// sameStringSet reports whether "a" and "b" contain the same strings, in any order.
+func sameStringSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+
+	return true
+}
+
 //CreateSvcAccPayload the data transfer object when we create a new service account
 type CreateSvcAccPayload struct {
 	Token string `json:"token,omitempty"`
@@ -22,7 +51,12 @@ type CreateSvcAccPayload struct {
 
 //GetServiceAccounts returns the list of service accounts
 func (c *Client) GetServiceAccounts() (serviceAccounts []ServiceAccount, err error) {
-	resp, err := c.Do(http.MethodGet, serviceAccountPath, contentTypeJSON, nil)
+	return c.GetServiceAccountsContext(context.Background())
+}
+
+//GetServiceAccountsContext is like `GetServiceAccounts` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetServiceAccountsContext(ctx context.Context) (serviceAccounts []ServiceAccount, err error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, serviceAccountPath, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -30,25 +64,42 @@ func (c *Client) GetServiceAccounts() (serviceAccounts []ServiceAccount, err err
 	return
 }
 
-//GetServiceAccount returns the service account by the provided name
+//GetServiceAccount returns the service account by the provided name,
+//a `NotFoundError` if it does not exist.
 func (c *Client) GetServiceAccount(name string) (serviceAccount ServiceAccount, err error) {
+	return c.GetServiceAccountContext(context.Background(), name)
+}
+
+//GetServiceAccountContext is like `GetServiceAccount` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetServiceAccountContext(ctx context.Context, name string) (serviceAccount ServiceAccount, err error) {
 	if name == "" {
 		err = errRequired("name")
 		return
 	}
 
 	path := fmt.Sprintf("%s/%s", serviceAccountPath, name)
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
+		if resErr, ok := err.(ResourceError); ok && resErr.StatusCode == http.StatusNotFound {
+			err = NotFoundError{Kind: "service account", Name: name}
+		}
 		return
 	}
 
-	err = c.ReadJSON(resp, &serviceAccount)
+	if err = c.ReadJSON(resp, &serviceAccount); err == nil && serviceAccount.Name == "" {
+		// some versions answer with a null body instead of a 404.
+		err = NotFoundError{Kind: "service account", Name: name}
+	}
 	return
 }
 
 //CreateServiceAccount creates a service account
 func (c *Client) CreateServiceAccount(serviceAccount *ServiceAccount) (token CreateSvcAccPayload, err error) {
+	return c.CreateServiceAccountContext(context.Background(), serviceAccount)
+}
+
+// CreateServiceAccountContext is like `CreateServiceAccount` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateServiceAccountContext(ctx context.Context, serviceAccount *ServiceAccount) (token CreateSvcAccPayload, err error) {
 	if serviceAccount.Name == "" {
 		err = errRequired("name")
 		return
@@ -63,7 +114,7 @@ func (c *Client) CreateServiceAccount(serviceAccount *ServiceAccount) (token Cre
 		return
 	}
 
-	resp, err := c.Do(http.MethodPost, serviceAccountPath, contentTypeJSON, payload)
+	resp, err := c.DoContext(ctx, http.MethodPost, serviceAccountPath, contentTypeJSON, payload)
 	if err != nil {
 		return
 	}
@@ -71,14 +122,50 @@ func (c *Client) CreateServiceAccount(serviceAccount *ServiceAccount) (token Cre
 	return
 }
 
+//CreateServiceAccounts creates the service accounts concurrently, see `WithBulkConcurrency`,
+// and returns the tokens of the created ones by their name.
+// If some of them failed, the rest are still created and the error is a `BulkError`.
+func (c *Client) CreateServiceAccounts(serviceAccounts []ServiceAccount) (map[string]CreateSvcAccPayload, error) {
+	return c.CreateServiceAccountsContext(context.Background(), serviceAccounts)
+}
+
+// CreateServiceAccountsContext is like `CreateServiceAccounts` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateServiceAccountsContext(ctx context.Context, serviceAccounts []ServiceAccount) (map[string]CreateSvcAccPayload, error) {
+	names := make([]string, len(serviceAccounts))
+	for i, serviceAccount := range serviceAccounts {
+		names[i] = serviceAccount.Name
+	}
+
+	var mu sync.Mutex
+	tokens := make(map[string]CreateSvcAccPayload, len(serviceAccounts))
+	err := c.bulk("service account", names, func(i int) error {
+		token, err := c.CreateServiceAccountContext(ctx, &serviceAccounts[i])
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		tokens[serviceAccounts[i].Name] = token
+		mu.Unlock()
+		return nil
+	})
+
+	return tokens, err
+}
+
 //DeleteServiceAccount deletes a service account
 func (c *Client) DeleteServiceAccount(name string) error {
+	return c.DeleteServiceAccountContext(context.Background(), name)
+}
+
+// DeleteServiceAccountContext is like `DeleteServiceAccount` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteServiceAccountContext(ctx context.Context, name string) error {
 	if name == "" {
 		return errRequired("name")
 	}
 
 	path := fmt.Sprintf("%s/%s", serviceAccountPath, name)
-	_, err := c.Do(http.MethodDelete, path, contentTypeJSON, nil)
+	_, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, nil)
 	if err != nil {
 		return err
 	}
@@ -87,6 +174,11 @@ func (c *Client) DeleteServiceAccount(name string) error {
 
 //UpdateServiceAccount updates a service account
 func (c *Client) UpdateServiceAccount(serviceAccount *ServiceAccount) error {
+	return c.UpdateServiceAccountContext(context.Background(), serviceAccount)
+}
+
+// UpdateServiceAccountContext is like `UpdateServiceAccount` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateServiceAccountContext(ctx context.Context, serviceAccount *ServiceAccount) error {
 	if serviceAccount.Name == "" {
 		return errRequired("name")
 	}
@@ -100,15 +192,91 @@ func (c *Client) UpdateServiceAccount(serviceAccount *ServiceAccount) error {
 	}
 
 	path := fmt.Sprintf("%s/%s", serviceAccountPath, serviceAccount.Name)
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+//AddServiceAccountGroups adds the service account to the "groups", the ones it's already a member of are skipped,
+// see `EditServiceAccountGroups`.
+func (c *Client) AddServiceAccountGroups(name string, groups ...string) (ServiceAccount, error) {
+	return c.AddServiceAccountGroupsContext(context.Background(), name, groups...)
+}
+
+// AddServiceAccountGroupsContext is like `AddServiceAccountGroups` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) AddServiceAccountGroupsContext(ctx context.Context, name string, groups ...string) (ServiceAccount, error) {
+	return c.EditServiceAccountGroupsContext(ctx, name, groups, nil)
+}
+
+//RemoveServiceAccountGroups removes the service account from the "groups", see `EditServiceAccountGroups`.
+func (c *Client) RemoveServiceAccountGroups(name string, groups ...string) (ServiceAccount, error) {
+	return c.RemoveServiceAccountGroupsContext(context.Background(), name, groups...)
+}
+
+// RemoveServiceAccountGroupsContext is like `RemoveServiceAccountGroups` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) RemoveServiceAccountGroupsContext(ctx context.Context, name string, groups ...string) (ServiceAccount, error) {
+	return c.EditServiceAccountGroupsContext(ctx, name, nil, groups)
+}
+
+//EditServiceAccountGroups adds the service account to the "add" groups and removes it from the "remove" ones.
+// Unlike the `UpdateServiceAccount`, the current groups are fetched first and the rest of them are kept.
+// The API has no conditional update, so a concurrent edit between the fetch and the update is still overridden,
+// the edits of the same service account should not run concurrently. The groups are deduplicated
+// and the service account is not updated if they are not changed. It returns the updated service account.
+func (c *Client) EditServiceAccountGroups(name string, add, remove []string) (ServiceAccount, error) {
+	return c.EditServiceAccountGroupsContext(context.Background(), name, add, remove)
+}
+
+// EditServiceAccountGroupsContext is like `EditServiceAccountGroups` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) EditServiceAccountGroupsContext(ctx context.Context, name string, add, remove []string) (ServiceAccount, error) {
+	serviceAccount, err := c.GetServiceAccountContext(ctx, name)
+	if err != nil {
+		return serviceAccount, err
+	}
+
+	groups := editGroups(serviceAccount.Groups, add, remove)
+	if len(groups) == 0 {
+		return serviceAccount, fmt.Errorf("service account [%s] should be a member of at least one group", name)
+	}
+
+	if sameStringSet(groups, serviceAccount.Groups) {
+		return serviceAccount, nil
+	}
+
+	serviceAccount.Groups = groups
+	return serviceAccount, c.UpdateServiceAccountContext(ctx, &serviceAccount)
+}
+
+// editGroups returns the "groups" plus the "add" minus the "remove" ones, without duplicates and in their order.
+func editGroups(groups, add, remove []string) []string {
+	removed := make(map[string]bool, len(remove))
+	for _, group := range remove {
+		removed[group] = true
+	}
+
+	seen := make(map[string]bool, len(groups)+len(add))
+	edited := make([]string, 0, len(groups)+len(add))
+	for _, group := range append(append([]string(nil), groups...), add...) {
+		if group == "" || seen[group] || removed[group] {
+			continue
+		}
+
+		seen[group] = true
+		edited = append(edited, group)
+	}
+
+	return edited
+}
+
 //RevokeServiceAccountToken returns the service account token for the provided name
 func (c *Client) RevokeServiceAccountToken(name string, newToken string) (token CreateSvcAccPayload, err error) {
+	return c.RevokeServiceAccountTokenContext(context.Background(), name, newToken)
+}
+
+// RevokeServiceAccountTokenContext is like `RevokeServiceAccountToken` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) RevokeServiceAccountTokenContext(ctx context.Context, name string, newToken string) (token CreateSvcAccPayload, err error) {
 	if name == "" {
 		err = errRequired("name")
 		return
@@ -119,7 +287,7 @@ func (c *Client) RevokeServiceAccountToken(name string, newToken string) (token
 	}
 
 	path := fmt.Sprintf("%s/%s/revoke", serviceAccountPath, name)
-	resp, err := c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	resp, err := c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServiceAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount/ingestion":
+			w.Write([]byte(`{"name": "ingestion", "owner": "admin", "groups": ["dev"]}`))
+		case "/api/v1/serviceaccount/removed":
+			w.Write([]byte("null"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Service account not found"))
+		}
+	}))
+	defer server.Close()
+
+	c, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	svcacc, err := c.GetServiceAccount("ingestion")
+	assert.Nil(t, err)
+	assert.Equal(t, ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"dev"}}, svcacc)
+
+	_, err = c.GetServiceAccount("missing")
+	assert.Equal(t, NotFoundError{Kind: "service account", Name: "missing"}, err)
+	assert.EqualError(t, err, "service account [missing] not found")
+
+	_, err = c.GetServiceAccount("removed")
+	assert.Equal(t, NotFoundError{Kind: "service account", Name: "removed"}, err)
+}
+
+func TestEditServiceAccountGroups(t *testing.T) {
+	current := ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"dev", "ops"}}
+	var updates int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/serviceaccount/ingestion", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(current)
+		case http.MethodPut:
+			updates++
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&current))
+		}
+	}))
+	defer server.Close()
+
+	c, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	// the current groups are kept and the duplicates are skipped.
+	svcacc, err := c.AddServiceAccountGroups("ingestion", "audit", "dev", "audit")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dev", "ops", "audit"}, svcacc.Groups)
+	assert.Equal(t, ServiceAccount{Name: "ingestion", Owner: "admin", Groups: []string{"dev", "ops", "audit"}}, current)
+
+	svcacc, err = c.RemoveServiceAccountGroups("ingestion", "ops", "missing")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dev", "audit"}, svcacc.Groups)
+	assert.Equal(t, []string{"dev", "audit"}, current.Groups)
+	assert.Equal(t, 2, updates)
+
+	// unchanged, not updated.
+	_, err = c.AddServiceAccountGroups("ingestion", "dev")
+	assert.Nil(t, err)
+	_, err = c.RemoveServiceAccountGroups("ingestion", "ops")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, updates)
+
+	// a group is both added and removed, it's removed.
+	svcacc, err = c.EditServiceAccountGroups("ingestion", []string{"ops"}, []string{"ops", "audit"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dev"}, svcacc.Groups)
+
+	_, err = c.RemoveServiceAccountGroups("ingestion", "dev")
+	assert.EqualError(t, err, "service account [ingestion] should be a member of at least one group")
+	assert.Equal(t, []string{"dev"}, current.Groups)
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	wwwAuthenticateHeaderKey = "WWW-Authenticate"
+	negotiateScheme          = "Negotiate"
+)
+
+// isNegotiateChallenge reports whether the "resp" is a SPNEGO challenge, a 401 status code with a
+// "WWW-Authenticate: Negotiate" header, i.e of a SPNEGO protected reverse proxy in front of Lenses.
+// The challenged request should be sent again with the "Authorization: Negotiate <token>" header of the kerberos ticket,
+// see `KerberosAuthentication`.
+func isNegotiateChallenge(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized {
+		return false
+	}
+
+	for _, challenge := range resp.Header[http.CanonicalHeaderKey(wwwAuthenticateHeaderKey)] {
+		scheme := strings.Fields(challenge)
+		if len(scheme) > 0 && strings.EqualFold(scheme[0], negotiateScheme) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,120 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newNegotiateProxy challenges the requests without a SPNEGO token and rejects the invalid ones, like a SPNEGO protected proxy,
+// the Lenses token is checked too.
+func newNegotiateProxy(t *testing.T, validToken string, challenges *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get(xKafkaLensesTokenHeaderKey))
+
+		authorization := r.Header.Get("Authorization")
+		if !strings.HasPrefix(authorization, negotiateScheme+" ") {
+			atomic.AddInt32(challenges, 1)
+			w.Header().Set(wwwAuthenticateHeaderKey, negotiateScheme)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if strings.TrimPrefix(authorization, negotiateScheme+" ") != validToken {
+			w.Header().Set(wwwAuthenticateHeaderKey, negotiateScheme)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte("ok"))
+	}))
+}
+
+func newNegotiateClient(t *testing.T, host string, auth Authentication, acquired *int32) *Client {
+	c, err := OpenConnection(ClientConfig{Host: host, Token: "secret", Authentication: auth})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.kerberos != nil {
+		c.kerberos.acquire = func() (kerberosTicket, error) {
+			atomic.AddInt32(acquired, 1)
+			return fakeKerberosTicket(1), nil
+		}
+	}
+
+	return c
+}
+
+func TestSPNEGONegotiation(t *testing.T) {
+	var challenges, acquired int32
+	server := newNegotiateProxy(t, "HTTP/127.0.0.1-1", &challenges)
+	defer server.Close()
+
+	auth := KerberosAuthentication{Method: KerberosWithKeytab{Username: "svc-lenses@EXAMPLE.COM", KeytabFile: "/tmp/svc.keytab"}}
+	c := newNegotiateClient(t, server.URL, auth, &acquired)
+
+	for i := 0; i < 3; i++ {
+		resp, err := c.Do(http.MethodGet, "api/topics", "", nil)
+		if assert.Nil(t, err) {
+			b, _ := c.ReadResponseBody(resp)
+			assert.Equal(t, "ok", string(b))
+		}
+	}
+
+	// challenged once, the next requests send the ticket upfront.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&challenges))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&acquired))
+}
+
+func TestSPNEGONegotiationRejected(t *testing.T) {
+	var challenges, acquired int32
+	server := newNegotiateProxy(t, "HTTP/127.0.0.1-2", &challenges)
+	defer server.Close()
+
+	auth := KerberosAuthentication{Method: KerberosWithKeytab{Username: "svc-lenses@EXAMPLE.COM", KeytabFile: "/tmp/svc.keytab"}}
+	c := newNegotiateClient(t, server.URL, auth, &acquired)
+
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Equal(t, ErrCredentialsMissing, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&acquired))
+}
+
+func TestSPNEGONegotiationWithoutKerberos(t *testing.T) {
+	var challenges, acquired int32
+	server := newNegotiateProxy(t, "HTTP/127.0.0.1-1", &challenges)
+	defer server.Close()
+
+	c := newNegotiateClient(t, server.URL, nil, &acquired)
+
+	_, err := c.Do(http.MethodGet, "api/topics", "", nil)
+	assert.Equal(t, ErrCredentialsMissing, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&acquired))
+}
+
+func TestIsNegotiateChallenge(t *testing.T) {
+	tests := []struct {
+		status    int
+		challenge []string
+		expected  bool
+	}{
+		{status: http.StatusUnauthorized, challenge: []string{"Negotiate"}, expected: true},
+		{status: http.StatusUnauthorized, challenge: []string{"Basic realm=\"lenses\"", "negotiate"}, expected: true},
+		{status: http.StatusUnauthorized, challenge: []string{"Basic realm=\"lenses\""}},
+		{status: http.StatusUnauthorized},
+		{status: http.StatusForbidden, challenge: []string{"Negotiate"}},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Header: make(http.Header)}
+		for _, challenge := range tt.challenge {
+			resp.Header.Add(wwwAuthenticateHeaderKey, challenge)
+		}
+
+		assert.Equal(t, tt.expected, isNegotiateChallenge(resp), "%d %v", tt.status, tt.challenge)
+	}
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTokenRefreshSkew is the time before the token's expiry that the client renews it,
+// when the `ClientConfig#Authentication` is available, see `WithTokenRefreshSkew`.
+const DefaultTokenRefreshSkew = 30 * time.Second
+
+// reloginInterval is the minimum time between the login of the client and a new login for a rejected opaque token,
+// so the permission errors of a valid token do not make the client login on every request, see `relogin`.
+const reloginInterval = 30 * time.Second
+
+// ErrTokenExpired fires when the `ClientConfig#Token` is expired and there is no `Authentication` to renew it.
+var ErrTokenExpired = fmt.Errorf("token expired, please re-login")
+
+// WithTokenRefreshSkew sets the time before the token's expiry that the client renews it.
+// Defaults to `DefaultTokenRefreshSkew`.
+func WithTokenRefreshSkew(skew time.Duration) ConnectionOption {
+	return func(c *Client) {
+		c.tokenRefreshSkew = skew
+	}
+}
+
+// OnTokenRefresh registers a listener which is fired after the client renewed its token,
+// the listener receives the client's configuration with the new `Token` and `TokenExpiry`,
+// it can be used to persist them, i.e to the configuration file.
+func OnTokenRefresh(listener func(cfg ClientConfig)) ConnectionOption {
+	return func(c *Client) {
+		c.onTokenRefresh = listener
+	}
+}
+
+// ParseTokenExpiry returns the expiry of the `token`, it's read from its "exp" claim if it's a JWT.
+// It returns nil if the expiry is unknown.
+func ParseTokenExpiry(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Exp <= 0 {
+		return nil
+	}
+
+	expiry := time.Unix(claims.Exp, 0)
+	return &expiry
+}
+
+// authenticate generates a new token based on the `ClientConfig#Authentication` and keeps its expiry.
+// The login runs on a copy of the client, without a token, so the concurrent requests
+// keep sending the current token until it's replaced, see `loginClient`.
+func (c *Client) authenticate() error {
+	login := c.loginClient()
+	if err := c.Config.Authentication.Auth(login); err != nil {
+		return err
+	}
+
+	if login.User.Token == "" { // this should never happen.
+		return fmt.Errorf("login failure: token is undefined")
+	}
+
+	c.tokenMu.Lock()
+	c.User = login.User
+	c.Config.Token = login.Config.Token
+	// the login response contains just the token, so the expiry is known only for JWT tokens.
+	c.Config.TokenExpiry = ParseTokenExpiry(c.Config.Token)
+	c.loggedIn = time.Now()
+	c.tokenMu.Unlock()
+
+	if resp := login.LastResponse(); resp != nil {
+		c.setLastResponse(resp)
+	}
+
+	return nil
+}
+
+// loginClient returns a copy of the client without the token, its requests are not checked by the `refreshToken`.
+// It shares the settings of the client, only its token, its user and its state are not copied.
+func (c *Client) loginClient() *Client {
+	c.tokenMu.RLock()
+	cfg := *c.Config
+	c.tokenMu.RUnlock()
+	cfg.Token, cfg.TokenExpiry = "", nil
+
+	return &Client{
+		Config:                     &cfg,
+		configFull:                 c.configFull,
+		PersistentRequestModifier:  c.PersistentRequestModifier,
+		clientOptions:              c.clientOptions,
+		requestCompressionRejected: atomic.LoadInt32(&c.requestCompressionRejected),
+	}
+}
+
+// token returns the current `ClientConfig#Token`, it's safe for concurrent use, see `authenticate`.
+func (c *Client) token() string {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.Config.Token
+}
+
+// tokenExpiry returns the current `ClientConfig#Token` and its expiry, if known.
+func (c *Client) tokenExpiry() (string, *time.Time) {
+	c.tokenMu.RLock()
+	defer c.tokenMu.RUnlock()
+	return c.Config.Token, c.Config.TokenExpiry
+}
+
+// needsRefresh reports whether the token is about to expire, based on the `ClientConfig#TokenExpiry` and the refresh skew.
+func (c *Client) needsRefresh() (time.Time, bool) {
+	token, expiry := c.tokenExpiry()
+	// the `loginClient` has no token, so its requests are not checked here.
+	if token == "" || expiry == nil {
+		return time.Time{}, false
+	}
+
+	return *expiry, time.Until(*expiry) <= c.tokenRefreshSkew
+}
+
+// refreshToken renews the token if it's about to expire, otherwise it does nothing.
+// The concurrent requests share a single renewal, the ones which wait for it don't login again.
+func (c *Client) refreshToken() error {
+	expiry, ok := c.needsRefresh()
+	if !ok {
+		return nil
+	}
+
+	if c.Config.Authentication == nil {
+		if time.Now().Before(expiry) {
+			// it can't be renewed but it's still valid.
+			return nil
+		}
+
+		return ErrTokenExpired
+	}
+
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	if expiry, ok = c.needsRefresh(); !ok {
+		// renewed by another request meanwhile.
+		return nil
+	}
+
+	c.Logger().Debugf("Client#refreshToken: token expires at [%s], renewing", expiry)
+	return c.renewToken()
+}
+
+// shouldRelogin reports whether the "resp" may be the rejection of an expired opaque token, a token without a known expiry
+// which can be renewed by the `ClientConfig#Authentication`, see `relogin`. The tokens with a known expiry are renewed
+// before they expire by the `refreshToken` instead.
+func (c *Client) shouldRelogin(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	// the `loginClient` has no token, so its requests never login again.
+	token, expiry := c.tokenExpiry()
+	return token != "" && expiry == nil && c.Config.Authentication != nil
+}
+
+// relogin renews the opaque "token" that a request was rejected with, see `shouldRelogin`.
+// The concurrent rejected requests share a single login, the ones which were sent with a token which is already replaced
+// don't login again, and a token issued by a login less than 30 seconds ago is not renewed, the rejection is a permission error.
+// It reports whether the token is renewed, so the request can be sent once more.
+func (c *Client) relogin(token string) (bool, error) {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+
+	c.tokenMu.RLock()
+	current, loggedIn := c.Config.Token, c.loggedIn
+	c.tokenMu.RUnlock()
+
+	if current != token {
+		// renewed by another request meanwhile.
+		return true, nil
+	}
+
+	if !loggedIn.IsZero() && time.Since(loggedIn) < reloginInterval {
+		return false, nil
+	}
+
+	c.Logger().Debugf("Client#relogin: token rejected, renewing")
+	if err := c.renewToken(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// renewToken logs in again and fires the `OnTokenRefresh` listener, the caller holds the `refreshMu`.
+func (c *Client) renewToken() error {
+	if err := c.authenticate(); err != nil {
+		return fmt.Errorf("client: token refresh failure: [%w]", err)
+	}
+
+	if c.onTokenRefresh != nil {
+		c.tokenMu.RLock()
+		cfg := *c.Config
+		c.tokenMu.RUnlock()
+		c.onTokenRefresh(cfg)
+	}
+
+	return nil
+}
@@ -0,0 +1,239 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestToken(exp time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"sub":"user","exp":%d}`, exp.Unix())))
+	return "eyJhbGciOiJIUzI1NiJ9." + payload + ".signature"
+}
+
+func newTokenServer(t *testing.T, logins *int, token string) *httptest.Server {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			*logins++
+			w.Write([]byte(token))
+		case "/api/auth":
+			assert.Equal(t, token, r.Header.Get(xKafkaLensesTokenHeaderKey))
+			fmt.Fprintf(w, `{"token": "%s", "user": "user"}`, token)
+		default:
+			w.Write([]byte("[]"))
+		}
+	})
+
+	return httptest.NewServer(h)
+}
+
+func TestParseTokenExpiry(t *testing.T) {
+	exp := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+	assert.Equal(t, exp, *ParseTokenExpiry(newTestToken(exp)))
+	assert.Nil(t, ParseTokenExpiry("opaque-token"))
+	assert.Nil(t, ParseTokenExpiry("a.b.c"))
+}
+
+func TestOpenConnectionRefreshesTokenWithinSkew(t *testing.T) {
+	var logins int
+	newToken := newTestToken(time.Now().Add(time.Hour))
+	server := newTokenServer(t, &logins, newToken)
+	defer server.Close()
+
+	var refreshed ClientConfig
+	cfg := ClientConfig{
+		Host:           server.URL,
+		Token:          newTestToken(time.Now().Add(10 * time.Second)),
+		Authentication: BasicAuthentication{Username: "user", Password: "pass"},
+	}
+
+	client, err := OpenConnection(cfg, OnTokenRefresh(func(cfg ClientConfig) { refreshed = cfg }))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, newToken, client.Config.Token)
+	assert.Equal(t, newToken, refreshed.Token)
+	assert.Equal(t, ParseTokenExpiry(newToken), refreshed.TokenExpiry)
+
+	// the new token is not close to its expiry, no more logins.
+	_, err = client.GetTopics()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, logins)
+}
+
+func TestRequestRefreshesTokenWithinSkew(t *testing.T) {
+	var logins int
+	newToken := newTestToken(time.Now().Add(time.Hour))
+	server := newTokenServer(t, &logins, newToken)
+	defer server.Close()
+
+	cfg := ClientConfig{
+		Host:           server.URL,
+		Token:          newTestToken(time.Now().Add(30 * time.Minute)),
+		Authentication: BasicAuthentication{Username: "user", Password: "pass"},
+	}
+
+	client, err := OpenConnection(cfg, WithTokenRefreshSkew(time.Minute))
+	assert.Nil(t, err)
+	assert.Equal(t, 0, logins)
+
+	soon := time.Now().Add(30 * time.Second)
+	client.Config.TokenExpiry = &soon
+
+	_, err = client.GetTopics()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, logins)
+	assert.Equal(t, newToken, client.Config.Token)
+}
+
+func TestExpiredTokenWithoutAuthentication(t *testing.T) {
+	var logins int
+	server := newTokenServer(t, &logins, "")
+	defer server.Close()
+
+	_, err := OpenConnection(ClientConfig{Host: server.URL, Token: newTestToken(time.Now().Add(-time.Minute))})
+	assert.True(t, errors.Is(err, ErrTokenExpired))
+	assert.True(t, errors.Is(err, ErrAuth))
+
+	// close to its expiry but still valid.
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: newTestToken(time.Now().Add(10 * time.Second))})
+	assert.Nil(t, err)
+
+	expired := time.Now().Add(-time.Second)
+	client.Config.TokenExpiry = &expired
+	_, err = client.GetTopics()
+	assert.Equal(t, ErrTokenExpired, err)
+	assert.Equal(t, 0, logins)
+}
+
+func TestConcurrentRequestsRefreshTokenOnce(t *testing.T) {
+	var logins int32
+	oldToken := newTestToken(time.Now().Add(time.Hour))
+	newToken := newTestToken(time.Now().Add(2 * time.Hour))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			atomic.AddInt32(&logins, 1)
+			// a slow login, so the requests pile up behind it.
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(newToken))
+		case "/api/auth":
+			fmt.Fprintf(w, `{"token": "%s", "user": "user"}`, newToken)
+		default:
+			if token := r.Header.Get(xKafkaLensesTokenHeaderKey); token != oldToken && token != newToken {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	cfg := ClientConfig{
+		Host:           server.URL,
+		Token:          oldToken,
+		Authentication: BasicAuthentication{Username: "user", Password: "pass"},
+	}
+
+	var refreshes int32
+	client, err := OpenConnection(cfg, OnTokenRefresh(func(ClientConfig) { atomic.AddInt32(&refreshes, 1) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// simulate the expiry, the token is within the refresh skew now.
+	client.tokenMu.Lock()
+	expiry := time.Now().Add(DefaultTokenRefreshSkew / 2)
+	client.Config.TokenExpiry = &expiry
+	client.tokenMu.Unlock()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := client.GetTopics()
+				assert.Nil(t, err)
+				assert.NotEmpty(t, client.GetAccessToken())
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+	assert.Equal(t, newToken, client.GetAccessToken())
+}
+
+func TestConcurrentRejectedRequestsReloginOnce(t *testing.T) {
+	var logins int32
+	var valid atomic.Value
+	valid.Store("opaque-1")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			atomic.AddInt32(&logins, 1)
+			// a slow login, so the rejected requests pile up behind it.
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(valid.Load().(string)))
+		case "/api/auth":
+			fmt.Fprintf(w, `{"token": "%s", "user": "user"}`, r.Header.Get(xKafkaLensesTokenHeaderKey))
+		case "/api/acl":
+			// a permission error of a valid token.
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			if r.Header.Get(xKafkaLensesTokenHeaderKey) != valid.Load().(string) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte("[]"))
+		}
+	}))
+	defer server.Close()
+
+	cfg := ClientConfig{
+		Host:           server.URL,
+		Token:          "opaque-1",
+		Authentication: BasicAuthentication{Username: "user", Password: "pass"},
+	}
+
+	var refreshes int32
+	client, err := OpenConnection(cfg, OnTokenRefresh(func(ClientConfig) { atomic.AddInt32(&refreshes, 1) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the opaque token expires on the server, without notice.
+	valid.Store("opaque-2")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetTopics()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+	assert.Equal(t, "opaque-2", client.GetAccessToken())
+
+	// the token was just issued, the rejection is returned as it is.
+	_, err = client.GetACLs()
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+}
@@ -0,0 +1,98 @@
+package api
+
+import (
+	"context"
+	"net/url"
+	"strings"
+)
+
+// Tracer starts the spans of the client's calls, see `WithTracer`.
+//
+// The client does not depend on any tracing library, an OpenTelemetry tracer can be used through a small adapter:
+//
+//	type otelTracer struct{ tracer trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, api.Span) {
+//	    ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//	    return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttribute(key string, value interface{}) {
+//	    s.SetAttributes(attribute.String(key, fmt.Sprint(value)))
+//	}
+//
+//	func (s otelSpan) RecordError(err error) { s.Span.RecordError(err) }
+//	func (s otelSpan) End()                  { s.Span.End() }
+type Tracer interface {
+	// Start starts a span and returns a context which holds it,
+	// the HTTP request of the call is bound to that context,
+	// so a `RequestInterceptor` or a tracing `http.RoundTripper` can propagate it to the server.
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced call of the client, see `Tracer`.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// The attributes of the client's spans, they follow the OpenTelemetry HTTP semantic conventions.
+const (
+	SpanAttributeMethod      = "http.request.method"
+	SpanAttributeHost        = "server.address"
+	SpanAttributeEndpoint    = "url.path"
+	SpanAttributeStatusCode  = "http.response.status_code"
+	SpanAttributeResendCount = "http.request.resend_count"
+)
+
+// WithTracer makes the client wrap each of its calls in a span of the "tracer",
+// named after the method and the endpoint of the call, i.e "GET /api/topics".
+// The call's context, see the `Context` methods of the client, is the parent of its span.
+//
+// Without a tracer the calls are not traced at all, the default.
+func WithTracer(tracer Tracer) ConnectionOption {
+	return func(c *Client) {
+		c.tracer = tracer
+	}
+}
+
+// endpoint returns the path of a call without its query, it's the same for all the calls of an API.
+func endpoint(path string) string {
+	if idx := strings.IndexByte(path, '?'); idx >= 0 {
+		path = path[:idx]
+	}
+
+	return "/" + path
+}
+
+func (c *Client) startSpan(ctx context.Context, method, path string) (context.Context, Span) {
+	endpoint := endpoint(path)
+	ctx, span := c.tracer.Start(ctx, method+" "+endpoint)
+	span.SetAttribute(SpanAttributeMethod, method)
+	host := c.Config.Host
+	if u, err := url.Parse(host); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+	span.SetAttribute(SpanAttributeHost, host)
+	span.SetAttribute(SpanAttributeEndpoint, endpoint)
+	return ctx, span
+}
+
+func endSpan(span Span, stats *callStats, err error) {
+	if stats.statusCode > 0 {
+		span.SetAttribute(SpanAttributeStatusCode, stats.statusCode)
+	}
+
+	if stats.resent > 0 {
+		span.SetAttribute(SpanAttributeResendCount, stats.resent)
+	}
+
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	span.End()
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedSpan struct {
+	name       string
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *recordedSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *recordedSpan) RecordError(err error)                      { s.err = err }
+func (s *recordedSpan) End()                                       { s.ended = true }
+
+type spanKey struct{}
+
+// spanRecorder is an in-memory `Tracer`.
+type spanRecorder struct {
+	spans []*recordedSpan
+}
+
+func (r *spanRecorder) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &recordedSpan{name: name, attributes: make(map[string]interface{})}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestTracer(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/topics":
+			w.Write([]byte("[]"))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	recorder := new(spanRecorder)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithTracer(recorder),
+		WithRequestInterceptors(func(req *http.Request) {
+			assert.NotNil(t, req.Context().Value(spanKey{}), "the request should be bound to the span's context")
+		}))
+	assert.Nil(t, err)
+
+	_, err = client.GetTopicsNames()
+	assert.Nil(t, err)
+
+	_, err = client.DoContext(context.Background(), http.MethodGet, "/api/unknown?name=a", "", nil)
+	assert.NotNil(t, err)
+
+	assert.Len(t, recorder.spans, 2)
+
+	span := recorder.spans[0]
+	assert.Equal(t, "GET /api/topics", span.name)
+	assert.Equal(t, http.MethodGet, span.attributes[SpanAttributeMethod])
+	assert.Equal(t, "127.0.0.1", span.attributes[SpanAttributeHost])
+	assert.Equal(t, http.StatusOK, span.attributes[SpanAttributeStatusCode])
+	assert.Nil(t, span.err)
+	assert.True(t, span.ended)
+
+	span = recorder.spans[1]
+	assert.Equal(t, "GET /api/unknown", span.name)
+	assert.Equal(t, http.StatusNotFound, span.attributes[SpanAttributeStatusCode])
+	assert.NotNil(t, span.err)
+	assert.True(t, span.ended)
+}
+
+func TestTracerResendCount(t *testing.T) {
+	var compressed int
+	server := newGzipServer(t, false, &compressed)
+	defer server.Close()
+
+	recorder := new(spanRecorder)
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"}, WithTracer(recorder), WithRequestCompression(1))
+	assert.Nil(t, err)
+
+	echo(t, client, []byte(`{"name": "rejected"}`))
+	assert.Len(t, recorder.spans, 1)
+	assert.Equal(t, 1, recorder.spans[0].attributes[SpanAttributeResendCount])
+	assert.Equal(t, http.StatusOK, recorder.spans[0].attributes[SpanAttributeStatusCode])
+}
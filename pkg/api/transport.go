@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The defaults of the `TransportOptions`, the same as the `http.DefaultTransport`'s.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = http.DefaultMaxIdleConnsPerHost
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// TransportOptions tunes the connection pool of the HTTP transport the client constructs, see `ClientConfig#Transport`.
+// They are ignored when the HTTP client is supplied, i.e by the `WithHTTPClient`
+// or by the `UsingClient` with a transport.
+type TransportOptions struct {
+	// MaxIdleConns is the maximum number of idle (keep-alive) connections across all hosts.
+	//
+	// Defaults to `DefaultMaxIdleConns`.
+	MaxIdleConns int `json:"maxIdleConns,omitempty" yaml:"MaxIdleConns,omitempty"`
+	// MaxIdleConnsPerHost is the maximum number of idle (keep-alive) connections to keep per host.
+	//
+	// Defaults to `DefaultMaxIdleConnsPerHost`.
+	MaxIdleConnsPerHost int `json:"maxIdleConnsPerHost,omitempty" yaml:"MaxIdleConnsPerHost,omitempty"`
+	// IdleConnTimeout is the maximum amount of time an idle (keep-alive) connection will remain idle before closing itself,
+	// i.e "90s" or "5m".
+	//
+	// Defaults to `DefaultIdleConnTimeout`.
+	IdleConnTimeout string `json:"idleConnTimeout,omitempty" yaml:"IdleConnTimeout,omitempty"`
+}
+
+// Validate returns an error if the numbers of connections are negative or the idle timeout is not a valid duration.
+func (o TransportOptions) Validate() error {
+	if o.MaxIdleConns < 0 {
+		return fmt.Errorf("invalid max idle connections [%d], expected a positive number", o.MaxIdleConns)
+	}
+
+	if o.MaxIdleConnsPerHost < 0 {
+		return fmt.Errorf("invalid max idle connections per host [%d], expected a positive number", o.MaxIdleConnsPerHost)
+	}
+
+	if o.IdleConnTimeout != "" {
+		if d, err := time.ParseDuration(o.IdleConnTimeout); err != nil || d < 0 {
+			return fmt.Errorf("invalid idle connection timeout [%s], expected a positive duration, i.e 90s or 5m", o.IdleConnTimeout)
+		}
+	}
+
+	return nil
+}
+
+// apply sets the connection pool of the "t", the defaults are used for the nil or unset options.
+func (o *TransportOptions) apply(t *http.Transport) {
+	t.MaxIdleConns = DefaultMaxIdleConns
+	t.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	t.IdleConnTimeout = DefaultIdleConnTimeout
+
+	if o == nil {
+		return
+	}
+
+	if o.MaxIdleConns > 0 {
+		t.MaxIdleConns = o.MaxIdleConns
+	}
+
+	if o.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+
+	if d, err := time.ParseDuration(o.IdleConnTimeout); err == nil && d > 0 {
+		t.IdleConnTimeout = d
+	}
+}
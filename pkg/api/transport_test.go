@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransportOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Transport: &TransportOptions{
+		MaxIdleConns:        10,
+		MaxIdleConnsPerHost: 5,
+		IdleConnTimeout:     "30s",
+	}})
+	assert.Nil(t, err)
+
+	transport, ok := client.client.Transport.(*http.Transport)
+	if assert.True(t, ok) {
+		assert.Equal(t, 10, transport.MaxIdleConns)
+		assert.Equal(t, 5, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, 30*time.Second, transport.IdleConnTimeout)
+	}
+
+	// the defaults of the http.DefaultTransport.
+	client, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Transport: &TransportOptions{MaxIdleConns: 10}})
+	assert.Nil(t, err)
+
+	transport, ok = client.client.Transport.(*http.Transport)
+	if assert.True(t, ok) {
+		defaultTransport := http.DefaultTransport.(*http.Transport)
+		assert.Equal(t, 10, transport.MaxIdleConns)
+		assert.Equal(t, defaultTransport.IdleConnTimeout, transport.IdleConnTimeout)
+		assert.Equal(t, http.DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	}
+
+	client, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	transport, ok = client.client.Transport.(*http.Transport)
+	if assert.True(t, ok) {
+		defaultTransport := http.DefaultTransport.(*http.Transport)
+		assert.Equal(t, defaultTransport.MaxIdleConns, transport.MaxIdleConns)
+		assert.Equal(t, defaultTransport.IdleConnTimeout, transport.IdleConnTimeout)
+	}
+
+	// ignored by a custom client.
+	httpClient := &http.Client{Transport: &http.Transport{MaxIdleConns: 1}}
+	client, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Transport: &TransportOptions{MaxIdleConns: 10}},
+		WithHTTPClient(httpClient))
+	assert.Nil(t, err)
+	assert.Equal(t, 1, client.client.Transport.(*http.Transport).MaxIdleConns)
+
+	_, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Transport: &TransportOptions{IdleConnTimeout: "forever"}})
+	assert.EqualError(t, err, "invalid configuration: invalid idle connection timeout [forever], expected a positive duration, i.e 90s or 5m")
+
+	_, err = OpenConnection(ClientConfig{Host: server.URL, Token: "secret", Transport: &TransportOptions{MaxIdleConnsPerHost: -1}})
+	assert.EqualError(t, err, "invalid configuration: invalid max idle connections per host [-1], expected a positive number")
+}
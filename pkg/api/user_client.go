@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -19,7 +20,12 @@ type UserMember struct {
 
 //GetUsers returns the list of users
 func (c *Client) GetUsers() (users []UserMember, err error) {
-	resp, err := c.Do(http.MethodGet, usersPath, contentTypeJSON, nil)
+	return c.GetUsersContext(context.Background())
+}
+
+// GetUsersContext is like `GetUsers` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetUsersContext(ctx context.Context) (users []UserMember, err error) {
+	resp, err := c.DoContext(ctx, http.MethodGet, usersPath, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -29,13 +35,18 @@ func (c *Client) GetUsers() (users []UserMember, err error) {
 
 //GetUser returns the user by the provided name
 func (c *Client) GetUser(name string) (user UserMember, err error) {
+	return c.GetUserContext(context.Background(), name)
+}
+
+// GetUserContext is like `GetUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetUserContext(ctx context.Context, name string) (user UserMember, err error) {
 	if name == "" {
 		err = errRequired("name")
 		return
 	}
 
 	path := fmt.Sprintf("%s/%s", usersPath, name)
-	resp, err := c.Do(http.MethodGet, path, contentTypeJSON, nil)
+	resp, err := c.DoContext(ctx, http.MethodGet, path, contentTypeJSON, nil)
 	if err != nil {
 		return
 	}
@@ -46,6 +57,11 @@ func (c *Client) GetUser(name string) (user UserMember, err error) {
 
 //CreateUser creates a user
 func (c *Client) CreateUser(user *UserMember) error {
+	return c.CreateUserContext(context.Background(), user)
+}
+
+// CreateUserContext is like `CreateUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) CreateUserContext(ctx context.Context, user *UserMember) error {
 	if user.Username == "" {
 		return errRequired("username")
 	}
@@ -58,7 +74,7 @@ func (c *Client) CreateUser(user *UserMember) error {
 		return err
 	}
 
-	_, err = c.Do(http.MethodPost, usersPath, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPost, usersPath, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -67,12 +83,17 @@ func (c *Client) CreateUser(user *UserMember) error {
 
 //DeleteUser deletes a user
 func (c *Client) DeleteUser(username string) error {
+	return c.DeleteUserContext(context.Background(), username)
+}
+
+// DeleteUserContext is like `DeleteUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) DeleteUserContext(ctx context.Context, username string) error {
 	if username == "" {
 		return errRequired("name")
 	}
 
 	path := fmt.Sprintf("%s/%s", usersPath, username)
-	_, err := c.Do(http.MethodDelete, path, contentTypeJSON, nil)
+	_, err := c.DoContext(ctx, http.MethodDelete, path, contentTypeJSON, nil)
 	if err != nil {
 		return err
 	}
@@ -81,6 +102,11 @@ func (c *Client) DeleteUser(username string) error {
 
 //UpdateUser updates a user
 func (c *Client) UpdateUser(user *UserMember) error {
+	return c.UpdateUserContext(context.Background(), user)
+}
+
+// UpdateUserContext is like `UpdateUser` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateUserContext(ctx context.Context, user *UserMember) error {
 	if user.Username == "" {
 		return errRequired("name")
 	}
@@ -91,7 +117,7 @@ func (c *Client) UpdateUser(user *UserMember) error {
 	}
 
 	path := fmt.Sprintf("%s/%s", usersPath, user.Username)
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -104,6 +130,11 @@ type changePassword struct {
 
 //UpdateUserPassword updaes the password of a user
 func (c *Client) UpdateUserPassword(username, password string) error {
+	return c.UpdateUserPasswordContext(context.Background(), username, password)
+}
+
+// UpdateUserPasswordContext is like `UpdateUserPassword` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) UpdateUserPasswordContext(ctx context.Context, username, password string) error {
 	if username == "" {
 		return errRequired("name")
 	}
@@ -117,7 +148,7 @@ func (c *Client) UpdateUserPassword(username, password string) error {
 	}
 
 	path := fmt.Sprintf("%s/%s/password", usersPath, username)
-	_, err = c.Do(http.MethodPut, path, contentTypeJSON, payload)
+	_, err = c.DoContext(ctx, http.MethodPut, path, contentTypeJSON, payload)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const serverVersionPath = "api/version"
+
+// ServerVersion describes the version and the build information of the connected Lenses server,
+// see `GetServerVersion`.
+type ServerVersion struct {
+	Version   string `json:"version" yaml:"version" header:"Version"`
+	Revision  string `json:"revision,omitempty" yaml:"revision,omitempty" header:"Revision"`
+	BuildTime string `json:"buildTime,omitempty" yaml:"buildTime,omitempty" header:"Build Time"`
+}
+
+// Major returns the major version of the server, see `MajorVersion`.
+func (v ServerVersion) Major() (int, bool) {
+	return MajorVersion(v.Version)
+}
+
+// GetServerVersion returns the version of the connected Lenses server.
+// Servers without the version endpoint report only their version, through their configuration.
+func (c *Client) GetServerVersion() (ServerVersion, error) {
+	return c.GetServerVersionContext(context.Background())
+}
+
+// GetServerVersionContext is like `GetServerVersion` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) GetServerVersionContext(ctx context.Context) (ServerVersion, error) {
+	var version ServerVersion
+
+	resp, err := c.DoContext(ctx, http.MethodGet, serverVersionPath, "", nil)
+	if err != nil {
+		if resErr, ok := err.(ResourceError); !ok || resErr.StatusCode != http.StatusNotFound {
+			return version, err
+		}
+
+		var box struct {
+			Version string `json:"lenses.version"`
+		}
+
+		err = c.getBoxConfig(ctx, &box)
+		version.Version = box.Version
+		return version, err
+	}
+
+	err = c.ReadJSON(resp, &version)
+	return version, err
+}
+
+// MajorVersion returns the major part of a "major.minor.patch" version, the "v" prefix is optional.
+// It reports false if the version does not start with a number, i.e a development build.
+func MajorVersion(version string) (int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if idx := strings.IndexByte(version, '.'); idx > 0 {
+		version = version[:idx]
+	}
+
+	major, err := strconv.Atoi(version)
+	if err != nil {
+		return 0, false
+	}
+
+	return major, true
+}
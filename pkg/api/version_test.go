@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetServerVersion(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/"+serverVersionPath, r.URL.Path)
+		w.Write([]byte(`{"version": "3.1.2", "revision": "8a7c0e9", "buildTime": "2020-03-10T12:00:00Z"}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	version, err := client.GetServerVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, ServerVersion{Version: "3.1.2", Revision: "8a7c0e9", BuildTime: "2020-03-10T12:00:00Z"}, version)
+
+	major, ok := version.Major()
+	assert.True(t, ok)
+	assert.Equal(t, 3, major)
+}
+
+func TestGetServerVersionFromConfig(t *testing.T) {
+	// older servers, without the version endpoint.
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/"+configPath {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"lenses.version": "2.3.0"}`))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	version, err := client.GetServerVersion()
+	assert.Nil(t, err)
+	assert.Equal(t, ServerVersion{Version: "2.3.0"}, version)
+}
+
+func TestMajorVersion(t *testing.T) {
+	for version, expected := range map[string]int{"3.1.0": 3, "v4.0": 4, "10": 10} {
+		major, ok := MajorVersion(version)
+		assert.True(t, ok, version)
+		assert.Equal(t, expected, major, version)
+	}
+
+	_, ok := MajorVersion("blop")
+	assert.False(t, ok)
+	_, ok = MajorVersion("")
+	assert.False(t, ok)
+}
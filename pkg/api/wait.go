@@ -0,0 +1,137 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrWaitTimeout is returned by the `WaitFor` when the "timeout" elapses before the "poll" is done.
+var ErrWaitTimeout = errors.New("timed out waiting for the condition")
+
+// DefaultWaitInterval is the interval between the status requests of the `Client#WaitForProcessor` and `Client#WaitForConnector`.
+var DefaultWaitInterval = 2 * time.Second
+
+// WaitFor calls the "poll" immediately and then every "interval" until it reports done,
+// it fails or the "timeout" elapses, in that case it returns the `ErrWaitTimeout`.
+// A zero or negative "timeout" polls just once.
+func WaitFor(poll func() (done bool, err error), timeout, interval time.Duration) error {
+	return WaitForContext(context.Background(), poll, timeout, interval)
+}
+
+// WaitForContext is like `WaitFor` but it stops waiting with the `ctx` error when the `ctx` is done.
+func WaitForContext(ctx context.Context, poll func() (done bool, err error), timeout, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	deadline := time.Now().Add(timeout)
+
+	for {
+		done, err := poll()
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return ErrWaitTimeout
+		}
+
+		if interval < remaining {
+			remaining = interval
+		}
+
+		timer := time.NewTimer(remaining)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// WaitForProcessor polls the processor of the "processorID" until its deployment state is running,
+// it fails fast if the processor is failed and on timeout the returned error contains its last state.
+func (c *Client) WaitForProcessor(processorID string, timeout time.Duration) (ProcessorStream, error) {
+	return c.WaitForProcessorContext(context.Background(), processorID, timeout)
+}
+
+// WaitForProcessorContext is like `WaitForProcessor` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) WaitForProcessorContext(ctx context.Context, processorID string, timeout time.Duration) (ProcessorStream, error) {
+	var processor ProcessorStream
+
+	err := WaitForContext(ctx, func() (bool, error) {
+		var err error
+		processor, err = c.GetProcessorContext(ctx, processorID)
+		if err != nil {
+			return false, err
+		}
+
+		switch ConnectorState(processor.DeploymentState) {
+		case RUNNING:
+			return true, nil
+		case FAILED:
+			return false, fmt.Errorf("processor [%s] failed to start", processorID)
+		default:
+			return false, nil
+		}
+	}, timeout, DefaultWaitInterval)
+
+	if err == ErrWaitTimeout {
+		err = fmt.Errorf("processor [%s] is not running after %s, last state [%s]", processorID, timeout, processor.DeploymentState)
+	}
+
+	return processor, err
+}
+
+// WaitForConnector polls the status of the connector until the connector is running with at least one task,
+// all of them running, the tasks are assigned after the connector starts. It fails fast if the connector or one of its tasks is failed and on timeout the returned error contains its last state.
+func (c *Client) WaitForConnector(clusterName, name string, timeout time.Duration) (ConnectorStatus, error) {
+	return c.WaitForConnectorContext(context.Background(), clusterName, name, timeout)
+}
+
+// WaitForConnectorContext is like `WaitForConnector` but the request is bound to the `ctx`, so it can be canceled or given a deadline.
+func (c *Client) WaitForConnectorContext(ctx context.Context, clusterName, name string, timeout time.Duration) (ConnectorStatus, error) {
+	var status ConnectorStatus
+
+	err := WaitForContext(ctx, func() (bool, error) {
+		var err error
+		status, err = c.GetConnectorStatusContext(ctx, clusterName, name)
+		if err != nil {
+			return false, err
+		}
+
+		if ConnectorState(status.Connector.State) == FAILED {
+			return false, fmt.Errorf("connector [%s] failed to start", name)
+		}
+
+		running := ConnectorState(status.Connector.State) == RUNNING
+		for _, task := range status.Tasks {
+			switch ConnectorState(task.State) {
+			case FAILED:
+				return false, fmt.Errorf("task [%d] of the connector [%s] failed to start: %s", task.ID, name, task.Trace)
+			case RUNNING:
+			default:
+				running = false
+			}
+		}
+
+		return running && len(status.Tasks) > 0, nil
+	}, timeout, DefaultWaitInterval)
+
+	if err == ErrWaitTimeout {
+		err = fmt.Errorf("connector [%s] is not running after %s, last state [%s]", name, timeout, status.Connector.State)
+		if len(status.Tasks) == 0 {
+			err = fmt.Errorf("%v, no tasks assigned", err)
+		}
+	}
+
+	return status, err
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitFor(t *testing.T) {
+	// ready after a few polls.
+	polls := 0
+	err := WaitFor(func() (bool, error) {
+		polls++
+		return polls == 3, nil
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, polls)
+
+	// timeout.
+	start := time.Now()
+	err = WaitFor(func() (bool, error) { return false, nil }, 20*time.Millisecond, 5*time.Millisecond)
+	assert.Equal(t, ErrWaitTimeout, err)
+	assert.True(t, time.Since(start) >= 20*time.Millisecond)
+
+	// the poll's error stops the wait.
+	failure := errors.New("failed")
+	err = WaitFor(func() (bool, error) { return false, failure }, time.Second, time.Millisecond)
+	assert.Equal(t, failure, err)
+
+	// the canceled ctx stops the wait between the polls.
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+	start = time.Now()
+	err = WaitForContext(ctx, func() (bool, error) { return false, nil }, time.Minute, time.Minute)
+	assert.Equal(t, context.Canceled, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "the wait did not stop promptly")
+}
+
+func TestWaitForConnector(t *testing.T) {
+	defer func(interval time.Duration) { DefaultWaitInterval = interval }(DefaultWaitInterval)
+	DefaultWaitInterval = time.Millisecond
+
+	polls := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		switch r.URL.Path {
+		case "/api/proxy-connect/dev/connectors/ready/status":
+			state := "UNASSIGNED"
+			if polls >= 2 {
+				state = "RUNNING"
+			}
+			fmt.Fprintf(w, `{"name":"ready","connector":{"state":"RUNNING"},"tasks":[{"id":0,"state":%q}]}`, state)
+		case "/api/proxy-connect/dev/connectors/pending/status":
+			w.Write([]byte(`{"name":"pending","connector":{"state":"UNASSIGNED"}}`))
+		case "/api/proxy-connect/dev/connectors/unassigned/status":
+			w.Write([]byte(`{"name":"unassigned","connector":{"state":"RUNNING"},"tasks":[]}`))
+		case "/api/proxy-connect/dev/connectors/failed/status":
+			w.Write([]byte(`{"name":"failed","connector":{"state":"RUNNING"},"tasks":[{"id":1,"state":"FAILED","trace":"boom"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	status, err := client.WaitForConnector("dev", "ready", time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "RUNNING", status.Tasks[0].State)
+	assert.Equal(t, 2, polls)
+
+	_, err = client.WaitForConnector("dev", "pending", 10*time.Millisecond)
+	assert.EqualError(t, err, "connector [pending] is not running after 10ms, last state [UNASSIGNED], no tasks assigned")
+
+	// the tasks are not assigned yet.
+	_, err = client.WaitForConnector("dev", "unassigned", 10*time.Millisecond)
+	assert.EqualError(t, err, "connector [unassigned] is not running after 10ms, last state [RUNNING], no tasks assigned")
+
+	_, err = client.WaitForConnector("dev", "failed", time.Second)
+	assert.EqualError(t, err, "task [1] of the connector [failed] failed to start: boom")
+}
+
+func TestWaitForProcessor(t *testing.T) {
+	defer func(interval time.Duration) { DefaultWaitInterval = interval }(DefaultWaitInterval)
+	DefaultWaitInterval = time.Millisecond
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/streams/running":
+			w.Write([]byte(`{"id":"running","deploymentState":"RUNNING"}`))
+		case "/api/streams/pending":
+			w.Write([]byte(`{"id":"pending","deploymentState":"PENDING"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	client, err := OpenConnection(ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+
+	processor, err := client.WaitForProcessor("running", time.Second)
+	assert.Nil(t, err)
+	assert.Equal(t, "RUNNING", processor.DeploymentState)
+
+	_, err = client.WaitForProcessor("pending", 10*time.Millisecond)
+	assert.EqualError(t, err, "processor [pending] is not running after 10ms, last state [PENDING]")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/landoop/tableprinter"
 	"github.com/spf13/cobra"
 )
@@ -28,14 +29,18 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 			// Audits entries are accessible for all roles atm.
 			withoutContentColumn := strings.ToUpper(bite.GetOutPutFlag(cmd)) == "TABLE" && !tableOnlyWithContent
 			if sse {
+				if len(utils.GetSortBy(cmd)) > 0 {
+					return fmt.Errorf("--%s can not be used with --live, the entries are printed as they arrive", utils.SortByFlag)
+				}
+
 				handler := func(entry api.AuditEntry) error {
 					if withoutContentColumn {
 						// entry.Content = nil, no need.
 						newEntry := tableprinter.RemoveStructHeader(entry, "Content")
-						return bite.PrintObject(cmd, newEntry)
+						return utils.PrintObject(cmd, newEntry)
 
 					}
-					return bite.PrintObject(cmd, entry)
+					return utils.PrintObject(cmd, entry)
 				}
 
 				return config.Client.GetAuditEntriesLive(handler)
@@ -51,6 +56,11 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 			}
 
 			if withoutContentColumn {
+				// the entries are printed one by one, sort them here so the --sort-by covers all of them.
+				if err = utils.SortSlice(entries, utils.GetSortBy(cmd)); err != nil {
+					return err
+				}
+
 				// print each one without content,
 				// bite is smart enough to see that it's the same type and it will append a row instead of a creating a new table,
 				// although some further space on the "USER" header needed.
@@ -60,7 +70,7 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 					// show the length of types by overriding the type header struct(cached or not), printer don't really know how much they are in this time.
 					// LINK:api.Entry.Type
 					newEntry = tableprinter.SetStructHeader(newEntry, "Type", fmt.Sprintf("TYPE [%d]", len(entries)))
-					if err = bite.PrintObject(cmd, newEntry); err != nil {
+					if err = utils.PrintObject(cmd, newEntry); err != nil {
 						return err
 					}
 				}
@@ -68,14 +78,14 @@ func NewGetAuditEntriesCommand() *cobra.Command {
 				return nil
 			}
 
-			return bite.PrintObject(cmd, entries)
+			return utils.PrintObject(cmd, entries)
 		},
 	}
 
 	cmd.Flags().BoolVar(&sse, "live", false, "Subscribe to live audit feeds")
 	cmd.Flags().BoolVar(&tableOnlyWithContent, "with-content", false, "Add a table column to display the raw json content of the event action")
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -0,0 +1,76 @@
+package broker
+
+import (
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+//NewBrokersGroupCommand creates the `brokers` command
+func NewBrokersGroupCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "brokers",
+		Short: "List the brokers of the kafka cluster",
+		Example: `brokers
+brokers config --id 1 [--overridden-only]`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			brokers, err := config.Client.GetBrokers()
+			if err != nil {
+				return err
+			}
+
+			return utils.PrintObject(cmd, brokers)
+		},
+	}
+
+	utils.CanPrintJSON(cmd)
+
+	cmd.AddCommand(NewBrokerConfigCommand())
+
+	return cmd
+}
+
+//NewBrokerConfigCommand creates the `brokers config` command
+func NewBrokerConfigCommand() *cobra.Command {
+	var (
+		brokerID       int
+		overriddenOnly bool
+	)
+
+	cmd := &cobra.Command{
+		Use:              "config",
+		Short:            "Print the configuration of a broker, sensitive values are hidden",
+		Example:          `brokers config --id 1 [--overridden-only]`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			configs, err := config.Client.GetBrokerConfig(brokerID)
+			if err != nil {
+				return err
+			}
+
+			if overriddenOnly {
+				var overridden []api.BrokerConfigEntry
+				for _, entry := range configs {
+					if !entry.IsDefault() {
+						overridden = append(overridden, entry)
+					}
+				}
+				configs = overridden
+			}
+
+			return utils.PrintObject(cmd, configs)
+		},
+	}
+
+	cmd.Flags().IntVar(&brokerID, "id", 0, "The broker id")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().BoolVar(&overriddenOnly, "overridden-only", false, "Print only the configs which are not set to their default value")
+
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
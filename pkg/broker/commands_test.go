@@ -0,0 +1,50 @@
+package broker
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+const brokerConfigResponse = `[
+	{"name": "log.cleaner.threads", "value": "1", "source": "DEFAULT_CONFIG", "sensitive": false},
+	{"name": "ssl.keystore.password", "value": "should-not-leak", "source": "STATIC_BROKER_CONFIG", "sensitive": true}
+]`
+
+func TestBrokerConfigCommandOverriddenOnly(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/kafka/brokers/0/configs", r.URL.Path)
+		w.Write([]byte(brokerConfigResponse))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	cmd := NewBrokersGroupCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "config", "--id", "0", "--overridden-only")
+	assert.Nil(t, err)
+
+	var configs []api.BrokerConfigEntry
+	assert.Nil(t, json.Unmarshal([]byte(output), &configs))
+	assert.Equal(t, []api.BrokerConfigEntry{
+		{Name: "ssl.keystore.password", Value: api.RedactedConfigValue, Source: api.BrokerConfigStatic, Sensitive: true},
+	}, configs)
+	assert.NotContains(t, output, "should-not-leak")
+}
+
+func TestBrokerConfigCommandMissingID(t *testing.T) {
+	_, err := test.ExecuteCommand(NewBrokersGroupCommand(), "config")
+	assert.NotNil(t, err)
+}
@@ -3,7 +3,8 @@ package config
 import (
 	"fmt"
 
-	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -34,7 +35,7 @@ func NewGetConfigsCommand() *cobra.Command {
 					return fmt.Errorf("retrieve config value [%s] failed: [%v]", configEntryName, err)
 				}
 
-				return bite.PrintJSON(cmd, value) // keep json.
+				return utils.PrintJSON(cmd, value) // keep json.
 			}
 
 			config, err := Client.GetConfig()
@@ -42,11 +43,42 @@ func NewGetConfigsCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, config)
+			return utils.PrintObject(cmd, config)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+
+	cmd.AddCommand(NewTestConfigCommand())
+
+	return cmd
+}
+
+//NewTestConfigCommand creates the `configs test` command
+func NewTestConfigCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "test",
+		Short:            "Test the connection and the credentials of the current context",
+		Example:          "config test",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := Manager.Config.CurrentContext
+			currentConfig := Manager.Config.GetCurrent()
+			if err := currentConfig.Validate(); err != nil {
+				return fmt.Errorf("invalid context [%s]: %v", name, err)
+			}
+
+			result, err := api.TestConnection(*currentConfig)
+			if err != nil {
+				return fmt.Errorf("context [%s]: %v", name, err)
+			}
+
+			return utils.PrintObject(cmd, result)
+		},
+	}
+
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// CompleteResourceNames returns a completion function which suggests the live names of a resource,
+// as returned by the `list` func, for the current context.
+// It suggests nothing if the client could not be set up, i.e invalid configuration.
+func CompleteResourceNames(resource string, list func(client *api.Client) ([]string, error)) utils.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if Client == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		client := Client
+		key := completionCacheKey(resource, client)
+		return utils.CompleteNames(key, func() ([]string, error) {
+			return list(client)
+		})(cmd, args, toComplete)
+	}
+}
+
+// completionCacheKey identifies the cached names of a resource by the current context, the user and the host,
+// so the names of a context or a user are never suggested for another one of the same host.
+func completionCacheKey(resource string, client *api.Client) string {
+	contextName := ""
+	if Manager != nil {
+		contextName = Manager.Config.CurrentContext
+	}
+
+	return fmt.Sprintf("%s@%s/%s@%s", resource, contextName, client.User.Name, client.Config.Host)
+}
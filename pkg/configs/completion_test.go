@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletionCacheKey(t *testing.T) {
+	Manager = NewEmptyConfigManager()
+	defer func() { Manager = nil }()
+
+	Manager.Config.AddContext("dev", &api.ClientConfig{Host: "http://domain.com"})
+	Manager.Config.AddContext("prod", &api.ClientConfig{Host: "http://domain.com"})
+	Manager.Config.SetCurrent("dev")
+
+	client := &api.Client{Config: Manager.Config.GetCurrent(), User: api.User{Name: "admin"}}
+	dev := completionCacheKey("connections", client)
+	assert.Equal(t, "connections@dev/admin@http://domain.com", dev)
+
+	client.User.Name = "viewer"
+	assert.NotEqual(t, dev, completionCacheKey("connections", client))
+
+	Manager.Config.SetCurrent("prod")
+	client = &api.Client{Config: Manager.Config.GetCurrent(), User: api.User{Name: "admin"}}
+	assert.NotEqual(t, dev, completionCacheKey("connections", client))
+}
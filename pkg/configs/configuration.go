@@ -6,10 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/kataras/golog"
+	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
@@ -27,6 +31,12 @@ type ConfigurationManager struct {
 	insecure, debug                                                                                               bool
 
 	Filepath string
+
+	// set on `Load`, see `CanSave` and `Save`.
+	loadedFromFile, authFromFlags bool
+	// fileConfig is a clone of the configuration file before the flags of the run are applied
+	// and runConfig a clone after them, see `restoreFileValues`.
+	fileConfig, runConfig *api.Config
 }
 
 /*
@@ -55,9 +65,9 @@ func NewConfigurationManager(set *pflag.FlagSet) *ConfigurationManager {
 		},
 	}
 
-	set.StringVar(&m.CurrentContext, "context", "", "Load specific environment, embedded configuration based on the configuration's 'Contexts'")
+	set.StringVar(&m.CurrentContext, "context", "", "Load specific environment, embedded configuration based on the configuration's 'Contexts', for this run only")
 
-	set.StringVar(&m.host, "host", "", "Lenses host")
+	set.StringVar(&m.host, "host", "", "Lenses host, it overrides the context's host for this run only")
 	// basic auth.
 
 	// if --kerberos-conf set and not other kerberos-* flag set,
@@ -68,17 +78,19 @@ func NewConfigurationManager(set *pflag.FlagSet) *ConfigurationManager {
 	set.StringVar(&m.kerberosConf, "kerberos-conf", "", "krb5.conf")
 	// if --kerberos-realm not set but --kerberos-config does then auth using kerberos with the default realm, otherwise using that realm.
 	set.StringVar(&m.kerberosRealm, "kerberos-realm", "", "Kerberos realm")
-	// if --kerberos-keytab & --kerberos-conf set then auth using kerberos keytab file.
+	// if --kerberos-keytab set then auth using kerberos keytab file, with the --kerberos-conf or the default krb5.conf.
 	set.StringVar(&m.kerberosKeytab, "kerberos-keytab", "", "KeyTab file")
-	// if --kerberos-ccache & --kerberos-conf set then auth from kerberos ccache file.
-	set.StringVar(&m.kerberosCCache, "kerberos-ccache", "", "Kerberos keytab file")
+	// if --kerberos-ccache set, or the KRB5CCNAME without --user and --pass, then auth from kerberos ccache file.
+	set.StringVar(&m.kerberosCCache, "kerberos-ccache", "", "Kerberos ccache file, defaults to the KRB5CCNAME")
 
-	set.StringVar(&m.timeout, "timeout", "", "Timeout for the connection establishment")
+	set.StringVar(&m.timeout, "timeout", "", "Timeout for the connection establishment, i.e 30s or 1m, it overrides the context's timeout")
 	set.BoolVar(&m.insecure, "insecure", false, "All insecure http requests")
 	set.StringVar(&m.token, "token", "", "Lenses auth token")
 	set.BoolVar(&m.debug, "debug", false, "Print some information that are necessary for debugging")
 
-	set.StringVar(&m.Filepath, "config", "", "Load or save the host, user, pass and debug fields from or to a configuration file (yaml or json)")
+	set.StringVar(&m.Filepath, "config", "", "Load or save the host, user, pass and debug fields from or to a configuration file (yaml or json), "+
+		"instead of looking for it in the current, the executable's and the home directory, defaults to the "+configFileEnvKey+" environment variable")
+	set.StringVar(&m.Filepath, "config-file", "", "Alias of the --config flag")
 	return m
 }
 
@@ -91,16 +103,37 @@ func NewEmptyConfigManager() *ConfigurationManager {
 	}
 }
 
-const currentContextEnvKey = "LENSES_CLI_CONTEXT"
+const (
+	currentContextEnvKey = "LENSES_CLI_CONTEXT"
+	// configFileEnvKey is the environment variable of the configuration file, the --config flag has priority.
+	configFileEnvKey = "LENSES_CONFIG"
+)
 
 //Load loads the configuration
 func (m *ConfigurationManager) Load() (bool, error) {
 	c := m.Config
 
+	if m.timeout != "" {
+		if _, err := time.ParseDuration(m.timeout); err != nil {
+			return false, fmt.Errorf("invalid --timeout [%s], expected a duration, i.e 30s, 1m or 1m30s", m.timeout)
+		}
+	}
+
 	var found bool
 
+	source := "--config flag"
+	if m.Filepath == "" {
+		if envFilepath := strings.TrimSpace(os.Getenv(configFileEnvKey)); envFilepath != "" {
+			m.Filepath, source = envFilepath, configFileEnvKey+" environment variable"
+		}
+	}
+
 	if m.Filepath != "" {
-		// must read from file, otherwise fail.
+		// must read from file, otherwise fail, never fallback to the lookup.
+		if _, err := os.Stat(m.Filepath); err != nil {
+			return false, fmt.Errorf("configuration file [%s] of the %s not found", m.Filepath, source)
+		}
+
 		if err := api.TryReadConfigFromFile(m.Filepath, c); err != nil {
 			return false, err
 		}
@@ -109,20 +142,50 @@ func (m *ConfigurationManager) Load() (bool, error) {
 	} else if found = api.TryReadConfigFromExecutable(c); found {
 	} else if found = api.TryReadConfigFromHome(c); found {
 	}
+
+	// authentication flags passed, override or set the particular authentication method.
+	authFromFlags, authLoadedFromFlags := MakeAuthentication(m.user, m.pass, m.kerberosConf, m.kerberosRealm, m.kerberosKeytab, m.kerberosCCache)
+	if found && authLoadedFromFlags && m.user == "" && m.pass == "" && m.kerberosConf == "" && m.kerberosKeytab == "" && m.kerberosCCache == "" {
+		// only the KRB5CCNAME is set, keep the authentication of the configuration file.
+		authLoadedFromFlags = false
+	}
+	m.loadedFromFile, m.authFromFlags = found, authLoadedFromFlags
+
+	if found {
+		// decrypt before any flag is applied, the passwords are encrypted based on the saved hosts.
+		for _, v := range c.Contexts {
+			DecryptPassword(v)
+		}
+	}
+
 	// check --context flag (prio) and the configuration's one, if it's there and set the current context upfront.
 	currentContext := c.CurrentContext
-	currentContextChanged := false
-	if flag := m.CurrentContext; flag != "" && flag != currentContext {
+	if flag := m.CurrentContext; flag != "" {
 		currentContext = flag
-		currentContextChanged = true
-	} else if currentContext == "" {
+	} else if found && !authLoadedFromFlags {
+		// try to set the current context from *.env file or from system 's env variables,
+		// if not empty, the env value has a priority over the configurated `CurrentContext`
+		// but --context flag has a priority over all.
+		godotenv.Load()
+		if envContext := strings.TrimSpace(os.Getenv(currentContextEnvKey)); envContext != "" {
+			currentContext = envContext
+		}
+	}
+
+	if currentContext == "" {
 		currentContext = api.DefaultContextKey
 	}
 
+	contextExists := c.ContextExists(currentContext)
+
+	if found {
+		// keep the file's values, the --context and the client configuration flags apply to this run only, see `Save`.
+		fileConfig := c.Clone()
+		m.fileConfig = &fileConfig
+	}
+
 	c.SetCurrent(currentContext)
 
-	// authentication flags passed, override or set the particular authentication method.
-	authFromFlags, authLoadedFromFlags := makeAuthFromFlags(m.user, m.pass, m.kerberosConf, m.kerberosRealm, m.kerberosKeytab, m.kerberosCCache)
 	if authLoadedFromFlags {
 		c.GetCurrent().Authentication = authFromFlags
 	}
@@ -137,45 +200,77 @@ func (m *ConfigurationManager) Load() (bool, error) {
 		Debug:    m.debug,
 	})
 
-	if found {
+	if found && !contextExists && currentContext != m.fileConfig.CurrentContext {
+		return false, fmt.Errorf("unknown context [%s] given, please use the `configure --context="+currentContext+" --reset`", currentContext)
+	}
 
-		if currentContextChanged {
-			// save the config, the current context changed.
-			for _, v := range c.Contexts {
-				DecryptPassword(v)
-			}
-			if err := m.Save(); err != nil {
-				return false, err
-			}
-		} else {
-			// check if loaded from flags, if so and we proceed then the password field goes empty.
-			if !authLoadedFromFlags {
-				// try to set the current context from *.env file or from system 's env variables,
-				// if not empty, the env value has a priority over the configurated `CurrentContext`
-				// but --context flag has a priority over all (look above).
-				//
-				// Note that the env variable will NOT change the `CurrentContext` field from the configuration file, by purpose.
-				godotenv.Load()
-				if envContext := strings.TrimSpace(os.Getenv(currentContextEnvKey)); envContext != "" {
-					c.CurrentContext = envContext
-				}
-				for _, v := range c.Contexts {
-					DecryptPassword(v)
-				}
-			}
-		}
+	valid := c.IsValid() // note that it formats the hosts.
+	if m.fileConfig != nil {
+		runConfig := c.Clone()
+		m.runConfig = &runConfig
 	}
 
-	if c.CurrentContext != "" && !c.CurrentContextExists() {
-		return false, fmt.Errorf("unknown context [%s] given, please use the `configure --context="+c.CurrentContext+" --reset`", c.CurrentContext)
+	return valid, nil
+}
+
+//KeepOverrides makes the next `Save` calls persist the --context, --host, --timeout, --insecure and --debug flags,
+// i.e when the user configures the context explicitly.
+func (m *ConfigurationManager) KeepOverrides() {
+	m.fileConfig, m.runConfig = nil, nil
+}
+
+// restoreFileValues reverts the overrides of the flags, on the "c" to be saved,
+// unless a command changed the current context or the overridden fields on purpose.
+func (m *ConfigurationManager) restoreFileValues(c *api.Config) {
+	file, run := m.fileConfig, m.runConfig
+	if file == nil || run == nil {
+		return
+	}
+
+	context := run.CurrentContext
+	if c.CurrentContext == context && file.CurrentContext != "" {
+		c.CurrentContext = file.CurrentContext
+	}
+
+	cfg, ok := c.Contexts[context]
+	overridden, overriddenOk := run.Contexts[context]
+	if !ok || !overriddenOk {
+		return
+	}
+
+	// the context may not exist in the file, i.e when it's created by this run.
+	var fileCfg api.ClientConfig
+	if v, ok := file.Contexts[context]; ok {
+		fileCfg = *v
+	}
+
+	if m.host != "" && cfg.Host == overridden.Host {
+		cfg.Host = fileCfg.Host
+	}
+
+	if m.timeout != "" && cfg.Timeout == overridden.Timeout {
+		cfg.Timeout = fileCfg.Timeout
+	}
+
+	if m.insecure && cfg.Insecure {
+		cfg.Insecure = fileCfg.Insecure
 	}
 
-	return c.IsValid(), nil
+	if m.debug && cfg.Debug {
+		cfg.Debug = fileCfg.Debug
+	}
+}
+
+//CanSave reports whether the loaded configuration can be saved back, i.e to cache a new token.
+// It's false if no configuration file was found or the authentication was given by flags.
+func (m *ConfigurationManager) CanSave() bool {
+	return m.loadedFromFile && !m.authFromFlags
 }
 
 //Save saves the configuration
 func (m *ConfigurationManager) Save() error {
 	c := m.Config.Clone() // copy the configuration so all changes here will not be present after the save().
+	m.restoreFileValues(&c)
 
 	// we encrypt every password (main and contexts) because
 	// they are decrypted on load, even if user didn't select to update a specific context.
@@ -254,6 +349,35 @@ func DecryptPassword(cfg *api.ClientConfig) {
 
 }
 
+//LoadMerged reads and merges the configuration files of the "paths" in order, see `api.LoadMerged`,
+// the passwords of each file are decrypted with the hosts of its contexts before the merge, see `DecryptPassword`.
+// The passwords of the contexts without a host in their file are kept as they are.
+func LoadMerged(paths ...string) (*api.Config, error) {
+	return api.LoadMergedWith(func(layer *api.Config) {
+		for _, cfg := range layer.Contexts {
+			if cfg != nil && cfg.Host != "" {
+				DecryptPassword(cfg)
+			}
+		}
+	}, paths...)
+}
+
+//ApplyDefaultOutput sets the --output flag of the "cmd" to the current context's `DefaultOutput`,
+// unless the flag was passed explicitly.
+func ApplyDefaultOutput(cmd *cobra.Command) error {
+	defaultOutput := Manager.Config.GetCurrent().DefaultOutput
+	if defaultOutput == "" {
+		return nil
+	}
+
+	flag := cmd.Flags().Lookup(bite.GetOutPutFlagKey())
+	if flag == nil || flag.Changed {
+		return nil
+	}
+
+	return flag.Value.Set(defaultOutput)
+}
+
 //SetupConfigManager config manager
 func SetupConfigManager(set *pflag.FlagSet) {
 	Manager = NewConfigurationManager(set)
@@ -262,14 +386,42 @@ func SetupConfigManager(set *pflag.FlagSet) {
 //Client used for the rest of the commands
 var Client *api.Client
 
-//SetupClient setups a new API client
+//SetupClient setups a new API client, the large request bodies, i.e of the imports, are gzip compressed.
+// The debug mode of the current context logs the debug messages, i.e the HTTP requests and responses, whatever the -v.
 func SetupClient() (err error) {
-	Client, err = api.OpenConnection(*Manager.Config.GetCurrent())
+	clientConfig := *Manager.Config.GetCurrent()
+	if clientConfig.Debug {
+		golog.SetLevel("debug")
+	}
+
+	Client, err = api.OpenConnection(clientConfig, api.OnTokenRefresh(saveRefreshedToken), api.WithVersionNegotiation(),
+		api.WithRequestCompression(api.DefaultRequestCompressionMinSize))
 	return
 }
 
-func makeAuthFromFlags(user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache string) (api.Authentication, bool) {
-	if kerberosConf != "" {
+// saveRefreshedToken caches the renewed token of the current context, if the configuration can be saved.
+func saveRefreshedToken(cfg api.ClientConfig) {
+	currentConfig := Manager.Config.GetCurrent()
+	currentConfig.Token = cfg.Token
+	currentConfig.TokenExpiry = cfg.TokenExpiry
+
+	if !Manager.CanSave() {
+		return
+	}
+
+	if err := Manager.Save(); err != nil {
+		golog.Debugf("unable to save the renewed token: [%v]", err)
+	}
+}
+
+//MakeAuthentication returns the authentication method based on the given credentials,
+// kerberos if the kerberosConf, the kerberosKeytab or the kerberosCCache is not empty
+// or if the KRB5CCNAME environment variable is set and there is no username and password, otherwise basic.
+// An empty kerberosConf means the KRB5_CONFIG or the default krb5.conf, see `api.KerberosAuthentication#ConfPath`.
+// It returns false if the credentials are not enough for any authentication method.
+func MakeAuthentication(user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache string) (api.Authentication, bool) {
+	hasCCacheEnv := os.Getenv(api.KerberosCCacheEnv) != "" && (user == "" || pass == "")
+	if kerberosConf != "" || kerberosKeytab != "" || kerberosCCache != "" || hasCCacheEnv {
 		auth := api.KerberosAuthentication{
 			ConfFile: kerberosConf,
 		}
@@ -277,8 +429,9 @@ func makeAuthFromFlags(user, pass, kerberosConf, kerberosRealm, kerberosKeytab,
 		if kerberosKeytab == "" && kerberosCCache == "" && user != "" && pass != "" {
 			auth.Method = api.KerberosWithPassword{Username: user, Password: pass, Realm: kerberosRealm}
 		} else if kerberosKeytab != "" {
-			auth.Method = api.KerberosWithKeytab{KeytabFile: kerberosKeytab}
-		} else if kerberosCCache != "" {
+			auth.Method = api.KerberosWithKeytab{Username: user, Realm: kerberosRealm, KeytabFile: kerberosKeytab}
+		} else if kerberosCCache != "" || os.Getenv(api.KerberosCCacheEnv) != "" {
+			// an empty ccache file means the KRB5CCNAME one, i.e after a `kinit`.
 			auth.Method = api.KerberosFromCCache{CCacheFile: kerberosCCache}
 		} else {
 			return nil, false
@@ -0,0 +1,354 @@
+package config
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDefaultOutput(t *testing.T) {
+	Manager = NewEmptyConfigManager()
+	defer func() { Manager = nil }()
+
+	Manager.Config.AddContext("master", &api.ClientConfig{Host: "http://domain.com", Token: "secret", DefaultOutput: "json"})
+	Manager.Config.SetCurrent("master")
+
+	newCmd := func(args ...string) *cobra.Command {
+		var output string
+		cmd := &cobra.Command{Use: "test"}
+		bite.RegisterOutPutFlag(cmd, &output)
+		assert.Nil(t, cmd.ParseFlags(args))
+		return cmd
+	}
+
+	// the context's default.
+	cmd := newCmd()
+	assert.Nil(t, ApplyDefaultOutput(cmd))
+	assert.Equal(t, "json", bite.GetOutPutFlag(cmd))
+
+	// the flag has priority.
+	cmd = newCmd("--output=yaml")
+	assert.Nil(t, ApplyDefaultOutput(cmd))
+	assert.Equal(t, "yaml", bite.GetOutPutFlag(cmd))
+
+	// no default, the flag's default.
+	Manager.Config.GetCurrent().DefaultOutput = ""
+	cmd = newCmd()
+	assert.Nil(t, ApplyDefaultOutput(cmd))
+	assert.Equal(t, "table", bite.GetOutPutFlag(cmd))
+}
+
+func TestTimeoutFlagOverridesContext(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lenses-cli-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := api.Config{
+		CurrentContext: "master",
+		Contexts: map[string]*api.ClientConfig{
+			"master": {Host: "http://domain.com", Token: "secret", Timeout: "30s"},
+		},
+	}
+	b, err := api.ConfigMarshalYAML(cfg)
+	assert.Nil(t, err)
+	configFile := filepath.Join(dir, "lenses-cli.yml")
+	assert.Nil(t, ioutil.WriteFile(configFile, b, 0600))
+
+	run := func(args ...string) error {
+		root := &cobra.Command{Use: "lenses-cli"}
+		SetupConfigManager(root.PersistentFlags())
+		root.AddCommand(&cobra.Command{
+			Use: "test",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if _, err := Manager.Load(); err != nil {
+					return err
+				}
+				return SetupClient()
+			},
+		})
+		root.SetArgs(append([]string{"test", "--config", configFile}, args...))
+		root.SilenceUsage, root.SilenceErrors = true, true
+		return root.Execute()
+	}
+	defer func() { Manager, Client = nil, nil }()
+
+	assert.Nil(t, run("--timeout", "7s"))
+	assert.Equal(t, 7*time.Second, Client.Timeout())
+
+	// the context's timeout when the flag is not set.
+	assert.Nil(t, run())
+	assert.Equal(t, 30*time.Second, Client.Timeout())
+
+	err = run("--timeout", "7 seconds")
+	assert.EqualError(t, err, "invalid --timeout [7 seconds], expected a duration, i.e 30s, 1m or 1m30s")
+}
+
+func TestContextAndHostFlagsAreNotSaved(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lenses-cli-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := api.Config{
+		CurrentContext: "master",
+		Contexts: map[string]*api.ClientConfig{
+			"master": {Host: "http://master.com:80", Token: "secret"},
+			"other":  {Host: "http://other.com:80", Token: "secret"},
+		},
+	}
+	b, err := api.ConfigMarshalYAML(cfg)
+	assert.Nil(t, err)
+	configFile := filepath.Join(dir, "lenses-cli.yml")
+	assert.Nil(t, ioutil.WriteFile(configFile, b, 0600))
+
+	run := func(fn func() error, args ...string) error {
+		root := &cobra.Command{Use: "lenses-cli"}
+		SetupConfigManager(root.PersistentFlags())
+		root.AddCommand(&cobra.Command{
+			Use: "test",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if _, err := Manager.Load(); err != nil {
+					return err
+				}
+				return fn()
+			},
+		})
+		root.SetArgs(append([]string{"test", "--config", configFile}, args...))
+		root.SilenceUsage, root.SilenceErrors = true, true
+		return root.Execute()
+	}
+	defer func() { Manager = nil }()
+
+	readFile := func() api.Config {
+		var saved api.Config
+		assert.Nil(t, api.TryReadConfigFromFile(configFile, &saved))
+		return saved
+	}
+
+	err = run(func() error {
+		assert.Equal(t, "other", Manager.Config.CurrentContext)
+		assert.Equal(t, "http://override.com:80", Manager.Config.GetCurrent().Host)
+
+		// i.e a renewed token is saved.
+		Manager.Config.GetCurrent().Token = "renewed"
+		return Manager.Save()
+	}, "--context", "other", "--host", "http://override.com")
+	assert.Nil(t, err)
+
+	saved := readFile()
+	assert.Equal(t, "master", saved.CurrentContext)
+	assert.Equal(t, "http://other.com:80", saved.Contexts["other"].Host)
+	assert.Equal(t, "renewed", saved.Contexts["other"].Token)
+
+	// the next run uses the saved current context.
+	err = run(func() error {
+		assert.Equal(t, "master", Manager.Config.CurrentContext)
+		assert.Equal(t, "http://master.com:80", Manager.Config.GetCurrent().Host)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	// a context switch on purpose is saved.
+	err = run(func() error {
+		Manager.Config.SetCurrent("other")
+		return Manager.Save()
+	}, "--host", "http://override.com")
+	assert.Nil(t, err)
+
+	saved = readFile()
+	assert.Equal(t, "other", saved.CurrentContext)
+	assert.Equal(t, "http://master.com:80", saved.Contexts["master"].Host)
+
+	err = run(func() error { return nil }, "--context", "missing")
+	assert.EqualError(t, err, "unknown context [missing] given, please use the `configure --context=missing --reset`")
+}
+
+func TestExplicitConfigFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lenses-cli-config")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeConfig := func(filename, host string) string {
+		b, err := api.ConfigMarshalYAML(api.Config{
+			CurrentContext: "master",
+			Contexts:       map[string]*api.ClientConfig{"master": {Host: host, Token: "secret"}},
+		})
+		assert.Nil(t, err)
+
+		configFile := filepath.Join(dir, filename)
+		assert.Nil(t, ioutil.WriteFile(configFile, b, 0600))
+		return configFile
+	}
+
+	// the discovered one, on the current directory.
+	writeConfig("lenses-cli.yml", "http://discovered.com")
+	explicit := writeConfig("explicit.yml", "http://explicit.com")
+	env := writeConfig("env.yml", "http://env.com")
+
+	wd, err := os.Getwd()
+	assert.Nil(t, err)
+	assert.Nil(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	load := func(args ...string) (string, error) {
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		Manager = NewConfigurationManager(flags)
+		defer func() { Manager = nil }()
+		assert.Nil(t, flags.Parse(args))
+
+		if _, err := Manager.Load(); err != nil {
+			return "", err
+		}
+
+		return Manager.Config.GetCurrent().Host, nil
+	}
+
+	host, err := load()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://discovered.com:80", host)
+
+	host, err = load("--config=" + explicit)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://explicit.com:80", host)
+
+	host, err = load("--config-file=" + explicit)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://explicit.com:80", host)
+
+	os.Setenv(configFileEnvKey, env)
+	defer os.Unsetenv(configFileEnvKey)
+
+	host, err = load()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://env.com:80", host)
+
+	// the flag has priority.
+	host, err = load("--config=" + explicit)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://explicit.com:80", host)
+
+	// never fallback to the discovered one.
+	missing := filepath.Join(dir, "missing.yml")
+	_, err = load("--config=" + missing)
+	assert.EqualError(t, err, "configuration file ["+missing+"] of the --config flag not found")
+
+	os.Setenv(configFileEnvKey, missing)
+	_, err = load()
+	assert.EqualError(t, err, "configuration file ["+missing+"] of the LENSES_CONFIG environment variable not found")
+
+	malformed := filepath.Join(dir, "malformed.yml")
+	assert.Nil(t, ioutil.WriteFile(malformed, []byte("contexts: [\n"), 0600))
+	_, err = load("--config=" + malformed)
+	assert.NotNil(t, err)
+}
+
+func TestMakeAuthentication(t *testing.T) {
+	tests := []struct {
+		name                                    string
+		user, pass, conf, keytab, ccache, ccEnv string
+		expected                                api.Authentication
+		expectedOK                              bool
+	}{
+		{name: "basic", user: "user", pass: "pass", expected: api.BasicAuthentication{Username: "user", Password: "pass"}, expectedOK: true},
+		{name: "basic with KRB5CCNAME", user: "user", pass: "pass", ccEnv: "FILE:/tmp/krb5cc",
+			expected: api.BasicAuthentication{Username: "user", Password: "pass"}, expectedOK: true},
+		{name: "kerberos password", user: "user", pass: "pass", conf: "krb5.conf",
+			expected: api.KerberosAuthentication{ConfFile: "krb5.conf", Method: api.KerberosWithPassword{Username: "user", Password: "pass"}}, expectedOK: true},
+		{name: "keytab without conf", user: "svc", keytab: "svc.keytab",
+			expected: api.KerberosAuthentication{Method: api.KerberosWithKeytab{Username: "svc", KeytabFile: "svc.keytab"}}, expectedOK: true},
+		{name: "ccache without conf", ccache: "/tmp/krb5cc",
+			expected: api.KerberosAuthentication{Method: api.KerberosFromCCache{CCacheFile: "/tmp/krb5cc"}}, expectedOK: true},
+		{name: "KRB5CCNAME", ccEnv: "FILE:/tmp/krb5cc",
+			expected: api.KerberosAuthentication{Method: api.KerberosFromCCache{}}, expectedOK: true},
+		{name: "none"},
+		{name: "conf only", conf: "krb5.conf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer setEnv(t, api.KerberosCCacheEnv, tt.ccEnv)()
+
+			auth, ok := MakeAuthentication(tt.user, tt.pass, tt.conf, "", tt.keytab, tt.ccache)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expected, auth)
+		})
+	}
+}
+
+func setEnv(t *testing.T, key, value string) func() {
+	prev, had := os.LookupEnv(key)
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+
+	return func() {
+		if had {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestSetupClientCompressesLargeRequests(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+	}))
+	defer server.Close()
+
+	Manager = NewEmptyConfigManager()
+	defer func() { Manager, Client = nil, nil }()
+	Manager.Config.AddContext("master", &api.ClientConfig{Host: server.URL, Token: "secret"})
+	Manager.Config.SetCurrent("master")
+
+	assert.Nil(t, SetupClient())
+
+	large := make([]byte, api.DefaultRequestCompressionMinSize)
+	for _, send := range [][]byte{[]byte(`{}`), large} {
+		resp, err := Client.Do(http.MethodPost, "api/v1/import", "application/json", send)
+		if assert.Nil(t, err) {
+			resp.Body.Close()
+		}
+	}
+
+	assert.Equal(t, []string{"", "gzip"}, encodings)
+}
+
+func TestLoadMergedDecryptsPasswords(t *testing.T) {
+	dir, err := ioutil.TempDir("", "lenses-config-merge")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	host := "https://shared.lenses.io:443"
+	encrypted, err := utils.EncryptString("shared-pass", host)
+	assert.Nil(t, err)
+
+	base := filepath.Join(dir, "base.yml")
+	b, err := api.ConfigMarshalYAML(api.Config{
+		CurrentContext: "master",
+		Contexts: map[string]*api.ClientConfig{
+			"master": {Host: host, Authentication: api.BasicAuthentication{Username: "shared", Password: encrypted}},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(base, b, 0600))
+
+	user := filepath.Join(dir, "user.yml")
+	assert.Nil(t, ioutil.WriteFile(user, []byte("Contexts:\n  master:\n    Timeout: 1m\n"), 0600))
+
+	c, err := LoadMerged(base, user)
+	assert.Nil(t, err)
+	assert.Equal(t, "1m", c.Contexts["master"].Timeout)
+	assert.Equal(t, api.BasicAuthentication{Username: "shared", Password: "shared-pass"}, c.Contexts["master"].Authentication)
+}
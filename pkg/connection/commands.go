@@ -1,12 +1,15 @@
 package connection
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/conntemplate"
+	"github.com/landoop/lenses-go/pkg/utils"
 	cobra "github.com/spf13/cobra"
 )
 
@@ -29,11 +32,11 @@ connections
 			}
 
 			outputFlagValue := strings.ToUpper(bite.GetOutPutFlag(cmd))
-			if outputFlagValue != "JSON" && outputFlagValue != "YAML" {
+			if outputFlagValue != "JSON" && outputFlagValue != "YAML" && outputFlagValue != utils.NDJSON && !utils.HasQuery(cmd) {
 				bite.PrintInfo(cmd, "Info: use JSON or YAML output to get the complete object\n\n")
 			}
 
-			return bite.PrintObject(cmd, connections)
+			return utils.PrintObject(cmd, connections)
 		},
 	}
 
@@ -41,8 +44,11 @@ connections
 	cmd.AddCommand(NewConnectionCreateCommand())
 	cmd.AddCommand(NewConnectionDeleteCommand())
 	cmd.AddCommand(NewConnectionUpdateCommand())
+	cmd.AddCommand(NewConnectionStatusCommand())
+	cmd.AddCommand(NewConnectionTemplatesCommand())
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+	utils.CanWatch(cmd)
 
 	return cmd
 }
@@ -56,6 +62,7 @@ func NewConnectionGetCommand() *cobra.Command {
 		Short: `Get Lenses connections`,
 		Example: `
 connections get --name connection-name
+connections get --name kafka --query "configuration[?key=='kafkaBootstrapServers'].value | [0]"
 		`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
@@ -67,18 +74,68 @@ connections get --name connection-name
 			}
 
 			outputFlagValue := strings.ToUpper(bite.GetOutPutFlag(cmd))
-			if outputFlagValue != "JSON" && outputFlagValue != "YAML" {
+			if outputFlagValue != "JSON" && outputFlagValue != "YAML" && outputFlagValue != utils.NDJSON && !utils.HasQuery(cmd) {
 				bite.PrintInfo(cmd, "Info: use JSON or YAML output to get the complete object\n\n")
 			}
 
-			return bite.PrintObject(cmd, connection)
+			return utils.PrintObject(cmd, connection)
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "connection name")
 	cmd.MarkFlagRequired("name")
+	cmd.RegisterFlagCompletionFunc("name", completeConnectionNames)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// NewConnectionStatusCommand creates `connections status` command
+func NewConnectionStatusCommand() *cobra.Command {
+	var names []string
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: `Print the health of Lenses connections`,
+		Example: `
+connections status
+connections status --name connection1 --name connection2
+		`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(names) == 0 {
+				connections, err := config.Client.GetConnections()
+				if err != nil {
+					golog.Errorf("Failed to retrieve connections. [%s]", err.Error())
+					return err
+				}
+
+				for _, connection := range connections {
+					names = append(names, connection.Name)
+				}
+			}
+
+			statuses := make([]api.ConnectionStatus, 0, len(names))
+			for _, name := range names {
+				status, err := config.Client.GetConnectionStatus(name)
+				if err != nil {
+					golog.Errorf("Failed to retrieve the status of connection [%s]. [%s]", name, err.Error())
+					return err
+				}
+
+				statuses = append(statuses, status)
+			}
+
+			return utils.PrintObject(cmd, statuses)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&names, "name", nil, "connection name, can be defined multiple times, defaults to all the connections")
+	cmd.RegisterFlagCompletionFunc("name", completeConnectionNames)
+
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -86,7 +143,7 @@ connections get --name connection-name
 // NewConnectionCreateCommand creates `connections create` group command
 func NewConnectionCreateCommand() *cobra.Command {
 	var name, connectionConfig, templateName string
-	var tags []string
+	var tags, properties []string
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -96,10 +153,40 @@ connections create --name connection1 \
                    --tag t1 \
                    --template-name Cassandra \
                    --connection-config '[{"key":"port","value":["9042"]},{"key":"contact-points","value":["cassandra-host"]},{"key":"ssl-client-cert-auth","value":true}]'
+
+connections create --name connection1 \
+                   --template-name Cassandra \
+                   --property port=9042 \
+                   --property contact-points=cassandra-host \
+                   --property ssl-client-cert-auth=true
                 `,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if (connectionConfig == "") == (len(properties) == 0) {
+				return fmt.Errorf("one of --connection-config or --property is required")
+			}
+
+			if len(properties) > 0 {
+				template, err := config.Client.GetConnectionTemplate(templateName)
+				if err != nil {
+					golog.Errorf("Failed to retrieve the connection template [%s]. [%s]", templateName, err.Error())
+					return err
+				}
+
+				props, err := parseProperties(template, properties)
+				if err != nil {
+					return err
+				}
+
+				if err = config.Client.CreateConnectionFromTemplate(name, templateName, props, tags); err != nil {
+					golog.Errorf("Failed to create Lenses connection. [%s]", err.Error())
+					return err
+				}
+
+				return bite.PrintInfo(cmd, "Lenses connection has been successfully created.")
+			}
+
 			if err := config.Client.CreateConnection(name, templateName, connectionConfig, []api.ConnectionConfig{}, tags); err != nil {
 				golog.Errorf("Failed to create Lenses connection. [%s]", err.Error())
 				return err
@@ -112,16 +199,58 @@ connections create --name connection1 \
 	cmd.Flags().StringVar(&name, "name", "", "Name of the connection")
 	cmd.Flags().StringVar(&templateName, "template-name", "", "Template connection name")
 	cmd.Flags().StringVar(&connectionConfig, "connection-config", "", "configuration keys and values as json. Example: [{\"key\":\"port\",\"value\":[\"9042\"]}]")
+	cmd.Flags().StringArrayVar(&properties, "property", nil, "property of the template as key=value, can be defined multiple times, "+
+		"the values are validated against the template, the lists are comma separated, see `connections templates`")
 	cmd.Flags().StringArrayVar(&tags, "tag", []string{}, "tag assigned to the connection, can be defined multiple times")
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("template-name")
-	cmd.MarkFlagRequired("connection-config")
+	cmd.RegisterFlagCompletionFunc("template-name", conntemplate.CompleteNames)
 	// Required for bite to send standard output to cmd execution buffer
 	_ = bite.CanBeSilent(cmd)
 
 	return cmd
 }
 
+// parseProperties parses the key=value "properties" of the --property flags based on the types of the "template".
+func parseProperties(template api.ConnectionTemplate, properties []string) (map[string]interface{}, error) {
+	props := make(map[string]interface{}, len(properties))
+	for _, property := range properties {
+		idx := strings.IndexByte(property, '=')
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid --property [%s], expected key=value", property)
+		}
+
+		key, value := property[:idx], property[idx+1:]
+		cfg, ok := template.Property(key)
+		if !ok {
+			// reported along with the rest by the validation of the template.
+			props[key] = value
+			continue
+		}
+
+		v, err := cfg.ParseValue(value)
+		if err != nil {
+			return nil, err
+		}
+		props[key] = v
+	}
+
+	return props, nil
+}
+
+// NewConnectionTemplatesCommand creates `connections templates` command,
+// it's the `connection-templates` command under the connections.
+func NewConnectionTemplatesCommand() *cobra.Command {
+	cmd := conntemplate.NewConnectionTemplateGroupCommand()
+	cmd.Use = "templates"
+	cmd.Example = `
+connections templates
+connections templates --name Kafka
+		`
+
+	return cmd
+}
+
 // NewConnectionUpdateCommand creates `connections update` group command
 func NewConnectionUpdateCommand() *cobra.Command {
 	var name, newName, connectionConfig string
@@ -152,6 +281,7 @@ connections update --name connection1 \
 	cmd.Flags().StringArrayVar(&tags, "tag", []string{}, "tag assigned to the connection, can be defined multiple times")
 	cmd.MarkFlagRequired("name")
 	cmd.MarkFlagRequired("connection-config")
+	cmd.RegisterFlagCompletionFunc("name", completeConnectionNames)
 	// Required for bite to send standard output to cmd execution buffer
 	_ = bite.CanBeSilent(cmd)
 
@@ -182,9 +312,25 @@ connections delete --name connection-name
 
 	cmd.Flags().StringVar(&name, "name", "", "connection name")
 	cmd.MarkFlagRequired("name")
+	cmd.RegisterFlagCompletionFunc("name", completeConnectionNames)
 
 	// Required for bite to send standard output to cmd execution buffer
 	_ = bite.CanBeSilent(cmd)
 
 	return cmd
 }
+
+// completeConnectionNames suggests the live connection names for the `--name` flag.
+var completeConnectionNames = config.CompleteResourceNames("connections", func(client *api.Client) ([]string, error) {
+	connections, err := client.GetConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(connections))
+	for _, connection := range connections {
+		names = append(names, connection.Name)
+	}
+
+	return names, nil
+})
@@ -1,13 +1,20 @@
 package connection
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/landoop/lenses-go/test"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -125,6 +132,42 @@ func TestConnectionGetCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+func TestConnectionGetCommandQuery(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"name": "kafka", "templateName": "Kafka", "configuration": [
+			{"key": "kafkaBootstrapServers", "value": ["PLAINTEXT://broker:9092"]},
+			{"key": "protocol", "value": "PLAINTEXT"}]}`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	// just the value, without the info of the table output.
+	cmd := NewConnectionGetCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "table", "")
+	output, err := test.ExecuteCommand(cmd, "--name=kafka", "--query=configuration[?key=='protocol'].value | [0]")
+	assert.Nil(t, err)
+	assert.Equal(t, "PLAINTEXT\n", output)
+
+	cmd = NewConnectionGetCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err = test.ExecuteCommand(cmd, "--name=kafka", "--pretty=false", "--query=configuration[].key")
+	assert.Nil(t, err)
+	assert.Equal(t, `["kafkaBootstrapServers","protocol"]`+"\n", output)
+
+	cmd = NewConnectionGetCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	_, err = test.ExecuteCommand(cmd, "--name=kafka", "--query=configuration[?key=='protocol'")
+	assert.EqualError(t, err, "invalid --query, SyntaxError: Expected tRbracket, received: tEOF at:\nconfiguration[?key=='protocol'\n                              ^")
+	// the --query is shared by the commands, reset it.
+	cmd.Flags().Set("query", "")
+}
+
 func TestConnectionCreateCommandSuccess(t *testing.T) {
 	// setup http request handler
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -155,6 +198,84 @@ func TestConnectionCreateCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+const connectionTemplatesResponse = `[{
+	"name": "Slack",
+	"version": "1",
+	"configuration": [
+		{"key": "webhookUrl", "required": true, "type": {"name": "Secret"}},
+		{"key": "channels", "required": false, "type": {"name": "List"}}
+	]
+}]`
+
+func TestConnectionCreateCommandProperties(t *testing.T) {
+	var created api.CreateConnectionPayload
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connection-templates":
+			w.Write([]byte(connectionTemplatesResponse))
+		case "/api/v1/connection/connections":
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&created))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	_, err = test.ExecuteCommand(NewConnectionCreateCommand(), "--name=slack", "--template-name=Slack", "--property=channels=alerts")
+	assert.EqualError(t, err, "missing required properties of the connection template [Slack]: webhookUrl")
+	assert.Empty(t, created.Name)
+
+	_, err = test.ExecuteCommand(NewConnectionCreateCommand(), "--name=slack", "--template-name=Slack",
+		"--property=webhookUrl=https://hooks.slack.com/", "--property=color=red")
+	assert.EqualError(t, err, "unknown properties of the connection template [Slack]: color")
+
+	output, err := test.ExecuteCommand(NewConnectionCreateCommand(), "--name=slack", "--template-name=Slack",
+		"--property=webhookUrl=https://hooks.slack.com/", "--property=channels=alerts, ops")
+	assert.Nil(t, err)
+	assert.Equal(t, "Lenses connection has been successfully created.\n", output)
+	assert.Equal(t, []api.ConnectionConfig{
+		{Key: "channels", Value: []interface{}{"alerts", "ops"}},
+		{Key: "webhookUrl", Value: "https://hooks.slack.com/"},
+	}, created.Configuration)
+
+	_, err = test.ExecuteCommand(NewConnectionCreateCommand(), "--name=slack", "--template-name=Slack")
+	assert.EqualError(t, err, "one of --connection-config or --property is required")
+}
+
+func TestConnectionTemplatesCommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(connectionTemplatesResponse))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	cmd := NewConnectionTemplatesCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd)
+	assert.Nil(t, err)
+
+	// the `connection-templates` command.
+	var templates []api.ConnectionTemplate
+	assert.Nil(t, json.Unmarshal([]byte(output), &templates))
+	if assert.Len(t, templates, 1) {
+		assert.Equal(t, "Slack", templates[0].Name)
+		assert.Equal(t, []string{"webhookUrl"}, templates[0].RequiredProperties())
+		assert.Equal(t, []string{"channels"}, templates[0].OptionalProperties())
+	}
+}
+
 func TestConnectionUpdateCommandSuccess(t *testing.T) {
 	// setup http request handler
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -207,3 +328,135 @@ func TestConnectionDeleteCommandSuccess(t *testing.T) {
 
 	config.Client = nil
 }
+
+func TestConnectionStatusCommandSuccess(t *testing.T) {
+	// setup http request handler
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			w.Write([]byte(connectionListResponse))
+		case "/api/v1/connection/connections/TestConn0/status":
+			w.Write([]byte(`{"state": "UP"}`))
+		case "/api/v1/connection/connections/TestConn1/status":
+			w.Write([]byte(`{"state": "DOWN", "error": "connection refused"}`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	// setup http client
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	// test `connections status` command
+	cmd := NewConnectionStatusCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd)
+
+	assert.Nil(t, err)
+
+	var statuses []api.ConnectionStatus
+	assert.Nil(t, json.Unmarshal([]byte(output), &statuses))
+	assert.Equal(t, []api.ConnectionStatus{
+		{Name: "TestConn0", State: "UP"},
+		{Name: "TestConn1", State: "DOWN", Error: "connection refused"},
+	}, statuses)
+
+	// only the given connections.
+	cmd = NewConnectionStatusCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err = test.ExecuteCommand(cmd, "--name", "TestConn1")
+
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal([]byte(output), &statuses))
+	assert.Equal(t, []api.ConnectionStatus{{Name: "TestConn1", State: "DOWN", Error: "connection refused"}}, statuses)
+}
+
+func TestConnectionNameCompletion(t *testing.T) {
+	// setup http request handler
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(connectionListResponse))
+	})
+	// setup http client
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	cacheDir, err := ioutil.TempDir("", "lenses-cli-completion")
+	assert.Nil(t, err)
+	defer os.RemoveAll(cacheDir)
+	utils.CompletionCacheDir = cacheDir
+
+	cmd := NewConnectionGetCommand()
+
+	// no client, no suggestions.
+	names, directive := completeConnectionNames(cmd, nil, "")
+	assert.Empty(t, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	names, directive = completeConnectionNames(cmd, nil, "TestConn")
+	assert.Equal(t, []string{"TestConn0", "TestConn1"}, names)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	names, _ = completeConnectionNames(cmd, nil, "TestConn1")
+	assert.Equal(t, []string{"TestConn1"}, names)
+
+	config.Client = nil
+}
+
+func TestConnectionGroupCommandWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// setup http request handler, every refresh returns a different connection
+	// and the watch is stopped after the second one.
+	requests := 0
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 2 {
+			cancel()
+		}
+		fmt.Fprintf(w, `[{"name": "TestConn%d", "templateName": "Slack"}]`, requests)
+	})
+	// setup http client
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewConnectionGroupCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+
+	buf := new(bytes.Buffer)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.SetArgs([]string{"--watch", "--interval=10ms"})
+
+	err = cmd.ExecuteContext(ctx)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+	// output is not a terminal, frames are appended.
+	assert.Contains(t, buf.String(), "TestConn1")
+	assert.Contains(t, buf.String(), "TestConn2")
+	assert.NotContains(t, buf.String(), "\033[2J")
+
+	config.Client = nil
+}
@@ -5,12 +5,14 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +20,7 @@ import (
 func NewConnectorsCommand() *cobra.Command {
 	var (
 		clusterName string
+		filter      string
 
 		namesOnly bool // if true then print only the connector names and not the details as json.
 		unwrap    bool // if true and namesOnly is true then print just the connectors names as a list of strings.
@@ -57,10 +60,10 @@ func NewConnectorsCommand() *cobra.Command {
 						return nil
 					}
 
-					return bite.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", names))
+					return utils.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", names))
 				}
 
-				return bite.PrintObject(cmd, connectorsInfo)
+				return utils.PrintObject(cmd, connectorsInfo)
 			}
 
 			connectorNames := make(map[string][]string) // clusterName:[] connectors names.
@@ -73,14 +76,14 @@ func NewConnectorsCommand() *cobra.Command {
 					return err
 				}
 				for _, cluster := range clusters {
-					clusterConnectorsNames, err := config.Client.GetConnectors(cluster.Name)
+					clusterConnectorsNames, err := config.Client.FilterConnectors(cluster.Name, filter)
 					if err != nil {
 						return err
 					}
 					connectorNames[cluster.Name] = append(connectorNames[cluster.Name], clusterConnectorsNames...)
 				}
 			} else {
-				names, err := config.Client.GetConnectors(clusterName)
+				names, err := config.Client.FilterConnectors(clusterName, filter)
 				if err != nil {
 					golog.Errorf("Failed to find connectors in cluster [%s]. [%s]", clusterName, err.Error())
 					return err
@@ -105,7 +108,7 @@ func NewConnectorsCommand() *cobra.Command {
 				}
 
 				// return printJSON(cmd, outlineStringResults("name", names))
-				return bite.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", names))
+				return utils.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", names))
 			}
 
 			// if json output requested, create a json object which is the group of cluster:[]connectors and print as json.
@@ -126,16 +129,18 @@ func NewConnectorsCommand() *cobra.Command {
 				}
 			}
 
-			return bite.PrintObject(cmd, connectors)
+			return utils.PrintObject(cmd, connectors)
 		},
 	}
 
 	root.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
+	root.Flags().StringVar(&filter, "filter", "", `Select only the connectors whose name contains the filter`)
 	root.Flags().BoolVar(&namesOnly, "names", false, `Print connector names only`)
 	root.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap")
 	root.Flags().BoolVar(&showSupportedOnly, "supported", false, "List all the supported Kafka Connectors instead of the currently deployed")
 
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
+	utils.CanPage(root)
 
 	// plugins subcommand.
 	root.AddCommand(NewGetConnectorsPluginsCommand())
@@ -188,13 +193,13 @@ func NewGetConnectorsPluginsCommand() *cobra.Command {
 				}
 			}
 
-			return bite.PrintObject(cmd, plugins)
+			return utils.PrintObject(cmd, plugins)
 		},
 	}
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -239,13 +244,13 @@ func NewGetConnectorsClustersCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, clusters)
-			return bite.PrintObject(cmd, clusters)
+			return utils.PrintObject(cmd, clusters)
 		},
 	}
 
 	cmd.Flags().BoolVar(&namesOnly, "names", false, `Print connector names only`)
 	cmd.Flags().BoolVar(&noNewLine, "no-newline", false, "Remove line breakers between string output, if --names is passed")
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -271,11 +276,11 @@ func NewConnectorGroupCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, connector)
-			return bite.PrintObject(cmd, connector)
+			return utils.PrintObject(cmd, connector)
 		},
 	}
 
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
 
 	root.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 	root.Flags().StringVar(&name, "name", "", `Connector name`)
@@ -299,8 +304,10 @@ func NewConnectorGroupCommand() *cobra.Command {
 //NewConnectorCreateCommand creates the `connector create` command
 func NewConnectorCreateCommand() *cobra.Command {
 	var (
-		configRaw string
-		connector = api.CreateUpdateConnectorPayload{Config: make(api.ConnectorConfig)}
+		configRaw   string
+		connector   = api.CreateUpdateConnectorPayload{Config: make(api.ConnectorConfig)}
+		wait        bool
+		waitTimeout time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -332,6 +339,14 @@ func NewConnectorCreateCommand() *cobra.Command {
 				return err
 			}
 
+			if wait {
+				if _, err = config.Client.WaitForConnector(connector.ClusterName, connector.Name, waitTimeout); err != nil {
+					return err
+				}
+
+				return bite.PrintInfo(cmd, "Connector [%s] created and running", connector.Name)
+			}
+
 			return bite.PrintInfo(cmd, "Connector [%s] created", connector.Name)
 		},
 	}
@@ -339,6 +354,8 @@ func NewConnectorCreateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&connector.ClusterName, "cluster-name", "", `Connect cluster name`)
 	cmd.Flags().StringVar(&connector.Name, "name", "", `Connector name`)
 	cmd.Flags().StringVar(&configRaw, "configs", "", `Connector config .e.g."{\"key\": \"value\"}"`) // --config conflicts with the global flag.
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the connector and its tasks are running")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum time to wait for the connector when --wait is set")
 	bite.CanBeSilent(cmd)
 
 	bite.ShouldTryLoadFile(cmd, &connector)
@@ -399,7 +416,7 @@ func NewConnectorUpdateCommand() *cobra.Command {
 			//  why we print it back based on the --silent? Because of the connector.Tasks.
 			if !bite.ExpectsFeedback(cmd) {
 				bite.PrintInfo(cmd, "Connector [%s] updated\n\n", connector.Name)
-				return bite.PrintObject(cmd, updatedConnector)
+				return utils.PrintObject(cmd, updatedConnector)
 			}
 
 			return nil
@@ -411,7 +428,7 @@ func NewConnectorUpdateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&configRaw, "configs", "", `Connector configs .e.g. "{\"key\": \"value\"}"`)
 
 	bite.CanBeSilent(cmd)
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	bite.ShouldTryLoadFile(cmd, &connector)
 
@@ -440,14 +457,14 @@ func NewConnectorGetConfigCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, cfg)
-			return bite.PrintObject(cmd, cfg)
+			return utils.PrintObject(cmd, cfg)
 		},
 	}
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -474,14 +491,14 @@ func NewConnectorGetStatusCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, cs)
-			return bite.PrintObject(cmd, cs)
+			return utils.PrintObject(cmd, cs)
 		},
 	}
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name"`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -599,14 +616,14 @@ func NewConnectorGetTasksCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, tasksMap)
+			return utils.PrintObject(cmd, tasksMap)
 		},
 	}
 
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -651,7 +668,7 @@ func NewConnectorGetCurrentTaskStatusCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, cst)
-			return bite.PrintObject(cmd, cst)
+			return utils.PrintObject(cmd, cst)
 		},
 	}
 
@@ -661,7 +678,7 @@ func NewConnectorGetCurrentTaskStatusCommand() *cobra.Command {
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", `Connect cluster name`)
 	cmd.Flags().StringVar(&name, "name", "", `Connector name`)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -5,22 +5,36 @@ import (
 
 	"github.com/kataras/golog"
 	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	cobra "github.com/spf13/cobra"
 )
 
 // NewConnectionTemplateGroupCommand creates `connection-templates` command
 func NewConnectionTemplateGroupCommand() *cobra.Command {
+	var name string
 
 	cmd := &cobra.Command{
 		Use:   "connection-templates",
 		Short: `List the connection templates`,
 		Example: `
 connection-templates
+connection-templates --name Kafka
 		`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if name != "" {
+				template, err := config.Client.GetConnectionTemplate(name)
+				if err != nil {
+					golog.Errorf("Failed to retrieve the connection template [%s]. [%s]", name, err.Error())
+					return err
+				}
+
+				return utils.PrintObject(cmd, template.Config)
+			}
+
 			connectionTemplates, err := config.Client.GetConnectionTemplates()
 			if err != nil {
 				golog.Errorf("Failed to retrieve connection templates. [%s]", err.Error())
@@ -28,15 +42,33 @@ connection-templates
 			}
 
 			outputFlagValue := strings.ToUpper(bite.GetOutPutFlag(cmd))
-			if outputFlagValue != "JSON" && outputFlagValue != "YAML" {
+			if outputFlagValue != "JSON" && outputFlagValue != "YAML" && outputFlagValue != utils.NDJSON {
 				bite.PrintInfo(cmd, "Info: use JSON or YAML output to get the complete object\n\n")
 			}
 
-			return bite.PrintObject(cmd, connectionTemplates)
+			return utils.PrintObject(cmd, connectionTemplates)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	cmd.Flags().StringVar(&name, "name", "", "connection template name, prints its properties and whether they are required")
+	cmd.RegisterFlagCompletionFunc("name", CompleteNames)
+
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
+
+// CompleteNames suggests the connection template names, i.e for the `--template-name` of the `connections create`.
+var CompleteNames = config.CompleteResourceNames("connection-templates", func(client *api.Client) ([]string, error) {
+	templates, err := client.GetConnectionTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(templates))
+	for _, template := range templates {
+		names = append(names, template.Name)
+	}
+
+	return names, nil
+})
@@ -24,6 +24,7 @@ const (
 	SchemasPath       = "schemas"
 	AlertSettingsPath = "alert-settings"
 	PoliciesPath      = "policies"
+	AuditPath         = "audit"
 
 	ConnectionsFilePath        = "connections"
 	ConnectionsAPIPath         = "v1/connection/connections"
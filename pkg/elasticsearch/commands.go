@@ -1,8 +1,8 @@
 package elasticsearch
 
 import (
-	"github.com/landoop/bite"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -24,14 +24,14 @@ func IndexesCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, indexes)
+			return utils.PrintObject(cmd, indexes)
 		},
 	}
 
 	cmd.Flags().StringVar(&connectionName, "connection", "", "Connection to use")
 	cmd.Flags().BoolVar(&includeSystemIndexes, "include-system-indexes", false, "Show system indexes")
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	return cmd
 }
 
@@ -56,14 +56,14 @@ func IndexCommand() *cobra.Command {
 
 			indexview := MakeIndexView(index)
 
-			return bite.PrintObject(cmd, indexview)
+			return utils.PrintObject(cmd, indexview)
 		},
 	}
 
 	cmd.Flags().StringVar(&connectionName, "connection", "", "Connection to use")
 	cmd.Flags().StringVar(&indexName, "name", "", "Index to look for")
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
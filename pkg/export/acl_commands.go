@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,10 +21,14 @@ func NewExportAclsCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
-
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
 			if err := writeACLs(cmd, config.Client); err != nil {
-				golog.Errorf("Error writing ACLS. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing ACLS. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +36,9 @@ func NewExportAclsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "acls", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
@@ -51,5 +56,5 @@ func writeACLs(cmd *cobra.Command, client *api.Client) error {
 		return err
 	}
 
-	return utils.WriteFile(landscapeDir, pkg.AclsPath, fileName, output, acls)
+	return writeResource(pkg.AclsPath, "", fileName, output, acls)
 }
@@ -4,13 +4,11 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/alert"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -24,9 +22,14 @@ func NewExportAlertsCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
 			if err := writeAlertSetting(cmd, config.Client); err != nil {
-				golog.Errorf("Error writing alert-settings. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing alert-settings. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +37,9 @@ func NewExportAlertsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "alert settings", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
@@ -58,7 +64,7 @@ func writeAlertSettingsAsRequest(cmd *cobra.Command, settings alert.SettingCondi
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("alert-setting.%s", strings.ToLower(output))
 
-	return utils.WriteFile(landscapeDir, pkg.AlertSettingsPath, fileName, output, settings)
+	return writeResource(pkg.AlertSettingsPath, "", fileName, output, settings)
 }
 
 func getAlertSettings(cmd *cobra.Command, client *api.Client, topics []string) (alert.SettingConditionPayloads, error) {
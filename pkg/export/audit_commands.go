@@ -0,0 +1,211 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	auditFormatJSON = "json"
+	auditFormatCSV  = "csv"
+)
+
+const auditDateLayout = "2006-01-02"
+
+// auditTimeLayouts are the accepted layouts of the `export audit --from --to` flags.
+var auditTimeLayouts = []string{time.RFC3339, auditDateLayout}
+
+//NewExportAuditCommand creates `export audit`
+func NewExportAuditCommand() *cobra.Command {
+	var (
+		from, to, user, format string
+		types                  []string
+		pageSize               int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "export the audit entries of a time range as json or csv",
+		Example: `export audit --dir my-dir --from 2020-01-01 --to 2020-02-01
+export audit --dir my-dir --from 2020-01-01T10:00:00Z --user admin --type TOPIC --type ACL --format csv`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format = strings.ToLower(format)
+			if format != auditFormatJSON && format != auditFormatCSV {
+				return fmt.Errorf("unsupported format [%s], format must be json or csv", format)
+			}
+
+			opts := api.AuditOptions{
+				QueryFiltering: api.QueryFiltering{PageSize: pageSize},
+				User:           user,
+			}
+
+			var err error
+			if opts.From, err = parseAuditTime(from, false); err != nil {
+				return fmt.Errorf("invalid --from: %v", err)
+			}
+			if opts.To, err = parseAuditTime(to, true); err != nil {
+				return fmt.Errorf("invalid --to: %v", err)
+			}
+			if !opts.From.IsZero() && !opts.To.IsZero() && opts.To.Before(opts.From) {
+				return fmt.Errorf("--to [%s] is before --from [%s]", to, from)
+			}
+
+			for _, typ := range types {
+				opts.Types = append(opts.Types, api.AuditEntryType(strings.ToUpper(typ)))
+			}
+
+			path, written, err := exportAuditEntries(config.Client, opts, format)
+			if err != nil {
+				config.Client.Logger().Errorf("Error writing audit entries. [%s]", err.Error())
+				return err
+			}
+
+			return bite.PrintInfo(cmd, "%d audit entries exported to [%s]", written, path)
+		},
+	}
+
+	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	cmd.Flags().StringVar(&from, "from", "", "Export the entries created from this time, RFC3339 or YYYY-MM-DD")
+	cmd.Flags().StringVar(&to, "to", "", "Export the entries created up to this time, RFC3339 or YYYY-MM-DD which includes the whole day")
+	cmd.Flags().StringVar(&user, "user", "", "Export only the entries of this user")
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Export only the entries of this type, i.e TOPIC, can be repeated")
+	cmd.Flags().StringVar(&format, "format", auditFormatJSON, "The file format, json or csv")
+	cmd.Flags().IntVar(&pageSize, "page-size", api.DefaultPageSize, "The number of entries to fetch per request")
+	bite.CanBeSilent(cmd)
+	return cmd
+}
+
+// parseAuditTime parses the "value" of the --from or --to flag. A date of the --to ("endOfDay")
+// means the end of that day, the last millisecond before the next midnight, as the range is inclusive.
+func parseAuditTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range auditTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			if endOfDay && layout == auditDateLayout {
+				t = t.AddDate(0, 0, 1).Add(-time.Millisecond)
+			}
+
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("[%s] is not a RFC3339 time or a YYYY-MM-DD date", value)
+}
+
+// exportAuditEntries writes the audit entries to the "audit.<format>" file of the audit directory,
+// it returns the path of the file and the number of the written entries.
+func exportAuditEntries(client *api.Client, opts api.AuditOptions, format string) (string, int, error) {
+	dir := filepath.Join(landscapeDir, pkg.AuditPath)
+	if err := utils.CreateDirectory(dir); err != nil {
+		return "", 0, err
+	}
+
+	path := filepath.Join(dir, "audit."+format)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC|os.O_CREATE, 0666)
+	if err != nil {
+		return path, 0, err
+	}
+
+	written, err := writeAuditEntries(file, format, client, opts)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return path, written, err
+}
+
+// writeAuditEntries fetches the audit entries page by page and writes each page to the "w" as soon as it arrives,
+// the entries are never buffered in memory all together. The json format is an array of the entries,
+// the csv format has a header and the content of each entry as json.
+func writeAuditEntries(w io.Writer, format string, client *api.Client, opts api.AuditOptions) (int, error) {
+	var written int
+
+	if format == auditFormatCSV {
+		csvWriter := csv.NewWriter(w)
+		if err := csvWriter.Write([]string{"type", "change", "user", "timestamp", "content"}); err != nil {
+			return written, err
+		}
+
+		err := client.WalkAuditEntries(opts, func(entries []api.AuditEntry) error {
+			for _, entry := range entries {
+				content, err := json.Marshal(entry.Content)
+				if err != nil {
+					return err
+				}
+
+				record := []string{
+					string(entry.Type),
+					string(entry.Change),
+					entry.UserID,
+					strconv.FormatInt(entry.Timestamp, 10),
+					string(content),
+				}
+
+				if err = csvWriter.Write(record); err != nil {
+					return err
+				}
+				written++
+			}
+
+			csvWriter.Flush()
+			return csvWriter.Error()
+		})
+		if err != nil {
+			return written, err
+		}
+
+		csvWriter.Flush()
+		return written, csvWriter.Error()
+	}
+
+	if _, err := io.WriteString(w, "["); err != nil {
+		return written, err
+	}
+
+	err := client.WalkAuditEntries(opts, func(entries []api.AuditEntry) error {
+		for _, entry := range entries {
+			b, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+
+			if written > 0 {
+				if _, err = io.WriteString(w, ",\n"); err != nil {
+					return err
+				}
+			}
+
+			if _, err = w.Write(b); err != nil {
+				return err
+			}
+			written++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return written, err
+	}
+
+	_, err = io.WriteString(w, "]\n")
+	return written, err
+}
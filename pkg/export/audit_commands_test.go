@@ -0,0 +1,126 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+)
+
+const auditEntriesAmount = 5
+
+func newAuditEntriesHandler(t *testing.T, requestedPages *[]int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/audit", r.URL.Path)
+		assert.Equal(t, "1577836800000", r.URL.Query().Get("from"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		*requestedPages = append(*requestedPages, page)
+
+		start, end := (page-1)*pageSize, page*pageSize
+		if end > auditEntriesAmount {
+			end = auditEntriesAmount
+		}
+
+		var values []api.AuditEntry
+		for i := start; i < end; i++ {
+			values = append(values, api.AuditEntry{
+				Type:      api.AuditEntryTopic,
+				Change:    api.AuditEntryAdd,
+				UserID:    "admin",
+				Timestamp: int64(1577836800000 + i),
+				Content:   map[string]string{"topicName": fmt.Sprintf("topic-%d", i)},
+			})
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"pagesAmount": (auditEntriesAmount + pageSize - 1) / pageSize,
+			"totalCount":  auditEntriesAmount,
+			"values":      values,
+		})
+	}
+}
+
+func TestExportAuditCommandStreamsPagesToFile(t *testing.T) {
+	var requestedPages []int
+	httpClient, teardown := test.TestingHTTPClient(newAuditEntriesHandler(t, &requestedPages))
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-audit")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, format := range []string{"json", "csv"} {
+		requestedPages = nil
+
+		cmd := NewExportAuditCommand()
+		_, err = test.ExecuteCommand(cmd, "--dir", dir, "--from", "2020-01-01", "--page-size", "2", "--format", format, "--silent")
+		assert.Nil(t, err)
+		assert.Equal(t, []int{1, 2, 3}, requestedPages)
+
+		file, err := os.Open(filepath.Join(dir, "audit", "audit."+format))
+		if !assert.Nil(t, err) {
+			continue
+		}
+
+		if format == "json" {
+			var entries []api.AuditEntry
+			assert.Nil(t, json.NewDecoder(file).Decode(&entries))
+			assert.Len(t, entries, auditEntriesAmount)
+			assert.Equal(t, "topic-4", entries[4].Content["topicName"])
+		} else {
+			records, err := csv.NewReader(file).ReadAll()
+			assert.Nil(t, err)
+			assert.Len(t, records, auditEntriesAmount+1)
+			assert.Equal(t, []string{"type", "change", "user", "timestamp", "content"}, records[0])
+			assert.Equal(t, []string{"TOPIC", "ADD", "admin", "1577836800004", `{"topicName":"topic-4"}`}, records[5])
+		}
+
+		file.Close()
+	}
+}
+
+func TestExportAuditCommandInvalidFlags(t *testing.T) {
+	_, err := test.ExecuteCommand(NewExportAuditCommand(), "--format", "xml")
+	assert.NotNil(t, err)
+
+	_, err = test.ExecuteCommand(NewExportAuditCommand(), "--from", "yesterday")
+	assert.NotNil(t, err)
+
+	_, err = test.ExecuteCommand(NewExportAuditCommand(), "--from", "2020-02-01", "--to", "2020-01-01")
+	assert.NotNil(t, err)
+}
+
+func TestParseAuditTime(t *testing.T) {
+	from, err := parseAuditTime("2020-01-01", false)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC), from)
+
+	// the whole day of a date-only --to is included.
+	to, err := parseAuditTime("2020-01-01", true)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC).Add(-time.Millisecond), to)
+
+	to, err = parseAuditTime("2020-01-01T10:00:00Z", true)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC), to)
+}
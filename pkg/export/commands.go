@@ -3,14 +3,17 @@ package export
 import (
 	"fmt"
 	"os"
+	"path"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
 	"github.com/landoop/lenses-go/pkg/utils"
 
-	"github.com/kataras/golog"
 	"github.com/spf13/cobra"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/plumbing"
@@ -42,8 +45,24 @@ var systemTopicExclusions = []string{
 	"_lenses_",
 }
 
-var topicExclusions string
+var exclusions []string
+var redactSecrets bool
 var prefix string
+var layout string
+var withManifest bool
+
+// sortBy are the --sort-by fields of the lists written to a single file, i.e the acls, see `writeResource`.
+var sortBy []string
+
+// since is the time of the --since flag, the zero time exports all the resources, see `modifiedSince`.
+var since time.Time
+
+// checkpoint is the manifest of the --dir during an export with the --manifest or the --resume,
+// each written file is appended to its journal right away, so an interrupted export keeps its progress, see `writeResource`.
+var checkpoint *utils.Checkpoint
+
+// resume skips the files which a previous export already wrote to the --dir, see `isExported`.
+var resume bool
 
 //NewExportGroupCommand creates the `export` command
 func NewExportGroupCommand() *cobra.Command {
@@ -62,7 +81,12 @@ export policies --dir my-dir --resource-name my-policy
 export connections --dir my-dir
 export connections --dir my-dir --connection-id 1
 export groups --dir groups
-export serviceaccounts --dir serviceaccounts`,
+export serviceaccounts --dir serviceaccounts
+export topics --dir my-dir --layout '{type}/{name}'
+export topics --dir my-dir --manifest
+export connections --dir my-dir --resume
+export connections --dir my-dir --since 24h
+export audit --dir my-dir --from 2020-01-01 --to 2020-02-01 --format csv`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 	}
@@ -79,6 +103,7 @@ export serviceaccounts --dir serviceaccounts`,
 	cmd.AddCommand(NewExportConnectionsCommand())
 	cmd.AddCommand(NewExportGroupsCommand())
 	cmd.AddCommand(NewExportServiceAccountsCommand())
+	cmd.AddCommand(NewExportAuditCommand())
 
 	return cmd
 }
@@ -140,7 +165,6 @@ func createBranch(cmd *cobra.Command, branchName string) error {
 	dir, err := os.Getwd()
 
 	if err != nil {
-		golog.Fatal(err)
 		return err
 	}
 
@@ -183,7 +207,7 @@ func handleDependents(cmd *cobra.Command, client *api.Client, id string) error {
 	}
 
 	if len(topics) == 0 && dependents {
-		golog.Error(fmt.Sprintf("No topics found in the topology for processor [%s]", id))
+		client.Logger().Errorf("No topics found in the topology for processor [%s]", id)
 	}
 
 	// write topics
@@ -218,10 +242,11 @@ func handleDependents(cmd *cobra.Command, client *api.Client, id string) error {
 	}
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 	fileName := fmt.Sprintf("acls-%s.%s", "all", strings.ToLower(output))
-	return utils.WriteFile(landscapeDir, pkg.AclsPath, fileName, output, topicAcls)
+	return writeResource(pkg.AclsPath, "", fileName, output, topicAcls)
 }
 
-func checkFileFlags(cmd *cobra.Command) {
+// checkFileFlags sets the output format of the exported files, it returns an error if it's neither json nor yaml.
+func checkFileFlags(cmd *cobra.Command) error {
 
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
@@ -230,11 +255,274 @@ func checkFileFlags(cmd *cobra.Command) {
 	}
 
 	if output != "JSON" && output != "YAML" {
-		golog.Fatalf("Unsupported output format [%s]. Output type must be json or yaml for export", bite.GetOutPutFlag(cmd))
-		return
+		return fmt.Errorf("unsupported output format [%s], output type must be json or yaml for export", bite.GetOutPutFlag(cmd))
 	}
 
 	cmd.Flag(bite.GetOutPutFlagKey()).Value.Set(output)
+	sortBy = utils.GetSortBy(cmd)
+
+	return nil
+}
+
+// addExcludeFlag adds the --exclude flag of the "resource" names to skip, literal names or glob patterns, see `isExcluded`.
+func addExcludeFlag(cmd *cobra.Command, resource string) {
+	cmd.Flags().StringSliceVar(&exclusions, "exclude", nil,
+		fmt.Sprintf("The %s to exclude, comma separated names or glob patterns, i.e --exclude name1,name2 --exclude 'test-*'", resource))
+}
+
+// addLayoutFlag adds the --layout flag, the path template of the exported files, see `utils.ExportPath`.
+func addLayoutFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&layout, "layout", "",
+		"The path template of the exported files, relative to the --dir, i.e --layout '{type}/{name}'. "+
+			"The tokens are {type} and {name}, the file extension is added and the sub directories are created")
+}
+
+// checkLayout returns an error if the --layout is set and it's invalid.
+func checkLayout() error {
+	if layout == "" {
+		return nil
+	}
+
+	return utils.ValidateLayout(layout)
+}
+
+// writeResource writes the "resource" of the "resourceType" to the "fileName", or to the path of the --layout.
+// The lists of the "resource" are sorted first, by name, see `utils.Canonicalize`, and a list "resource" by the --sort-by fields,
+// so the exports to a git repository are diffed by their changes only.
+func writeResource(resourceType, name, fileName, output string, resource interface{}) error {
+	dir, file, err := utils.ExportPath(layout, resourceType, name, fileName)
+	if err != nil {
+		return err
+	}
+
+	if err = utils.Canonicalize(resource); err != nil {
+		return err
+	}
+
+	if err = utils.SortSlice(resource, sortBy); err != nil {
+		return err
+	}
+
+	if isExported(path.Join(dir, file)) {
+		return nil
+	}
+
+	if err := utils.WriteFile(landscapeDir, dir, file, output, resource); err != nil {
+		return err
+	}
+
+	if checkpoint == nil {
+		return nil
+	}
+
+	return checkpoint.Add(path.Join(dir, file))
+}
+
+// isExported reports whether the file at the "relPath" of the --dir should be skipped because of the --resume:
+// it's listed in the manifest and its size and checksum match, the missing, truncated or modified files are exported again.
+func isExported(relPath string) bool {
+	if !resume || checkpoint == nil {
+		return false
+	}
+
+	if _, ok := checkpoint.Manifest.Get(relPath); !ok {
+		return false
+	}
+
+	if err := checkpoint.Manifest.VerifyFile(landscapeDir, relPath); err != nil {
+		config.Client.Logger().Warnf("Exporting the file [%s] again: %v", relPath, err)
+		return false
+	}
+
+	config.Client.Logger().Debugf("Skipping the file [%s], it was already exported", relPath)
+	return true
+}
+
+// isResourceExported is like the `isExported` but for the "fileName" of the "resourceType", before the resource is fetched.
+func isResourceExported(resourceType, name, fileName string) bool {
+	dir, file, err := utils.ExportPath(layout, resourceType, name, fileName)
+	return err == nil && isExported(path.Join(dir, file))
+}
+
+// addManifestFlag adds the --manifest flag, the `utils.ManifestFileName` is written to the --dir during the export,
+// and the --resume flag which skips the files of a previous, interrupted, export. It must be called after the `RunE` of the "cmd" is set.
+func addManifestFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&withManifest, "manifest", false,
+		"Write the "+utils.ManifestFileName+" of the exported files, with their SHA-256 checksums, to the --dir. "+
+			"The entries of the previous exports to the same directory are kept")
+	cmd.Flags().BoolVar(&resume, "resume", false,
+		"Skip the files which a previous, interrupted, export wrote to the --dir, the ones of its "+utils.ManifestFileName+
+			" with a matching size and checksum. The rest are exported again. Implies --manifest")
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		checkpoint = nil
+		if withManifest || resume {
+			var err error
+			if checkpoint, err = utils.OpenCheckpoint(landscapeDir); err != nil {
+				return err
+			}
+		}
+
+		err := run(cmd, args)
+		if checkpoint == nil {
+			return err
+		}
+
+		// on failure, the manifest keeps the files written so far for the --resume.
+		if err != nil {
+			if commitErr := checkpoint.Commit(); commitErr != nil {
+				config.Client.Logger().Errorf("Unable to write the manifest of the files exported so far: %v", commitErr)
+			}
+			return err
+		}
+
+		return writeManifest()
+	}
+}
+
+// writeManifest completes the `checkpoint` of the --dir with the version of the server and the time of the export.
+func writeManifest() error {
+	checkpoint.Manifest.Timestamp = time.Now().UTC()
+	if version, err := config.Client.GetServerVersion(); err == nil {
+		checkpoint.Manifest.ServerVersion = version.Version
+	} else {
+		config.Client.Logger().Warnf("Unable to retrieve the server version for the manifest: %v", err)
+	}
+
+	config.Client.Logger().Debugf("Writing the manifest of [%d] files to [%s]", len(checkpoint.Manifest.Files), landscapeDir)
+	return checkpoint.Commit()
+}
+
+// addSinceFlag adds the --since flag, the resources which were not modified since that time are skipped.
+// The "modifiedTime" describes the time of the "resource" which is compared, for the help of the flag,
+// if it's empty then the "resource" has no modified time and all of them are exported with a warning.
+// It must be called after the `RunE` of the "cmd" is set.
+func addSinceFlag(cmd *cobra.Command, resource, modifiedTime string) {
+	usage := "Export only the resources modified since this time, RFC3339, YYYY-MM-DD or a duration ago, i.e 24h. "
+	if modifiedTime != "" {
+		usage += fmt.Sprintf("The %s are compared by %s", resource, modifiedTime)
+	} else {
+		usage += fmt.Sprintf("The %s have no modified time, they are all exported", resource)
+	}
+
+	var value string
+	cmd.Flags().StringVar(&value, "since", "", usage)
+
+	run := cmd.RunE
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		t, err := parseSince(value, time.Now())
+		if err != nil {
+			return fmt.Errorf("invalid --since: %v", err)
+		}
+
+		if !t.IsZero() && modifiedTime == "" {
+			config.Client.Logger().Warnf("The %s have no modified time, --since is ignored and all of them are exported", resource)
+			t = time.Time{}
+		}
+
+		since = t
+		return run(cmd, args)
+	}
+}
+
+// parseSince parses the "value" of the --since flag as a time, see `auditTimeLayouts`, or as a duration before "now".
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("[%s] is a negative duration", value)
+		}
+
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range auditTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("[%s] is not a RFC3339 time, a YYYY-MM-DD date or a duration", value)
+}
+
+// modifiedSince reports whether a resource, modified at the "modifiedAt" unix milliseconds, should be exported
+// because of the --since. The resources without a modified time, zero, are always exported.
+func modifiedSince(modifiedAt int64) bool {
+	if since.IsZero() || modifiedAt <= 0 {
+		return true
+	}
+
+	return !time.Unix(0, modifiedAt*int64(time.Millisecond)).Before(since)
+}
+
+// addRedactSecretsFlag adds the --redact-secrets flag, the secret values are replaced by `api.SecretPlaceholder`s.
+func addRedactSecretsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&redactSecrets, "redact-secrets", false,
+		"Replace the values of the secret fields and of the SET statements of the processors, i.e the passwords, keys and tokens, with ${VAR} placeholders. "+
+			"The files can't be imported as they are, the variables must be set on import")
+}
+
+// redactConfig returns a copy of the "config" of the "resource", i.e of a connector or the configs of a topic,
+// with the `api.IsSecretField` values replaced by placeholders.
+func redactConfig(resource string, config map[string]interface{}) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(config))
+	for key, value := range config {
+		if value != nil && value != "" && api.IsSecretField(key) {
+			value = api.SecretPlaceholder(resource, key)
+		}
+		redacted[key] = value
+	}
+
+	return redacted
+}
+
+// setStatementPattern matches the SET statements of the SQL of the processors, i.e "SET 'sasl.jaas.config'='...';",
+// the key is the second or the third group and the value is the fourth.
+var setStatementPattern = regexp.MustCompile(`(?i)(\bSET\s+(?:'([^']+)'|([\w.\-]+))\s*=\s*)('[^']*'|"[^"]*"|[^;\s]+)`)
+
+// redactSQL returns the "sql" of the "resource" with the values of its SET statements of the `api.IsSecretField` keys
+// replaced by placeholders.
+func redactSQL(resource, sql string) string {
+	return setStatementPattern.ReplaceAllStringFunc(sql, func(statement string) string {
+		match := setStatementPattern.FindStringSubmatch(statement)
+		key := match[2] + match[3]
+		if !api.IsSecretField(key) || match[4] == "''" || match[4] == `""` {
+			return statement
+		}
+
+		return match[1] + "'" + api.SecretPlaceholder(resource, key) + "'"
+	})
+}
+
+// checkExclusions returns an error if the --exclude is combined with the "nameFlag" of a single resource.
+func checkExclusions(cmd *cobra.Command, nameFlag string) error {
+	if len(exclusions) > 0 && cmd.Flags().Changed(nameFlag) {
+		return fmt.Errorf("--exclude and --%s can not be used together", nameFlag)
+	}
+
+	return nil
+}
+
+// isExcluded reports whether the "name" is equal to or matches the glob pattern of any of the "patterns".
+func isExcluded(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if pattern == name {
+			return true
+		}
+
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
 
-	return
+	return false
 }
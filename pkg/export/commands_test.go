@@ -0,0 +1,522 @@
+package export
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsExcluded(t *testing.T) {
+	patterns := []string{"orders", " payments ", "test-*", "tmp?"}
+
+	tests := []struct {
+		name     string
+		excluded bool
+	}{
+		{"orders", true},
+		{"payments", true},
+		{"orders-v2", false},
+		{"test-1", true},
+		{"test-", true},
+		{"a-test-1", false},
+		{"tmp1", true},
+		{"tmp12", false},
+		{"customers", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.excluded, isExcluded(tt.name, patterns), tt.name)
+	}
+
+	assert.False(t, isExcluded("orders", nil))
+	// an invalid pattern matches literally only.
+	assert.True(t, isExcluded("[orders", []string{"[orders"}))
+	assert.False(t, isExcluded("orders", []string{"[orders"}))
+}
+
+func TestExportConnectionsExclude(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch name := strings.TrimPrefix(r.URL.Path, "/api/v1/connection/connections"); name {
+		case "":
+			w.Write([]byte(`[{"name": "slack"}, {"name": "test-1"}, {"name": "test-2"}, {"name": "kafka"}]`))
+		default:
+			w.Write([]byte(`{"name": "` + strings.TrimPrefix(name, "/") + `"}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := NewExportConnectionsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--exclude", "kafka,test-*")
+	assert.Nil(t, err)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, pkg.ConnectionsFilePath))
+	assert.Nil(t, err)
+
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"connection-slack-slack.yaml"}, names)
+
+	cmd = NewExportConnectionsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--name", "slack", "--exclude", "kafka")
+	assert.EqualError(t, err, "--exclude and --name can not be used together")
+}
+
+func TestExportConnectionsUnsupportedOutput(t *testing.T) {
+	cmd := NewExportConnectionsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "csv", "")
+	_, err := test.ExecuteCommand(cmd, "--dir", os.TempDir())
+	assert.EqualError(t, err, "unsupported output format [csv], output type must be json or yaml for export")
+}
+
+func TestExportConnectionsLayout(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch name := strings.TrimPrefix(r.URL.Path, "/api/v1/connection/connections"); name {
+		case "":
+			w.Write([]byte(`[{"name": "slack"}, {"name": "kafka"}]`))
+		default:
+			w.Write([]byte(`{"name": "` + strings.TrimPrefix(name, "/") + `"}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := NewExportConnectionsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--layout", "{type}/{name}")
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(dir, "connections", "slack.yaml"))
+	assert.FileExists(t, filepath.Join(dir, "connections", "kafka.yaml"))
+
+	cmd = NewExportConnectionsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--name", "slack", "--layout", "by-name/{name}/{type}")
+	assert.Nil(t, err)
+	assert.FileExists(t, filepath.Join(dir, "by-name", "slack", "connections.json"))
+
+	cmd = NewExportConnectionsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--layout", "{kind}/{name}")
+	assert.EqualError(t, err, "unknown token {kind} in the layout [{kind}/{name}], expected {type} or {name}")
+}
+
+func TestExportConnectionsDeterministic(t *testing.T) {
+	responses := []string{
+		`{"name": "kafka", "configuration": [{"key": "protocol", "value": "SSL"}, {"key": "kafkaBootstrapServers", "value": ["PLAINTEXT://b:9092", "PLAINTEXT://a:9092"]}], "tags": ["prod", "eu"]}`,
+		`{"name": "kafka", "configuration": [{"key": "kafkaBootstrapServers", "value": ["PLAINTEXT://b:9092", "PLAINTEXT://a:9092"]}, {"key": "protocol", "value": "SSL"}], "tags": ["prod", "eu"]}`,
+	}
+	var calls int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			w.Write([]byte(`[{"name": "kafka"}]`))
+		case "/api/v1/connection/connections/kafka":
+			w.Write([]byte(responses[calls%len(responses)]))
+			calls++
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	for _, output := range []string{"yaml", "json"} {
+		var exports [][]byte
+		for range responses {
+			dir, err := ioutil.TempDir("", "export-connections")
+			assert.Nil(t, err)
+			defer os.RemoveAll(dir)
+
+			cmd := NewExportConnectionsCommand()
+			var outputValue string
+			cmd.PersistentFlags().StringVar(&outputValue, "output", output, "")
+			_, err = test.ExecuteCommand(cmd, "--dir", dir, "--layout", "{type}/{name}")
+			assert.Nil(t, err)
+
+			b, err := ioutil.ReadFile(filepath.Join(dir, "connections", "kafka."+output))
+			assert.Nil(t, err)
+			exports = append(exports, b)
+		}
+
+		assert.Equal(t, string(exports[0]), string(exports[1]), output)
+		// the configuration is sorted by key, the rest of the lists keep their order.
+		assert.True(t, strings.Index(string(exports[0]), "kafkaBootstrapServers") < strings.Index(string(exports[0]), "protocol"), output)
+		assert.True(t, strings.Index(string(exports[0]), "b:9092") < strings.Index(string(exports[0]), "a:9092"), output)
+	}
+}
+
+func TestExportConnectionsManifest(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			w.Write([]byte(`{"version": "4.0.0"}`))
+			return
+		}
+
+		switch name := strings.TrimPrefix(r.URL.Path, "/api/v1/connection/connections"); name {
+		case "":
+			w.Write([]byte(`[{"name": "slack"}, {"name": "kafka"}]`))
+		default:
+			w.Write([]byte(`{"name": "` + strings.TrimPrefix(name, "/") + `"}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := NewExportConnectionsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--manifest")
+	assert.Nil(t, err)
+
+	manifest, err := utils.ReadManifest(filepath.Join(dir, utils.ManifestFileName))
+	assert.Nil(t, err)
+	assert.Equal(t, "4.0.0", manifest.ServerVersion)
+	assert.False(t, manifest.Timestamp.IsZero())
+
+	var paths []string
+	for _, file := range manifest.Files {
+		paths = append(paths, file.Path)
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, file.Path))
+		assert.Nil(t, err)
+		assert.Equal(t, utils.Checksum(data), file.SHA256)
+		assert.Equal(t, int64(len(data)), file.Size)
+	}
+	assert.Equal(t, []string{"connections/connection-kafka-kafka.yaml", "connections/connection-slack-slack.yaml"}, paths)
+
+	// the entries of the previous exports are kept.
+	cmd = NewExportConnectionsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--name", "slack", "--manifest")
+	assert.Nil(t, err)
+
+	manifest, err = utils.ReadManifest(filepath.Join(dir, utils.ManifestFileName))
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Files, 3)
+	_, ok := manifest.Get("connections/connection-slack-slack.json")
+	assert.True(t, ok)
+}
+
+func TestExportConnectionsResume(t *testing.T) {
+	var (
+		fetched []string
+		failing = "kafka"
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/version" {
+			w.Write([]byte(`{"version": "4.0.0"}`))
+			return
+		}
+
+		switch name := strings.TrimPrefix(r.URL.Path, "/api/v1/connection/connections"); name {
+		case "":
+			w.Write([]byte(`[{"name": "slack"}, {"name": "kafka"}, {"name": "pagerduty"}]`))
+		default:
+			name = strings.TrimPrefix(name, "/")
+			fetched = append(fetched, name)
+			if name == failing {
+				w.WriteHeader(http.StatusBadGateway)
+				w.Write([]byte("connection reset"))
+				return
+			}
+			w.Write([]byte(`{"name": "` + name + `"}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	export := func(args ...string) error {
+		cmd := NewExportConnectionsCommand()
+		var outputValue string
+		cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+		_, err := test.ExecuteCommand(cmd, append([]string{"--dir", dir}, args...)...)
+		return err
+	}
+
+	// interrupted on the second connection, the first one is checkpointed.
+	assert.Error(t, export("--manifest"))
+	manifest, err := utils.ReadManifest(filepath.Join(dir, utils.ManifestFileName))
+	assert.Nil(t, err)
+	if assert.Len(t, manifest.Files, 1) {
+		assert.Equal(t, "connections/connection-slack-slack.yaml", manifest.Files[0].Path)
+	}
+
+	fetched, failing = nil, ""
+	assert.Nil(t, export("--resume"))
+	assert.Equal(t, []string{"kafka", "pagerduty"}, fetched)
+
+	manifest, err = utils.ReadManifest(filepath.Join(dir, utils.ManifestFileName))
+	assert.Nil(t, err)
+	assert.Equal(t, "4.0.0", manifest.ServerVersion)
+	assert.Len(t, manifest.Files, 3)
+
+	// a truncated file is fetched again.
+	file := filepath.Join(dir, pkg.ConnectionsFilePath, "connection-kafka-kafka.yaml")
+	data, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(file, data[:len(data)/2], 0644))
+
+	fetched = nil
+	assert.Nil(t, export("--resume"))
+	assert.Equal(t, []string{"kafka"}, fetched)
+
+	manifest, err = utils.ReadManifest(filepath.Join(dir, utils.ManifestFileName))
+	assert.Nil(t, err)
+	for _, f := range manifest.Files {
+		assert.Nil(t, manifest.VerifyFile(dir, f.Path))
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"", time.Time{}},
+		{"24h", now.Add(-24 * time.Hour)},
+		{"2020-03-01", time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"2020-03-01T10:30:00Z", time.Date(2020, 3, 1, 10, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.value, now)
+		assert.Nil(t, err, tt.value)
+		assert.True(t, tt.expected.Equal(got), "%s: expected %s but got %s", tt.value, tt.expected, got)
+	}
+
+	_, err := parseSince("yesterday", now)
+	assert.EqualError(t, err, "[yesterday] is not a RFC3339 time, a YYYY-MM-DD date or a duration")
+	_, err = parseSince("-1h", now)
+	assert.EqualError(t, err, "[-1h] is a negative duration")
+}
+
+func TestModifiedSince(t *testing.T) {
+	defer func() { since = time.Time{} }()
+
+	modified := time.Date(2020, 3, 10, 12, 0, 0, 0, time.UTC)
+	modifiedAt := modified.UnixNano() / int64(time.Millisecond)
+
+	since = time.Time{}
+	assert.True(t, modifiedSince(modifiedAt), "no --since")
+
+	since = modified.Add(-time.Hour)
+	assert.True(t, modifiedSince(modifiedAt))
+	assert.True(t, modifiedSince(0), "no modified time")
+
+	since = modified
+	assert.True(t, modifiedSince(modifiedAt), "modified at the --since")
+
+	since = modified.Add(time.Millisecond)
+	assert.False(t, modifiedSince(modifiedAt))
+}
+
+func TestExportConnectionsSince(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch name := strings.TrimPrefix(r.URL.Path, "/api/v1/connection/connections"); name {
+		case "":
+			w.Write([]byte(`[{"name": "old"}, {"name": "new"}, {"name": "unknown"}]`))
+		case "/old":
+			w.Write([]byte(`{"name": "old", "modifiedAt": 1577836800000}`)) // 2020-01-01
+		case "/new":
+			w.Write([]byte(`{"name": "new", "modifiedAt": 1583841600000}`)) // 2020-03-10
+		default:
+			w.Write([]byte(`{"name": "` + strings.TrimPrefix(name, "/") + `"}`))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+	defer func() { since = time.Time{} }()
+
+	dir, err := ioutil.TempDir("", "export-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := NewExportConnectionsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--since", "2020-02-01")
+	assert.Nil(t, err)
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, pkg.ConnectionsFilePath))
+	assert.Nil(t, err)
+
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"connection-new-new.yaml", "connection-unknown-unknown.yaml"}, names)
+
+	cmd = NewExportConnectionsCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--since", "last week")
+	assert.EqualError(t, err, "invalid --since: [last week] is not a RFC3339 time, a YYYY-MM-DD date or a duration")
+}
+
+func TestExportGroupsSinceUnsupported(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"name": "admins"}, {"name": "dev"}]`))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-groups")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := NewExportGroupsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--since", "1h")
+	assert.Nil(t, err)
+	assert.True(t, since.IsZero(), "--since should be ignored by the resources without a modified time")
+
+	files, err := ioutil.ReadDir(filepath.Join(dir, pkg.GroupsPath))
+	assert.Nil(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestExportConnectionsRedactSecrets(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			w.Write([]byte(`[{"name": "kafka"}]`))
+		case "/api/v1/connection/connections/kafka":
+			w.Write([]byte(`{"name": "kafka", "templateName": "Kafka", "configuration": [
+				{"key": "kafkaBootstrapServers", "value": ["PLAINTEXT://broker:9092"]},
+				{"key": "sslKeyPassword", "value": "p@ss"},
+				{"key": "sslKeystorePassword", "value": ""},
+				{"key": "saslJaasConfig", "value": "org.apache.kafka.common.security.plain.PlainLoginModule required;"}
+			]}`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "export-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := NewExportConnectionsCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	_, err = test.ExecuteCommand(cmd, "--dir", dir, "--redact-secrets")
+	assert.Nil(t, err)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, pkg.ConnectionsFilePath, "connection-kafka-kafka.json"))
+	assert.Nil(t, err)
+
+	var connection api.Connection
+	assert.Nil(t, json.Unmarshal(b, &connection))
+	assert.Equal(t, "kafka", connection.Name)
+	assert.Equal(t, "Kafka", connection.TemplateName)
+	// sorted by key, see `utils.Canonicalize`.
+	assert.Equal(t, []api.ConnectionConfig{
+		{Key: "kafkaBootstrapServers", Value: []interface{}{"PLAINTEXT://broker:9092"}},
+		{Key: "saslJaasConfig", Value: "${KAFKA_SASLJAASCONFIG}"},
+		{Key: "sslKeyPassword", Value: "${KAFKA_SSLKEYPASSWORD}"},
+		{Key: "sslKeystorePassword", Value: ""},
+	}, connection.Configuration)
+	assert.NotContains(t, string(b), "p@ss")
+	assert.NotContains(t, string(b), "PlainLoginModule")
+}
+
+func TestRedactSQL(t *testing.T) {
+	sql := "SET autocreate = true;\nSET 'sasl.jaas.config'='PlainLoginModule required password=\"p@ss\";';\nSET ssl.key.password=p@ss;\nSET 'token'='';\n" +
+		"INSERT INTO target SELECT STREAM * FROM source;"
+
+	assert.Equal(t, "SET autocreate = true;\nSET 'sasl.jaas.config'='${ORDERS_SASL_JAAS_CONFIG}';\nSET ssl.key.password='${ORDERS_SSL_KEY_PASSWORD}';\nSET 'token'='';\n"+
+		"INSERT INTO target SELECT STREAM * FROM source;", redactSQL("orders", sql))
+}
+
+func TestRedactConfig(t *testing.T) {
+	configs := api.KV{"retention.ms": "1000", "sasl.jaas.config": "PlainLoginModule required;", "ssl.key.password": ""}
+
+	assert.Equal(t, map[string]interface{}{
+		"retention.ms":     "1000",
+		"sasl.jaas.config": "${ORDERS_SASL_JAAS_CONFIG}",
+		"ssl.key.password": "",
+	}, redactConfig("orders", configs))
+	assert.Equal(t, "PlainLoginModule required;", configs["sasl.jaas.config"], "the config is copied")
+}
@@ -4,28 +4,39 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 // NewExportConnectionsCommand creates `export connections`
 func NewExportConnectionsCommand() *cobra.Command {
 	var connectionName string
+	var withStatus bool
 	cmd := &cobra.Command{
 		Use:   "connections",
 		Short: "export connections",
 		Example: `export connections
-export connections --name connection-name`,
+export connections --name connection-name
+export connections --with-status
+export connections --exclude connection1,'test-*'
+export connections --redact-secrets`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
-			if err := writeConnections(cmd, connectionName); err != nil {
-				golog.Errorf("Error while exporting connections. [%s]", err.Error())
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "name"); err != nil {
+				return err
+			}
+			if err := writeConnections(cmd, connectionName, withStatus); err != nil {
+				config.Client.Logger().Errorf("Error while exporting connections. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -33,15 +44,21 @@ export connections --name connection-name`,
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "connections", "their modified time")
 	cmd.Flags().StringVar(&connectionName, "name", "", "The name of the connection to extract")
+	addExcludeFlag(cmd, "connections")
+	addRedactSecretsFlag(cmd)
+	cmd.Flags().BoolVar(&withStatus, "with-status", false, "Embed the last-known status of the connections, it's ignored on import")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
 // writeConnections retrieves and writes one or all connections to a file
-func writeConnections(cmd *cobra.Command, connectionName string) error {
-	golog.Infof("Writing connections to [%s]", landscapeDir)
+func writeConnections(cmd *cobra.Command, connectionName string, withStatus bool) error {
+	config.Client.Logger().Infof("Writing connections to [%s]", landscapeDir)
 
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
@@ -51,8 +68,23 @@ func writeConnections(cmd *cobra.Command, connectionName string) error {
 			return err
 		}
 
+		if !modifiedSince(connection.ModifiedAt) {
+			config.Client.Logger().Infof("Connection [%s] was not modified since [%s]", connection.Name, since)
+			return nil
+		}
+
+		if withStatus {
+			if err = setConnectionStatus(&connection); err != nil {
+				return err
+			}
+		}
+
+		if redactSecrets {
+			redactConnection(&connection)
+		}
+
 		fileName := fmt.Sprintf("connection-%s-%s.%s", strings.ToLower(strings.ReplaceAll(connection.Name, " ", "_")), connection.Name, strings.ToLower(output))
-		return utils.WriteFile(landscapeDir, pkg.ConnectionsFilePath, fileName, output, connection)
+		return writeResource(pkg.ConnectionsFilePath, connection.Name, fileName, output, connection)
 	}
 
 	connections, err := config.Client.GetConnections()
@@ -61,13 +93,36 @@ func writeConnections(cmd *cobra.Command, connectionName string) error {
 	}
 
 	for _, connection := range connections {
+		if isExcluded(connection.Name, exclusions) {
+			continue
+		}
+
+		fileName := fmt.Sprintf("connection-%s-%s.%s", strings.ToLower(strings.ReplaceAll(connection.Name, " ", "_")), connection.Name, strings.ToLower(output))
+		if isResourceExported(pkg.ConnectionsFilePath, connection.Name, fileName) {
+			continue
+		}
+
 		connectionComplete, err := config.Client.GetConnection(connection.Name)
 		if err != nil {
 			return err
 		}
 
-		fileName := fmt.Sprintf("connection-%s-%s.%s", strings.ToLower(strings.ReplaceAll(connection.Name, " ", "_")), connection.Name, strings.ToLower(output))
-		err = utils.WriteFile(landscapeDir, pkg.ConnectionsFilePath, fileName, output, connectionComplete)
+		if !modifiedSince(connectionComplete.ModifiedAt) {
+			config.Client.Logger().Debugf("Skipping connection [%s], not modified since [%s]", connection.Name, since)
+			continue
+		}
+
+		if withStatus {
+			if err = setConnectionStatus(&connectionComplete); err != nil {
+				return err
+			}
+		}
+
+		if redactSecrets {
+			redactConnection(&connectionComplete)
+		}
+
+		err = writeResource(pkg.ConnectionsFilePath, connection.Name, fileName, output, connectionComplete)
 		if err != nil {
 			fmt.Printf("Could not write connection to file %s", fileName)
 		}
@@ -75,3 +130,25 @@ func writeConnections(cmd *cobra.Command, connectionName string) error {
 
 	return nil
 }
+
+// setConnectionStatus embeds the last-known status of the "connection", see `export connections --with-status`.
+func setConnectionStatus(connection *api.Connection) error {
+	status, err := config.Client.GetConnectionStatus(connection.Name)
+	if err != nil {
+		return err
+	}
+
+	connection.Status = &status
+	return nil
+}
+
+// redactConnection replaces the values of the secret fields of the "connection" with placeholders, see `--redact-secrets`.
+func redactConnection(connection *api.Connection) {
+	for i, config := range connection.Configuration {
+		if config.Value == nil || config.Value == "" || !api.IsSecretField(config.Key) {
+			continue
+		}
+
+		connection.Configuration[i].Value = api.SecretPlaceholder(connection.Name, config.Key)
+	}
+}
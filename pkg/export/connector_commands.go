@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,9 +24,17 @@ func NewExportConnectorsCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := config.Client
 			setExecutionMode(client)
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "resource-name"); err != nil {
+				return err
+			}
 			if err := writeConnectors(cmd, client, cluster, name); err != nil {
-				golog.Errorf("Error writing connectors. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing connectors. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -36,10 +42,16 @@ func NewExportConnectorsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "connectors", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
+	addWithDefaultsFlag(cmd)
 	cmd.Flags().StringVar(&name, "resource-name", "", "The resource name to export")
 	cmd.Flags().StringVar(&cluster, "cluster-name", "", "Select by cluster name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Connector with the prefix in the name only")
+	addExcludeFlag(cmd, "connectors")
+	addRedactSecretsFlag(cmd)
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
@@ -59,7 +71,7 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 
 		connectorNames, err := client.GetConnectors(cluster.Name)
 		if err != nil {
-			golog.Error(err)
+			client.Logger().Errorf("%v", err)
 			return err
 		}
 
@@ -77,6 +89,16 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 				continue
 			}
 
+			if isExcluded(connectorName, exclusions) {
+				continue
+			}
+
+			output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+			fileName := fmt.Sprintf("connector-%s-%s.%s", strings.ToLower(cluster.Name), strings.ToLower(connectorName), strings.ToLower(output))
+			if isResourceExported(pkg.ConnectorsPath, "", fileName) {
+				continue
+			}
+
 			connector, err := client.GetConnector(cluster.Name, connectorName)
 			if err != nil {
 				return err
@@ -87,16 +109,16 @@ func writeConnectors(cmd *cobra.Command, client *api.Client, clusterName string,
 			}
 
 			request := connector.ConnectorAsRequest()
-
-			output := strings.ToUpper(bite.GetOutPutFlag(cmd))
-			fileName := fmt.Sprintf("connector-%s-%s.%s", strings.ToLower(cluster.Name), strings.ToLower(connectorName), strings.ToLower(output))
+			if redactSecrets {
+				request.Config = redactConfig(connectorName, request.Config)
+			}
 
 			if output == "TABLE" {
 				output = "YAML"
 			}
 
-			golog.Debugf("Exporting connector [%s.%s] to [%s%s]", cluster.Name, connectorName, landscapeDir, fileName)
-			if err := utils.WriteFile(landscapeDir, pkg.ConnectorsPath, fileName, output, request); err != nil {
+			client.Logger().Debugf("Exporting connector [%s.%s] to [%s%s]", cluster.Name, connectorName, landscapeDir, fileName)
+			if err := writeResource(pkg.ConnectorsPath, "", fileName, output, request); err != nil {
 				return err
 			}
 
@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -16,15 +14,24 @@ import (
 func NewExportGroupsCommand() *cobra.Command {
 	var name string
 	cmd := &cobra.Command{
-		Use:              "groups",
-		Short:            "export groups",
-		Example:          `export groups`,
+		Use:   "groups",
+		Short: "export groups",
+		Example: `export groups
+export groups --exclude admins,'test-*'`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "name"); err != nil {
+				return err
+			}
 			if err := writeGroups(cmd, name); err != nil {
-				golog.Errorf("Error writing Users. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing Users. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -32,7 +39,11 @@ func NewExportGroupsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "groups", "")
 	cmd.Flags().StringVar(&name, "name", "", "The group name to extract")
+	addExcludeFlag(cmd, "groups")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
@@ -49,7 +60,7 @@ func writeGroups(cmd *cobra.Command, groupName string) error {
 		}
 
 		fileName := fmt.Sprintf("groups-%s.%s", strings.ToLower(group.Name), strings.ToLower(output))
-		return utils.WriteFile(landscapeDir, pkg.GroupsPath, fileName, output, group)
+		return writeResource(pkg.GroupsPath, group.Name, fileName, output, group)
 	}
 	groups, err := config.Client.GetGroups()
 	if err != nil {
@@ -57,12 +68,16 @@ func writeGroups(cmd *cobra.Command, groupName string) error {
 	}
 
 	for _, group := range groups {
+		if isExcluded(group.Name, exclusions) {
+			continue
+		}
+
 		fileName := fmt.Sprintf("groups-%s.%s", strings.ToLower(group.Name), strings.ToLower(output))
 		if groupName != "" && group.Name == groupName {
-			return utils.WriteFile(landscapeDir, pkg.GroupsPath, fileName, output, group)
+			return writeResource(pkg.GroupsPath, group.Name, fileName, output, group)
 		}
 
-		err := utils.WriteFile(landscapeDir, pkg.GroupsPath, fileName, output, group)
+		err := writeResource(pkg.GroupsPath, group.Name, fileName, output, group)
 		if err != nil {
 			return err
 		}
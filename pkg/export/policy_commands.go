@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -27,9 +25,17 @@ func NewExportPoliciesCommand() *cobra.Command {
 			client := config.Client
 
 			setExecutionMode(client)
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "resource-name"); err != nil {
+				return err
+			}
 			if err := writePolicies(cmd, client, name, ID); err != nil {
-				golog.Errorf("Error writing policies. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing policies. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -37,9 +43,13 @@ func NewExportPoliciesCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "policies", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The resource name to export")
 	cmd.Flags().StringVar(&ID, "id", "", "The policy id to extract")
+	addExcludeFlag(cmd, "policies")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
@@ -56,7 +66,7 @@ func writePolicies(cmd *cobra.Command, client *api.Client, name string, ID strin
 
 		fileName := fmt.Sprintf("policies-%s.%s", strings.ToLower(policy.Name), strings.ToLower(output))
 		request := client.PolicyAsRequest(policy)
-		return utils.WriteFile(landscapeDir, pkg.PoliciesPath, fileName, output, request)
+		return writeResource(pkg.PoliciesPath, policy.Name, fileName, output, request)
 	}
 
 	policies, err := client.GetPolicies()
@@ -65,13 +75,17 @@ func writePolicies(cmd *cobra.Command, client *api.Client, name string, ID strin
 	}
 
 	for _, policy := range policies {
-		fileName := fmt.Sprintf("policies-%s.%s", strings.ToLower(policy.Name), strings.ToLower(output))
-		if name != "" && policy.Name == name {
-			return utils.WriteFile(landscapeDir, pkg.PoliciesPath, fileName, output, policy)
+		if name != "" && policy.Name != name {
+			continue
 		}
 
-		err := utils.WriteFile(landscapeDir, pkg.PoliciesPath, fileName, output, policy)
-		if err != nil {
+		if isExcluded(policy.Name, exclusions) {
+			continue
+		}
+
+		fileName := fmt.Sprintf("policies-%s.%s", strings.ToLower(policy.Name), strings.ToLower(output))
+		request := client.PolicyAsRequest(policy)
+		if err := writeResource(pkg.PoliciesPath, policy.Name, fileName, output, request); err != nil {
 			return err
 		}
 	}
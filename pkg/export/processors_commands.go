@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -27,9 +25,17 @@ func NewExportProcessorsCommand() *cobra.Command {
 			client := config.Client
 
 			setExecutionMode(client)
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "resource-name"); err != nil {
+				return err
+			}
 			if err := writeProcessors(cmd, client, id, cluster, namespace, name); err != nil {
-				golog.Errorf("Error writing processors. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing processors. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -37,12 +43,18 @@ func NewExportProcessorsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "processors", "their last start or stop, the server keeps no modified time of the processors, so a processor restarted without changes is exported too")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
+	addWithDefaultsFlag(cmd)
 	cmd.Flags().StringVar(&name, "resource-name", "", "The processor name to export")
 	cmd.Flags().StringVar(&cluster, "cluster-name", "", "Select by cluster name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Select by namespace, available only in KUBERNETES mode")
 	cmd.Flags().StringVar(&id, "id", "", "ID of the processor to export")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Processor with the prefix in the name only")
+	addExcludeFlag(cmd, "processors")
+	addRedactSecretsFlag(cmd)
 
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
@@ -55,7 +67,7 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 		cluster = "IN_PROC"
 		namespace = "lenses"
 	}
-	processors, err := client.GetProcessors()
+	processors, err := client.GetProcessorsInNamespace(namespace)
 	if err != nil {
 		return err
 	}
@@ -79,6 +91,15 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 			if prefix != "" && !strings.HasPrefix(processor.Name, prefix) {
 				continue
 			}
+
+			if isExcluded(processor.Name, exclusions) {
+				continue
+			}
+		}
+
+		if !modifiedSince(processorModifiedAt(processor)) {
+			client.Logger().Debugf("Skipping processor [%s], not modified since [%s]", processor.Name, since)
+			continue
 		}
 		request := processor.ProcessorAsRequest()
 
@@ -103,7 +124,11 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 		request.SQL = strings.Replace(request.SQL, "\t", "  ", -1)
 		request.SQL = strings.Replace(request.SQL, " \n", "\n", -1)
 
-		if err := utils.WriteFile(landscapeDir, pkg.SQLPath, fileName, output, request); err != nil {
+		if redactSecrets {
+			request.SQL = redactSQL(processor.Name, request.SQL)
+		}
+
+		if err := writeResource(pkg.SQLPath, "", fileName, output, request); err != nil {
 			return err
 		}
 		if dependents {
@@ -113,3 +138,14 @@ func writeProcessors(cmd *cobra.Command, client *api.Client, id, cluster, namesp
 
 	return nil
 }
+
+// processorModifiedAt returns the last time, in unix milliseconds, the "processor" was started or stopped,
+// a processor is restarted to apply its changes. It's not the time of its last change, the server does not keep it,
+// see the help of the --since.
+func processorModifiedAt(processor api.ProcessorStream) int64 {
+	if processor.StopTimestamp > processor.StartTimestamp {
+		return processor.StopTimestamp
+	}
+
+	return processor.StartTimestamp
+}
@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,10 +21,14 @@ func NewExportQuotasCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
-
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
 			if err := writeQuotas(cmd, config.Client); err != nil {
-				golog.Errorf("Error writing quotas. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing quotas. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +36,9 @@ func NewExportQuotasCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "quotas", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -56,5 +61,5 @@ func writeQuotas(cmd *cobra.Command, client *api.Client) error {
 		requests = append(requests, q.GetQuotaAsRequest())
 	}
 
-	return utils.WriteFile(landscapeDir, pkg.QuotasPath, fileName, output, requests)
+	return writeResource(pkg.QuotasPath, "", fileName, output, requests)
 }
@@ -1,11 +1,10 @@
 package export
 
 import (
-	"fmt"
 	"os"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/api"
 	"github.com/spf13/cobra"
 	"gopkg.in/src-d/go-git.v4"
 	"gopkg.in/src-d/go-git.v4/config"
@@ -47,7 +46,7 @@ func addGitSupport(cmd *cobra.Command, gitURL string) error {
 
 	if err == nil {
 		pwd, _ := os.Getwd()
-		golog.Error(fmt.Sprintf("Git repo already exists in directory [%s]", pwd))
+		api.DefaultLogger().Errorf("Git repo already exists in directory [%s]", pwd)
 		return err
 	}
 
@@ -55,7 +54,8 @@ func addGitSupport(cmd *cobra.Command, gitURL string) error {
 	repo, initErr := git.PlainInit("", false)
 
 	if initErr != nil {
-		golog.Error("A repo already exists")
+		api.DefaultLogger().Errorf("A repo already exists")
+		return initErr
 	}
 
 	file, err := os.OpenFile(
@@ -65,7 +65,7 @@ func addGitSupport(cmd *cobra.Command, gitURL string) error {
 	)
 
 	if err != nil {
-		golog.Fatal(err)
+		return err
 	}
 	defer file.Close()
 
@@ -76,7 +76,7 @@ func addGitSupport(cmd *cobra.Command, gitURL string) error {
 	)
 
 	if err != nil {
-		golog.Fatal(err)
+		return err
 	}
 	defer readme.Close()
 
@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
@@ -25,24 +24,32 @@ func NewExportSchemasCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "resource-name"); err != nil {
+				return err
+			}
 
 			versionInt, err := strconv.Atoi(version)
 			if err != nil {
-				golog.Errorf("Version [%s] is not at integer", version)
+				config.Client.Logger().Errorf("Version [%s] is not at integer", version)
 				return err
 			}
 
 			if name != "" {
 				if err := writeSchema(cmd, config.Client, name, versionInt); err != nil {
-					golog.Errorf("Error writing schema. [%s]", err.Error())
+					config.Client.Logger().Errorf("Error writing schema. [%s]", err.Error())
 					return err
 				}
 				return nil
 			}
 
 			if err := writeSchemas(cmd, config.Client); err != nil {
-				golog.Errorf("Error writing schemas. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing schemas. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -50,10 +57,14 @@ func NewExportSchemasCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "schemas", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The schema to export. Both the key schema and value schema are exported")
 	cmd.Flags().StringVar(&version, "version", "0", "The schema version to export.")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Schemas with the prefix only")
+	addExcludeFlag(cmd, "schemas")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
@@ -72,6 +83,10 @@ func writeSchemas(cmd *cobra.Command, client *api.Client) error {
 			continue
 		}
 
+		if isExcluded(subject, exclusions) {
+			continue
+		}
+
 		// don't export control topics
 		excluded := false
 		for _, exclude := range systemTopicExclusions {
@@ -88,8 +103,13 @@ func writeSchemas(cmd *cobra.Command, client *api.Client) error {
 			continue
 		}
 
+		fileName := fmt.Sprintf("schema-%s.%s", strings.ToLower(subject), strings.ToLower(bite.GetOutPutFlag(cmd)))
+		if isResourceExported(pkg.SchemasPath, subject, fileName) {
+			continue
+		}
+
 		if err := writeSchema(cmd, client, subject, 0); err != nil {
-			golog.Error(fmt.Sprintf("Error while exporting schema [%s]", subject))
+			client.Logger().Errorf("Error while exporting schema [%s]", subject)
 			return err
 		}
 	}
@@ -121,5 +141,5 @@ func writeSchema(cmd *cobra.Command, client *api.Client, name string, version in
 
 	request := client.GetSchemaAsRequest(schema)
 	fileName := fmt.Sprintf("schema-%s.%s", strings.ToLower(name), strings.ToLower(output))
-	return utils.WriteFile(landscapeDir, pkg.SchemasPath, fileName, output, request)
+	return writeResource(pkg.SchemasPath, name, fileName, output, request)
 }
@@ -4,11 +4,9 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -22,10 +20,18 @@ func NewExportServiceAccountsCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "name"); err != nil {
+				return err
+			}
 
 			if err := writeServiceAccounts(cmd, name); err != nil {
-				golog.Errorf("Error writing service accounts. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing service accounts. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -33,7 +39,11 @@ func NewExportServiceAccountsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "service accounts", "")
 	cmd.Flags().StringVar(&name, "name", "", "The service account name to extract")
+	addExcludeFlag(cmd, "service accounts")
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
@@ -49,7 +59,7 @@ func writeServiceAccounts(cmd *cobra.Command, accountName string) error {
 		}
 
 		fileName := fmt.Sprintf("svc-accounts-%s.%s", strings.ToLower(svcAcc.Name), strings.ToLower(output))
-		return utils.WriteFile(landscapeDir, pkg.ServiceAccountsPath, fileName, output, svcAcc)
+		return writeResource(pkg.ServiceAccountsPath, svcAcc.Name, fileName, output, svcAcc)
 	}
 	svcaccs, err := config.Client.GetServiceAccounts()
 	if err != nil {
@@ -57,12 +67,16 @@ func writeServiceAccounts(cmd *cobra.Command, accountName string) error {
 	}
 
 	for _, svcAcc := range svcaccs {
+		if isExcluded(svcAcc.Name, exclusions) {
+			continue
+		}
+
 		fileName := fmt.Sprintf("svc-accounts-%s.%s", strings.ToLower(svcAcc.Name), strings.ToLower(output))
 		if accountName != "" && svcAcc.Name == accountName {
-			return utils.WriteFile(landscapeDir, pkg.ServiceAccountsPath, fileName, output, svcAcc)
+			return writeResource(pkg.ServiceAccountsPath, svcAcc.Name, fileName, output, svcAcc)
 		}
 
-		err := utils.WriteFile(landscapeDir, pkg.ServiceAccountsPath, fileName, output, svcAcc)
+		err := writeResource(pkg.ServiceAccountsPath, svcAcc.Name, fileName, output, svcAcc)
 		if err != nil {
 			return err
 		}
@@ -4,12 +4,10 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -23,9 +21,17 @@ func NewExportTopicsCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			checkFileFlags(cmd)
+			if err := checkFileFlags(cmd); err != nil {
+				return err
+			}
+			if err := checkLayout(); err != nil {
+				return err
+			}
+			if err := checkExclusions(cmd, "resource-name"); err != nil {
+				return err
+			}
 			if err := writeTopics(cmd, config.Client, name); err != nil {
-				golog.Errorf("Error writing topics. [%s]", err.Error())
+				config.Client.Logger().Errorf("Error writing topics. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -33,10 +39,15 @@ func NewExportTopicsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&landscapeDir, "dir", ".", "Base directory to export to")
+	addLayoutFlag(cmd)
+	addManifestFlag(cmd)
+	addSinceFlag(cmd, "topics", "")
 	cmd.Flags().BoolVar(&dependents, "dependents", false, "Extract dependencies, topics, acls, quotas, alerts")
 	cmd.Flags().StringVar(&name, "resource-name", "", "The topic name to export")
-	cmd.Flags().StringVar(&topicExclusions, "exclude", "", "Topics to exclude")
+	addExcludeFlag(cmd, "topics")
 	cmd.Flags().StringVar(&prefix, "prefix", "", "Topics with the prefix only")
+	addWithDefaultsFlag(cmd)
+	addRedactSecretsFlag(cmd)
 	bite.CanBeSilent(cmd)
 	bite.CanPrintJSON(cmd)
 	return cmd
@@ -70,15 +81,7 @@ func writeTopics(cmd *cobra.Command, client *api.Client, topicName string) error
 		}
 
 		// exclude any user defined
-		excluded = false
-		for _, exclude := range strings.Split(topicExclusions, ",") {
-			if topic.TopicName == exclude {
-				excluded = true
-				break
-			}
-		}
-
-		if excluded {
+		if isExcluded(topic.TopicName, exclusions) {
 			continue
 		}
 
@@ -107,7 +110,11 @@ func writeTopicsAsRequest(cmd *cobra.Command, requests []api.CreateTopicPayload)
 
 		fileName := fmt.Sprintf("topic-%s.%s", strings.ToLower(topic.TopicName), strings.ToLower(output))
 
-		if err := utils.WriteFile(landscapeDir, pkg.TopicsPath, fileName, output, topic); err != nil {
+		if redactSecrets {
+			topic.Configs = redactConfig(topic.TopicName, topic.Configs)
+		}
+
+		if err := writeResource(pkg.TopicsPath, topic.TopicName, fileName, output, topic); err != nil {
 			return err
 		}
 	}
@@ -115,25 +122,48 @@ func writeTopicsAsRequest(cmd *cobra.Command, requests []api.CreateTopicPayload)
 	return nil
 }
 
+// withDefaults exports the topic configs which are equal to their defaults too, see `getTopicConfigOverrides`.
+var withDefaults bool
+
+// addWithDefaultsFlag adds the --with-defaults flag to the commands which export topics.
+func addWithDefaultsFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&withDefaults, "with-defaults", false,
+		"Export all the topic configs, including the ones inherited from the broker and the ones set to their default value")
+}
+
+// getTopicConfigOverrides returns the configs of a topic which override their defaults, so the exported files are minimal,
+// the inherited configs and the ones set to their default value, the broker's or the topic's, are skipped,
+// unless the --with-defaults is set.
 func getTopicConfigOverrides(configs []api.KV) api.KV {
 	overrides := make(api.KV)
 
 	for _, kv := range configs {
-		if val, ok := kv["isDefault"]; ok {
-			if val.(bool) == false {
-				var name, value string
-
-				if val, ok := kv["name"]; ok {
-					name = val.(string)
-				}
-
-				if val, ok := kv["originalValue"]; ok {
-					value = val.(string)
-				}
-				overrides[name] = value
+		name, _ := kv["name"].(string)
+		value := topicConfigValue(kv)
+
+		if !withDefaults {
+			if isDefault, ok := kv["isDefault"].(bool); !ok || isDefault {
+				continue
+			}
+
+			if defaultValue, ok := kv["defaultValue"]; ok && fmt.Sprint(defaultValue) == value {
+				continue
 			}
 		}
+
+		overrides[name] = value
 	}
 
 	return overrides
 }
+
+// topicConfigValue returns the value of a topic config, its "originalValue" or its "value" if missing.
+func topicConfigValue(kv api.KV) string {
+	for _, key := range []string{"originalValue", "value", "defaultValue"} {
+		if val, ok := kv[key]; ok && val != nil {
+			return fmt.Sprint(val)
+		}
+	}
+
+	return ""
+}
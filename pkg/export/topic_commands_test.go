@@ -0,0 +1,88 @@
+package export
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const testTopicsResponse = `[{
+	"topicName": "orders",
+	"partitions": 3,
+	"replication": 1,
+	"config": [
+		{"name": "cleanup.policy", "originalValue": "compact", "defaultValue": "delete", "isDefault": false},
+		{"name": "retention.ms", "originalValue": "604800000", "defaultValue": "604800000", "isDefault": false},
+		{"name": "segment.bytes", "originalValue": "1073741824", "defaultValue": "1073741824", "isDefault": true}
+	]
+}]`
+
+func TestExportTopicsWithDefaults(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/topics", r.URL.Path)
+		w.Write([]byte(testTopicsResponse))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected map[string]string
+	}{
+		{
+			name:     "overrides only",
+			expected: map[string]string{"cleanup.policy": "compact"},
+		},
+		{
+			name: "with defaults",
+			args: []string{"--with-defaults"},
+			expected: map[string]string{
+				"cleanup.policy": "compact",
+				"retention.ms":   "604800000",
+				"segment.bytes":  "1073741824",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "export-topics")
+			assert.Nil(t, err)
+			defer os.RemoveAll(dir)
+
+			cmd := NewExportTopicsCommand()
+			var outputValue string
+			cmd.PersistentFlags().StringVar(&outputValue, "output", "yaml", "")
+			_, err = test.ExecuteCommand(cmd, append([]string{"--dir", dir}, tt.args...)...)
+			assert.Nil(t, err)
+
+			contents, err := ioutil.ReadFile(filepath.Join(dir, pkg.TopicsPath, "topic-orders.yaml"))
+			if !assert.Nil(t, err) {
+				return
+			}
+
+			var topic struct {
+				Name    string            `yaml:"name"`
+				Configs map[string]string `yaml:"configs"`
+			}
+			assert.Nil(t, yaml.Unmarshal(contents, &topic))
+			assert.Equal(t, "orders", topic.Name)
+			assert.Equal(t, tt.expected, topic.Configs)
+		})
+	}
+}
@@ -2,17 +2,17 @@ package imports
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-var acl api.ACL
+// aclGroupPrincipalPrefix is the prefix of the ACL principals which reference a user group.
+const aclGroupPrincipalPrefix = "Group:"
 
 //NewImportAclsCommand creates `import acls` command
 func NewImportAclsCommand() *cobra.Command {
@@ -28,7 +28,7 @@ func NewImportAclsCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.AclsPath)
 			if err := loadAcls(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load acls. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load acls. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -36,6 +36,7 @@ func NewImportAclsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -44,8 +45,16 @@ func NewImportAclsCommand() *cobra.Command {
 }
 
 func loadAcls(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading acls from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
+
+	client.Logger().Infof("Loading acls from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
 
 	lacls, err := client.GetACLs()
 
@@ -53,37 +62,90 @@ func loadAcls(client *api.Client, cmd *cobra.Command, loadpath string) error {
 		return err
 	}
 
+	var (
+		groups []api.Group
+		// the files which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
+
 	for _, file := range files {
 		var acls []api.ACL
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &acls); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
-		}
-
-		found := true
-		for _, l := range lacls {
-			if acl.Host == l.Host &&
-				acl.Operation == l.Operation &&
-				acl.PermissionType == l.PermissionType &&
-				acl.Principal == l.Principal &&
-				acl.ResourceName == l.ResourceName &&
-				acl.ResourceType == l.ResourceType {
-				found = true
+		if err := file.loadFile(cmd, "acl", &acls); err != nil {
+			client.Logger().Errorf("Error loading file [%s]", loadpath)
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
 			}
+			continue
 		}
 
-		if found {
+		if err := checkAclGroups(client, &groups, file, acls); err != nil {
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
 			continue
 		}
 
 		for _, acl := range acls {
+			if aclExists(lacls, acl) {
+				result.Unchanged++
+				continue
+			}
+
 			if err := client.CreateOrUpdateACL(acl); err != nil {
-				golog.Errorf("Error creating/updating acl from [%s] [%s]", loadpath, err.Error())
-				return err
+				client.Logger().Errorf("Error creating/updating acl from [%s] [%s]", loadpath, err.Error())
+				if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+					return err
+				}
+				continue
+			}
+			result.Created++
+		}
+
+		client.Logger().Infof("Created/updated ACLs from [%s]", loadpath)
+	}
+
+	if !keepGoing {
+		return nil
+	}
+
+	return summarize(client.Logger(), "acl", result, failed)
+}
+
+// checkAclGroups returns an error if the group principals of the "acls" of the "file" reference missing user groups,
+// the "groups" are fetched on the first group principal.
+func checkAclGroups(client *api.Client, groups *[]api.Group, file importFile, acls []api.ACL) (err error) {
+	for _, acl := range acls {
+		if !strings.HasPrefix(acl.Principal, aclGroupPrincipalPrefix) {
+			continue
+		}
+
+		if *groups == nil {
+			if *groups, err = client.GetGroups(); err != nil {
+				return
 			}
 		}
 
-		golog.Infof("Created/updated ACLs from [%s]", loadpath)
+		groupName := strings.TrimPrefix(acl.Principal, aclGroupPrincipalPrefix)
+		if missing := missingGroups(*groups, []string{groupName}); len(missing) > 0 {
+			return errMissingGroups("acl of file", file.Name(), missing)
+		}
 	}
-	return nil
+
+	return
+}
+
+func aclExists(acls []api.ACL, acl api.ACL) bool {
+	for _, l := range acls {
+		if acl.Host == l.Host &&
+			acl.Operation == l.Operation &&
+			acl.PermissionType == l.PermissionType &&
+			acl.Principal == l.Principal &&
+			acl.ResourceName == l.ResourceName &&
+			acl.ResourceType == l.ResourceType {
+			return true
+		}
+	}
+
+	return false
 }
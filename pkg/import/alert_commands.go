@@ -3,13 +3,11 @@ package imports
 import (
 	"fmt"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/alert"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -27,7 +25,7 @@ func NewImportAlertSettingsCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.AlertSettingsPath)
 			if err := loadAlertSettings(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load alert-settings. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load alert-settings. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -35,6 +33,7 @@ func NewImportAlertSettingsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -43,8 +42,16 @@ func NewImportAlertSettingsCommand() *cobra.Command {
 }
 
 func loadAlertSettings(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading alert-settings from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
+
+	client.Logger().Infof("Loading alert-settings from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
 
 	asc, err := client.GetAlertSettingConditions(2000)
 
@@ -52,12 +59,21 @@ func loadAlertSettings(client *api.Client, cmd *cobra.Command, loadpath string)
 		return err
 	}
 
+	var (
+		// the files which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
+
 	for _, file := range files {
 
 		var conds alert.SettingConditionPayloads
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &conds); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+		if err := file.loadFile(cmd, "alert-setting", &conds); err != nil {
+			client.Logger().Errorf("Error loading file [%s]", loadpath)
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		alertID := conds.AlertID
@@ -71,15 +87,25 @@ func loadAlertSettings(client *api.Client, cmd *cobra.Command, loadpath string)
 			}
 
 			if found {
+				result.Unchanged++
 				continue
 			}
 
 			if err := client.CreateOrUpdateAlertSettingCondition(alertID, condition); err != nil {
-				golog.Errorf("Error creating/updating alert setting from [%d] [%s] [%s]", alertID, loadpath, err.Error())
-				return err
+				client.Logger().Errorf("Error creating/updating alert setting from [%d] [%s] [%s]", alertID, loadpath, err.Error())
+				if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+					return err
+				}
+				continue
 			}
-			golog.Infof("Created/updated condition [%s] from [%s]", condition, loadpath)
+			client.Logger().Infof("Created/updated condition [%s] from [%s]", condition, loadpath)
+			result.Created++
 		}
 	}
-	return nil
+
+	if !keepGoing {
+		return nil
+	}
+
+	return summarize(client.Logger(), "alert setting", result, failed)
 }
@@ -0,0 +1,102 @@
+package imports
+
+import "reflect"
+
+// ResourceCache holds the current resources of a kind for the length of an import run, so the `Reconcile`
+// looks each one of them up once, however many files and dependencies refer to it, see `Reconciler#Cache`.
+type ResourceCache struct {
+	// List returns all the current resources, as a slice. It's called once, on the first `All`,
+	// or on the first `Lookup` if the `Fetch` is nil.
+	List func() (interface{}, error)
+	// Name returns the key of a resource, like the `Reconciler#Name`.
+	Name func(resource interface{}) string
+	// Fetch returns a single resource by its name and false if it does not exist.
+	// If not nil, the `Lookup`s fetch the resources one by one until the `All` lists them.
+	Fetch func(name string) (interface{}, bool, error)
+
+	listed bool
+	all    interface{}
+	// byName are the listed or fetched resources and missing are the fetched names which do not exist.
+	byName  map[string]interface{}
+	missing map[string]bool
+	// stale are the names which were written after they were cached, see `Invalidate`.
+	stale map[string]bool
+}
+
+// All returns the current resources, they are listed on the first call.
+func (c *ResourceCache) All() (interface{}, error) {
+	if c.listed {
+		return c.all, nil
+	}
+
+	all, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	c.byName = make(map[string]interface{})
+	c.missing, c.stale = nil, nil
+	values := reflect.ValueOf(all)
+	for i := 0; i < values.Len(); i++ {
+		resource := values.Index(i).Interface()
+		c.byName[c.Name(resource)] = resource
+	}
+
+	c.listed, c.all = true, all
+	return all, nil
+}
+
+// Lookup returns the current resource of the "name" and false if it does not exist.
+func (c *ResourceCache) Lookup(name string) (interface{}, bool, error) {
+	if !c.stale[name] {
+		if resource, ok := c.byName[name]; ok {
+			return resource, true, nil
+		}
+
+		if c.listed || c.missing[name] {
+			return nil, false, nil
+		}
+	}
+
+	if c.Fetch == nil {
+		// the stale ones are listed again.
+		c.listed = false
+		if _, err := c.All(); err != nil {
+			return nil, false, err
+		}
+
+		resource, ok := c.byName[name]
+		return resource, ok, nil
+	}
+
+	resource, found, err := c.Fetch(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	delete(c.stale, name)
+	if !found {
+		delete(c.byName, name)
+		if c.missing == nil {
+			c.missing = make(map[string]bool)
+		}
+		c.missing[name] = true
+		return nil, false, nil
+	}
+
+	if c.byName == nil {
+		c.byName = make(map[string]interface{})
+	}
+	delete(c.missing, name)
+	c.byName[name] = resource
+	return resource, true, nil
+}
+
+// Invalidate marks the cached resource of the "name" as stale after a write, its next `Lookup` fetches it again,
+// or lists all of them again if the `Fetch` is nil.
+func (c *ResourceCache) Invalidate(name string) {
+	if c.stale == nil {
+		c.stale = make(map[string]bool)
+	}
+	c.stale[name] = true
+}
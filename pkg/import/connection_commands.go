@@ -3,12 +3,10 @@ package imports
 import (
 	"fmt"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -17,16 +15,20 @@ func NewImportConnectionsCommand() *cobra.Command {
 	var path string
 
 	cmd := &cobra.Command{
-		Use:              "connections",
-		Short:            "Import from a directory named connections",
-		Example:          `import connections --dir lenses_export`,
+		Use:   "connections",
+		Short: "Import from a directory named connections",
+		Example: `
+import connections --dir lenses_export
+import connections --dir lenses_export --dry-run
+import connections --dir lenses_export --prune --yes
+import connections --dir lenses_export --on-error continue`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.ConnectionsFilePath)
 			if err := loadConnections(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to import connections. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to import connections. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,67 +36,138 @@ func NewImportConnectionsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import from")
+	cmd.Flags().Bool(dryRunFlag, false, "Print the changes without applying them")
+	addPruneFlags(cmd, "connections")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	_ = bite.CanBeSilent(cmd)
 	return cmd
 }
 
+// loadConnections reconciles the connections of the files, written by the `export connections`, by name:
+// the existing ones are updated and the rest are created, like the `loadServiceAccounts`.
 func loadConnections(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading connections from [%s]", loadpath)
-
-	currentConnections, err := client.GetConnections()
+	keepGoing, err := continueOnError(cmd)
 	if err != nil {
 		return err
 	}
 
-	files := utils.FindFiles(loadpath)
-	connTemplates, err := config.Client.GetConnectionTemplates()
+	client.Logger().Infof("Loading connections from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
 	if err != nil {
-		golog.Errorf("Error getting connection templates [%s]", err.Error())
 		return err
 	}
 
+	// the files and the connections which failed under the --on-error continue.
+	var failed []Failure
+
+	// load and validate all the files before any change.
+	connections := make([]api.Connection, 0, len(files))
+	orders := make(map[string]ImportOrder)
 	for _, file := range files {
 		var connection api.Connection
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &connection); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
-		}
-
-		found := false
-		for _, currentConn := range currentConnections {
-			if currentConn.Name == connection.Name {
-				found = true
-				golog.Infof("Updating connection [%s]", connection.Name)
-				if err := config.Client.UpdateConnection(currentConn.Name, connection.Name, "", connection.Configuration, connection.Tags); err != nil {
-					golog.Errorf("Error updating connection [%s]. [%s]", connection.Name, err.Error())
-					return err
-				}
-				golog.Infof("Updated connection [%s]", connection.Name)
-				continue
+		order, err := file.loadOrdered(cmd, "connection", &connection)
+		if err != nil {
+			client.Logger().Errorf("Error loading file [%s]", file.Name())
+			if !keepGoing {
+				return err
 			}
+			failed = append(failed, Failure{Name: file.Name(), Err: err})
+			continue
 		}
-		if !found {
-			golog.Infof("Creating new connection [%s]", file.Name())
-			var connTemplateName string
-			for _, connTemplate := range connTemplates {
-				if connTemplate.Name == connection.TemplateName {
-					connTemplateName = connTemplate.Name
-					break
-				}
-			}
-			if connTemplateName == "" {
-				golog.Errorf("Connection template %s for connection %s not found [%s]", connection.TemplateName, connection.Name, err.Error())
+
+		connections = append(connections, connection)
+		orders[connection.Name] = order
+	}
+
+	currentConnections, err := client.GetConnections()
+	if err != nil {
+		return err
+	}
+
+	connTemplates, err := client.GetConnectionTemplates()
+	if err != nil {
+		client.Logger().Errorf("Error getting connection templates [%s]", err.Error())
+		return err
+	}
+
+	valid := connections[:0]
+	for _, connection := range connections {
+		if !hasConnection(currentConnections, connection.Name) && !hasConnectionTemplate(connTemplates, connection.TemplateName) {
+			err := fmt.Errorf("connection template [%s] of the connection [%s] not found", connection.TemplateName, connection.Name)
+			if !keepGoing {
 				return err
 			}
-			if err := config.Client.CreateConnection(connection.Name, connTemplateName, "", connection.Configuration, connection.Tags); err != nil {
-				golog.Errorf("Error creating connection [%s] from [%s] [%s]", connection.Name, loadpath, err.Error())
-				return err
+			failed = append(failed, Failure{Name: connection.Name, Err: err})
+			continue
+		}
+
+		valid = append(valid, connection)
+	}
+	connections = valid
+
+	prune := isPrune(cmd)
+	if prune && len(failed) > 0 {
+		// the connections of the failed files would be deleted as if they were removed.
+		client.Logger().Warnf("Skipping the --%s, [%d] files or connections failed to load", pruneFlag, len(failed))
+		prune = false
+	}
+
+	result, err := Reconcile(Reconciler{
+		Kind: "connection",
+		Name: func(resource interface{}) string {
+			// the desired are the files and the current are the listed connections.
+			if connection, ok := resource.(api.Connection); ok {
+				return connection.Name
 			}
-			golog.Infof("Created connection [%s]", connection.Name)
+
+			return resource.(api.ConnectionList).Name
+		},
+		// the current connections are listed without their configuration, so the matched ones are always updated.
+		Create: func(desired interface{}) error {
+			return client.CreateConnectionFrom(desired.(api.Connection))
+		},
+		Update: func(desired, current interface{}) error {
+			return client.UpdateConnectionFrom(current.(api.ConnectionList).Name, desired.(api.Connection))
+		},
+		Delete: func(current interface{}) error {
+			return client.DeleteConnection(current.(api.ConnectionList).Name)
+		},
+		Ordering: func(desired interface{}) ImportOrder {
+			return orders[desired.(api.Connection).Name]
+		},
+		Prune:           prune,
+		ForcePrune:      isForcePrune(cmd),
+		Confirm:         confirmPrune(cmd),
+		DryRun:          isDryRun(cmd),
+		ContinueOnError: keepGoing,
+		Logger:          client.Logger(),
+	}, connections, currentConnections)
+
+	if err != nil || !keepGoing {
+		return err
+	}
+
+	return summarize(client.Logger(), "connection", result, append(failed, result.Failed...))
+}
+
+func hasConnection(connections []api.ConnectionList, name string) bool {
+	for _, connection := range connections {
+		if connection.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasConnectionTemplate(templates []api.ConnectionTemplate, name string) bool {
+	for _, template := range templates {
+		if template.Name == name {
+			return true
 		}
 	}
 
-	return nil
+	return false
 }
@@ -0,0 +1,272 @@
+package imports
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportConnectionsIgnoresStatus(t *testing.T) {
+	var updateBody string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			w.Write([]byte(`[{"name": "slack", "templateName": "Slack"}]`))
+		case "/api/v1/connection/connection-templates":
+			w.Write([]byte(`[]`))
+		case "/api/v1/connection/connections/slack":
+			assert.Equal(t, http.MethodPut, r.Method)
+			b, _ := ioutil.ReadAll(r.Body)
+			updateBody = string(b)
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	connectionsDir := filepath.Join(dir, pkg.ConnectionsFilePath)
+	assert.Nil(t, os.MkdirAll(connectionsDir, 0755))
+
+	// as exported by `export connections --with-status`.
+	contents := `name: slack
+templateName: Slack
+configuration:
+- key: webhookUrl
+  value: https://hooks.slack.com/
+tags:
+- t1
+status:
+  name: slack
+  state: DOWN
+  error: connection refused
+`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-slack-slack.yaml"), []byte(contents), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir)
+	assert.Nil(t, err)
+	assert.Contains(t, updateBody, "webhookUrl")
+	assert.NotContains(t, updateBody, "status")
+	assert.NotContains(t, updateBody, "DOWN")
+}
+
+func TestImportConnectionsReconcile(t *testing.T) {
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			if r.Method == http.MethodPost {
+				var payload api.CreateConnectionPayload
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+				requests = append(requests, "create "+payload.Name)
+				return
+			}
+			w.Write([]byte(`[{"name": "slack", "templateName": "Slack"}]`))
+		case "/api/v1/connection/connection-templates":
+			w.Write([]byte(`[{"name": "Slack"}, {"name": "Kafka"}]`))
+		case "/api/v1/connection/connections/slack":
+			assert.Equal(t, http.MethodPut, r.Method)
+			requests = append(requests, "update slack")
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	connectionsDir := filepath.Join(dir, pkg.ConnectionsFilePath)
+	assert.Nil(t, os.MkdirAll(connectionsDir, 0755))
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-kafka-kafka.yaml"),
+		[]byte("name: kafka\ntemplateName: Kafka\nconfiguration:\n- key: kafkaBootstrapServers\n  value:\n  - PLAINTEXT://broker:9092\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-slack-slack.yaml"),
+		[]byte("name: slack\ntemplateName: Slack\nconfiguration:\n- key: webhookUrl\n  value: https://hooks.slack.com/\n"), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir, "--dry-run")
+	assert.Nil(t, err)
+	assert.Empty(t, requests, "no changes on a dry run")
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"create kafka", "update slack"}, requests)
+
+	// a new connection of a missing template fails before any change.
+	requests = nil
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-es-es.yaml"),
+		[]byte("name: es\ntemplateName: Elasticsearch\nconfiguration:\n- key: nodes\n  value:\n  - http://es:9200\n"), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir)
+	assert.EqualError(t, err, "connection template [Elasticsearch] of the connection [es] not found")
+	assert.Empty(t, requests)
+}
+
+func TestImportConnectionsPrune(t *testing.T) {
+	var deleted []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			w.Write([]byte(`[{"name": "slack"}, {"name": "old-kafka"}, {"name": "old-es"}]`))
+		case "/api/v1/connection/connection-templates":
+			w.Write([]byte(`[{"name": "Slack"}]`))
+		case "/api/v1/connection/connections/slack":
+			assert.Equal(t, http.MethodPut, r.Method)
+		default:
+			assert.Equal(t, http.MethodDelete, r.Method, r.URL.Path)
+			deleted = append(deleted, filepath.Base(r.URL.Path))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	canPrompt := utils.CanPrompt
+	utils.CanPrompt = func() bool { return false }
+	defer func() { utils.CanPrompt = canPrompt }()
+
+	dir, err := ioutil.TempDir("", "import-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	connectionsDir := filepath.Join(dir, pkg.ConnectionsFilePath)
+	assert.Nil(t, os.MkdirAll(connectionsDir, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-slack-slack.yaml"),
+		[]byte("name: slack\ntemplateName: Slack\nconfiguration:\n- key: webhookUrl\n  value: https://hooks.slack.com/\n"), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir, "--prune", "--dry-run")
+	assert.Nil(t, err)
+	assert.Empty(t, deleted, "nothing is pruned on a dry run")
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir, "--prune")
+	assert.EqualError(t, err, "unable to prompt for the deletion of the 2 pruned connection resources [old-kafka, old-es], "+
+		"no terminal is attached, use --yes to confirm it")
+	assert.Empty(t, deleted)
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir, "--prune", "--yes")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"old-kafka", "old-es"}, deleted)
+}
+
+func TestImportConnectionsDependsOn(t *testing.T) {
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			if r.Method == http.MethodPost {
+				var payload api.CreateConnectionPayload
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+				requests = append(requests, "create "+payload.Name)
+				return
+			}
+			w.Write([]byte(`[]`))
+		case "/api/v1/connection/connection-templates":
+			w.Write([]byte(`[{"name": "Kafka"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	connectionsDir := filepath.Join(dir, pkg.ConnectionsFilePath)
+	assert.Nil(t, os.MkdirAll(connectionsDir, 0755))
+
+	// the files are found by name, "a" depends on "b" which depends on "c".
+	for name, dependsOn := range map[string]string{"a": "b", "b": "c", "c": ""} {
+		contents := "name: " + name + "\ntemplateName: Kafka\nconfiguration:\n- key: kafkaBootstrapServers\n  value:\n  - PLAINTEXT://broker:9092\n"
+		if dependsOn != "" {
+			contents += "dependsOn:\n- " + dependsOn + "\n"
+		}
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-"+name+".yaml"), []byte(contents), 0644))
+	}
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"create c", "create b", "create a"}, requests)
+
+	// a missing dependency fails before any change.
+	requests = nil
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(connectionsDir, "connection-c.yaml"),
+		[]byte("name: c\ntemplateName: Kafka\ndependsOn: [d]\nconfiguration:\n- key: kafkaBootstrapServers\n  value:\n  - PLAINTEXT://broker:9092\n"), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir)
+	assert.EqualError(t, err, "connection [c] depends on [d] which is neither in the files nor exists")
+	assert.Empty(t, requests)
+}
+
+func TestImportConnectionsPruneEmpty(t *testing.T) {
+	var deleted []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/connection/connections":
+			w.Write([]byte(`[{"name": "slack"}, {"name": "kafka"}]`))
+		case "/api/v1/connection/connection-templates":
+			w.Write([]byte(`[{"name": "Slack"}]`))
+		default:
+			assert.Equal(t, http.MethodDelete, r.Method, r.URL.Path)
+			deleted = append(deleted, filepath.Base(r.URL.Path))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-connections")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// the directory exists but holds no connections.
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, pkg.ConnectionsFilePath), 0755))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir, "--prune", "--yes")
+	assert.EqualError(t, err, "refusing to prune all the connection resources as none were loaded, use --force-prune to delete them")
+	assert.Empty(t, deleted)
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connections", "--dir", dir, "--prune", "--yes", "--force-prune")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"slack", "kafka"}, deleted)
+}
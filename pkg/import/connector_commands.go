@@ -5,12 +5,10 @@ import (
 	"reflect"
 	"time"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -28,7 +26,7 @@ func NewImportConnectorsCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.ConnectorsPath)
 			if err := loadConnectors(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load connectors. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load connectors. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -36,6 +34,7 @@ func NewImportConnectorsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -43,60 +42,115 @@ func NewImportConnectorsCommand() *cobra.Command {
 	return cmd
 }
 
+// connectorCreateDelay is the pause after the creation of a connector, before the next one is imported.
+var connectorCreateDelay = 10 * time.Second
+
 func loadConnectors(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading connectors from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
 
+	client.Logger().Infof("Loading connectors from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
+
+	// load all the files first, so the connectors are created after the ones of their dependsOn, see `ImportOrder`.
+	var (
+		connectors []api.Connector
+		// the files which failed under the --on-error continue.
+		failed []Failure
+	)
+	orders := make(map[string]ImportOrder)
 	for _, file := range files {
-		var connector api.CreateUpdateConnectorPayload
-		if err := load(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &connector); err != nil {
-			return err
+		var payload api.CreateUpdateConnectorPayload
+		order, err := file.loadOrdered(cmd, "connector", &payload)
+		if err != nil {
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
-		connectors, err := client.GetConnectors(connector.ClusterName)
+		connectors = append(connectors, api.Connector{ClusterName: payload.ClusterName, Name: payload.Name, Config: payload.Config})
+		orders[payload.Name] = order
+	}
 
-		if err != nil {
-			return err
-		}
+	current, err := currentConnectors(client, connectors)
+	if err != nil {
+		return err
+	}
 
-		existsOrUpdated := false
-		for _, name := range connectors {
-			if name == connector.Name {
-				c, err := client.GetConnector(connector.ClusterName, connector.Name)
+	result, err := Reconcile(Reconciler{
+		Kind: "connector",
+		Name: func(resource interface{}) string {
+			return resource.(api.Connector).Name
+		},
+		Equal: func(desired, current interface{}) bool {
+			return reflect.DeepEqual(desired.(api.Connector).Config, current.(api.Connector).Config)
+		},
+		Create: func(desired interface{}) error {
+			connector := desired.(api.Connector)
+			if _, err := client.CreateConnector(connector.ClusterName, connector.Name, connector.Config); err != nil {
+				return err
+			}
 
-				if err != nil {
-					return err
-				}
+			time.Sleep(connectorCreateDelay)
+			return nil
+		},
+		Update: func(desired, _ interface{}) error {
+			connector := desired.(api.Connector)
+			_, err := client.UpdateConnector(connector.ClusterName, connector.Name, connector.Config)
+			return err
+		},
+		Ordering: func(desired interface{}) ImportOrder {
+			return orders[desired.(api.Connector).Name]
+		},
+		ContinueOnError: keepGoing,
+		Logger:          client.Logger(),
+	}, connectors, current)
 
-				if !reflect.DeepEqual(c.Config, connector.Config) {
-					_, errU := client.UpdateConnector(connector.ClusterName, connector.Name, connector.Config)
-					if errU != nil {
-						golog.Errorf("Error updating connector from file [%s]. [%s]", loadpath, errU.Error())
-						return errU
-					}
+	if err != nil || !keepGoing {
+		return err
+	}
 
-					golog.Infof("Updated connector config for cluster [%s], connector [%s]", connector.ClusterName, connector.Name)
-					break
-				}
+	return summarize(client.Logger(), "connector", result, append(failed, result.Failed...))
+}
 
-				existsOrUpdated = true
-				break
-			}
-		}
+// currentConnectors returns the connectors of the clusters of the "desired" ones, the ones with the same name with their config,
+// the rest are named only, so the dependencies which are not in the files can exist already, see `ImportOrder`.
+func currentConnectors(client *api.Client, desired []api.Connector) ([]api.Connector, error) {
+	desiredNames := make(map[string]bool)
+	for _, connector := range desired {
+		desiredNames[connector.Name] = true
+	}
 
-		if existsOrUpdated {
+	var current []api.Connector
+	listed := make(map[string]bool)
+	for _, connector := range desired {
+		if listed[connector.ClusterName] {
 			continue
 		}
-		_, errC := client.CreateConnector(connector.ClusterName, connector.Name, connector.Config)
+		listed[connector.ClusterName] = true
 
-		if errC != nil {
-			golog.Errorf("Error creating connector from file [%s]. [%s]", loadpath, errC.Error())
-			return err
+		names, err := client.GetConnectors(connector.ClusterName)
+		if err != nil {
+			return nil, err
 		}
 
-		golog.Infof("Created/updated connector from [%s]", loadpath)
-		time.Sleep(10 * time.Second)
+		for _, name := range names {
+			existing := api.Connector{ClusterName: connector.ClusterName, Name: name}
+			if desiredNames[name] {
+				if existing, err = client.GetConnector(connector.ClusterName, name); err != nil {
+					return nil, err
+				}
+			}
+
+			current = append(current, existing)
+		}
 	}
 
-	return nil
+	return current, nil
 }
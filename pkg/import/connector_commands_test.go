@@ -0,0 +1,69 @@
+package imports
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportConnectorsOrder(t *testing.T) {
+	delay := connectorCreateDelay
+	connectorCreateDelay = 0
+	defer func() { connectorCreateDelay = delay }()
+
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["source", "legacy", "unchanged"]`))
+		case "GET /api/proxy-connect/dev/connectors/source":
+			w.Write([]byte(`{"name": "source", "config": {"connector.class": "Source", "tasks.max": "1"}}`))
+		case "GET /api/proxy-connect/dev/connectors/unchanged":
+			w.Write([]byte(`{"name": "unchanged", "config": {"connector.class": "Sink"}}`))
+		case "PUT /api/proxy-connect/dev/connectors/source/config", "POST /api/proxy-connect/dev/connectors":
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{}`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-connectors")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	connectorsDir := filepath.Join(dir, pkg.ConnectorsPath)
+	assert.Nil(t, os.MkdirAll(connectorsDir, 0755))
+
+	// the sink is read first, its dependency is set by a variable.
+	files := map[string]string{
+		"connector-dev-a-sink.yaml":      "clusterName: dev\nname: sink\nconfig:\n  connector.class: Sink\ndependsOn:\n- ${SOURCE}\n",
+		"connector-dev-b-source.yaml":    "clusterName: dev\nname: source\nconfig:\n  connector.class: Source\n  tasks.max: \"2\"\n",
+		"connector-dev-c-unchanged.yaml": "clusterName: dev\nname: unchanged\nconfig:\n  connector.class: Sink\ndependsOn:\n- legacy\n",
+	}
+	for name, contents := range files {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(connectorsDir, name), []byte(contents), 0644))
+	}
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "connectors", "--dir", dir, "--var", "SOURCE=source")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"PUT /api/proxy-connect/dev/connectors/source/config",
+		"POST /api/proxy-connect/dev/connectors",
+	}, requests)
+}
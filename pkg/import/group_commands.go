@@ -2,13 +2,13 @@ package imports
 
 import (
 	"fmt"
+	"reflect"
+	"strings"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,7 +26,7 @@ func NewImportGroupsCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.GroupsPath)
 			if err := loadGroups(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load user groups. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load user groups. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +34,7 @@ func NewImportGroupsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -42,53 +43,135 @@ func NewImportGroupsCommand() *cobra.Command {
 }
 
 func loadGroups(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading user groups from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
-
-	currentGroups, err := client.GetGroups()
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
 
+	client.Logger().Infof("Loading user groups from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
 	if err != nil {
 		return err
 	}
-	for _, file := range files {
 
+	var (
+		groups []api.Group
+		// the files and the groups which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
+
+	for _, file := range files {
 		var group api.Group
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &group); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+		if err := file.loadFile(cmd, "group", &group); err != nil {
+			client.Logger().Errorf("Error loading file [%s]", loadpath)
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
-		found := false
-		for _, g := range currentGroups {
-			if g.Name == group.Name {
-				found = true
-				payload := &api.Group{
-					Name:              group.Name,
-					Description:       group.Description,
-					Namespaces:        group.Namespaces,
-					ScopedPermissions: group.ScopedPermissions,
-					AdminPermissions:  group.AdminPermissions,
-				}
-
-				if err := config.Client.UpdateGroup(payload); err != nil {
-					golog.Errorf("Error updating user group [%s]. [%s]", group.Name, err.Error())
-					return err
-				}
-				golog.Infof("Updated group [%s]", group.Name)
+		groups = append(groups, group)
+	}
+
+	currentGroups, err := client.GetGroups()
+	if err != nil {
+		return err
+	}
+
+	creates, updates := reconcileGroups(client, currentGroups, groups)
+
+	for i := range creates {
+		group := creates[i]
+		if err := client.CreateGroup(&group); err != nil {
+			logResource(client.Logger(), "group", group.Name, "create").Errorf("Error creating user group [%s] from [%s] [%s]", group.Name, loadpath, err.Error())
+			if err = recordFailure(&failed, keepGoing, group.Name, err); err != nil {
+				return err
 			}
+			continue
 		}
+		logResource(client.Logger(), "group", group.Name, "create").Infof("Created user group [%s]", group.Name)
+		result.Created++
+	}
 
-		if found {
+	for i := range updates {
+		group := updates[i]
+		if err := client.UpdateGroup(&group); err != nil {
+			logResource(client.Logger(), "group", group.Name, "update").Errorf("Error updating user group [%s]. [%s]", group.Name, err.Error())
+			if err = recordFailure(&failed, keepGoing, group.Name, err); err != nil {
+				return err
+			}
 			continue
 		}
+		logResource(client.Logger(), "group", group.Name, "update").Infof("Updated group [%s]", group.Name)
+		result.Updated++
+	}
 
-		if err := client.CreateGroup(&group); err != nil {
-			golog.Errorf("Error creating user group [%s] from [%s] [%s]", group.Name, loadpath, err.Error())
-			return err
+	if !keepGoing {
+		return nil
+	}
+
+	return summarize(client.Logger(), "user group", result, failed)
+}
+
+// reconcileGroups matches the "groups" to the "existing" ones by name,
+// it returns the groups to create and the groups to update, the up to date groups are skipped.
+func reconcileGroups(client *api.Client, existing []api.Group, groups []api.Group) (creates, updates []api.Group) {
+	byName := make(map[string]api.Group, len(existing))
+	for _, g := range existing {
+		byName[g.Name] = g
+	}
+
+	for _, group := range groups {
+		// the accounts counters are read-only, not part of the payload.
+		payload := api.Group{
+			Name:              group.Name,
+			Description:       group.Description,
+			Namespaces:        group.Namespaces,
+			ScopedPermissions: group.ScopedPermissions,
+			AdminPermissions:  group.AdminPermissions,
+		}
+
+		current, found := byName[group.Name]
+		if !found {
+			creates = append(creates, payload)
+			continue
+		}
+
+		if current.Description == payload.Description &&
+			reflect.DeepEqual(current.Namespaces, payload.Namespaces) &&
+			reflect.DeepEqual(current.ScopedPermissions, payload.ScopedPermissions) &&
+			reflect.DeepEqual(current.AdminPermissions, payload.AdminPermissions) {
+			client.Logger().Debugf("User group [%s] is up to date", group.Name)
+			continue
 		}
-		golog.Infof("Created user group [%s]", group.Name)
 
+		updates = append(updates, payload)
 	}
 
-	return nil
+	return
+}
+
+// missingGroups returns the "referenced" group names that are not part of the "groups".
+func missingGroups(groups []api.Group, referenced []string) (missing []string) {
+	for _, name := range referenced {
+		found := false
+		for _, g := range groups {
+			if g.Name == name {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, name)
+		}
+	}
+
+	return
+}
+
+func errMissingGroups(kind, name string, missing []string) error {
+	return fmt.Errorf("%s [%s] references the missing user groups [%s], create or import the groups first",
+		kind, name, strings.Join(missing, ", "))
 }
@@ -0,0 +1,114 @@
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcileGroups(t *testing.T) {
+	namespaces := []api.Namespace{{Wildcards: []string{"*"}, Permissions: []string{"ShowTopic"}, System: "Kafka", Instance: "Dev"}}
+	existing := []api.Group{
+		{Name: "unchanged", Description: "d", Namespaces: namespaces, ScopedPermissions: []string{"ViewKafkaConsumers"}, UserAccountsCount: 3},
+		{Name: "changed", Description: "d", Namespaces: namespaces, ScopedPermissions: []string{"ViewKafkaConsumers"}},
+	}
+
+	groups := []api.Group{
+		// the accounts counters of exported groups do not count as a change.
+		{Name: "unchanged", Description: "d", Namespaces: namespaces, ScopedPermissions: []string{"ViewKafkaConsumers"}, UserAccountsCount: 1},
+		{Name: "changed", Description: "d", Namespaces: namespaces, ScopedPermissions: []string{"ViewKafkaConsumers", "ManageKafkaConsumers"}},
+		{Name: "new", AdminPermissions: []string{"ManageUsers"}, ServiceAccountsCount: 2},
+	}
+
+	creates, updates := reconcileGroups(nil, existing, groups)
+
+	assert.Equal(t, []api.Group{{Name: "new", AdminPermissions: []string{"ManageUsers"}}}, creates)
+	assert.Equal(t, []api.Group{groups[1]}, updates)
+}
+
+func TestMissingGroups(t *testing.T) {
+	groups := []api.Group{{Name: "a"}, {Name: "b"}}
+	assert.Nil(t, missingGroups(groups, []string{"a", "b"}))
+	assert.Equal(t, []string{"c"}, missingGroups(groups, []string{"a", "c"}))
+}
+
+func TestLoadAclsMissingGroup(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/acl":
+			assert.Equal(t, http.MethodGet, r.Method, "no acl should be created")
+			w.Write([]byte("[]"))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-acls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	acls := `[{"resourceType": "TOPIC", "resourceName": "t", "principal": "Group:ops", "permissionType": "Allow", "host": "*", "operation": "Read"}]`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "acls.json"), []byte(acls), 0644))
+
+	err = loadAcls(client, NewImportAclsCommand(), dir)
+	assert.EqualError(t, err, "acl of file [acls.json] references the missing user groups [ops], create or import the groups first")
+}
+
+func TestLoadAclsOnErrorContinue(t *testing.T) {
+	var created []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/acl" && r.Method == http.MethodGet:
+			w.Write([]byte("[]"))
+		case r.URL.Path == "/api/acl":
+			var acl api.ACL
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&acl))
+			created = append(created, acl.ResourceName)
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-acls")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, principal := range map[string]string{"acls-a.json": "Group:dev", "acls-b.json": "Group:ops", "acls-c.json": "User:bob"} {
+		acls := fmt.Sprintf(`[{"resourceType": "TOPIC", "resourceName": "%s", "principal": "%s", "permissionType": "Allow", "host": "*", "operation": "Read"}]`,
+			strings.TrimSuffix(name, ".json"), principal)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(acls), 0644))
+	}
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "acls-d.json"), []byte("not json"), 0644))
+
+	cmd := NewImportAclsCommand()
+	assert.Nil(t, cmd.Flags().Set(onErrorFlag, "continue"))
+	err = loadAcls(client, cmd, dir)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "failed to import 2 acl resources: [acls-b.json]: acl of file [acls-b.json] references the missing user groups [ops]")
+		assert.Contains(t, err.Error(), "[acls-d.json]: ")
+	}
+	assert.Equal(t, []string{"acls-a", "acls-c"}, created)
+}
@@ -1,8 +1,36 @@
 package imports
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
 	"github.com/landoop/bite"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/jsonschema"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// skipValidationFlag disables the validation of the files against their schema, see `validateDocument`.
+	skipValidationFlag = "skip-validation"
+	// varFlag sets the value of a ${VAR} placeholder of the files, it has priority over the environment variables.
+	varFlag = "var"
+	// allowUnsetFlag keeps the placeholders of the unset variables instead of failing, see `readFile`.
+	allowUnsetFlag = "allow-unset"
+	// manifestFlag is the path of the manifest.json of an export to verify the files against, see `importManifest`.
+	manifestFlag = "manifest"
+	// ignoreManifestFlag imports the files which don't match the manifest anyway,
+	// it's not named --force as the import processors has its own --force.
+	ignoreManifestFlag = "ignore-manifest"
 )
 
 //NewImportGroupCommand creates `import` command
@@ -15,13 +43,17 @@ import acls --landscape my-acls-dir
 import alert-settings --landscape my-acls-dir
 import connectors --landscape my-acls-dir
 import connections --landscape my-acls-dir
+import connections --landscape my-acls-dir --var KAFKA_SSLKEYPASSWORD=secret
+import topics --dir my-dir --set replication=3 --set /configs/retention.ms=3600000
+import topics --dir my-dir --manifest my-dir/manifest.json
 import processors  --landscape my-acls-dir
 import quota --landscape my-acls-dir
 import schemas --landscape my-acls-dir
 import topics --landscape my-acls-dir
 import policies --landscape my-acls-dir
 import groups --dir groups
-import serviceaccounts --dir serviceaccounts`,
+import serviceaccounts --dir serviceaccounts
+cat topics.yaml | import topics --stdin`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 	}
@@ -38,9 +70,365 @@ import serviceaccounts --dir serviceaccounts`,
 	cmd.AddCommand(NewImportGroupsCommand())
 	cmd.AddCommand(NewImportServiceAccountsCommand())
 
+	cmd.PersistentFlags().Bool(skipValidationFlag, false, "Do not validate the files against the schema of their resource before the import")
+	cmd.PersistentFlags().StringArray(varFlag, nil, "Value of a ${VAR} placeholder of the files, i.e --var KAFKA_PASSWORD=secret, "+
+		"can be defined multiple times, the placeholders are resolved against the environment variables as well")
+	cmd.PersistentFlags().StringArray(setFlag, nil, "Override a field of each file, i.e --set replication=3 or --set /configs/retention.ms=3600000, "+
+		"can be defined multiple times, the path is dot separated or a JSON pointer for the keys with dots, "+
+		"a dot separated path matches the longest existing key with dots first, i.e configs.retention.ms sets the retention.ms of the configs, "+
+		"the array elements are set by their index and the value is converted to the type of the field. "+
+		"The overrides are applied after the --var placeholders are resolved, so they win over them and their values are not resolved")
+	cmd.PersistentFlags().Bool(allowUnsetFlag, false, "Import the placeholders of the unset variables as they are instead of failing")
+	cmd.PersistentFlags().String(manifestFlag, "", "The manifest.json of the export, written by export --manifest, "+
+		"the files which are not listed or their SHA-256 checksum does not match are refused")
+	cmd.PersistentFlags().Bool(ignoreManifestFlag, false, "Import the files which do not match the --manifest anyway")
+	cmd.PersistentFlags().Bool(stdinFlag, false, "Read the resources from the standard input instead of the --dir, "+
+		"a JSON document, a stream of JSON documents, one per line, or YAML documents separated by ---")
+
 	return cmd
 }
 
-func load(cmd *cobra.Command, path string, data interface{}) error {
-	return bite.TryReadFile(path, data)
+func load(cmd *cobra.Command, path string, manifest *importManifest, resource string, data interface{}) error {
+	contents, err := readFile(cmd, path, manifest)
+	if err != nil {
+		return err
+	}
+
+	return decodeContents(cmd, path, resource, contents, data)
+}
+
+// decodeContents decodes the already read "contents" of the file to the "data", see `decodeDocument`.
+func decodeContents(cmd *cobra.Command, path, resource string, contents []byte, data interface{}) error {
+	doc, err := decodeDocument(cmd, path, resource, contents)
+	if err != nil {
+		return err
+	}
+
+	return decodeValue(path, doc, data)
+}
+
+// decodeDocument decodes the "contents" of the "resource" file to a document of objects, arrays and values,
+// then it resolves the ${VAR} placeholders of its string values, applies the --set overrides and validates it,
+// see `resolveVariables`, `applyOverrides` and `validateDocument`. The placeholders are resolved on the decoded values,
+// so the quotes, the newlines or the YAML indicators of their values can't break the file or add fields to it.
+func decodeDocument(cmd *cobra.Command, path, resource string, contents []byte) (interface{}, error) {
+	var (
+		doc interface{}
+		err error
+	)
+
+	if isYAMLFile(path) {
+		err = yaml.Unmarshal(contents, &doc)
+		doc = normalizeYAML(doc)
+	} else {
+		dec := json.NewDecoder(bytes.NewReader(contents))
+		dec.UseNumber()
+		err = dec.Decode(&doc)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the file [%s]: %v", path, err)
+	}
+
+	schema, err := jsonschema.GenerateResource(resource, fileTag(path))
+	if err != nil {
+		return nil, err
+	}
+
+	if doc, err = resolveVariables(cmd, path, doc, schema); err != nil {
+		return nil, err
+	}
+
+	if doc, err = applyOverrides(cmd, path, doc, schema); err != nil {
+		return nil, err
+	}
+
+	if err = validateDocument(cmd, path, resource, doc, schema); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+// decodeValue decodes the "doc", see `decodeDocument`, to the "data" as the YAML or the JSON file of the "path" would be.
+func decodeValue(path string, doc, data interface{}) error {
+	var (
+		contents []byte
+		err      error
+	)
+
+	if isYAMLFile(path) {
+		contents, err = yaml.Marshal(doc)
+	} else {
+		contents, err = json.Marshal(doc)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return decodeFile(path, contents, data)
+}
+
+// loadFile is like `bite.LoadFile` but it resolves the ${VAR} placeholders and validates the file first,
+// see `decodeDocument`.
+func loadFile(cmd *cobra.Command, path string, manifest *importManifest, resource string, data interface{}) error {
+	if err := bite.PrintInfo(cmd, "Loading from file '%s'", path); err != nil {
+		return err
+	}
+
+	return load(cmd, path, manifest, resource, data)
+}
+
+// readFile returns the contents of the file, verified against the "manifest", the --manifest, see `importManifest`.
+func readFile(cmd *cobra.Command, path string, manifest *importManifest) ([]byte, error) {
+	contents, err := bite.TryReadFileContents(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = manifest.verify(cmd, path, contents); err != nil {
+		return nil, err
+	}
+
+	return contents, nil
+}
+
+// resolveVariables resolves the ${VAR} placeholders of the string values of the "doc" of the file, see `substituteVariables`.
+// A value which is a single placeholder is converted to the type of its field in the "schema", i.e `partitions: ${PARTITIONS}`,
+// the rest of the values are kept as strings.
+func resolveVariables(cmd *cobra.Command, path string, doc interface{}, schema *jsonschema.Schema) (interface{}, error) {
+	vars := make(map[string]string)
+	if flag := cmd.Flag(varFlag); flag != nil {
+		if values, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, kv := range values.GetSlice() {
+				idx := strings.IndexByte(kv, '=')
+				if idx <= 0 {
+					return nil, fmt.Errorf("invalid --%s [%s], expected key=value", varFlag, kv)
+				}
+				vars[kv[:idx]] = kv[idx+1:]
+			}
+		}
+	}
+
+	allowUnset := false
+	if flag := cmd.Flag(allowUnsetFlag); flag != nil {
+		allowUnset = flag.Value.String() == "true"
+	}
+
+	unset := make(map[string]bool)
+	doc, err := resolveValue(doc, vars, schema, unset)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve the variables of the file [%s]: %v", path, err)
+	}
+
+	if len(unset) > 0 && !allowUnset {
+		names := make([]string, 0, len(unset))
+		for name := range unset {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("unset variables in the file [%s]: %s, set them or use --%s to import them as they are",
+			path, strings.Join(names, ", "), allowUnsetFlag)
+	}
+
+	return doc, nil
+}
+
+// resolveValue resolves the placeholders of the string values of the "node", the names of the unset variables are added to the "unset".
+func resolveValue(node interface{}, vars map[string]string, schema *jsonschema.Schema, unset map[string]bool) (interface{}, error) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		for key, field := range n {
+			value, err := resolveValue(field, vars, propertySchema(schema, key), unset)
+			if err != nil {
+				return nil, err
+			}
+			n[key] = value
+		}
+	case []interface{}:
+		var itemSchema *jsonschema.Schema
+		if schema != nil {
+			itemSchema = schema.Items
+		}
+
+		for i, item := range n {
+			value, err := resolveValue(item, vars, itemSchema, unset)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = value
+		}
+	case string:
+		value, names := substituteVariables(n, vars)
+		for _, name := range names {
+			unset[name] = true
+		}
+
+		// converted only if the whole value is a single placeholder which is set.
+		if value == n || variablePattern.FindString(n) != n {
+			return value, nil
+		}
+
+		if schema == nil || schema.Type == "" || schema.Type == jsonschema.TypeString {
+			return value, nil
+		}
+
+		converted, err := coerceValue(value, schema, nil)
+		if err != nil {
+			return nil, fmt.Errorf("the value of %s: %v", n, err)
+		}
+		return converted, nil
+	}
+
+	return node, nil
+}
+
+// importManifest is the --manifest of an import, it's read once by the `findFiles` and shared by its files.
+type importManifest struct {
+	path string
+	// dir is the absolute directory of the manifest, its paths are relative to it, the export directory.
+	dir string
+	utils.Manifest
+}
+
+// readManifest reads the --manifest of the "cmd", it returns nil if it's not set.
+func readManifest(cmd *cobra.Command) (*importManifest, error) {
+	flag := cmd.Flag(manifestFlag)
+	if flag == nil || flag.Value.String() == "" {
+		return nil, nil
+	}
+
+	manifestPath := flag.Value.String()
+	manifest, err := utils.ReadManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(manifestPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return &importManifest{path: manifestPath, dir: dir, Manifest: manifest}, nil
+}
+
+// verify checks the "contents" of the file, as read, against the manifest, a nil manifest accepts every file.
+func (m *importManifest) verify(cmd *cobra.Command, path string, contents []byte) error {
+	if m == nil {
+		return nil
+	}
+
+	file, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(m.dir, file)
+	if err != nil {
+		return err
+	}
+
+	if err = m.Verify(rel, contents); err != nil {
+		if flag := cmd.Flag(ignoreManifestFlag); flag != nil && flag.Value.String() == "true" {
+			config.Client.Logger().Warnf("Importing the file [%s] anyway: %v", path, err)
+			return nil
+		}
+
+		return fmt.Errorf("manifest [%s]: %v, use --%s to import it anyway", m.path, err, ignoreManifestFlag)
+	}
+
+	return nil
+}
+
+var variablePattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// substituteVariables replaces the ${VAR} placeholders of the "value" with the "vars", the --var flags,
+// or with the environment variables. The placeholders of the variables which are not set are kept as they are
+// and their sorted names are returned.
+func substituteVariables(value string, vars map[string]string) (string, []string) {
+	var unset []string
+
+	value = variablePattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := placeholder[2 : len(placeholder)-1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+
+		for _, s := range unset {
+			if s == name {
+				return placeholder
+			}
+		}
+
+		unset = append(unset, name)
+		return placeholder
+	})
+
+	sort.Strings(unset)
+	return value, unset
+}
+
+// isYAMLFile reports whether the file of the "path" is YAML, based on its extension, the rest are JSON.
+func isYAMLFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".yml", ".yaml":
+		return true
+	default:
+		return false
+	}
+}
+
+// fileTag returns the struct tag of the fields of the file of the "path", "yaml" or "json".
+func fileTag(path string) string {
+	if isYAMLFile(path) {
+		return "yaml"
+	}
+
+	return "json"
+}
+
+// decodeFile decodes the "contents" of the file, as YAML or JSON based on the extension of its "path".
+func decodeFile(path string, contents []byte, data interface{}) error {
+	if isYAMLFile(path) {
+		return yaml.Unmarshal(contents, data)
+	}
+
+	return json.Unmarshal(contents, data)
+}
+
+func skipValidation(cmd *cobra.Command) bool {
+	flag := cmd.Flag(skipValidationFlag)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// validateDocument checks the decoded "doc" of the file against the "schema" of its "resource", see `jsonschema.Resources`,
+// so the misspelled keys are reported with their path instead of being silently dropped by the decoder.
+func validateDocument(cmd *cobra.Command, path, resource string, doc interface{}, schema *jsonschema.Schema) error {
+	if skipValidation(cmd) {
+		return nil
+	}
+
+	// the ordering fields are not part of the resource, see `ImportOrder`.
+	if fields, ok := doc.(map[string]interface{}); ok {
+		resourceFields := make(map[string]interface{}, len(fields))
+		for key, field := range fields {
+			resourceFields[key] = field
+		}
+		for _, field := range importOrderFields {
+			delete(resourceFields, field)
+		}
+		doc = resourceFields
+	}
+
+	if err := schema.Validate(doc); err != nil {
+		return fmt.Errorf("invalid %s file [%s]: %v, use --%s to import it anyway", resource, path, err, skipValidationFlag)
+	}
+
+	return nil
 }
@@ -0,0 +1,272 @@
+package imports
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/jsonschema"
+	"github.com/landoop/lenses-go/pkg/utils"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstituteVariables(t *testing.T) {
+	os.Setenv("LENSES_TEST_OWNER", "admin")
+	defer os.Unsetenv("LENSES_TEST_OWNER")
+
+	value, unset := substituteVariables("owner: ${LENSES_TEST_OWNER}\nname: ${NAME}\npassword: ${PASSWORD}\ntoken: ${TOKEN}${PASSWORD}\nkeep: $NAME",
+		map[string]string{"NAME": "ingestion"})
+
+	assert.Equal(t, "owner: admin\nname: ingestion\npassword: ${PASSWORD}\ntoken: ${TOKEN}${PASSWORD}\nkeep: $NAME", value)
+	assert.Equal(t, []string{"PASSWORD", "TOKEN"}, unset)
+}
+
+func newVariablesCommand(t *testing.T, vars ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringArray(varFlag, nil, "")
+	cmd.Flags().Bool(allowUnsetFlag, false, "")
+	for _, v := range vars {
+		if err := cmd.Flags().Set(varFlag, v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return cmd
+}
+
+func TestDecodeContentsVariablesEscaping(t *testing.T) {
+	// the values are not spliced into the text of the file, so they can't break it or add fields to it.
+	password := "p\"a\\ss: 1\n- admin\nreadOnly: true"
+	for _, value := range []string{password, "*anchor", "&anchor", "!tag", "@at", "'quoted'"} {
+		cmd := newVariablesCommand(t, "PASSWORD="+value)
+
+		var connection api.Connection
+		assert.Nil(t, decodeContents(cmd, "connection-kafka.yaml", "connection",
+			[]byte("name: kafka\ntemplateName: Kafka\nreadOnly: false\nconfiguration:\n- key: sslKeyPassword\n  value: ${PASSWORD}\n"), &connection), value)
+		assert.Equal(t, []api.ConnectionConfig{{Key: "sslKeyPassword", Value: value}}, connection.Configuration, value)
+		assert.False(t, connection.ReadOnly, value)
+
+		connection = api.Connection{}
+		assert.Nil(t, decodeContents(cmd, "connection-kafka.json", "connection",
+			[]byte(`{"name": "kafka", "templateName": "Kafka", "configuration": [{"key": "sslKeyPassword", "value": "${PASSWORD}"}]}`), &connection), value)
+		assert.Equal(t, []api.ConnectionConfig{{Key: "sslKeyPassword", Value: value}}, connection.Configuration, value)
+	}
+}
+
+func TestDecodeContentsVariablesTypes(t *testing.T) {
+	cmd := newVariablesCommand(t, "PARTITIONS=6", "ENV=prod")
+
+	// a single placeholder is converted to the type of its field, the rest are strings.
+	var topic api.CreateTopicPayload
+	assert.Nil(t, decodeContents(cmd, "topic-orders.yaml", "topic",
+		[]byte("name: orders-${ENV}\nreplication: 1\npartitions: ${PARTITIONS}\nconfigs:\n  retention.ms: ${PARTITIONS}\n"), &topic))
+	assert.Equal(t, "orders-prod", topic.TopicName)
+	assert.Equal(t, 6, topic.Partitions)
+	assert.Equal(t, "6", topic.Configs["retention.ms"])
+
+	topic = api.CreateTopicPayload{}
+	assert.Nil(t, decodeContents(cmd, "topic-orders.json", "topic",
+		[]byte(`{"topicName": "orders", "replication": 1, "partitions": "${PARTITIONS}"}`), &topic))
+	assert.Equal(t, 6, topic.Partitions)
+
+	err := decodeContents(newVariablesCommand(t, "PARTITIONS=many"), "topic-orders.yaml", "topic",
+		[]byte("name: orders\nreplication: 1\npartitions: ${PARTITIONS}\n"), &topic)
+	assert.EqualError(t, err, "unable to resolve the variables of the file [topic-orders.yaml]: the value of ${PARTITIONS}: [many] is not an integer")
+}
+
+func TestImportVariables(t *testing.T) {
+	var created api.ServiceAccount
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, "[]") {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount":
+			if r.Method == http.MethodPost {
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&created))
+				w.Write([]byte(`{"token": "t"}`))
+				return
+			}
+			w.Write([]byte("[]"))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-variables")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, pkg.ServiceAccountsPath)
+	assert.Nil(t, os.MkdirAll(svcAccsDir, 0755))
+
+	file := filepath.Join(svcAccsDir, "svc-accounts-ingestion.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: ingestion\nowner: ${SVC_OWNER}\ngroups:\n- ${SVC_GROUP}\n"), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir)
+	assert.EqualError(t, err, "unset variables in the file ["+file+"]: SVC_GROUP, SVC_OWNER, set them or use --allow-unset to import them as they are")
+	assert.Empty(t, created.Name, "no service account should be created with unset variables")
+
+	os.Setenv("SVC_GROUP", "dev")
+	defer os.Unsetenv("SVC_GROUP")
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--var", "SVC_OWNER=admin=1")
+	assert.Nil(t, err)
+	assert.Equal(t, "ingestion", created.Name)
+	assert.Equal(t, "admin=1", created.Owner)
+	assert.Equal(t, []string{"dev"}, created.Groups)
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--var", "SVC_OWNER")
+	assert.EqualError(t, err, "invalid --var [SVC_OWNER], expected key=value")
+}
+
+func TestImportManifest(t *testing.T) {
+	var created []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, "[]") {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount":
+			if r.Method == http.MethodPost {
+				var svcAcc api.ServiceAccount
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&svcAcc))
+				created = append(created, svcAcc.Name)
+				w.Write([]byte(`{"token": "t"}`))
+				return
+			}
+			w.Write([]byte("[]"))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-manifest")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, pkg.ServiceAccountsPath)
+	assert.Nil(t, os.MkdirAll(svcAccsDir, 0755))
+
+	file := filepath.Join(svcAccsDir, "svc-accounts-ingestion.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: ingestion\ngroups:\n- dev\n"), 0644))
+
+	var manifest utils.Manifest
+	assert.Nil(t, manifest.Add(dir, pkg.ServiceAccountsPath+"/svc-accounts-ingestion.yaml"))
+	assert.Nil(t, utils.WriteManifest(dir, manifest))
+	manifestPath := filepath.Join(dir, utils.ManifestFileName)
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--manifest", manifestPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ingestion"}, created)
+
+	// modified after the export.
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: ingestion\nowner: admin\ngroups:\n- dev\n"), 0644))
+	created = nil
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--manifest", manifestPath)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch of the file [service-accounts/svc-accounts-ingestion.yaml]")
+	assert.Contains(t, err.Error(), "use --ignore-manifest to import it anyway")
+	assert.Empty(t, created, "no service account should be created when the checksum does not match")
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--manifest", manifestPath, "--ignore-manifest")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"ingestion"}, created)
+}
+
+// populate sets every exported field of the "v" to a non-zero value, so the round trip covers all of them.
+func populate(v reflect.Value) {
+	if values, ok := jsonschema.Enums[v.Type()]; ok {
+		v.SetString(values[0])
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("value")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(1.5)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(v.Elem())
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		populate(v.Index(0))
+	case reflect.Map:
+		key, value := reflect.New(v.Type().Key()).Elem(), reflect.New(v.Type().Elem()).Elem()
+		populate(key)
+		populate(value)
+		v.Set(reflect.MakeMap(v.Type()))
+		v.SetMapIndex(key, value)
+	case reflect.Interface:
+		v.Set(reflect.ValueOf("value"))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				populate(v.Field(i))
+			}
+		}
+	}
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	// the files are written as the export commands write them, see `utils.WriteFile`,
+	// and they must pass the validation of the import, the schemas don't allow unknown fields.
+	cmd := newVariablesCommand(t)
+	for _, resource := range jsonschema.ResourceNames() {
+		exported := reflect.New(reflect.TypeOf(jsonschema.Resources[resource]))
+		populate(exported.Elem())
+
+		yamlContents, err := utils.ToYaml(exported.Interface())
+		assert.Nil(t, err, resource)
+		jsonContents, err := json.Marshal(exported.Interface())
+		assert.Nil(t, err, resource)
+
+		for path, contents := range map[string][]byte{resource + ".yaml": yamlContents, resource + ".json": jsonContents} {
+			doc, err := decodeDocument(cmd, path, resource, contents)
+			if !assert.Nil(t, err, path) {
+				continue
+			}
+
+			imported := reflect.New(exported.Elem().Type())
+			assert.Nil(t, decodeValue(path, doc, imported.Interface()), path)
+			assert.Equal(t, exported.Interface(), imported.Interface(), path)
+		}
+	}
+}
@@ -2,13 +2,12 @@ package imports
 
 import (
 	"fmt"
+	"reflect"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,7 +25,7 @@ func NewImportPoliciesCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.PoliciesPath)
 			if err := loadPolicies(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load policies. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load policies. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +33,7 @@ func NewImportPoliciesCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -42,53 +42,114 @@ func NewImportPoliciesCommand() *cobra.Command {
 }
 
 func loadPolicies(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading data policies from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
-
-	polices, err := client.GetPolicies()
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
 
+	client.Logger().Infof("Loading data policies from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
 	if err != nil {
 		return err
 	}
 
-	for _, file := range files {
+	var (
+		policies []api.DataPolicyRequest
+		// the files and the policies which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
 
+	for _, file := range files {
 		var policy api.DataPolicyRequest
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &policy); err != nil {
-			return err
+		if err := file.loadFile(cmd, "policy", &policy); err != nil {
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
-		found := false
-
-		for _, p := range polices {
-			if p.Name == policy.Name {
-				found = true
-
-				payload := api.DataPolicyUpdateRequest{
-					ID:          p.ID,
-					Name:        p.Name,
-					Category:    p.Category,
-					ImpactType:  p.ImpactType,
-					Obfuscation: p.Obfuscation,
-					Fields:      p.Fields,
-				}
-
-				if err := client.UpdatePolicy(payload); err != nil {
-					golog.Errorf("Error updating data policy [%s]. [%s]", p.Name, err.Error())
-					return err
-				}
-				golog.Infof("Updated policy [%s]", p.Name)
+		if err := policy.Validate(); err != nil {
+			err = fmt.Errorf("invalid data policy file [%s]: %v", file.Name(), err)
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
 			}
+			continue
 		}
 
-		if !found {
-			if err := client.CreatePolicy(policy); err != nil {
-				golog.Errorf("Error creating data policy [%s]. [%s]", policy.Name, err.Error())
+		policies = append(policies, policy)
+	}
+
+	existing, err := client.GetPolicies()
+	if err != nil {
+		return err
+	}
+
+	creates, updates := reconcilePolicies(client, existing, policies)
+
+	for _, policy := range creates {
+		if err := client.CreatePolicy(policy); err != nil {
+			logResource(client.Logger(), "data policy", policy.Name, "create").Errorf("Error creating data policy [%s]. [%s]", policy.Name, err.Error())
+			if err = recordFailure(&failed, keepGoing, policy.Name, err); err != nil {
 				return err
 			}
-			golog.Infof("Created data policy [%s]", policy.Name)
+			continue
 		}
+		logResource(client.Logger(), "data policy", policy.Name, "create").Infof("Created data policy [%s]", policy.Name)
+		result.Created++
+	}
+
+	for _, policy := range updates {
+		if err := client.UpdatePolicy(policy); err != nil {
+			logResource(client.Logger(), "data policy", policy.Name, "update").Errorf("Error updating data policy [%s]. [%s]", policy.Name, err.Error())
+			if err = recordFailure(&failed, keepGoing, policy.Name, err); err != nil {
+				return err
+			}
+			continue
+		}
+		logResource(client.Logger(), "data policy", policy.Name, "update").Infof("Updated policy [%s]", policy.Name)
+		result.Updated++
+	}
+
+	if !keepGoing {
+		return nil
+	}
+
+	return summarize(client.Logger(), "data policy", result, failed)
+}
+
+// reconcilePolicies matches the "policies" to the "existing" ones by name,
+// it returns the policies to create and the updates of the existing policies that differ.
+func reconcilePolicies(client *api.Client, existing []api.DataPolicy, policies []api.DataPolicyRequest) (creates []api.DataPolicyRequest, updates []api.DataPolicyUpdateRequest) {
+	byName := make(map[string]api.DataPolicy, len(existing))
+	for _, p := range existing {
+		byName[p.Name] = p
+	}
+
+	for _, policy := range policies {
+		current, found := byName[policy.Name]
+		if !found {
+			creates = append(creates, policy)
+			continue
+		}
+
+		update := api.DataPolicyUpdateRequest{
+			ID:          current.ID,
+			Name:        policy.Name,
+			Category:    policy.Category,
+			ImpactType:  policy.ImpactType,
+			Obfuscation: policy.Obfuscation,
+			Fields:      policy.Fields,
+		}
+
+		if current.Category == update.Category && current.ImpactType == update.ImpactType &&
+			current.Obfuscation == update.Obfuscation && reflect.DeepEqual(current.Fields, update.Fields) {
+			client.Logger().Debugf("Data policy [%s] is up to date", policy.Name)
+			continue
+		}
+
+		updates = append(updates, update)
 	}
 
-	return nil
+	return
 }
@@ -0,0 +1,35 @@
+package imports
+
+import (
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcilePolicies(t *testing.T) {
+	existing := []api.DataPolicy{
+		{ID: "1", Name: "unchanged", Category: "Address", ImpactType: "HIGH", Obfuscation: "Email", Fields: []string{"address"}},
+		{ID: "2", Name: "changed", Category: "Address", ImpactType: "HIGH", Obfuscation: "Email", Fields: []string{"address"}},
+		{ID: "3", Name: "not-in-files", Category: "Address", ImpactType: "LOW", Obfuscation: "All", Fields: []string{"name"}},
+	}
+
+	policies := []api.DataPolicyRequest{
+		{Name: "unchanged", Category: "Address", ImpactType: "HIGH", Obfuscation: "Email", Fields: []string{"address"}},
+		{Name: "changed", Category: "Address", ImpactType: "MEDIUM", Obfuscation: "Last-4", Fields: []string{"address", "phone"}},
+		{Name: "new", Category: "Name", ImpactType: "LOW", Obfuscation: "Initials", Fields: []string{"name"}},
+	}
+
+	creates, updates := reconcilePolicies(nil, existing, policies)
+
+	assert.Equal(t, []api.DataPolicyRequest{policies[2]}, creates)
+	// updates carry the file's values and the existing policy's id.
+	assert.Equal(t, []api.DataPolicyUpdateRequest{{
+		ID:          "2",
+		Name:        "changed",
+		Category:    "Address",
+		ImpactType:  "MEDIUM",
+		Obfuscation: "Last-4",
+		Fields:      []string{"address", "phone"},
+	}}, updates)
+}
@@ -7,9 +7,7 @@ import (
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 
-	"github.com/kataras/golog"
 	"github.com/spf13/cobra"
 )
 
@@ -17,19 +15,22 @@ var importDir string
 
 //NewImportProcessorsCommand import processors command
 func NewImportProcessorsCommand() *cobra.Command {
-	var path string
+	var (
+		path, namespace string
+		force           bool
+	)
 
 	cmd := &cobra.Command{
 		Use:              "processors",
 		Short:            "processors",
-		Example:          `import processors --dir /my-landscape --ignore-errors`,
+		Example:          `import processors --dir /my-landscape --ignore-errors [--namespace my-namespace]`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.SQLPath)
-			if err := loadProcessors(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load processors. [%s]", err.Error())
+			if err := loadProcessors(config.Client, cmd, path, namespace, force); err != nil {
+				config.Client.Logger().Errorf("Failed to load processors. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -37,6 +38,9 @@ func NewImportProcessorsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	cmd.Flags().StringVar(&namespace, "namespace", "", "Import only to this namespace, available only in KUBERNETES mode")
+	cmd.Flags().BoolVar(&force, "force", false, "Import the processors of other namespaces than the --namespace too")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -44,42 +48,92 @@ func NewImportProcessorsCommand() *cobra.Command {
 	return cmd
 }
 
-func loadProcessors(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading processors from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+// loadProcessors imports the processors of the "loadpath" directory,
+// if the "namespace" is not empty then the processors of other namespaces are refused, unless "force".
+func loadProcessors(client *api.Client, cmd *cobra.Command, loadpath, namespace string, force bool) error {
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
+
+	client.Logger().Infof("Loading processors from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
 
-	processors, err := client.GetProcessors()
+	// the forced processors may exist on other namespaces.
+	listNamespace := namespace
+	if force {
+		listNamespace = ""
+	}
+
+	processors, err := client.GetProcessorsInNamespace(listNamespace)
 
 	if err != nil {
-		golog.Errorf("Failed to retrieve processors. [%s]", err.Error())
+		client.Logger().Errorf("Failed to retrieve processors. [%s]", err.Error())
 	}
 
+	var (
+		// the files and the processors which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
+
 	for _, file := range files {
 
 		var processor api.CreateProcessorPayload
 
-		if err := load(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &processor); err != nil {
-			return err
+		if err := file.load(cmd, "processor", &processor); err != nil {
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
+		if namespace != "" && processor.Namespace != namespace {
+			if !force {
+				err := fmt.Errorf("processor [%s] of file [%s] belongs to the namespace [%s] instead of [%s], use --force to import it anyway",
+					processor.Name, file.Name(), processor.Namespace, namespace)
+				if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+					return err
+				}
+				continue
+			}
+
+			client.Logger().Warnf("Importing processor [%s] of file [%s] to its namespace [%s] instead of [%s]", processor.Name, file.Name(), processor.Namespace, namespace)
+		}
+
+		found := false
 		for _, p := range processors.Streams {
 			if processor.Name == p.Name &&
 				processor.ClusterName == p.ClusterName &&
 				processor.Namespace == p.Namespace {
+				found = true
 
 				if processor.Runners != p.Runners {
 					//scale
 					if err := client.UpdateProcessorRunners(p.ID, processor.Runners); err != nil {
-						golog.Errorf("Error scaling processor [%s] from file [%s/%s]. [%s]", p.ID, loadpath, file.Name(), err.Error())
-						return err
+						client.Logger().Errorf("Error scaling processor [%s] from file [%s/%s]. [%s]", p.ID, loadpath, file.Name(), err.Error())
+						if err = recordFailure(&failed, keepGoing, processor.Name, err); err != nil {
+							return err
+						}
+						break
 					}
-					golog.Infof("Scaled processor [%s] from file [%s/%s] from [%d] to [%d]", p.ID, loadpath, file.Name(), p.Runners, processor.Runners)
-					return nil
+					logResource(client.Logger(), "processor", p.ID, "scale").Infof("Scaled processor [%s] from file [%s/%s] from [%d] to [%d]", p.ID, loadpath, file.Name(), p.Runners, processor.Runners)
+					result.Updated++
+					break
 				}
-				golog.Warnf("Processor [%s] from file [%s/%s] already exists", p.ID, loadpath, file.Name())
+				client.Logger().Warnf("Processor [%s] from file [%s/%s] already exists", p.ID, loadpath, file.Name())
+				result.Unchanged++
 			}
 		}
 
+		// the existing processors are scaled, not created again.
+		if found {
+			continue
+		}
+
 		if err := client.CreateProcessor(
 			processor.Name,
 			processor.SQL,
@@ -88,12 +142,20 @@ func loadProcessors(client *api.Client, cmd *cobra.Command, loadpath string) err
 			processor.Namespace,
 			processor.Pipeline); err != nil {
 
-			golog.Errorf("Error creating processor from file [%s/%s]. [%s]", loadpath, file.Name(), err.Error())
-			return err
+			logResource(client.Logger(), "processor", processor.Name, "create").Errorf("Error creating processor from file [%s/%s]. [%s]", loadpath, file.Name(), err.Error())
+			if err = recordFailure(&failed, keepGoing, processor.Name, err); err != nil {
+				return err
+			}
+			continue
 		}
 
-		golog.Infof("Created processor from [%s/%s]", loadpath, file.Name())
+		logResource(client.Logger(), "processor", processor.Name, "create").Infof("Created processor from [%s/%s]", loadpath, file.Name())
+		result.Created++
+	}
+
+	if !keepGoing {
+		return nil
 	}
 
-	return nil
+	return summarize(client.Logger(), "processor", result, failed)
 }
@@ -0,0 +1,83 @@
+package imports
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadProcessorsNamespaceMismatch(t *testing.T) {
+	var creates int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/streams" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"streams": []}`))
+		case r.URL.Path == "/api/streams" && r.Method == http.MethodPost:
+			creates++
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-processors")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	processor := "name: p1\nsql: SET defaults.topic.autocreate=true; INSERT INTO b SELECT STREAM * FROM a\nrunners: 1\nclusterName: k8s\nnamespace: team-b\n"
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "processor-k8s-team-b-p1.yaml"), []byte(processor), 0644))
+
+	err = loadProcessors(client, NewImportProcessorsCommand(), dir, "team-a", false)
+	assert.EqualError(t, err, "processor [p1] of file [processor-k8s-team-b-p1.yaml] belongs to the namespace [team-b] instead of [team-a], use --force to import it anyway")
+	assert.Equal(t, 0, creates)
+
+	assert.Nil(t, loadProcessors(client, NewImportProcessorsCommand(), dir, "team-a", true))
+	assert.Equal(t, 1, creates)
+
+	assert.Nil(t, loadProcessors(client, NewImportProcessorsCommand(), dir, "team-b", false))
+	assert.Equal(t, 2, creates)
+}
+
+func TestLoadProcessorsExisting(t *testing.T) {
+	var requests []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/streams" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"streams": [
+				{"id": "1", "name": "p1", "runners": 1, "clusterName": "IN_PROC", "namespace": "lenses"},
+				{"id": "2", "name": "p2", "runners": 1, "clusterName": "IN_PROC", "namespace": "lenses"}
+			]}`))
+		default:
+			requests = append(requests, r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-processors")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for file, runners := range map[string]string{"processor-p1.yaml": "2", "processor-p2.yaml": "1", "processor-p3.yaml": "1"} {
+		name := file[len("processor-") : len(file)-len(".yaml")]
+		processor := "name: " + name + "\nsql: INSERT INTO b SELECT STREAM * FROM a\nrunners: " + runners + "\nclusterName: IN_PROC\nnamespace: lenses\n"
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, file), []byte(processor), 0644))
+	}
+
+	// the scaled and the up to date processors are not created again and the rest of the files are still imported.
+	assert.Nil(t, loadProcessors(client, NewImportProcessorsCommand(), dir, "", false))
+	assert.Equal(t, []string{"PUT /api/streams/1/scale/2", "POST /api/streams"}, requests)
+}
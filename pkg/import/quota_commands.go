@@ -3,13 +3,11 @@ package imports
 import (
 	"fmt"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
 	quotapkg "github.com/landoop/lenses-go/pkg/quota"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -27,7 +25,7 @@ func NewImportQuotasCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.QuotasPath)
 			if err := loadQuotas(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load quotas. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load quotas. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -35,6 +33,7 @@ func NewImportQuotasCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -43,8 +42,16 @@ func NewImportQuotasCommand() *cobra.Command {
 }
 
 func loadQuotas(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading quotas from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
+
+	client.Logger().Infof("Loading quotas from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
 
 	lensesQuotas, err := client.GetQuotas()
 	var lensesReq []api.CreateQuotaPayload
@@ -57,11 +64,20 @@ func loadQuotas(client *api.Client, cmd *cobra.Command, loadpath string) error {
 		lensesReq = append(lensesReq, lq.GetQuotaAsRequest())
 	}
 
+	var (
+		// the files which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
+
 	for _, file := range files {
 		var quotas []api.CreateQuotaPayload
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &quotas); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+		if err := file.loadFile(cmd, "quota", &quotas); err != nil {
+			client.Logger().Errorf("Error loading file [%s]", loadpath)
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		for _, quota := range quotas {
@@ -79,6 +95,7 @@ func loadQuotas(client *api.Client, cmd *cobra.Command, loadpath string) error {
 			}
 
 			if found {
+				result.Unchanged++
 				continue
 			}
 
@@ -86,26 +103,39 @@ func loadQuotas(client *api.Client, cmd *cobra.Command, loadpath string) error {
 				quota.QuotaType == string(api.QuotaEntityClients) ||
 				quota.QuotaType == string(api.QuotaEntityClientsDefault) {
 				if err := quotapkg.CreateQuotaForClients(cmd, client, quota); err != nil {
-					golog.Errorf("Error creating/updating quota type [%s], client [%s], user [%s] from [%s]. [%s]",
+					client.Logger().Errorf("Error creating/updating quota type [%s], client [%s], user [%s] from [%s]. [%s]",
 						quota.QuotaType, quota.ClientID, quota.User, loadpath, err.Error())
-					return err
+					if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+						return err
+					}
+					continue
 				}
 
-				golog.Infof("Created/updated quota type [%s], client [%s], user [%s] from [%s]",
+				client.Logger().Infof("Created/updated quota type [%s], client [%s], user [%s] from [%s]",
 					quota.QuotaType, quota.ClientID, quota.User, loadpath)
+				result.Created++
 				continue
 
 			}
 
 			if err := quotapkg.CreateQuotaForUsers(cmd, client, quota); err != nil {
-				golog.Errorf("Error creating/updating quota type [%s], client [%s], user [%s] from [%s]. [%s]",
+				client.Logger().Errorf("Error creating/updating quota type [%s], client [%s], user [%s] from [%s]. [%s]",
 					quota.QuotaType, quota.ClientID, quota.User, loadpath, err.Error())
-				return err
+				if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+					return err
+				}
+				continue
 			}
 
-			golog.Infof("Created/updated quota type [%s], client [%s], user [%s] from [%s]",
+			client.Logger().Infof("Created/updated quota type [%s], client [%s], user [%s] from [%s]",
 				quota.QuotaType, quota.ClientID, quota.User, loadpath)
+			result.Created++
 		}
 	}
-	return nil
+
+	if !keepGoing {
+		return nil
+	}
+
+	return summarize(client.Logger(), "quota", result, failed)
 }
@@ -0,0 +1,598 @@
+package imports
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/kataras/survey"
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// dryRunFlag makes the importers which use the `Reconcile` print the changes without applying them.
+	dryRunFlag = "dry-run"
+	// pruneFlag makes the importers which use the `Reconcile` delete the resources which are not in the files.
+	pruneFlag = "prune"
+	// yesFlag confirms the deletions of the --prune without a prompt.
+	yesFlag = "yes"
+	// forcePruneFlag lets the --prune delete all the resources when the files hold none, see `Reconciler#ForcePrune`.
+	forcePruneFlag = "force-prune"
+	// onErrorFlag is the policy of the importers which use the `Reconcile` when a file or a resource fails,
+	// see `continueOnError`.
+	onErrorFlag = "on-error"
+)
+
+func isDryRun(cmd *cobra.Command) bool {
+	flag := cmd.Flag(dryRunFlag)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// addPruneFlags adds the --prune, --yes and --force-prune flags of the "resource", see `isPrune`, `confirmPrune` and `isForcePrune`.
+func addPruneFlags(cmd *cobra.Command, resource string) {
+	cmd.Flags().Bool(pruneFlag, false, fmt.Sprintf("Delete the %s which are not in the files, after the rest are created or updated", resource))
+	cmd.Flags().Bool(yesFlag, false, "Delete the pruned resources without confirmation")
+	cmd.Flags().Bool(forcePruneFlag, false, fmt.Sprintf("Let the --%s delete all the %s when there are none in the files", pruneFlag, resource))
+}
+
+func isPrune(cmd *cobra.Command) bool {
+	flag := cmd.Flag(pruneFlag)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+func isForcePrune(cmd *cobra.Command) bool {
+	flag := cmd.Flag(forcePruneFlag)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// confirmPrune returns the `Reconciler#Confirm` of the "cmd", the deletions are confirmed by the --yes flag
+// or by a prompt, an error is returned if there is no terminal to prompt.
+func confirmPrune(cmd *cobra.Command) func(kind string, names []string) error {
+	return func(kind string, names []string) error {
+		if flag := cmd.Flag(yesFlag); flag != nil && flag.Value.String() == "true" {
+			return nil
+		}
+
+		what := fmt.Sprintf("the deletion of the %d pruned %s resources [%s]", len(names), kind, strings.Join(names, ", "))
+		if err := utils.RequirePrompt(what, "use --yes to confirm it"); err != nil {
+			return err
+		}
+
+		confirmed := false
+		if err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Delete the %s resources [%s], they are not in the files?", kind, strings.Join(names, ", ")),
+		}, &confirmed, nil); err != nil {
+			return err
+		}
+
+		if !confirmed {
+			return fmt.Errorf("the deletion of the pruned %s resources was not confirmed", kind)
+		}
+
+		return nil
+	}
+}
+
+// addOnErrorFlag adds the --on-error flag, "abort" stops on the first failed file or resource
+// and "continue" imports the rest and fails at the end, see `continueOnError` and `summarize`.
+func addOnErrorFlag(cmd *cobra.Command) {
+	cmd.Flags().String(onErrorFlag, "abort", "What to do when a file or a resource fails, abort on the first one or continue with the rest and fail at the end: abort, continue")
+}
+
+// continueOnError reports whether the --on-error of the "cmd" is "continue", it defaults to "abort".
+func continueOnError(cmd *cobra.Command) (bool, error) {
+	flag := cmd.Flag(onErrorFlag)
+	if flag == nil {
+		return false, nil
+	}
+
+	switch policy := flag.Value.String(); policy {
+	case "abort":
+		return false, nil
+	case "continue":
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid --%s [%s], expected abort or continue", onErrorFlag, policy)
+	}
+}
+
+// Failure is a file or a resource which failed to be imported under the --on-error continue.
+type Failure struct {
+	// Name is the file name or the resource name.
+	Name string
+	Err  error
+}
+
+// recordFailure records the "err" of the "name" file or resource to the "failed" ones under the --on-error continue,
+// so the import goes on with the rest, otherwise it returns the "err" to stop the import.
+func recordFailure(failed *[]Failure, keepGoing bool, name string, err error) error {
+	if !keepGoing {
+		return err
+	}
+
+	*failed = append(*failed, Failure{Name: name, Err: err})
+	return nil
+}
+
+// summarize logs the changes of an import of the "kind" resources and its "failed" files and resources,
+// it returns an error which lists the failures, if any.
+func summarize(logger api.Logger, kind string, result ReconcileResult, failed []Failure) error {
+	logger.Infof("Imported %s resources: [%d] created, [%d] updated, [%d] unchanged, [%d] deleted, [%d] failed",
+		kind, result.Created, result.Updated, result.Unchanged, result.Deleted, len(failed))
+
+	if len(failed) == 0 {
+		return nil
+	}
+
+	causes := make([]string, 0, len(failed))
+	for _, failure := range failed {
+		logger.Errorf("Failed to import [%s]. [%s]", failure.Name, failure.Err.Error())
+		causes = append(causes, fmt.Sprintf("[%s]: %v", failure.Name, failure.Err))
+	}
+
+	return fmt.Errorf("failed to import %d %s resources: %s", len(failed), kind, strings.Join(causes, ", "))
+}
+
+// logResource returns the "logger" with the "resource" kind, its "name" and the "action" as structured fields,
+// see `api.LoggerWithFields`.
+func logResource(logger api.Logger, resource, name, action string) api.Logger {
+	return api.LoggerWithFields(logger, api.Fields{"resource": resource, "name": name, "action": action})
+}
+
+// Reconciler describes how the resources of a kind are imported, see `Reconcile`.
+type Reconciler struct {
+	// Kind is the name of the resources on the logs, i.e "service account".
+	Kind string
+	// Name returns the key of a resource, a desired and a current resource with the same name are the same resource.
+	Name func(resource interface{}) string
+	// Equal reports whether the current resource is already in the desired state, so its update is skipped.
+	// If nil, the matched resources are always updated.
+	Equal func(desired, current interface{}) bool
+	// Create creates a desired resource which does not exist.
+	Create func(desired interface{}) error
+	// Update updates the current resource to the desired state.
+	Update func(desired, current interface{}) error
+	// Delete deletes a current resource which is not desired, see `Prune`.
+	Delete func(current interface{}) error
+	// Prune deletes the current resources which are not desired, after the rest are created or updated.
+	Prune bool
+	// ForcePrune lets the `Prune` delete all the current resources when there are no desired ones,
+	// i.e an empty directory, otherwise it's refused before any change, unless it's a `DryRun`.
+	ForcePrune bool
+	// Confirm is called with the names of the resources to prune before their deletion, an error aborts it.
+	// If nil, they are deleted without confirmation.
+	Confirm func(kind string, names []string) error
+	// DryRun logs the changes without calling the `Create`, the `Update` and the `Delete`.
+	DryRun bool
+	// ContinueOnError records the errors of the `Create`, the `Update` and the `Delete` to the `ReconcileResult#Failed`
+	// and continues with the rest of the resources, instead of stopping on the first one.
+	ContinueOnError bool
+	// Ordering returns the `ImportOrder` of a desired resource, the resources are created or updated
+	// by their `ImportOrder#Order` and after the resources of their `ImportOrder#DependsOn`, see `dependencyOrder`.
+	// If nil, they are created or updated in their order.
+	Ordering func(desired interface{}) ImportOrder
+	// CreateMany creates many desired resources which do not exist at once, instead of the `Create` one by one,
+	// when they are more than the `CreateManyThreshold`. It returns an `api.BulkError` if some of them failed.
+	// A resource which depends on another one of them is created on a later call, see `Ordering`.
+	CreateMany func(desired []interface{}) error
+	// CreateManyThreshold is the number of the resources to create above which the `CreateMany` is used.
+	CreateManyThreshold int
+	// Logger receives the changes, defaults to the `api.DefaultLogger`.
+	Logger api.Logger
+	// Cache looks up the current resources, once each for the whole run, when the current resources of the `Reconcile` are nil.
+	// The resources are invalidated after their writes.
+	Cache *ResourceCache
+}
+
+// ReconcileResult counts the changes of a `Reconcile`.
+type ReconcileResult struct {
+	Created, Updated, Unchanged, Deleted int
+	// Failed are the resources which failed under the `Reconciler#ContinueOnError`.
+	Failed []Failure
+}
+
+// Reconcile creates the "desired" resources which are missing from the "current" ones
+// and updates the ones which differ, both should be slices of the same type.
+// If the `Reconciler#Prune` is true, the current resources which are not desired are deleted afterwards.
+// It stops on the first error of the `Create`, the `Update` or the `Delete` and returns the changes so far,
+// unless the `Reconciler#ContinueOnError` is true.
+// The "current" resources may be nil to look them up by the `Reconciler#Cache`.
+func Reconcile(r Reconciler, desired, current interface{}) (result ReconcileResult, err error) {
+	logger := r.Logger
+	if logger == nil {
+		logger = api.DefaultLogger()
+	}
+
+	if r.Prune && !r.ForcePrune && !r.DryRun && reflect.ValueOf(desired).Len() == 0 {
+		err = fmt.Errorf("refusing to prune all the %s resources as none were loaded, use --%s to delete them", r.Kind, forcePruneFlag)
+		return
+	}
+
+	cache := r.Cache
+	if current != nil || cache == nil {
+		cache = &ResourceCache{
+			List: func() (interface{}, error) { return current, nil },
+			Name: r.Name,
+		}
+	}
+
+	desiredResources, err := orderDesired(r, reflect.ValueOf(desired), cache)
+	if err != nil {
+		return
+	}
+
+	// the resources to create by the next `Reconciler#CreateMany`, if they are many.
+	var (
+		bulk    bool
+		pending []interface{}
+	)
+	if r.CreateMany != nil && !r.DryRun {
+		var missing int
+		if missing, err = countMissing(r, desiredResources, cache); err != nil {
+			return
+		}
+		bulk = missing > r.CreateManyThreshold
+	}
+
+	desiredNames := make(map[string]bool)
+	for _, resource := range desiredResources {
+		name := r.Name(resource)
+		desiredNames[name] = true
+
+		if bulk && dependsOnAny(r, resource, pending) {
+			if err = createMany(r, logger, pending, &result); err != nil {
+				return
+			}
+			pending = nil
+		}
+
+		var (
+			existing interface{}
+			found    bool
+		)
+		if existing, found, err = cache.Lookup(name); err != nil {
+			return
+		}
+
+		if !r.DryRun {
+			// it may be written below, even a failed write may have changed it.
+			cache.Invalidate(name)
+		}
+
+		switch {
+		case !found && bulk:
+			pending = append(pending, resource)
+		case !found:
+			log := logResource(logger, r.Kind, name, "create")
+			if r.DryRun {
+				log.Infof("Would create %s [%s]", r.Kind, name)
+			} else if err = r.Create(resource); err != nil {
+				log.Errorf("Error creating %s [%s]. [%s]", r.Kind, name, err.Error())
+				if !r.ContinueOnError {
+					return
+				}
+				result.Failed = append(result.Failed, Failure{Name: name, Err: err})
+				err = nil
+				continue
+			} else {
+				log.Infof("Created %s [%s]", r.Kind, name)
+			}
+			result.Created++
+		case r.Equal != nil && r.Equal(resource, existing):
+			logResource(logger, r.Kind, name, "none").Infof("Unchanged %s [%s]", r.Kind, name)
+			result.Unchanged++
+		default:
+			log := logResource(logger, r.Kind, name, "update")
+			if r.DryRun {
+				log.Infof("Would update %s [%s]", r.Kind, name)
+			} else if err = r.Update(resource, existing); err != nil {
+				log.Errorf("Error updating %s [%s]. [%s]", r.Kind, name, err.Error())
+				if !r.ContinueOnError {
+					return
+				}
+				result.Failed = append(result.Failed, Failure{Name: name, Err: err})
+				err = nil
+				continue
+			} else {
+				log.Infof("Updated %s [%s]", r.Kind, name)
+			}
+			result.Updated++
+		}
+	}
+
+	if err = createMany(r, logger, pending, &result); err != nil {
+		return
+	}
+
+	if r.Prune {
+		var all interface{}
+		if all, err = cache.All(); err != nil {
+			return
+		}
+
+		err = prune(r, logger, reflect.ValueOf(all), desiredNames, &result)
+	}
+
+	return
+}
+
+// countMissing returns the number of the "desired" resources which are not "current".
+func countMissing(r Reconciler, desired []interface{}, current *ResourceCache) (n int, err error) {
+	for _, resource := range desired {
+		var found bool
+		if _, found, err = current.Lookup(r.Name(resource)); err != nil {
+			return
+		}
+
+		if !found {
+			n++
+		}
+	}
+
+	return
+}
+
+// dependsOnAny reports whether the "resource" depends on any of the "resources", see `Reconciler#Ordering`.
+func dependsOnAny(r Reconciler, resource interface{}, resources []interface{}) bool {
+	if r.Ordering == nil || len(resources) == 0 {
+		return false
+	}
+
+	for _, dependency := range r.Ordering(resource).DependsOn {
+		for _, other := range resources {
+			if r.Name(other) == dependency {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// createMany creates the "resources" by the `Reconciler#CreateMany` and counts them to the "result",
+// it returns the error of the call if some of them failed, unless the `Reconciler#ContinueOnError` is true.
+func createMany(r Reconciler, logger api.Logger, resources []interface{}, result *ReconcileResult) error {
+	if len(resources) == 0 {
+		return nil
+	}
+
+	err := r.CreateMany(resources)
+	bulkErr, partial := err.(api.BulkError)
+	for _, resource := range resources {
+		name := r.Name(resource)
+		log := logResource(logger, r.Kind, name, "create")
+
+		cause := err
+		if partial {
+			cause = bulkErr.Errors[name]
+		}
+
+		if cause != nil {
+			log.Errorf("Error creating %s [%s]. [%s]", r.Kind, name, cause.Error())
+			if r.ContinueOnError {
+				result.Failed = append(result.Failed, Failure{Name: name, Err: cause})
+			}
+			continue
+		}
+
+		log.Infof("Created %s [%s]", r.Kind, name)
+		result.Created++
+	}
+
+	if r.ContinueOnError {
+		return nil
+	}
+
+	return err
+}
+
+// orderDesired returns the "desired" resources in the order they should be created or updated, see `Reconciler#Ordering`,
+// it fails before any change if a dependency is neither desired nor current or if the dependencies form a cycle.
+func orderDesired(r Reconciler, desired reflect.Value, current *ResourceCache) ([]interface{}, error) {
+	resources := make([]interface{}, desired.Len())
+	for i := range resources {
+		resources[i] = desired.Index(i).Interface()
+	}
+
+	if r.Ordering == nil {
+		return resources, nil
+	}
+
+	names := make([]string, len(resources))
+	orders := make([]ImportOrder, len(resources))
+	for i, resource := range resources {
+		names[i] = r.Name(resource)
+		orders[i] = r.Ordering(resource)
+	}
+
+	// the dependencies which are not desired should exist already.
+	desiredNames := make(map[string]bool, len(names))
+	for _, name := range names {
+		desiredNames[name] = true
+	}
+
+	existing := make(map[string]bool)
+	for _, order := range orders {
+		for _, dependency := range order.DependsOn {
+			if desiredNames[dependency] {
+				continue
+			}
+
+			_, found, err := current.Lookup(dependency)
+			if err != nil {
+				return nil, err
+			}
+			existing[dependency] = found
+		}
+	}
+
+	indexes, err := dependencyOrder(r.Kind, names, orders, func(name string) bool {
+		return existing[name]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ordered := make([]interface{}, len(indexes))
+	for i, idx := range indexes {
+		ordered[i] = resources[idx]
+	}
+
+	return ordered, nil
+}
+
+// ImportOrder is the optional ordering of an imported file, declared by its top-level "dependsOn" and "order" fields,
+// i.e a sink connector which reads the topic of a source connector declares `dependsOn: [orders-source]`.
+type ImportOrder struct {
+	// DependsOn are the names of the resources of the same kind which are imported before this one.
+	DependsOn []string `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	// Order sorts the files, ascending, the dependencies are imported first anyway. Defaults to 0.
+	Order int `json:"order,omitempty" yaml:"order,omitempty"`
+}
+
+// importOrderFields are the fields of the `ImportOrder`, they are not part of the resources, see `validateContents`.
+var importOrderFields = []string{"dependsOn", "order"}
+
+// loadOrdered is like the `loadFile` but it returns the `ImportOrder` of the file too.
+// The order is decoded from the same document as the resource, so its ${VAR} placeholders and its --set overrides apply to it too.
+func (f importFile) loadOrdered(cmd *cobra.Command, resource string, data interface{}) (ImportOrder, error) {
+	doc, err := f.loadDocument(cmd, resource)
+	if err != nil {
+		return ImportOrder{}, err
+	}
+
+	if err = decodeValue(f.path, doc, data); err != nil {
+		return ImportOrder{}, err
+	}
+
+	var order ImportOrder
+	if err = decodeValue(f.path, doc, &order); err != nil {
+		return order, fmt.Errorf("unable to decode the dependsOn and the order of the file [%s]: %v", f.Name(), err)
+	}
+
+	return order, nil
+}
+
+// dependencyOrder returns the indexes of the "names" resources in the order they should be imported:
+// by their `ImportOrder#Order` and each one after the resources of its `ImportOrder#DependsOn`, otherwise in their order.
+// A dependency which is neither one of the "names" nor "exists" already is an error, so is a cycle.
+func dependencyOrder(kind string, names []string, orders []ImportOrder, exists func(name string) bool) ([]int, error) {
+	byName := make(map[string]int, len(names))
+	for i, name := range names {
+		byName[name] = i
+	}
+
+	sorted := make([]int, len(names))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return orders[sorted[i]].Order < orders[sorted[j]].Order
+	})
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		state   = make([]int, len(names))
+		ordered = make([]int, 0, len(names))
+		path    []string
+		visit   func(i int) error
+	)
+
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle between the %s resources [%s -> %s]", kind, strings.Join(path, " -> "), names[i])
+		}
+
+		state[i] = visiting
+		path = append(path, names[i])
+		for _, dependency := range orders[i].DependsOn {
+			j, desired := byName[dependency]
+			if !desired {
+				if exists == nil || !exists(dependency) {
+					return fmt.Errorf("%s [%s] depends on [%s] which is neither in the files nor exists", kind, names[i], dependency)
+				}
+				continue
+			}
+
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+
+		state[i] = visited
+		ordered = append(ordered, i)
+		return nil
+	}
+
+	for _, i := range sorted {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// prune deletes the "current" resources which are not in the "desired" names, in their order.
+func prune(r Reconciler, logger api.Logger, current reflect.Value, desired map[string]bool, result *ReconcileResult) error {
+	var (
+		orphans []interface{}
+		names   []string
+	)
+
+	for i := 0; i < current.Len(); i++ {
+		resource := current.Index(i).Interface()
+		if name := r.Name(resource); !desired[name] {
+			orphans = append(orphans, resource)
+			names = append(names, name)
+		}
+	}
+
+	if len(orphans) == 0 {
+		return nil
+	}
+
+	if r.DryRun {
+		for _, name := range names {
+			logResource(logger, r.Kind, name, "delete").Infof("Would delete %s [%s]", r.Kind, name)
+		}
+
+		result.Deleted += len(names)
+		return nil
+	}
+
+	if r.Confirm != nil {
+		if err := r.Confirm(r.Kind, names); err != nil {
+			return err
+		}
+	}
+
+	for i, resource := range orphans {
+		log := logResource(logger, r.Kind, names[i], "delete")
+		if err := r.Delete(resource); err != nil {
+			log.Errorf("Error deleting %s [%s]. [%s]", r.Kind, names[i], err.Error())
+			if !r.ContinueOnError {
+				return err
+			}
+			result.Failed = append(result.Failed, Failure{Name: names[i], Err: err})
+			continue
+		}
+
+		log.Infof("Deleted %s [%s]", r.Kind, names[i])
+		result.Deleted++
+	}
+
+	return nil
+}
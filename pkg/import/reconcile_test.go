@@ -0,0 +1,331 @@
+package imports
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type reconcileItem struct {
+	name, value string
+}
+
+func newTestReconciler(created, updated *[]string) Reconciler {
+	return Reconciler{
+		Kind: "item",
+		Name: func(resource interface{}) string {
+			return resource.(reconcileItem).name
+		},
+		Equal: func(desired, current interface{}) bool {
+			return desired.(reconcileItem).value == current.(reconcileItem).value
+		},
+		Create: func(desired interface{}) error {
+			*created = append(*created, desired.(reconcileItem).name)
+			return nil
+		},
+		Update: func(desired, current interface{}) error {
+			*updated = append(*updated, fmt.Sprintf("%s: %s -> %s", desired.(reconcileItem).name, current.(reconcileItem).value, desired.(reconcileItem).value))
+			return nil
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	current := []reconcileItem{{"unchanged", "a"}, {"changed", "a"}, {"not-desired", "a"}}
+	desired := []reconcileItem{{"unchanged", "a"}, {"changed", "b"}, {"new", "a"}}
+
+	var created, updated []string
+	result, err := Reconcile(newTestReconciler(&created, &updated), desired, current)
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Created: 1, Updated: 1, Unchanged: 1}, result)
+	assert.Equal(t, []string{"new"}, created)
+	assert.Equal(t, []string{"changed: a -> b"}, updated)
+
+	// without an equality check, all the matched resources are updated.
+	created, updated = nil, nil
+	r := newTestReconciler(&created, &updated)
+	r.Equal = nil
+	result, err = Reconcile(r, desired, current)
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Created: 1, Updated: 2}, result)
+	assert.Equal(t, []string{"unchanged: a -> a", "changed: a -> b"}, updated)
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	var created, updated []string
+	r := newTestReconciler(&created, &updated)
+	r.DryRun = true
+
+	result, err := Reconcile(r, []reconcileItem{{"changed", "b"}, {"new", "a"}}, []reconcileItem{{"changed", "a"}})
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Created: 1, Updated: 1}, result)
+	assert.Empty(t, created)
+	assert.Empty(t, updated)
+}
+
+func TestReconcileStopsOnError(t *testing.T) {
+	var created, updated []string
+	r := newTestReconciler(&created, &updated)
+	r.Create = func(desired interface{}) error {
+		return fmt.Errorf("forbidden")
+	}
+
+	result, err := Reconcile(r, []reconcileItem{{"new", "a"}, {"other", "a"}}, []reconcileItem{})
+	assert.EqualError(t, err, "forbidden")
+	assert.Equal(t, ReconcileResult{}, result)
+}
+
+func TestReconcilePrune(t *testing.T) {
+	current := []reconcileItem{{"orphan-1", "a"}, {"kept", "a"}, {"orphan-2", "a"}}
+	desired := []reconcileItem{{"kept", "b"}, {"new", "a"}}
+
+	var created, updated, deleted, confirmed []string
+	r := newTestReconciler(&created, &updated)
+	r.Prune = true
+	r.Delete = func(current interface{}) error {
+		deleted = append(deleted, current.(reconcileItem).name)
+		return nil
+	}
+	r.Confirm = func(kind string, names []string) error {
+		confirmed = append(confirmed, names...)
+		return nil
+	}
+
+	result, err := Reconcile(r, desired, current)
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Created: 1, Updated: 1, Deleted: 2}, result)
+	assert.Equal(t, []string{"orphan-1", "orphan-2"}, deleted)
+	assert.Equal(t, []string{"orphan-1", "orphan-2"}, confirmed)
+
+	// never on a dry run.
+	deleted, confirmed = nil, nil
+	r.DryRun = true
+	result, err = Reconcile(r, desired, current)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Deleted)
+	assert.Empty(t, deleted)
+	assert.Empty(t, confirmed)
+
+	// not confirmed.
+	r.DryRun = false
+	r.Confirm = func(kind string, names []string) error {
+		return fmt.Errorf("not confirmed")
+	}
+	result, err = Reconcile(r, desired, current)
+	assert.EqualError(t, err, "not confirmed")
+	assert.Equal(t, 0, result.Deleted)
+	assert.Empty(t, deleted)
+
+	// without --prune the orphans are kept.
+	r.Prune = false
+	result, err = Reconcile(r, desired, current)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.Deleted)
+	assert.Empty(t, deleted)
+}
+
+func TestReconcilePruneEmpty(t *testing.T) {
+	var created, updated, deleted []string
+	r := newTestReconciler(&created, &updated)
+	r.Prune = true
+	r.Delete = func(current interface{}) error {
+		deleted = append(deleted, current.(reconcileItem).name)
+		return nil
+	}
+
+	current := []reconcileItem{{"a", "a"}, {"b", "a"}}
+	_, err := Reconcile(r, []reconcileItem{}, current)
+	assert.EqualError(t, err, "refusing to prune all the item resources as none were loaded, use --force-prune to delete them")
+	assert.Empty(t, deleted)
+
+	r.ForcePrune = true
+	result, err := Reconcile(r, []reconcileItem{}, current)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Deleted)
+	assert.Equal(t, []string{"a", "b"}, deleted)
+}
+
+func TestReconcileContinueOnError(t *testing.T) {
+	var created, updated []string
+	r := newTestReconciler(&created, &updated)
+	create := r.Create
+	r.Create = func(desired interface{}) error {
+		if desired.(reconcileItem).name == "forbidden" {
+			return fmt.Errorf("forbidden")
+		}
+		return create(desired)
+	}
+	r.ContinueOnError = true
+
+	result, err := Reconcile(r, []reconcileItem{{"forbidden", "a"}, {"new", "a"}}, []reconcileItem{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, []Failure{{Name: "forbidden", Err: fmt.Errorf("forbidden")}}, result.Failed)
+	assert.Equal(t, []string{"new"}, created)
+}
+
+func TestReconcileOrdering(t *testing.T) {
+	orders := map[string]ImportOrder{
+		"sink":      {DependsOn: []string{"processor"}},
+		"processor": {DependsOn: []string{"source", "existing"}},
+		"late":      {Order: 10},
+		"early":     {Order: -1},
+	}
+
+	var created, updated []string
+	r := newTestReconciler(&created, &updated)
+	r.Ordering = func(desired interface{}) ImportOrder {
+		return orders[desired.(reconcileItem).name]
+	}
+
+	desired := []reconcileItem{{"late", "a"}, {"sink", "a"}, {"processor", "a"}, {"source", "a"}, {"early", "a"}}
+	result, err := Reconcile(r, desired, []reconcileItem{{"existing", "a"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, result.Created)
+	assert.Equal(t, []string{"early", "source", "processor", "sink", "late"}, created)
+}
+
+func TestReconcileOrderingErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		orders   map[string]ImportOrder
+		expected string
+	}{
+		{
+			name:     "missing dependency",
+			orders:   map[string]ImportOrder{"sink": {DependsOn: []string{"source"}}},
+			expected: "item [sink] depends on [source] which is neither in the files nor exists",
+		},
+		{
+			name: "cycle",
+			orders: map[string]ImportOrder{
+				"sink":      {DependsOn: []string{"processor"}},
+				"processor": {DependsOn: []string{"sink"}},
+			},
+			expected: "dependency cycle between the item resources [sink -> processor -> sink]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, updated []string
+			r := newTestReconciler(&created, &updated)
+			r.Ordering = func(desired interface{}) ImportOrder {
+				return tt.orders[desired.(reconcileItem).name]
+			}
+
+			_, err := Reconcile(r, []reconcileItem{{"sink", "a"}, {"processor", "a"}}, []reconcileItem{})
+			assert.EqualError(t, err, tt.expected)
+			assert.Empty(t, created, "nothing should be created")
+		})
+	}
+}
+
+func TestReconcileCreateMany(t *testing.T) {
+	var created, updated []string
+	r := newTestReconciler(&created, &updated)
+	r.CreateManyThreshold = 2
+
+	var calls [][]string
+	r.CreateMany = func(desired []interface{}) error {
+		var names []string
+		failed := make(map[string]error)
+		for _, resource := range desired {
+			name := resource.(reconcileItem).name
+			names = append(names, name)
+			if name == "forbidden" {
+				failed[name] = fmt.Errorf("forbidden")
+			}
+		}
+		calls = append(calls, names)
+
+		if len(failed) > 0 {
+			return api.BulkError{Kind: "item", Total: len(desired), Errors: failed}
+		}
+		return nil
+	}
+	r.Ordering = func(desired interface{}) ImportOrder {
+		if desired.(reconcileItem).name == "sink" {
+			return ImportOrder{DependsOn: []string{"source"}}
+		}
+		return ImportOrder{}
+	}
+	r.ContinueOnError = true
+
+	desired := []reconcileItem{{"source", "a"}, {"forbidden", "a"}, {"changed", "b"}, {"sink", "a"}, {"other", "a"}}
+	result, err := Reconcile(r, desired, []reconcileItem{{"changed", "a"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, []Failure{{Name: "forbidden", Err: fmt.Errorf("forbidden")}}, result.Failed)
+	// the sink is created after its source.
+	assert.Equal(t, [][]string{{"source", "forbidden"}, {"sink", "other"}}, calls)
+	assert.Empty(t, created)
+
+	// not more than the threshold, one by one.
+	calls = nil
+	result, err = Reconcile(r, desired[:2], []reconcileItem{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Created)
+	assert.Empty(t, calls)
+	assert.Equal(t, []string{"source", "forbidden"}, created)
+}
+
+func TestReconcileCache(t *testing.T) {
+	current := map[string]reconcileItem{"existing": {"existing", "a"}, "changed": {"changed", "a"}}
+
+	var lists int
+	fetched := make(map[string]int)
+	cache := &ResourceCache{
+		List: func() (interface{}, error) {
+			lists++
+			return []reconcileItem{current["existing"], current["changed"]}, nil
+		},
+		Name: func(resource interface{}) string {
+			return resource.(reconcileItem).name
+		},
+		Fetch: func(name string) (interface{}, bool, error) {
+			fetched[name]++
+			item, ok := current[name]
+			return item, ok, nil
+		},
+	}
+
+	var created, updated []string
+	r := newTestReconciler(&created, &updated)
+	r.Cache = cache
+	r.Ordering = func(desired interface{}) ImportOrder {
+		// all of them depend on the existing one, which is not desired.
+		if name := desired.(reconcileItem).name; name != "existing" {
+			return ImportOrder{DependsOn: []string{"existing"}}
+		}
+		return ImportOrder{}
+	}
+	r.CreateMany = func(desired []interface{}) error { return nil }
+	r.CreateManyThreshold = 10
+
+	desired := []reconcileItem{{"changed", "b"}, {"new", "a"}, {"other", "a"}}
+	result, err := Reconcile(r, desired, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, ReconcileResult{Created: 2, Updated: 1}, result)
+	assert.Equal(t, map[string]int{"existing": 1, "changed": 1, "new": 1, "other": 1}, fetched)
+	assert.Equal(t, 0, lists)
+
+	// the written ones are fetched again on their next lookup.
+	_, found, err := cache.Lookup("new")
+	assert.Nil(t, err)
+	assert.False(t, found)
+	assert.Equal(t, 2, fetched["new"])
+
+	// listed once for the prune.
+	r.Prune = true
+	r.Delete = func(current interface{}) error { return nil }
+	_, err = cache.All()
+	assert.Nil(t, err)
+	result, err = Reconcile(r, desired, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Deleted)
+	assert.Equal(t, 1, lists)
+}
@@ -3,12 +3,10 @@ package imports
 import (
 	"fmt"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,7 +24,7 @@ func NewImportSchemasCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.SchemasPath)
 			if err := loadSchemas(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load schemas. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load schemas. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +32,7 @@ func NewImportSchemasCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -42,24 +41,49 @@ func NewImportSchemasCommand() *cobra.Command {
 }
 
 func loadSchemas(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading schemas from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
+
+	client.Logger().Infof("Loading schemas from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
+
+	var (
+		// the files and the schemas which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
 
 	for _, file := range files {
 		var schema api.SchemaAsRequest
-		if err := load(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &schema); err != nil {
-			return err
+		if err := file.load(cmd, "schema", &schema); err != nil {
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		_, err := client.RegisterSchema(schema.Name, schema.AvroSchema)
 
 		if err != nil {
-			golog.Errorf("Error creating schema from file [%s]. [%s]", loadpath, err.Error())
-			return err
+			client.Logger().Errorf("Error creating schema from file [%s]. [%s]", loadpath, err.Error())
+			if err = recordFailure(&failed, keepGoing, schema.Name, err); err != nil {
+				return err
+			}
+			continue
 		}
 
-		golog.Infof("Created schema from [%s]", loadpath)
+		client.Logger().Infof("Created schema from [%s]", loadpath)
+		result.Created++
+	}
+
+	if !keepGoing {
+		return nil
 	}
 
-	return nil
+	return summarize(client.Logger(), "schema", result, failed)
 }
@@ -1,14 +1,14 @@
 package imports
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -17,21 +17,36 @@ type importServiceAccount struct {
 	Token string `json:"token" yaml:"token" header:"token"`
 }
 
+// tokensOutFlag is the file which the tokens of the created service accounts are written to,
+// they are only shown once, on creation.
+const tokensOutFlag = "tokens-out"
+
+// bulkCreateThreshold is the number of the new service accounts
+// above which they are created concurrently, see `api.Client#CreateServiceAccounts`.
+const bulkCreateThreshold = 10
+
+// singleLookupThreshold is the number of the imported service accounts
+// up to which the existing ones are fetched one by one instead of listing all of them, see `ResourceCache`.
+const singleLookupThreshold = 10
+
 //NewImportServiceAccountsCommand creates `import serviceaccounts` command
 func NewImportServiceAccountsCommand() *cobra.Command {
 	var path string
 
 	cmd := &cobra.Command{
-		Use:              "serviceaccounts",
-		Short:            "serviceaccounts",
-		Example:          `import serviceaccounts --dir users`,
+		Use:   "serviceaccounts",
+		Short: "serviceaccounts",
+		Example: `
+import serviceaccounts --dir users
+import serviceaccounts --dir users --prune --yes
+import serviceaccounts --dir users --on-error continue`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.ServiceAccountsPath)
 			if err := loadServiceAccounts(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load user groups. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load user groups. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -39,59 +54,212 @@ func NewImportServiceAccountsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	cmd.Flags().Bool(dryRunFlag, false, "Print the changes without applying them")
+	addPruneFlags(cmd, "service accounts")
+	addOnErrorFlag(cmd)
+	cmd.Flags().String(tokensOutFlag, "", "Write the tokens of the created service accounts to this file, as a JSON map of name to token")
 
 	bite.CanPrintJSON(cmd)
 	return cmd
 }
 
-func loadServiceAccounts(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading service accounts from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
-
-	currentSvcAccs, err := client.GetServiceAccounts()
+func loadServiceAccounts(client *api.Client, cmd *cobra.Command, loadpath string) (err error) {
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
 
+	client.Logger().Infof("Loading service accounts from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
 	if err != nil {
 		return err
 	}
 
-	for _, file := range files {
+	// the files and the service accounts which failed under the --on-error continue.
+	var failed []Failure
 
+	// load and validate all the files before any change.
+	svcaccs := make([]api.ServiceAccount, 0, len(files))
+	orders := make(map[string]ImportOrder)
+	for _, file := range files {
 		var svcacc api.ServiceAccount
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &svcacc); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+		order, err := file.loadOrdered(cmd, "serviceaccount", &svcacc)
+		if err != nil {
+			client.Logger().Errorf("Error loading file [%s]", file.Name())
+			if !keepGoing {
+				return err
+			}
+			failed = append(failed, Failure{Name: file.Name(), Err: err})
+			continue
 		}
 
-		found := false
-		for _, sva := range currentSvcAccs {
-			if sva.Name == svcacc.Name {
-				found = true
+		svcaccs = append(svcaccs, svcacc)
+		orders[svcacc.Name] = order
+	}
 
-				payload := &api.ServiceAccount{
-					Name:   svcacc.Name,
-					Owner:  svcacc.Owner,
-					Groups: svcacc.Groups,
-				}
+	groups, err := client.GetGroups()
+	if err != nil {
+		return err
+	}
 
-				if err := config.Client.UpdateServiceAccount(payload); err != nil {
-					golog.Errorf("Error updating service account [%s]. [%s]", svcacc.Name, err.Error())
-					return err
-				}
-				golog.Infof("Updated service account [%s]", svcacc.Name)
+	valid := svcaccs[:0]
+	for _, svcacc := range svcaccs {
+		if missing := missingGroups(groups, svcacc.Groups); len(missing) > 0 {
+			if !keepGoing {
+				return errMissingGroups("service account", svcacc.Name, missing)
 			}
-		}
-
-		if found {
+			failed = append(failed, Failure{Name: svcacc.Name, Err: errMissingGroups("service account", svcacc.Name, missing)})
 			continue
 		}
 
-		payload, err := client.CreateServiceAccount(&svcacc)
-		if err != nil {
-			golog.Errorf("Error creating service account [%s] from [%s] [%s]", svcacc.Name, loadpath, err.Error())
+		valid = append(valid, svcacc)
+	}
+	svcaccs = valid
+
+	prune := isPrune(cmd)
+	if prune && len(failed) > 0 {
+		// the service accounts of the failed files would be deleted as if they were removed.
+		client.Logger().Warnf("Skipping the --%s, [%d] files or service accounts failed to load", pruneFlag, len(failed))
+		prune = false
+	}
+
+	// the existing service accounts are looked up once each, however many files and dependencies refer to them.
+	current := &ResourceCache{
+		List: func() (interface{}, error) {
+			return client.GetServiceAccounts()
+		},
+		Name: func(resource interface{}) string {
+			return resource.(api.ServiceAccount).Name
+		},
+		Fetch: func(name string) (interface{}, bool, error) {
+			svcacc, err := client.GetServiceAccount(name)
+			if _, ok := err.(api.NotFoundError); ok {
+				return nil, false, nil
+			}
+			return svcacc, err == nil, err
+		},
+	}
+
+	// a few service accounts are fetched one by one, so the large environments are not listed as a whole,
+	// unless the --prune has to find the removed ones.
+	if prune || len(svcaccs) > singleLookupThreshold {
+		if _, err = current.All(); err != nil {
 			return err
 		}
-		golog.Infof("Created service account [%s], Token:[%s]", svcacc.Name, payload.Token)
 	}
 
-	return nil
+	dryRun := isDryRun(cmd)
+
+	// the tokens of the created service accounts, by name.
+	tokens := make(map[string]string)
+	tokensOut := cmd.Flag(tokensOutFlag).Value.String()
+	// addToken keeps the token of a created service account, it can't be retrieved again so it's printed
+	// whatever the verbosity, unless it's written to the --tokens-out.
+	addToken := func(name, token string) {
+		tokens[name] = token
+		if tokensOut == "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "Token of service account [%s]: [%s]\n", name, token)
+		}
+	}
+
+	if tokensOut != "" && !dryRun {
+		// write them even if the import fails in the middle, as the ones created so far can't be retrieved again.
+		defer func() {
+			if len(tokens) == 0 {
+				return
+			}
+
+			if writeErr := writeTokens(tokensOut, tokens); writeErr != nil {
+				client.Logger().Errorf("Error writing the service account tokens to [%s]. [%s]", tokensOut, writeErr.Error())
+				if err == nil {
+					err = writeErr
+				}
+				return
+			}
+
+			client.Logger().Infof("Wrote the tokens of [%d] created service accounts to [%s]", len(tokens), tokensOut)
+		}()
+	}
+
+	result, err := Reconcile(Reconciler{
+		Kind: "service account",
+		Name: func(resource interface{}) string {
+			return resource.(api.ServiceAccount).Name
+		},
+		Equal: func(desired, current interface{}) bool {
+			return desired.(api.ServiceAccount).Equal(current.(api.ServiceAccount))
+		},
+		Create: func(desired interface{}) error {
+			svcacc := desired.(api.ServiceAccount)
+			payload, err := client.CreateServiceAccount(&svcacc)
+			if err != nil {
+				return err
+			}
+
+			addToken(svcacc.Name, payload.Token)
+			return nil
+		},
+		Update: func(desired, current interface{}) error {
+			svcacc := desired.(api.ServiceAccount)
+			return client.UpdateServiceAccount(&svcacc)
+		},
+		Delete: func(current interface{}) error {
+			return client.DeleteServiceAccount(current.(api.ServiceAccount).Name)
+		},
+		// too many to create one by one, they are created concurrently, see `api.Client#CreateServiceAccounts`.
+		CreateMany: func(desired []interface{}) error {
+			newSvcAccs := make([]api.ServiceAccount, len(desired))
+			for i, resource := range desired {
+				newSvcAccs[i] = resource.(api.ServiceAccount)
+			}
+
+			created, err := client.CreateServiceAccounts(newSvcAccs)
+			for _, svcacc := range newSvcAccs {
+				if payload, ok := created[svcacc.Name]; ok {
+					addToken(svcacc.Name, payload.Token)
+				}
+			}
+
+			return err
+		},
+		CreateManyThreshold: bulkCreateThreshold,
+		Ordering: func(desired interface{}) ImportOrder {
+			return orders[desired.(api.ServiceAccount).Name]
+		},
+		Prune:           prune,
+		ForcePrune:      isForcePrune(cmd),
+		Confirm:         confirmPrune(cmd),
+		DryRun:          dryRun,
+		ContinueOnError: keepGoing,
+		Logger:          client.Logger(),
+		Cache:           current,
+	}, svcaccs, nil)
+
+	if err != nil || !keepGoing {
+		return err
+	}
+
+	return summarize(client.Logger(), "service account", result, append(failed, result.Failed...))
+}
+
+// writeTokens writes the "tokens" to the "path" as JSON, readable only by the current user.
+func writeTokens(path string, tokens map[string]string) error {
+	b, err := json.MarshalIndent(tokens, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// the file may already exist with wider permissions.
+	if err = f.Chmod(0600); err != nil {
+		return err
+	}
+
+	_, err = f.Write(b)
+	return err
 }
@@ -0,0 +1,592 @@
+package imports
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kataras/golog"
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportServiceAccountsValidation(t *testing.T) {
+	var creates int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, "[]") {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount":
+			if r.Method == http.MethodPost {
+				creates++
+				w.Write([]byte(`{"token": "t"}`))
+				return
+			}
+			w.Write([]byte("[]"))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, pkg.ServiceAccountsPath)
+	assert.Nil(t, os.MkdirAll(svcAccsDir, 0755))
+
+	file := filepath.Join(svcAccsDir, "svc-accounts-ingestion.yaml")
+	// "group" instead of "groups".
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: ingestion\nowner: admin\ngroup:\n- dev\n"), 0644))
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir)
+	assert.EqualError(t, err, "invalid serviceaccount file ["+file+"]: group: unknown field, use --skip-validation to import it anyway")
+	assert.Equal(t, 0, creates, "no service account should be created from an invalid file")
+
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: ingestion\nowner: admin\ngroups:\n- dev\n"), 0644))
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, creates)
+}
+
+func TestImportServiceAccountsOnErrorContinue(t *testing.T) {
+	var created []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, "[]") {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount":
+			if r.Method == http.MethodPost {
+				var svcacc api.ServiceAccount
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&svcacc))
+				if svcacc.Name == "rejected" {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte("invalid owner"))
+					return
+				}
+				created = append(created, svcacc.Name)
+				w.Write([]byte(`{"token": "t"}`))
+				return
+			}
+			w.Write([]byte("[]"))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, pkg.ServiceAccountsPath)
+	assert.Nil(t, os.MkdirAll(svcAccsDir, 0755))
+
+	files := map[string]string{
+		"svc-accounts-a.yaml":        "name: a\nowner: admin\ngroups:\n- dev\n",
+		"svc-accounts-b.yaml":        "name: b\nowner: admin\ngroup:\n- dev\n",
+		"svc-accounts-c.yaml":        "name: c\nowner: admin\ngroups:\n- dev\n",
+		"svc-accounts-rejected.yaml": "name: rejected\nowner: admin\ngroups:\n- dev\n",
+		"svc-accounts-z.yaml":        "name: z\nowner: admin\ngroups:\n- dev\n",
+	}
+	for name, contents := range files {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(svcAccsDir, name), []byte(contents), 0644))
+	}
+
+	// abort, by default, before any change.
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir)
+	assert.NotNil(t, err)
+	assert.Empty(t, created)
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--on-error", "continue")
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasPrefix(err.Error(), "failed to import 2 service account resources: [svc-accounts-b.yaml]: invalid serviceaccount file"), err.Error())
+	assert.Contains(t, err.Error(), "[rejected]: ")
+	assert.Equal(t, []string{"a", "c", "z"}, created)
+
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--on-error", "skip")
+	assert.EqualError(t, err, "invalid --on-error [skip], expected abort or continue")
+}
+
+func TestImportSkipValidation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "svc-accounts-ingestion.json")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(`{"name": "ingestion", "groups": ["dev"], "ownr": "admin"}`), 0644))
+
+	root := NewImportGroupCommand()
+	cmd, _, err := root.Find([]string{"serviceaccounts"})
+	assert.Nil(t, err)
+
+	var svcacc api.ServiceAccount
+	assert.EqualError(t, loadFile(cmd, file, nil, "serviceaccount", &svcacc),
+		"invalid serviceaccount file ["+file+"]: ownr: unknown field, use --skip-validation to import it anyway")
+
+	assert.Nil(t, root.PersistentFlags().Set(skipValidationFlag, "true"))
+	assert.Nil(t, loadFile(cmd, file, nil, "serviceaccount", &svcacc))
+	assert.Equal(t, api.ServiceAccount{Name: "ingestion", Groups: []string{"dev"}}, svcacc)
+
+	// the types are checked on the decoded document too.
+	_, err = decodeDocument(NewImportServiceAccountsCommand(), file, "serviceaccount", []byte(`{"name": "ingestion", "groups": "dev"}`))
+	assert.EqualError(t, err,
+		"invalid serviceaccount file ["+file+"]: groups: expected an array, got a string, use --skip-validation to import it anyway")
+}
+
+func TestImportServiceAccountsSkipsUnchanged(t *testing.T) {
+	var updates int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, `[{"name": "ingestion", "owner": "admin", "groups": ["ops", "dev"]}, {"name": "other", "owner": "admin", "groups": ["dev"]}]`) {
+			return
+		}
+
+		switch {
+		case r.Method == http.MethodPut:
+			updates++
+		case r.URL.Path == "/api/v1/serviceaccount":
+			w.Write([]byte(`[{"name": "ingestion", "owner": "admin", "groups": ["ops", "dev"]}, {"name": "other", "owner": "admin", "groups": ["dev"]}]`))
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}, {"name": "ops"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// same owner and groups, in another order.
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-ingestion.yaml"), []byte("name: ingestion\nowner: admin\ngroups:\n- dev\n- ops\n"), 0644))
+	assert.Nil(t, loadServiceAccounts(client, NewImportServiceAccountsCommand(), dir))
+	assert.Equal(t, 0, updates)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-other.yaml"), []byte("name: other\nowner: admin\ngroups:\n- ops\n"), 0644))
+	assert.Nil(t, loadServiceAccounts(client, NewImportServiceAccountsCommand(), dir))
+	assert.Equal(t, 1, updates)
+}
+
+func TestImportServiceAccountsInBulk(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		creates int
+	)
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodPost:
+			var svcacc api.ServiceAccount
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&svcacc))
+			if svcacc.Name == "svc-0" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			creates++
+			mu.Unlock()
+			w.Write([]byte(`{"token": "t"}`))
+		case r.Method == http.MethodPut:
+			assert.Equal(t, "/api/v1/serviceaccount/existing", r.URL.Path)
+		case r.URL.Path == "/api/v1/serviceaccount":
+			w.Write([]byte(`[{"name": "existing", "owner": "admin", "groups": ["ops"]}]`))
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	for i := 0; i <= bulkCreateThreshold; i++ {
+		content := fmt.Sprintf("name: svc-%d\nowner: admin\ngroups:\n- dev\n", i)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("svc-accounts-%d.yaml", i)), []byte(content), 0644))
+	}
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-existing.yaml"), []byte("name: existing\nowner: admin\ngroups:\n- dev\n"), 0644))
+
+	// the failed one of the bulk is on the summary of the rest.
+	cmd := NewImportServiceAccountsCommand()
+	assert.Nil(t, cmd.Flags().Set(onErrorFlag, "continue"))
+	err = loadServiceAccounts(client, cmd, dir)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "failed to import 1 service account resources: [svc-0]")
+	}
+	assert.Equal(t, bulkCreateThreshold, creates)
+}
+
+func TestImportServiceAccountsTokensOut(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, `[{"name": "existing", "owner": "admin", "groups": ["ops"]}]`) {
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"token": "new-token"}`))
+		case r.Method == http.MethodPut:
+		case r.URL.Path == "/api/v1/serviceaccount":
+			w.Write([]byte(`[{"name": "existing", "owner": "admin", "groups": ["ops"]}]`))
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, "svc-accounts")
+	assert.Nil(t, os.Mkdir(svcAccsDir, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(svcAccsDir, "svc-accounts-new.yaml"), []byte("name: new\nowner: admin\ngroups:\n- dev\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(svcAccsDir, "svc-accounts-existing.yaml"), []byte("name: existing\nowner: admin\ngroups:\n- dev\n"), 0644))
+
+	tokensOut := filepath.Join(dir, "tokens.json")
+
+	cmd := NewImportServiceAccountsCommand()
+	assert.Nil(t, cmd.Flags().Set(tokensOutFlag, tokensOut))
+	assert.Nil(t, cmd.Flags().Set(dryRunFlag, "true"))
+	assert.Nil(t, loadServiceAccounts(client, cmd, svcAccsDir))
+	_, err = os.Stat(tokensOut)
+	assert.True(t, os.IsNotExist(err), "no tokens should be written on dry run")
+
+	assert.Nil(t, cmd.Flags().Set(dryRunFlag, "false"))
+	assert.Nil(t, loadServiceAccounts(client, cmd, svcAccsDir))
+
+	info, err := os.Stat(tokensOut)
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	b, err := ioutil.ReadFile(tokensOut)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"new": "new-token"}`, string(b))
+}
+
+type capturingLogger struct {
+	messages []string
+}
+
+func (l *capturingLogger) Debugf(format string, args ...interface{}) {}
+func (l *capturingLogger) Infof(format string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+func (l *capturingLogger) Warnf(format string, args ...interface{})  {}
+func (l *capturingLogger) Errorf(format string, args ...interface{}) {}
+
+func TestImportServiceAccountsLogger(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, `[{"name": "existing", "owner": "admin", "groups": ["dev"]}]`) {
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"token": "t"}`))
+		case r.URL.Path == "/api/v1/serviceaccount":
+			w.Write([]byte(`[{"name": "existing", "owner": "admin", "groups": ["dev"]}]`))
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	logger := new(capturingLogger)
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient), api.WithLogger(logger))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-new.yaml"), []byte("name: new\nowner: admin\ngroups:\n- dev\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-existing.yaml"), []byte("name: existing\nowner: admin\ngroups:\n- dev\n"), 0644))
+
+	cmd := NewImportServiceAccountsCommand()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	assert.Nil(t, loadServiceAccounts(client, cmd, dir))
+	assert.Equal(t, []string{
+		"Loading service accounts from [" + dir + "]",
+		"Unchanged service account [existing]",
+		"Created service account [new]",
+	}, logger.messages)
+	// the token can't be retrieved again, it's printed whatever the logs level.
+	assert.Equal(t, "Token of service account [new]: [t]\n", out.String())
+}
+
+func TestImportServiceAccountsJSONLogs(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, "[]") {
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"token": "t"}`))
+		case r.URL.Path == "/api/v1/serviceaccount":
+			w.Write([]byte("[]"))
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var logs bytes.Buffer
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient), api.WithLogger(api.NewJSONLogger(&logs)))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-new.yaml"), []byte("name: new\nowner: admin\ngroups:\n- dev\n"), 0644))
+	assert.Nil(t, loadServiceAccounts(client, NewImportServiceAccountsCommand(), dir))
+
+	var created map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var entry map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &entry), line)
+		if entry["message"] == "Created service account [new]" {
+			created = entry
+		}
+	}
+
+	if assert.NotNil(t, created, "the created service account should be logged") {
+		assert.Equal(t, "info", created["level"])
+		assert.NotEmpty(t, created["time"])
+		assert.Equal(t, map[string]interface{}{"resource": "service account", "name": "new", "action": "create"}, created["fields"])
+	}
+}
+
+func TestImportServiceAccountsVerbosity(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, `[{"name": "existing", "owner": "admin", "groups": ["dev"]}]`) {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount":
+			w.Write([]byte(`[{"name": "existing", "owner": "admin", "groups": ["dev"]}]`))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	// the HTTP requests are logged on the debug mode only.
+	clientConfig := test.ClientConfig
+	clientConfig.Debug = false
+
+	var err error
+	config.Client, err = api.OpenConnection(clientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, pkg.ServiceAccountsPath)
+	assert.Nil(t, os.MkdirAll(svcAccsDir, 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(svcAccsDir, "svc-accounts-existing.yaml"), []byte("name: existing\nowner: admin\ngroups:\n- dev\n"), 0644))
+
+	var logs bytes.Buffer
+	golog.SetOutput(&logs)
+	defer func() {
+		golog.SetOutput(os.Stdout)
+		golog.SetLevel("info")
+	}()
+
+	run := func(args ...string) string {
+		logs.Reset()
+		root := &cobra.Command{
+			Use: "lenses-cli",
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				utils.SetupVerbosity(cmd)
+			},
+		}
+		utils.AddVerboseFlag(root)
+		root.AddCommand(NewImportGroupCommand())
+
+		_, err := test.ExecuteCommand(root, append([]string{"import", "serviceaccounts", "--dir", dir}, args...)...)
+		assert.Nil(t, err)
+		return logs.String()
+	}
+
+	assert.NotContains(t, run(), "Loading service accounts")
+
+	out := run("-v")
+	assert.Contains(t, out, "Loading service accounts")
+	assert.Contains(t, out, "Unchanged service account [existing]")
+	assert.NotContains(t, out, "Client#Do")
+
+	// the debug level without the client's debug mode, no HTTP dumps.
+	assert.NotContains(t, run("-vv"), "Client#Do")
+}
+
+func TestImportServiceAccountsFetchesOneByOne(t *testing.T) {
+	var fetched []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/api/v1/serviceaccount/") {
+			fetched = append(fetched, strings.TrimPrefix(r.URL.Path, "/api/v1/serviceaccount/"))
+		}
+		if serveServiceAccount(t, w, r, `[{"name": "existing", "owner": "admin", "groups": ["dev"]}]`) {
+			return
+		}
+
+		switch {
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"token": "t"}`))
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			// the whole list is not fetched.
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-existing.yaml"), []byte("name: existing\nowner: admin\ngroups:\n- dev\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "svc-accounts-new.yaml"), []byte("name: new\nowner: admin\ngroups:\n- dev\ndependsOn:\n- existing\n"), 0644))
+
+	tokensOut := filepath.Join(dir, "tokens.json")
+	cmd := NewImportServiceAccountsCommand()
+	assert.Nil(t, cmd.Flags().Set(tokensOutFlag, tokensOut))
+	assert.Nil(t, loadServiceAccounts(client, cmd, dir))
+	// once each, even the dependency.
+	assert.ElementsMatch(t, []string{"existing", "new"}, fetched)
+
+	// only the missing one is created.
+	b, err := ioutil.ReadFile(tokensOut)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"new": "t"}`, string(b))
+}
+
+func TestImportServiceAccountsListsOnce(t *testing.T) {
+	var lists, updates int
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodGet:
+			lists++
+			w.Write([]byte(`[{"name": "svc-0", "owner": "admin", "groups": ["ops"]}, {"name": "svc-1", "owner": "admin", "groups": ["dev"]}]`))
+		case r.URL.Path == "/api/v1/serviceaccount" && r.Method == http.MethodPost:
+			w.Write([]byte(`{"token": "t"}`))
+		case r.URL.Path == "/api/v1/serviceaccount/svc-0" && r.Method == http.MethodPut:
+			updates++
+		case r.URL.Path == "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.Method+" "+r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	dir, err := ioutil.TempDir("", "import-svc-accounts")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// more files than the single lookups, the service accounts are listed instead.
+	for i := 0; i < 2*singleLookupThreshold; i++ {
+		content := fmt.Sprintf("name: svc-%d\nowner: admin\ngroups:\n- dev\n", i)
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("svc-accounts-%d.yaml", i)), []byte(content), 0644))
+	}
+
+	assert.Nil(t, loadServiceAccounts(client, NewImportServiceAccountsCommand(), dir))
+	assert.Equal(t, 1, lists)
+	assert.Equal(t, 1, updates)
+}
+
+// serveServiceAccount answers the fetch of a single service account with the one of the "current" json list,
+// or 404 if it's not in the list. It reports whether the request was such a fetch.
+func serveServiceAccount(t *testing.T, w http.ResponseWriter, r *http.Request, current string) bool {
+	if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, "/api/v1/serviceaccount/") {
+		return false
+	}
+
+	var svcaccs []api.ServiceAccount
+	assert.Nil(t, json.Unmarshal([]byte(current), &svcaccs))
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/v1/serviceaccount/")
+	for _, svcacc := range svcaccs {
+		if svcacc.Name == name {
+			json.NewEncoder(w).Encode(svcacc)
+			return true
+		}
+	}
+
+	w.WriteHeader(http.StatusNotFound)
+	return true
+}
@@ -0,0 +1,281 @@
+package imports
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/landoop/lenses-go/pkg/jsonschema"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// setFlag overrides a field of each loaded document, i.e --set configuration.0.value=PLAINTEXT://kafka:9092, see `applyOverrides`.
+// The keys which contain dots are set by a JSON pointer, i.e --set /configs/retention.ms=3600000, see `splitOverridePath`.
+// The overrides are applied after the ${VAR} placeholders are resolved, so they win over the --var values
+// and their own values are taken as they are.
+const setFlag = "set"
+
+// override is a parsed --set path=value.
+type override struct {
+	flag string
+	path []string
+	// dotted is true for the dot separated paths, their segments may be parts of a key with dots, see `dottedKey`.
+	dotted bool
+	value  string
+}
+
+// parseOverrides returns the --set values of the "cmd", in their order.
+func parseOverrides(cmd *cobra.Command) ([]override, error) {
+	flag := cmd.Flag(setFlag)
+	if flag == nil {
+		return nil, nil
+	}
+
+	values, ok := flag.Value.(pflag.SliceValue)
+	if !ok {
+		return nil, nil
+	}
+
+	var overrides []override
+	for _, kv := range values.GetSlice() {
+		idx := strings.IndexByte(kv, '=')
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid --%s [%s], expected path.to.field=value", setFlag, kv)
+		}
+
+		path, err := splitOverridePath(kv[:idx])
+		if err != nil {
+			return nil, fmt.Errorf("invalid --%s [%s]: %v", setFlag, kv, err)
+		}
+
+		overrides = append(overrides, override{flag: kv, path: path, dotted: !strings.HasPrefix(kv, "/"), value: kv[idx+1:]})
+	}
+
+	return overrides, nil
+}
+
+// splitOverridePath splits a dot separated path, i.e "tags.0", or a JSON pointer, i.e "/configs/retention.ms",
+// for the keys that contain dots. The numeric segments are the indexes of the arrays.
+// The segments of a dot separated path are joined back to the existing keys with dots, see `dottedKey`.
+func splitOverridePath(path string) ([]string, error) {
+	var segments []string
+	if strings.HasPrefix(path, "/") {
+		segments = strings.Split(path[1:], "/")
+		for i, segment := range segments {
+			segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		}
+	} else {
+		segments = strings.Split(path, ".")
+	}
+
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("empty field of the path [%s]", path)
+		}
+	}
+
+	return segments, nil
+}
+
+// applyOverrides sets the --set values to the decoded "doc" of the file, see `decodeDocument`, and returns it.
+// The values are converted to the type of their field, based on the "schema" of its resource,
+// and to the type of the current value for the fields that accept anything.
+func applyOverrides(cmd *cobra.Command, path string, doc interface{}, schema *jsonschema.Schema) (interface{}, error) {
+	overrides, err := parseOverrides(cmd)
+	if err != nil || len(overrides) == 0 {
+		return doc, err
+	}
+
+	for _, o := range overrides {
+		if doc, err = setField(doc, o.path, o.dotted, o.value, schema); err != nil {
+			return nil, fmt.Errorf("unable to apply the --%s [%s] to the file [%s]: %v", setFlag, o.flag, path, err)
+		}
+	}
+
+	return doc, nil
+}
+
+// setField sets the "value" to the "path" of the "node" and returns the node, the missing objects and arrays of the path are created.
+// An index equal to the length of an array appends to it.
+func setField(node interface{}, path []string, dotted bool, value string, schema *jsonschema.Schema) (interface{}, error) {
+	if len(path) == 0 {
+		return coerceValue(value, schema, node)
+	}
+
+	key := path[0]
+	if node == nil {
+		if _, err := strconv.Atoi(key); err == nil && (schema == nil || schema.Type == "" || schema.Type == jsonschema.TypeArray) {
+			node = []interface{}{}
+		} else {
+			node = make(map[string]interface{})
+		}
+	}
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		rest := path[1:]
+		if dotted {
+			key, rest = dottedKey(n, path, schema)
+		}
+
+		field, err := setField(n[key], rest, dotted, value, propertySchema(schema, key))
+		if err != nil {
+			return nil, err
+		}
+		n[key] = field
+		return n, nil
+	case []interface{}:
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx > len(n) {
+			return nil, fmt.Errorf("index [%s] out of the range of the array of %d elements", key, len(n))
+		}
+
+		var item interface{}
+		if idx < len(n) {
+			item = n[idx]
+		}
+
+		var itemSchema *jsonschema.Schema
+		if schema != nil {
+			itemSchema = schema.Items
+		}
+
+		if item, err = setField(item, path[1:], dotted, value, itemSchema); err != nil {
+			return nil, err
+		}
+
+		if idx == len(n) {
+			return append(n, item), nil
+		}
+		n[idx] = item
+		return n, nil
+	}
+
+	return nil, fmt.Errorf("field [%s] is set on %v which is not an object or an array", key, node)
+}
+
+// dottedKey returns the key of the "fields" object which the dot separated "path" sets and the rest of the path.
+// The longest key with dots which exists already, or is a field of the "schema", wins, i.e "retention.ms" of "configs.retention.ms".
+// Otherwise the whole path is a new key of the maps, like the configs, and the first segment is a key of the rest of the objects.
+func dottedKey(fields map[string]interface{}, path []string, schema *jsonschema.Schema) (string, []string) {
+	for i := len(path); i > 1; i-- {
+		key := strings.Join(path[:i], ".")
+		if _, ok := fields[key]; ok {
+			return key, path[i:]
+		}
+
+		if schema != nil {
+			if _, ok := schema.Properties[key]; ok {
+				return key, path[i:]
+			}
+		}
+	}
+
+	if _, ok := fields[path[0]]; !ok && schema != nil && len(schema.Properties) == 0 {
+		if _, isMap := schema.AdditionalProperties.(*jsonschema.Schema); isMap {
+			return strings.Join(path, "."), nil
+		}
+	}
+
+	return path[0], path[1:]
+}
+
+// propertySchema returns the schema of the "key" field of an object, nil if it's unknown.
+func propertySchema(schema *jsonschema.Schema, key string) *jsonschema.Schema {
+	if schema == nil {
+		return nil
+	}
+
+	if s, ok := schema.Properties[key]; ok {
+		return s
+	}
+
+	s, _ := schema.AdditionalProperties.(*jsonschema.Schema)
+	return s
+}
+
+// coerceValue converts the --set "value" to the type of its field, the "current" value is used for the fields
+// that accept anything and the rest are kept as strings.
+func coerceValue(value string, schema *jsonschema.Schema, current interface{}) (interface{}, error) {
+	typ := ""
+	if schema != nil {
+		typ = schema.Type
+	}
+
+	if typ == "" {
+		switch current.(type) {
+		case bool:
+			typ = jsonschema.TypeBoolean
+		case int, int64, uint64, float64, json.Number:
+			typ = jsonschema.TypeNumber
+		case map[string]interface{}:
+			typ = jsonschema.TypeObject
+		case []interface{}:
+			typ = jsonschema.TypeArray
+		default:
+			typ = jsonschema.TypeString
+		}
+	}
+
+	switch typ {
+	case jsonschema.TypeInteger:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] is not an integer", value)
+		}
+		return v, nil
+	case jsonschema.TypeNumber:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] is not a number", value)
+		}
+		if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return i, nil
+		}
+		return v, nil
+	case jsonschema.TypeBoolean:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("[%s] is not a boolean", value)
+		}
+		return v, nil
+	case jsonschema.TypeObject, jsonschema.TypeArray:
+		// YAML is a superset of JSON, so both are accepted.
+		var v interface{}
+		if err := yaml.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("[%s] is not a valid %s: %v", value, typ, err)
+		}
+
+		v = normalizeYAML(v)
+		if _, isArray := v.([]interface{}); (typ == jsonschema.TypeArray) != isArray {
+			return nil, fmt.Errorf("[%s] is not an %s", value, typ)
+		}
+		if _, isObject := v.(map[string]interface{}); typ == jsonschema.TypeObject && !isObject {
+			return nil, fmt.Errorf("[%s] is not an %s", value, typ)
+		}
+		return v, nil
+	}
+
+	return value, nil
+}
+
+// normalizeYAML converts the objects of a decoded YAML value to string keys, like the JSON ones.
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			fields[fmt.Sprintf("%v", key)] = normalizeYAML(field)
+		}
+		return fields
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+	}
+
+	return value
+}
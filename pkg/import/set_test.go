@@ -0,0 +1,187 @@
+package imports
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func newOverridesCommand(t *testing.T, sets ...string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringArray(setFlag, nil, "")
+	for _, set := range sets {
+		if err := cmd.Flags().Set(setFlag, set); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return cmd
+}
+
+// applyTestOverrides applies the --set of the "cmd" to the "contents" of the file and encodes them back, see `decodeDocument`.
+func applyTestOverrides(cmd *cobra.Command, path, resource string, contents []byte) ([]byte, error) {
+	doc, err := decodeDocument(cmd, path, resource, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	if isYAMLFile(path) {
+		return yaml.Marshal(doc)
+	}
+
+	return json.Marshal(doc)
+}
+
+func TestApplyOverridesNestedFields(t *testing.T) {
+	contents := []byte("name: orders\nreplication: 1\npartitions: 3\nconfigs:\n  cleanup.policy: delete\n  retention.ms: 60000\n")
+
+	cmd := newOverridesCommand(t, "replication=3", "/configs/cleanup.policy=compact", "/configs/retention.ms=3600000",
+		"/configs/min.insync.replicas=2")
+	contents, err := applyTestOverrides(cmd, "topic-orders.yaml", "topic", contents)
+	assert.Nil(t, err)
+
+	var topic api.CreateTopicPayload
+	assert.Nil(t, decodeFile("topic-orders.yaml", contents, &topic))
+	assert.Equal(t, "orders", topic.TopicName)
+	assert.Equal(t, 3, topic.Replication)
+	assert.Equal(t, 3, topic.Partitions)
+	assert.Equal(t, "compact", topic.Configs["cleanup.policy"])
+	// the values of the configs accept anything, they follow the type of the current value.
+	assert.Equal(t, 3600000, topic.Configs["retention.ms"])
+	assert.Equal(t, "2", topic.Configs["min.insync.replicas"])
+
+	_, err = applyTestOverrides(newOverridesCommand(t, "partitions=many"), "topic-orders.yaml", "topic", contents)
+	assert.EqualError(t, err, "unable to apply the --set [partitions=many] to the file [topic-orders.yaml]: [many] is not an integer")
+}
+
+func TestApplyOverridesDottedKeys(t *testing.T) {
+	contents := []byte("name: orders\nreplication: 1\npartitions: 3\nconfigs:\n  cleanup.policy: delete\n  retention.ms: 60000\n")
+
+	// the examples of the --set help, the existing keys with dots and the new ones of the configs.
+	for _, set := range []string{"/configs/retention.ms=3600000", "configs.retention.ms=3600000"} {
+		cmd := newOverridesCommand(t, "replication=3", set, "configs.min.insync.replicas=2")
+		overridden, err := applyTestOverrides(cmd, "topic-orders.yaml", "topic", contents)
+		assert.Nil(t, err, set)
+
+		var topic api.CreateTopicPayload
+		assert.Nil(t, decodeFile("topic-orders.yaml", overridden, &topic), set)
+		assert.Equal(t, 3, topic.Replication, set)
+		assert.Equal(t, api.KV{"cleanup.policy": "delete", "retention.ms": 3600000, "min.insync.replicas": "2"}, topic.Configs, set)
+	}
+
+	// the configs are created too.
+	cmd := newOverridesCommand(t, "configs.retention.ms=3600000")
+	overridden, err := applyTestOverrides(cmd, "topic-orders.yaml", "topic", []byte("name: orders\nreplication: 1\npartitions: 3\n"))
+	assert.Nil(t, err)
+
+	var topic api.CreateTopicPayload
+	assert.Nil(t, decodeFile("topic-orders.yaml", overridden, &topic))
+	assert.Equal(t, api.KV{"retention.ms": "3600000"}, topic.Configs)
+}
+
+func TestApplyOverridesArrays(t *testing.T) {
+	contents := []byte(`{"name": "kafka", "templateName": "Kafka", "tags": ["dev"], "configuration": [` +
+		`{"key": "kafkaBootstrapServers", "value": ["PLAINTEXT://dev:9092"]}, {"key": "protocol", "value": "PLAINTEXT"}]}`)
+
+	cmd := newOverridesCommand(t,
+		"tags.0=prod",
+		"tags.1=eu",
+		`configuration.0.value=["SASL_SSL://prod-1:9092", "SASL_SSL://prod-2:9092"]`,
+		"configuration.1.value=SASL_SSL",
+		"configuration.2.key=sslKeyPassword",
+		"configuration.2.value=secret")
+	contents, err := applyTestOverrides(cmd, "connection-kafka.json", "connection", contents)
+	assert.Nil(t, err)
+
+	var connection api.Connection
+	assert.Nil(t, decodeFile("connection-kafka.json", contents, &connection))
+	assert.Equal(t, []string{"prod", "eu"}, connection.Tags)
+	assert.Equal(t, []api.ConnectionConfig{
+		{Key: "kafkaBootstrapServers", Value: []interface{}{"SASL_SSL://prod-1:9092", "SASL_SSL://prod-2:9092"}},
+		{Key: "protocol", Value: "SASL_SSL"},
+		{Key: "sslKeyPassword", Value: "secret"},
+	}, connection.Configuration)
+
+	_, err = applyTestOverrides(newOverridesCommand(t, "tags.3=eu"), "connection-kafka.json", "connection", contents)
+	assert.EqualError(t, err, "unable to apply the --set [tags.3=eu] to the file [connection-kafka.json]: index [3] out of the range of the array of 2 elements")
+
+	_, err = applyTestOverrides(newOverridesCommand(t, "tags=eu"), "connection-kafka.json", "connection", contents)
+	assert.EqualError(t, err, "unable to apply the --set [tags=eu] to the file [connection-kafka.json]: [eu] is not an array")
+
+	_, err = applyTestOverrides(newOverridesCommand(t, "name.first=kafka"), "connection-kafka.json", "connection", contents)
+	assert.EqualError(t, err, "unable to apply the --set [name.first=kafka] to the file [connection-kafka.json]: field [first] is set on kafka which is not an object or an array")
+
+	_, err = applyTestOverrides(newOverridesCommand(t, "tags..0=eu"), "connection-kafka.json", "connection", contents)
+	assert.EqualError(t, err, "invalid --set [tags..0=eu]: empty field of the path [tags..0]")
+
+	_, err = applyTestOverrides(newOverridesCommand(t, "tags"), "connection-kafka.json", "connection", contents)
+	assert.EqualError(t, err, "invalid --set [tags], expected path.to.field=value")
+}
+
+func TestImportSetOverridesVariables(t *testing.T) {
+	var created api.ServiceAccount
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveServiceAccount(t, w, r, "[]") {
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v1/serviceaccount":
+			if r.Method == http.MethodPost {
+				assert.Nil(t, json.NewDecoder(r.Body).Decode(&created))
+				w.Write([]byte(`{"token": "t"}`))
+				return
+			}
+			w.Write([]byte("[]"))
+		case "/api/v1/group":
+			w.Write([]byte(`[{"name": "dev"}, {"name": "ops"}]`))
+		default:
+			assert.Fail(t, "unexpected request", r.URL.Path)
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	dir, err := ioutil.TempDir("", "import-set")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	svcAccsDir := filepath.Join(dir, pkg.ServiceAccountsPath)
+	assert.Nil(t, os.MkdirAll(svcAccsDir, 0755))
+
+	file := filepath.Join(svcAccsDir, "svc-accounts-ingestion.yaml")
+	assert.Nil(t, ioutil.WriteFile(file, []byte("name: ingestion\nowner: ${SVC_OWNER}\ngroups:\n- dev\n"), 0644))
+
+	// the --set is applied after the --var, it wins and its own placeholders are not resolved.
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir,
+		"--var", "SVC_OWNER=admin", "--set", "owner=${SVC_OWNER}-ops", "--set", "groups.1=ops")
+	assert.Nil(t, err)
+	assert.Equal(t, "ingestion", created.Name)
+	assert.Equal(t, "${SVC_OWNER}-ops", created.Owner)
+	assert.Equal(t, []string{"dev", "ops"}, created.Groups)
+
+	// the --var is still required for the placeholders of the file, even if the --set overrides their field.
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--set", "owner=ops")
+	assert.EqualError(t, err, "unset variables in the file ["+file+"]: SVC_OWNER, set them or use --allow-unset to import them as they are")
+
+	// the overridden file is validated too.
+	_, err = test.ExecuteCommand(NewImportGroupCommand(), "serviceaccounts", "--dir", dir, "--var", "SVC_OWNER=admin", "--set", "ownr=ops")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid serviceaccount file ["+file+"]")
+}
@@ -0,0 +1,173 @@
+package imports
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// stdinFlag reads the resources from the standard input instead of the files of the --dir, see `readDocuments`.
+const stdinFlag = "stdin"
+
+// importFile is a file of the resources to import or a document of the --stdin.
+type importFile struct {
+	name string
+	path string
+	// contents are the contents of a --stdin document, nil for the files which are read on load.
+	contents []byte
+	// manifest is the --manifest the file is verified against on load, nil if it's not set.
+	manifest *importManifest
+}
+
+// Name returns the name of the file or the name of the --stdin document, i.e "stdin#1.yaml".
+func (f importFile) Name() string {
+	return f.name
+}
+
+// load is like the `load` but for the files and the --stdin documents.
+func (f importFile) load(cmd *cobra.Command, resource string, data interface{}) error {
+	if f.contents == nil {
+		return load(cmd, f.path, f.manifest, resource, data)
+	}
+
+	return decodeContents(cmd, f.path, resource, f.contents, data)
+}
+
+// loadFile is like the `loadFile` but for the files and the --stdin documents.
+func (f importFile) loadFile(cmd *cobra.Command, resource string, data interface{}) error {
+	if f.contents == nil {
+		return loadFile(cmd, f.path, f.manifest, resource, data)
+	}
+
+	return f.load(cmd, resource, data)
+}
+
+// loadDocument is like the `loadFile` but it returns the decoded document of the file or the --stdin document,
+// see `decodeDocument`.
+func (f importFile) loadDocument(cmd *cobra.Command, resource string) (interface{}, error) {
+	contents := f.contents
+	if contents == nil {
+		if err := bite.PrintInfo(cmd, "Loading from file '%s'", f.path); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if contents, err = readFile(cmd, f.path, f.manifest); err != nil {
+			return nil, err
+		}
+	}
+
+	return decodeDocument(cmd, f.path, resource, contents)
+}
+
+func readStdin(cmd *cobra.Command) bool {
+	flag := cmd.Flag(stdinFlag)
+	return flag != nil && flag.Value.String() == "true"
+}
+
+// findFiles returns the files of the "dir" or the documents of the standard input if the --stdin is set.
+// The --manifest is read once here for all the files of the import.
+func findFiles(cmd *cobra.Command, dir string) ([]importFile, error) {
+	if readStdin(cmd) {
+		if flag := cmd.Flag(manifestFlag); flag != nil && flag.Value.String() != "" {
+			return nil, fmt.Errorf("the --%s can't be used with the --%s", manifestFlag, stdinFlag)
+		}
+
+		return readDocuments(cmd.InOrStdin())
+	}
+
+	manifest, err := readManifest(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := utils.FindFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []importFile
+	for _, file := range infos {
+		files = append(files, importFile{name: file.Name(), path: fmt.Sprintf("%s/%s", dir, file.Name()), manifest: manifest})
+	}
+
+	return files, nil
+}
+
+// readDocuments splits the "r" to its resource documents, the format is detected by their first character:
+// a JSON document or a stream of JSON documents, i.e one per line, or YAML documents separated by "---".
+// The documents are named after their position and format, i.e "stdin#2.json", so they are decoded like the files.
+func readDocuments(r io.Reader) ([]importFile, error) {
+	contents, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	contents = bytes.TrimSpace(contents)
+	if len(contents) == 0 {
+		return nil, fmt.Errorf("no resources found in the standard input")
+	}
+
+	var docs [][]byte
+	ext := "yaml"
+	if c := contents[0]; c == '{' || c == '[' {
+		ext = "json"
+		dec := json.NewDecoder(bytes.NewReader(contents))
+		for dec.More() {
+			var doc json.RawMessage
+			if err = dec.Decode(&doc); err != nil {
+				return nil, fmt.Errorf("unable to decode the JSON document [%d] of the standard input: %v", len(docs)+1, err)
+			}
+			docs = append(docs, doc)
+		}
+	} else {
+		docs = splitYAML(contents)
+	}
+
+	files := make([]importFile, len(docs))
+	for i, doc := range docs {
+		name := fmt.Sprintf("stdin#%d.%s", i+1, ext)
+		files[i] = importFile{name: name, path: name, contents: doc}
+	}
+
+	return files, nil
+}
+
+// splitYAML splits the "contents" to the YAML documents separated by "---" lines, the empty documents are skipped.
+func splitYAML(contents []byte) [][]byte {
+	var (
+		docs [][]byte
+		doc  bytes.Buffer
+	)
+
+	add := func() {
+		if len(bytes.TrimSpace(doc.Bytes())) > 0 {
+			docs = append(docs, append([]byte(nil), doc.Bytes()...))
+		}
+		doc.Reset()
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	scanner.Buffer(nil, len(contents)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(line[3:]) == "" {
+			add()
+			continue
+		}
+
+		doc.WriteString(line)
+		doc.WriteByte('\n')
+	}
+	add()
+
+	return docs
+}
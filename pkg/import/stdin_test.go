@@ -0,0 +1,112 @@
+package imports
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDocuments(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		expectedNames []string
+		expectedDocs  []string
+	}{
+		{
+			name:          "yaml",
+			input:         "name: dev\ndescription: developers\n",
+			expectedNames: []string{"stdin#1.yaml"},
+			expectedDocs:  []string{"name: dev\ndescription: developers\n"},
+		},
+		{
+			name:          "yaml documents",
+			input:         "---\nname: dev\n---\n\n--- \nname: ops\n",
+			expectedNames: []string{"stdin#1.yaml", "stdin#2.yaml"},
+			expectedDocs:  []string{"name: dev\n", "name: ops\n"},
+		},
+		{
+			name:          "ndjson",
+			input:         "{\"name\": \"dev\"}\n{\"name\": \"ops\"}\n",
+			expectedNames: []string{"stdin#1.json", "stdin#2.json"},
+			expectedDocs:  []string{`{"name": "dev"}`, `{"name": "ops"}`},
+		},
+		{
+			name:          "json",
+			input:         "{\n  \"name\": \"dev\"\n}",
+			expectedNames: []string{"stdin#1.json"},
+			expectedDocs:  []string{"{\n  \"name\": \"dev\"\n}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, err := readDocuments(strings.NewReader(tt.input))
+			assert.Nil(t, err)
+
+			var names, docs []string
+			for _, file := range files {
+				names = append(names, file.Name())
+				docs = append(docs, string(file.contents))
+			}
+
+			assert.Equal(t, tt.expectedNames, names)
+			assert.Equal(t, tt.expectedDocs, docs)
+		})
+	}
+
+	_, err := readDocuments(strings.NewReader(" \n"))
+	assert.EqualError(t, err, "no resources found in the standard input")
+
+	_, err = readDocuments(strings.NewReader(`{"name": "dev"} {"name":`))
+	assert.EqualError(t, err, "unable to decode the JSON document [2] of the standard input: unexpected EOF")
+}
+
+func TestImportGroupsStdin(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "yaml", input: "name: dev\ndescription: developers\n---\nname: ops\n"},
+		{name: "ndjson", input: "{\"name\": \"dev\", \"description\": \"developers\"}\n{\"name\": \"ops\"}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created []api.Group
+			h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/group", r.URL.Path)
+				if r.Method == http.MethodPost {
+					var group api.Group
+					b, _ := ioutil.ReadAll(r.Body)
+					assert.Nil(t, json.Unmarshal(b, &group))
+					created = append(created, group)
+					return
+				}
+
+				w.Write([]byte("[]"))
+			})
+			httpClient, teardown := test.TestingHTTPClient(h)
+			defer teardown()
+
+			var err error
+			config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+			assert.Nil(t, err)
+			defer func() { config.Client = nil }()
+
+			cmd := NewImportGroupCommand()
+			cmd.SetIn(strings.NewReader(tt.input))
+			_, err = test.ExecuteCommand(cmd, "groups", "--stdin", "--dir", "does-not-exist")
+			assert.Nil(t, err)
+
+			assert.Equal(t, []api.Group{{Name: "dev", Description: "developers"}, {Name: "ops"}}, created)
+		})
+	}
+}
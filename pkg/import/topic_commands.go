@@ -3,12 +3,10 @@ package imports
 import (
 	"fmt"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
-	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -26,7 +24,7 @@ func NewImportTopicsCommand() *cobra.Command {
 
 			path = fmt.Sprintf("%s/%s", path, pkg.TopicsPath)
 			if err := loadTopics(config.Client, cmd, path); err != nil {
-				golog.Errorf("Failed to load topics. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to load topics. [%s]", err.Error())
 				return err
 			}
 			return nil
@@ -34,6 +32,7 @@ func NewImportTopicsCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&path, "dir", ".", "Base directory to import")
+	addOnErrorFlag(cmd)
 
 	bite.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
@@ -42,20 +41,38 @@ func NewImportTopicsCommand() *cobra.Command {
 }
 
 func loadTopics(client *api.Client, cmd *cobra.Command, loadpath string) error {
-	golog.Infof("Loading topics from [%s]", loadpath)
-	files := utils.FindFiles(loadpath)
+	keepGoing, err := continueOnError(cmd)
+	if err != nil {
+		return err
+	}
+
+	client.Logger().Infof("Loading topics from [%s]", loadpath)
+	files, err := findFiles(cmd, loadpath)
+	if err != nil {
+		return err
+	}
+
 	topics, err := client.GetTopics()
 
 	if err != nil {
-		golog.Errorf("Error retrieving topics [%s]", err.Error())
+		client.Logger().Errorf("Error retrieving topics [%s]", err.Error())
 		return err
 	}
 
+	var (
+		// the files and the topics which failed under the --on-error continue.
+		failed []Failure
+		result ReconcileResult
+	)
+
 	for _, file := range files {
 		var topic api.CreateTopicPayload
-		if err := bite.LoadFile(cmd, fmt.Sprintf("%s/%s", loadpath, file.Name()), &topic); err != nil {
-			golog.Errorf("Error loading file [%s]", loadpath)
-			return err
+		if err := file.loadFile(cmd, "topic", &topic); err != nil {
+			client.Logger().Errorf("Error loading file [%s]", loadpath)
+			if err = recordFailure(&failed, keepGoing, file.Name(), err); err != nil {
+				return err
+			}
+			continue
 		}
 
 		found := false
@@ -64,23 +81,35 @@ func loadTopics(client *api.Client, cmd *cobra.Command, loadpath string) error {
 			if lensesTopic.TopicName == topic.TopicName {
 				found = true
 				if err := client.UpdateTopic(topic.TopicName, []api.KV{topic.Configs}); err != nil {
-					golog.Errorf("Error updating topic [%s]. [%s]", topic.TopicName, err.Error())
-					return err
+					logResource(client.Logger(), "topic", topic.TopicName, "update").Errorf("Error updating topic [%s]. [%s]", topic.TopicName, err.Error())
+					if err = recordFailure(&failed, keepGoing, topic.TopicName, err); err != nil {
+						return err
+					}
+					break
 				}
 
-				golog.Infof("Updated topic [%s]", topic.TopicName)
+				logResource(client.Logger(), "topic", topic.TopicName, "update").Infof("Updated topic [%s]", topic.TopicName)
+				result.Updated++
 			}
 		}
 
 		if !found {
 			if err := client.CreateTopic(topic.TopicName, topic.Replication, topic.Partitions, topic.Configs); err != nil {
-				golog.Errorf("Error creating topic [%s]. [%s]", topic.TopicName, err.Error())
-				return err
+				logResource(client.Logger(), "topic", topic.TopicName, "create").Errorf("Error creating topic [%s]. [%s]", topic.TopicName, err.Error())
+				if err = recordFailure(&failed, keepGoing, topic.TopicName, err); err != nil {
+					return err
+				}
+				continue
 			}
 
-			golog.Infof("Created topic [%s]", topic.TopicName)
+			logResource(client.Logger(), "topic", topic.TopicName, "create").Infof("Created topic [%s]", topic.TopicName)
+			result.Created++
 		}
 	}
 
-	return nil
+	if !keepGoing {
+		return nil
+	}
+
+	return summarize(client.Logger(), "topic", result, failed)
 }
@@ -0,0 +1,46 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+//NewJSONSchemaCommand creates the `json-schema` command
+func NewJSONSchemaCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "json-schema <resource>",
+		Short: "Print the JSON Schema of a resource's exported file, for editor validation. Resources: " + strings.Join(ResourceNames(), ", "),
+		Example: `json-schema serviceaccount
+json-schema connection --format yaml > connection.schema.json`,
+		SilenceErrors: true,
+		Args:          cobra.ExactArgs(1),
+		ValidArgs:     ResourceNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format = strings.ToLower(format)
+			if format != "json" && format != "yaml" {
+				return fmt.Errorf("unsupported format [%s], format must be json or yaml", format)
+			}
+
+			s, err := GenerateResource(args[0], format)
+			if err != nil {
+				return err
+			}
+
+			b, err := json.MarshalIndent(s, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintln(cmd.OutOrStdout(), string(b))
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "json", "The format of the files to validate, json or yaml, the field names of the yaml files may differ")
+	return cmd
+}
@@ -0,0 +1,335 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Draft is the JSON Schema version of the generated schemas.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// The available types of a `Schema`.
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema is a JSON Schema, just the keywords that describe the Go types, see `Generate`.
+type Schema struct {
+	Schema     string             `json:"$schema,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	Type       string             `json:"type,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is false for the structs and the schema of the values for the maps.
+	AdditionalProperties interface{} `json:"additionalProperties,omitempty"`
+	Items                *Schema     `json:"items,omitempty"`
+}
+
+// Generate returns the JSON Schema of the "v"'s type.
+// The properties are named after the "tag" struct tags, "json" or "yaml", as the matching decoder does,
+// and the types registered on the `Enums` produce an "enum" constraint.
+func Generate(v interface{}, tag string) *Schema {
+	s := generate(reflect.TypeOf(v), tag)
+	s.Schema = Draft
+	return s
+}
+
+func generate(typ reflect.Type, tag string) *Schema {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if values, ok := Enums[typ]; ok {
+		return &Schema{Type: TypeString, Enum: values}
+	}
+
+	switch typ.Kind() {
+	case reflect.Struct:
+		s := &Schema{Type: TypeObject, Properties: make(map[string]*Schema), AdditionalProperties: false}
+		addProperties(s, typ, tag)
+		return s
+	case reflect.Map:
+		return &Schema{Type: TypeObject, AdditionalProperties: generate(typ.Elem(), tag)}
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 { // []byte is encoded as base64.
+			return &Schema{Type: TypeString}
+		}
+		return &Schema{Type: TypeArray, Items: generate(typ.Elem(), tag)}
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	}
+
+	// interface{} accepts anything.
+	return &Schema{}
+}
+
+// addProperties adds the exported fields of the struct "typ" to the "s",
+// the embedded structs without a name and the yaml ",inline" fields are flattened.
+func addProperties(s *Schema, typ reflect.Type, tag string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" && !field.Anonymous { // unexported.
+			continue
+		}
+
+		name, inline, skip := fieldName(field, tag)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if inline && fieldType.Kind() == reflect.Struct {
+			addProperties(s, fieldType, tag)
+			continue
+		}
+
+		if field.PkgPath != "" { // unexported embedded non-struct.
+			continue
+		}
+
+		s.Properties[name] = generate(field.Type, tag)
+	}
+}
+
+// fieldName returns the property name of a struct field, based on the rules of the json and yaml decoders.
+func fieldName(field reflect.StructField, tag string) (name string, inline, skip bool) {
+	value, ok := field.Tag.Lookup(tag)
+	if value == "-" {
+		return "", false, true
+	}
+
+	parts := strings.Split(value, ",")
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "inline" {
+			inline = true
+		}
+	}
+
+	if tag == "json" && field.Anonymous && (!ok || name == "") {
+		inline = true
+	}
+
+	if name == "" {
+		name = field.Name
+		if tag == "yaml" {
+			name = strings.ToLower(name)
+		}
+	}
+
+	return
+}
+
+// ValidationError describes why a value of a document does not match its schema.
+type ValidationError struct {
+	// Path is the path of the value, i.e "configuration[1].key", empty for the document itself.
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors are the errors of a `Validate` call.
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks the "doc", decoded by the json or the yaml decoder, against the schema.
+// It returns the `ValidationErrors` of all the mismatches or nil.
+// Note that the null values are valid, like the decoders accept them for any type.
+func (s *Schema) Validate(doc interface{}) error {
+	var errs ValidationErrors
+	s.validate("", doc, &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *ValidationErrors) {
+	if value == nil {
+		return
+	}
+
+	fail := func(format string, args ...interface{}) {
+		*errs = append(*errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch s.Type {
+	case TypeObject:
+		fields, ok := toMap(value)
+		if !ok {
+			fail("expected an object, got %s", typeName(value))
+			return
+		}
+
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			fieldPath := joinPath(path, key)
+			if property, ok := s.Properties[key]; ok {
+				property.validate(fieldPath, fields[key], errs)
+				continue
+			}
+
+			switch additional := s.AdditionalProperties.(type) {
+			case *Schema:
+				additional.validate(fieldPath, fields[key], errs)
+			case bool:
+				if !additional {
+					*errs = append(*errs, ValidationError{Path: fieldPath, Message: "unknown field" + didYouMean(key, s.Properties)})
+				}
+			}
+		}
+	case TypeArray:
+		items, ok := value.([]interface{})
+		if !ok {
+			fail("expected an array, got %s", typeName(value))
+			return
+		}
+
+		for i, item := range items {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+		}
+	case TypeString:
+		str, ok := value.(string)
+		if !ok {
+			fail("expected a string, got %s", typeName(value))
+			return
+		}
+
+		// the enums are compared case insensitive, i.e the ACLs are upper-cased before sent.
+		if len(s.Enum) > 0 && !containsFold(s.Enum, str) {
+			fail("invalid value [%s], expected one of: %s", str, strings.Join(s.Enum, ", "))
+		}
+	case TypeInteger:
+		if !isInteger(value) {
+			fail("expected an integer, got %s", typeName(value))
+		}
+	case TypeNumber:
+		if typeName(value) != "a number" {
+			fail("expected a number, got %s", typeName(value))
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			fail("expected a boolean, got %s", typeName(value))
+		}
+	}
+}
+
+// toMap returns the fields of a json (string keys) or a yaml (interface{} keys) object.
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		fields := make(map[string]interface{}, len(v))
+		for key, field := range v {
+			fields[fmt.Sprintf("%v", key)] = field
+		}
+		return fields, true
+	}
+
+	return nil, false
+}
+
+func typeName(value interface{}) string {
+	if _, ok := toMap(value); ok {
+		return "an object"
+	}
+
+	switch value.(type) {
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+		return "a number"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func isInteger(value interface{}) bool {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return true
+	case float64:
+		return v == float64(int64(v))
+	case float32:
+		return v == float32(int64(v))
+	case json.Number:
+		_, err := v.Int64()
+		return err == nil
+	}
+
+	return false
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// didYouMean suggests the property which differs only in case from the "key", a common typo.
+func didYouMean(key string, properties map[string]*Schema) string {
+	for name := range properties {
+		if strings.EqualFold(name, key) {
+			return fmt.Sprintf(", did you mean [%s]?", name)
+		}
+	}
+
+	return ""
+}
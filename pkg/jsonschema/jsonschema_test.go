@@ -0,0 +1,107 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	test "github.com/landoop/lenses-go/test"
+)
+
+func TestGenerateServiceAccount(t *testing.T) {
+	s := Generate(api.ServiceAccount{}, "json")
+
+	b, err := json.Marshal(s)
+	assert.Nil(t, err)
+
+	expected := `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"owner": {"type": "string"},
+			"groups": {"type": "array", "items": {"type": "string"}}
+		},
+		"additionalProperties": false
+	}`
+	assert.JSONEq(t, expected, string(b))
+}
+
+func TestValidateServiceAccountFile(t *testing.T) {
+	s := Generate(api.ServiceAccount{}, "yaml")
+
+	dir, err := ioutil.TempDir("", "jsonschema")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	validate := func(content string) error {
+		file := filepath.Join(dir, "svc-accounts-ingestion.yaml")
+		assert.Nil(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+		b, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+
+		var doc interface{}
+		assert.Nil(t, yaml.Unmarshal(b, &doc))
+		return s.Validate(doc)
+	}
+
+	assert.Nil(t, validate("name: ingestion\nowner: admin\ngroups:\n- dev\n- ops\n"))
+	// null values are accepted, like the decoder does.
+	assert.Nil(t, validate("name: ingestion\nowner: admin\ngroups:\n"))
+
+	err = validate("name: ingestion\nOwner: admin\ngroups: dev\n")
+	assert.EqualError(t, err, "Owner: unknown field, did you mean [owner]?; groups: expected an array, got a string")
+
+	err = validate("name: ingestion\ngroups:\n- dev\n- 1\n")
+	assert.EqualError(t, err, "groups[1]: expected a string, got a number")
+}
+
+func TestGenerateEnums(t *testing.T) {
+	s, err := GenerateResource("acl", "json")
+	assert.Nil(t, err)
+	assert.Equal(t, "acl", s.Title)
+	assert.Equal(t, TypeArray, s.Type)
+	assert.Equal(t, []string{"Allow", "Deny"}, s.Items.Properties["permissionType"].Enum)
+	assert.Contains(t, s.Items.Properties["operation"].Enum, "DESCRIBE_CONFIGS")
+
+	var doc interface{}
+	assert.Nil(t, json.Unmarshal([]byte(`[{"resourceName":"t","resourceType":"TOPIC","principal":"User:bob","permissionType":"Maybe","host":"*","operation":"READ"}]`), &doc))
+	assert.EqualError(t, s.Validate(doc), "[0].permissionType: invalid value [Maybe], expected one of: Allow, Deny")
+
+	_, err = GenerateResource("unknown", "json")
+	assert.NotNil(t, err)
+}
+
+func TestGenerateFieldNames(t *testing.T) {
+	// the yaml field names, the connector payload has no json tags.
+	s := Generate(api.CreateUpdateConnectorPayload{}, "yaml")
+	assert.Contains(t, s.Properties, "clusterName")
+	assert.Equal(t, TypeObject, s.Properties["config"].Type)
+
+	s = Generate(api.CreateUpdateConnectorPayload{}, "json")
+	assert.Contains(t, s.Properties, "ClusterName")
+
+	s = Generate(api.Group{}, "yaml")
+	assert.Contains(t, s.Properties, "dataNamespaces")
+	assert.NotContains(t, s.Properties, "namespaces")
+}
+
+func TestJSONSchemaCommand(t *testing.T) {
+	out, err := test.ExecuteCommand(NewJSONSchemaCommand(), "serviceaccount")
+	assert.Nil(t, err)
+
+	var s Schema
+	assert.Nil(t, json.Unmarshal([]byte(out), &s))
+	assert.Equal(t, "serviceaccount", s.Title)
+	assert.Contains(t, s.Properties, "groups")
+
+	_, err = test.ExecuteCommand(NewJSONSchemaCommand(), "serviceaccount", "--format", "xml")
+	assert.NotNil(t, err)
+}
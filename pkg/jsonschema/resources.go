@@ -0,0 +1,79 @@
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/landoop/lenses-go/pkg/alert"
+	"github.com/landoop/lenses-go/pkg/api"
+)
+
+// Enums are the values of the string types which accept a fixed set of values,
+// the `Generate` adds them as an "enum" constraint.
+var Enums = map[reflect.Type][]string{
+	reflect.TypeOf(api.ACLPermissionType("")): {
+		string(api.ACLPermissionAllow),
+		string(api.ACLPermissionDeny),
+	},
+	reflect.TypeOf(api.ACLResourceType("")): {
+		string(api.ACLResourceAny),
+		string(api.ACLResourceTopic),
+		string(api.ACLResourceGroup),
+		string(api.ACLResourceCluster),
+		string(api.ACLResourceTransactionalID),
+		string(api.ACLResourceDelegationToken),
+	},
+	reflect.TypeOf(api.ACLOperation("")): {
+		string(api.ACLOperationAny),
+		string(api.ACLOperationAll),
+		string(api.ACLOperationRead),
+		string(api.ACLOperationWrite),
+		string(api.ACLOperationCreate),
+		string(api.ACLOperationDelete),
+		string(api.ACLOperationAlter),
+		string(api.ACLOperationDescribe),
+		string(api.ACLOperationClusterAction),
+		string(api.ACLOperationDescribeConfigs),
+		string(api.ACLOperationAlterConfigs),
+		string(api.ACLOperationIdempotentWrite),
+	},
+}
+
+// Resources are the values of the resources' files of the export and import commands, by the resource name.
+var Resources = map[string]interface{}{
+	"acl":            []api.ACL{},
+	"alert-setting":  alert.SettingConditionPayloads{},
+	"connection":     api.Connection{},
+	"connector":      api.CreateUpdateConnectorPayload{},
+	"group":          api.Group{},
+	"policy":         api.DataPolicyRequest{},
+	"processor":      api.CreateProcessorPayload{},
+	"quota":          []api.CreateQuotaPayload{},
+	"schema":         api.SchemaAsRequest{},
+	"serviceaccount": api.ServiceAccount{},
+	"topic":          api.CreateTopicPayload{},
+}
+
+// ResourceNames returns the sorted names of the `Resources`.
+func ResourceNames() []string {
+	names := make([]string, 0, len(Resources))
+	for name := range Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateResource returns the schema of the files of the "resource", see `Resources`.
+func GenerateResource(resource, tag string) (*Schema, error) {
+	v, ok := Resources[strings.ToLower(resource)]
+	if !ok {
+		return nil, fmt.Errorf("unknown resource [%s], available: %s", resource, strings.Join(ResourceNames(), ", "))
+	}
+
+	s := Generate(v, tag)
+	s.Title = strings.ToLower(resource)
+	return s, nil
+}
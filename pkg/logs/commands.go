@@ -1,7 +1,6 @@
 package logs
 
 import (
-	"github.com/landoop/bite"
 	config "github.com/landoop/lenses-go/pkg/configs"
 	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
@@ -43,7 +42,7 @@ func NewGetLogsInfoCommand(asObjects *bool) *cobra.Command {
 			}
 
 			if *asObjects {
-				return bite.PrintObject(cmd, logs)
+				return utils.PrintObject(cmd, logs)
 			}
 
 			return utils.PrintLogLines(logs)
@@ -68,7 +67,7 @@ func NewGetLogsMetricsCommand(asObjects *bool) *cobra.Command {
 			}
 
 			if *asObjects {
-				return bite.PrintObject(cmd, logs)
+				return utils.PrintObject(cmd, logs)
 			}
 
 			return utils.PrintLogLines(logs)
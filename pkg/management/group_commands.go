@@ -8,6 +8,7 @@ import (
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -25,7 +26,7 @@ func NewGroupsCommand() *cobra.Command {
 				golog.Errorf("Failed to find groups. [%s]", err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, groups)
+			return utils.PrintObject(cmd, groups)
 		},
 	}
 
@@ -63,15 +64,16 @@ groups get --name=MyGroup --dataNamespaces
 				return err
 			}
 			if namespaceOnly {
-				return bite.PrintObject(cmd, group.Namespaces)
+				return utils.PrintObject(cmd, group.Namespaces)
 			}
-			return bite.PrintObject(cmd, PrintGroup(group))
+			return utils.PrintObject(cmd, PrintGroup(group))
 		},
 	}
 
 	cmd.Flags().StringVar(&groupName, "name", "", `Group name`)
+	cmd.RegisterFlagCompletionFunc("name", completeGroupNames)
 	cmd.Flags().BoolVar(&namespaceOnly, "dataNamespaces", false, `Print data namespaces only`)
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	return cmd
 }
 
@@ -166,7 +168,8 @@ func NewDeleteGroupCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Group name")
-	bite.CanPrintJSON(cmd)
+	cmd.RegisterFlagCompletionFunc("name", completeGroupNames)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -198,8 +201,9 @@ func NewCloneGroupCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Group name")
+	cmd.RegisterFlagCompletionFunc("name", completeGroupNames)
 	cmd.Flags().StringVar(&cloneName, "cloneName", "", "Name for the cloned group")
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -237,3 +241,18 @@ func addCreateUpdateFlags(cmd *cobra.Command, namespacesRaw *string, group *api.
 	bite.Prepend(cmd, bite.FileBind(&group))
 	bite.CanBeSilent(cmd)
 }
+
+// completeGroupNames suggests the live group names for the `--name` flag.
+var completeGroupNames = config.CompleteResourceNames("groups", func(client *api.Client) ([]string, error) {
+	groups, err := client.GetGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(groups))
+	for _, group := range groups {
+		names = append(names, group.Name)
+	}
+
+	return names, nil
+})
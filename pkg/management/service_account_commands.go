@@ -1,10 +1,14 @@
 package management
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -22,7 +26,7 @@ func NewServiceAccountsCommand() *cobra.Command {
 				golog.Errorf("Failed to find groups. [%s]", err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, svcaccs)
+			return utils.PrintObject(cmd, svcaccs)
 		},
 	}
 
@@ -56,12 +60,13 @@ serviceaccounts get --name=svcacc
 				golog.Errorf("Failed to find service account. [%s]", err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, svcacc)
+			return utils.PrintObject(cmd, svcacc)
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", `Service account name`)
-	bite.CanPrintJSON(cmd)
+	cmd.RegisterFlagCompletionFunc("name", completeServiceAccountNames)
+	utils.CanPrintJSON(cmd)
 	return cmd
 }
 
@@ -97,17 +102,25 @@ serviceaccounts create --name john --owner admin --groups MyGroup1 --groups MyGr
 
 //NewUpdateServiceAccountCommand creates`serviceaccounts update`
 func NewUpdateServiceAccountCommand() *cobra.Command {
-	var svcacc api.ServiceAccount
+	var (
+		svcacc                  api.ServiceAccount
+		addGroups, removeGroups []string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "update",
 		Short: "Update a service account",
 		Example: `
 serviceaccounts update --name john --owner admin --groups MyGroup1 --groups MyGroup2
+serviceaccounts update --name john --add-group MyGroup3 --remove-group MyGroup1
 `,
 		TraverseChildren: true,
 		SilenceErrors:    true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(addGroups) > 0 || len(removeGroups) > 0 {
+				return editServiceAccountGroups(cmd, svcacc, addGroups, removeGroups)
+			}
+
 			if err := validateCreateUpdateSvcAcc(cmd, &svcacc); err != nil {
 				return err
 			}
@@ -120,10 +133,32 @@ serviceaccounts update --name john --owner admin --groups MyGroup1 --groups MyGr
 		},
 	}
 	addCreateUpdateSvcAccFlags(cmd, &svcacc)
+	cmd.Flags().StringArrayVar(&addGroups, "add-group", nil, "Add the service account to a group, its other groups are kept")
+	cmd.Flags().StringArrayVar(&removeGroups, "remove-group", nil, "Remove the service account from a group, its other groups are kept")
 
 	return cmd
 }
 
+// editServiceAccountGroups adds the service account to the --add-group groups and removes it from the --remove-group ones,
+// the --groups and the --owner, which replace the whole service account, can't be combined with them.
+func editServiceAccountGroups(cmd *cobra.Command, svcacc api.ServiceAccount, addGroups, removeGroups []string) error {
+	if err := bite.CheckRequiredFlags(cmd, bite.FlagPair{"name": svcacc.Name}); err != nil {
+		return err
+	}
+
+	if len(svcacc.Groups) > 0 || svcacc.Owner != "" {
+		return fmt.Errorf("--groups and --owner can not be used together with --add-group or --remove-group")
+	}
+
+	updated, err := config.Client.EditServiceAccountGroups(svcacc.Name, addGroups, removeGroups)
+	if err != nil {
+		golog.Errorf("Failed to update the groups of service account [%s]. [%s]", svcacc.Name, err.Error())
+		return err
+	}
+
+	return bite.PrintInfo(cmd, "Service account [%s] updated, groups [%s]", updated.Name, strings.Join(updated.Groups, ", "))
+}
+
 //NewDeleteServiceAccountCommand creates  `serviceaccounts delete`
 func NewDeleteServiceAccountCommand() *cobra.Command {
 	var name string
@@ -148,7 +183,8 @@ func NewDeleteServiceAccountCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Service account name")
-	bite.CanPrintJSON(cmd)
+	cmd.RegisterFlagCompletionFunc("name", completeServiceAccountNames)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -177,8 +213,9 @@ func NewRevokeServiceAccountCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Service account name")
+	cmd.RegisterFlagCompletionFunc("name", completeServiceAccountNames)
 	cmd.Flags().StringVar(&token, "token", "", "Your own manual service account token. Otherwise will be autogenerated")
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -202,3 +239,18 @@ func addCreateUpdateSvcAccFlags(cmd *cobra.Command, svcacc *api.ServiceAccount)
 	bite.Prepend(cmd, bite.FileBind(&svcacc))
 	bite.CanBeSilent(cmd)
 }
+
+// completeServiceAccountNames suggests the live service account names for the `--name` flag.
+var completeServiceAccountNames = config.CompleteResourceNames("serviceaccounts", func(client *api.Client) ([]string, error) {
+	svcaccs, err := client.GetServiceAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(svcaccs))
+	for _, svcacc := range svcaccs {
+		names = append(names, svcacc.Name)
+	}
+
+	return names, nil
+})
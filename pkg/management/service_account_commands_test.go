@@ -249,6 +249,40 @@ func TestServiceAccountUpdateCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+func TestServiceAccountUpdateCommandEditGroups(t *testing.T) {
+	current := api.ServiceAccount{Name: "svcacc", Owner: "spiros", Groups: []string{"MyGroup1", "MyGroup2"}}
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/serviceaccount/svcacc", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			json.NewEncoder(w).Encode(current)
+		case http.MethodPut:
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&current))
+		}
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	output, err := test.ExecuteCommand(NewServiceAccountsCommand(), "update",
+		"--name=svcacc",
+		"--add-group=MyGroup3",
+		"--add-group=MyGroup2",
+		"--remove-group=MyGroup1",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "Service account [svcacc] updated, groups [MyGroup2, MyGroup3]\n", output)
+	assert.Equal(t, api.ServiceAccount{Name: "svcacc", Owner: "spiros", Groups: []string{"MyGroup2", "MyGroup3"}}, current)
+
+	_, err = test.ExecuteCommand(NewServiceAccountsCommand(), "update", "--name=svcacc", "--groups=MyGroup1", "--add-group=MyGroup3")
+	assert.EqualError(t, err, "--groups and --owner can not be used together with --add-group or --remove-group")
+}
+
 func TestServiceAccountDeleteMissingFieldsFails(t *testing.T) {
 	cmd := NewServiceAccountsCommand()
 	_, err := test.ExecuteCommand(cmd, "delete",
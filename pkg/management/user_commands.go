@@ -43,9 +43,9 @@ users --groups Group1 --groups Group2
 						filteredUsers = append(filteredUsers, filteredUser)
 					}
 				}
-				return bite.PrintObject(cmd, filteredUsers)
+				return utils.PrintObject(cmd, filteredUsers)
 			}
-			return bite.PrintObject(cmd, users)
+			return utils.PrintObject(cmd, users)
 		},
 	}
 
@@ -75,12 +75,13 @@ func NewGetUserCommand() *cobra.Command {
 				golog.Errorf("Failed to find user. [%s]", err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, user)
+			return utils.PrintObject(cmd, user)
 		},
 	}
 
 	cmd.Flags().StringVar(&userName, "username", "", `User username`)
-	bite.CanPrintJSON(cmd)
+	cmd.RegisterFlagCompletionFunc("username", completeUserNames)
+	utils.CanPrintJSON(cmd)
 	return cmd
 }
 
@@ -194,7 +195,8 @@ func NewDeleteUserCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&username, "username", "", "User username")
-	bite.CanPrintJSON(cmd)
+	cmd.RegisterFlagCompletionFunc("username", completeUserNames)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -226,8 +228,24 @@ func NewPasswordUserCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&username, "username", "", "User username")
+	cmd.RegisterFlagCompletionFunc("username", completeUserNames)
 	cmd.Flags().StringVar(&password, "secret", "", "User password")
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
+
+// completeUserNames suggests the live user names for the `--username` flag.
+var completeUserNames = config.CompleteResourceNames("users", func(client *api.Client) ([]string, error) {
+	users, err := client.GetUsers()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		names = append(names, user.Username)
+	}
+
+	return names, nil
+})
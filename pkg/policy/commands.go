@@ -8,6 +8,7 @@ import (
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +31,7 @@ func NewGetPoliciesCommand() *cobra.Command {
 
 			for _, policy := range result {
 				if name != "" && name == policy.Name {
-					return bite.PrintObject(cmd, policy)
+					return utils.PrintObject(cmd, policy)
 				}
 			}
 
@@ -39,14 +40,15 @@ func NewGetPoliciesCommand() *cobra.Command {
 				golog.Errorf("Failed to retrieve policy [%s]. [%s]", name, err.Error())
 				return err
 			}
-			return bite.PrintObject(cmd, result)
+			return utils.PrintObject(cmd, result)
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Policy name")
 	cmd.AddCommand(NewGetPoliciesObfuscationCommand())
 	cmd.AddCommand(NewGetPoliciesImpactTypesCommand())
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+	utils.CanPage(cmd)
 	return cmd
 }
 
@@ -65,11 +67,11 @@ func NewGetPoliciesObfuscationCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			return bite.PrintObject(cmd, r)
+			return utils.PrintObject(cmd, r)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -91,11 +93,11 @@ func NewGetPoliciesImpactTypesCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, r)
+			return utils.PrintObject(cmd, r)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -144,7 +146,7 @@ func NewViewPolicyCommand() *cobra.Command {
 
 			for _, p := range policies {
 				if p.Name == name {
-					bite.PrintObject(cmd, p)
+					utils.PrintObject(cmd, p)
 					return nil
 				}
 			}
@@ -155,7 +157,7 @@ func NewViewPolicyCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", "Policy name")
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -185,6 +187,10 @@ func NewCreatePolicyCommand() *cobra.Command {
 				return err
 			}
 
+			if err := api.ValidateRedaction(policy.Obfuscation); err != nil {
+				return err
+			}
+
 			policy.Fields = strings.Split(fields, ",")
 
 			if err := config.Client.CreatePolicy(policy); err != nil {
@@ -202,7 +208,7 @@ func NewCreatePolicyCommand() *cobra.Command {
 	cmd.Flags().StringVar(&policy.Obfuscation, "redaction", "", "Policy redaction type")
 	cmd.Flags().StringVar(&fields, "fields", "", "Schema fields, comma separated")
 	bite.Prepend(cmd, bite.FileBind(&policy))
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -233,6 +239,10 @@ func NewUpdatePolicyCommand() *cobra.Command {
 				return err
 			}
 
+			if err := api.ValidateRedaction(policy.Obfuscation); err != nil {
+				return err
+			}
+
 			policy.Fields = strings.Split(fields, ",")
 
 			if err := config.Client.UpdatePolicy(policy); err != nil {
@@ -251,7 +261,7 @@ func NewUpdatePolicyCommand() *cobra.Command {
 	cmd.Flags().StringVar(&policy.Obfuscation, "redaction", "", "Policy redaction type")
 	cmd.Flags().StringVar(&fields, "fields", "", "Schema fields, comma separated")
 	bite.Prepend(cmd, bite.FileBind(&policy))
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
@@ -282,7 +292,7 @@ func NewDeletePolicyCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&id, "id", "", "Policy id")
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 	return cmd
 }
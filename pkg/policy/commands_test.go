@@ -377,6 +377,31 @@ func TestPolicyCreateCommandSuccess(t *testing.T) {
 	config.Client = nil
 }
 
+func TestPolicyCreateCommandInvalidRedaction(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Fail(t, "unexpected request", "%s %s", r.Method, r.URL.Path)
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+
+	assert.Nil(t, err)
+
+	config.Client = client
+
+	cmd := NewPolicyGroupCommand()
+	_, err = test.ExecuteCommand(cmd, "create",
+		"--name=MyTestPolicy",
+		"--category=my-category",
+		"--impact=HIGH",
+		"--redaction=Last-9",
+		"--fields=f1,f2,f3",
+	)
+	assert.NotNil(t, err)
+	config.Client = nil
+}
+
 func TestPolicyCreateCommandFail(t *testing.T) {
 
 	//setup http client
@@ -3,8 +3,8 @@ package processor
 import (
 	"net/url"
 	"sort"
+	"time"
 
-	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
@@ -14,7 +14,7 @@ import (
 
 //NewGetProcessorsCommand creates `processors` command
 func NewGetProcessorsCommand() *cobra.Command {
-	var name, clusterName, namespace string
+	var name, clusterName, namespace, filter string
 
 	cmd := &cobra.Command{
 		Use:              "processors",
@@ -23,9 +23,9 @@ func NewGetProcessorsCommand() *cobra.Command {
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			result, err := config.Client.GetProcessors()
+			result, err := config.Client.FilterProcessors(filter)
 			if err != nil {
-				golog.Errorf("Failed to retrieve processors. [%s]", err.Error())
+				config.Client.Logger().Errorf("Failed to retrieve processors. [%s]", err.Error())
 				return err
 			}
 
@@ -59,7 +59,7 @@ func NewGetProcessorsCommand() *cobra.Command {
 				final = append(final, processor)
 			}
 
-			return bite.PrintObject(cmd, final)
+			return utils.PrintObject(cmd, final)
 		},
 	}
 
@@ -67,8 +67,11 @@ func NewGetProcessorsCommand() *cobra.Command {
 	cmd.Flags().StringVar(&name, "name", "", "Select by processor name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&clusterName, "cluster-name", "", "Select by cluster name, available only in CONNECT and KUBERNETES mode")
 	cmd.Flags().StringVar(&namespace, "namespace", "", "Select by namespace, available only in KUBERNETES mode")
+	cmd.Flags().StringVar(&filter, "filter", "", "Select only the processors whose name contains the filter")
 	// example: lenses-cli processors --query="[?ClusterName == 'IN_PROC'].Name | sort(@) | {Processor_Names_IN_PROC: join(', ', @)}"
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+	utils.CanPage(cmd)
+	utils.CanWatch(cmd)
 
 	cmd.AddCommand(NewProcessorsLogsCommand())
 
@@ -94,7 +97,6 @@ func NewProcessorsLogsCommand() *cobra.Command {
 				return err
 			}
 
-			golog.SetTimeFormat("")
 			handler := func(level, log string) error {
 				log, _ = url.QueryUnescape(log) // for LSQL lines.
 				utils.RichLog(level, log)
@@ -102,7 +104,7 @@ func NewProcessorsLogsCommand() *cobra.Command {
 			}
 
 			if err := config.Client.GetProcessorsLogs(clusterName, namespace, podName, follow, lines, handler); err != nil {
-				golog.Errorf("Failed to retrieve logs for pod [%s]. [%s]", podName, err.Error())
+				config.Client.Logger().Errorf("Failed to retrieve logs for pod [%s]. [%s]", podName, err.Error())
 				return err
 			}
 
@@ -154,16 +156,16 @@ func NewProcessorViewCommand() *cobra.Command {
 			processor, err := config.Client.GetProcessor(id)
 
 			if err != nil {
-				golog.Errorf("Failed to retrieve processor [%s]. [%s]", id, err.Error())
+				config.Client.Logger().Errorf("Failed to retrieve processor [%s]. [%s]", id, err.Error())
 				return err
 			}
 
-			return bite.PrintObject(cmd, processor)
+			return utils.PrintObject(cmd, processor)
 		},
 	}
 
 	cmd.Flags().StringVar(&id, "id", "", `Processor id`)
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 
 	return cmd
@@ -172,7 +174,11 @@ func NewProcessorViewCommand() *cobra.Command {
 //NewProcessorCreateCommand creates `processor create` command
 func NewProcessorCreateCommand() *cobra.Command {
 	// the processorName and sql are the required.
-	var processor api.CreateProcessorPayload
+	var (
+		processor   api.CreateProcessorPayload
+		wait        bool
+		waitTimeout time.Duration
+	)
 
 	cmd := &cobra.Command{
 		Use:              "create",
@@ -188,10 +194,23 @@ func NewProcessorCreateCommand() *cobra.Command {
 			err := config.Client.CreateProcessor(processor.Name, processor.SQL, processor.Runners, processor.ClusterName, processor.Namespace, processor.Pipeline)
 
 			if err != nil {
-				golog.Errorf("Failed to create processor [%s]. [%s]", processor.Name, err.Error())
+				config.Client.Logger().Errorf("Failed to create processor [%s]. [%s]", processor.Name, err.Error())
 				return err
 			}
 
+			if wait {
+				identifier, err := config.Client.LookupProcessorIdentifier("", processor.Name, processor.ClusterName, processor.Namespace)
+				if err != nil {
+					return err
+				}
+
+				if _, err = config.Client.WaitForProcessor(identifier, waitTimeout); err != nil {
+					return err
+				}
+
+				return bite.PrintInfo(cmd, "Processor [%s] created and running", processor.Name)
+			}
+
 			return bite.PrintInfo(cmd, "Processor [%s] created", processor.Name)
 		},
 	}
@@ -202,6 +221,8 @@ func NewProcessorCreateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&processor.SQL, "sql", "", `Lenses SQL to run .e.g. sql="SET autocreate=true;INSERT INTO topic1 SELECT * FROM topicA"`)
 	cmd.Flags().IntVar(&processor.Runners, "runners", 1, "Number of runners/instance to deploy")
 	cmd.Flags().StringVar(&processor.Pipeline, "pipeline", "", `A label to apply to kubernetes processors, defaults to processor name`)
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until the processor is running")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 2*time.Minute, "Maximum time to wait for the processor when --wait is set")
 
 	bite.Prepend(cmd, bite.FileBind(&processor))
 	bite.CanBeSilent(cmd)
@@ -226,7 +247,7 @@ func NewProcessorPauseCommand() *cobra.Command {
 			}
 
 			if err := config.Client.PauseProcessor(identifier); err != nil {
-				golog.Errorf("Failed to pause processor [%s]. [%s]", identifier, err.Error())
+				config.Client.Logger().Errorf("Failed to pause processor [%s]. [%s]", identifier, err.Error())
 				return err
 			}
 
@@ -260,7 +281,7 @@ func NewProcessorResumeCommand() *cobra.Command {
 			}
 
 			if err := config.Client.ResumeProcessor(identifier); err != nil {
-				golog.Errorf("Failed to resume processor [%s]. [%s]", identifier, err.Error())
+				config.Client.Logger().Errorf("Failed to resume processor [%s]. [%s]", identifier, err.Error())
 				return err
 			}
 
@@ -303,7 +324,7 @@ func NewProcessorUpdateRunnersCommand() *cobra.Command {
 			}
 
 			if err := config.Client.UpdateProcessorRunners(identifier, runners); err != nil {
-				golog.Errorf("Failed to scale processor [%s] to [%d]. [%s]", identifier, runners, err.Error())
+				config.Client.Logger().Errorf("Failed to scale processor [%s] to [%d]. [%s]", identifier, runners, err.Error())
 				return err
 			}
 
@@ -339,7 +360,7 @@ func NewProcessorDeleteCommand() *cobra.Command {
 
 			// delete the processor based on the identifier, based on the current running mode.
 			if err := config.Client.DeleteProcessor(identifier); err != nil {
-				golog.Errorf("Failed to delete processor [%s]. [%s]", identifier, err.Error())
+				config.Client.Logger().Errorf("Failed to delete processor [%s]. [%s]", identifier, err.Error())
 				return err
 			}
 
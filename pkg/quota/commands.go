@@ -9,6 +9,7 @@ import (
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -25,11 +26,12 @@ func NewGetQuotasCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, quotas)
+			return utils.PrintObject(cmd, quotas)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
+	utils.CanPage(cmd)
 
 	return cmd
 }
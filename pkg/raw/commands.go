@@ -0,0 +1,135 @@
+package raw
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodHead}
+
+//NewRawCommand creates the `raw` command
+func NewRawCommand() *cobra.Command {
+	var (
+		data        string
+		contentType string
+		headers     []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "raw <method> <path>",
+		Short: "Send an authenticated request to any endpoint of the current context and print the response",
+		Long: "Send an authenticated request to any endpoint of the current context and print the response.\n" +
+			"It's meant for the endpoints which have no command yet, the JSON responses respect the --pretty, the --indent and the --query.",
+		Example: `raw GET /api/v1/kafka/brokers
+raw POST /api/v1/group --data @group.json
+raw PUT api/v1/serviceaccount/sa1 --data '{"owner": "admin"}' --header "X-Request-Id: 42"`,
+		Args:             cobra.ExactArgs(2),
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			method := strings.ToUpper(args[0])
+			if !isMethod(method) {
+				return fmt.Errorf("invalid method [%s], expected one of: %s", args[0], strings.Join(methods, ", "))
+			}
+
+			var send []byte
+			if data != "" {
+				b, err := bite.TryReadFileContents(data)
+				if err != nil {
+					return fmt.Errorf("unable to read the --data: %v", err)
+				}
+				send = b
+			} else {
+				contentType = ""
+			}
+
+			var options []api.RequestOption
+			for _, header := range headers {
+				key, value, ok := parseHeader(header)
+				if !ok {
+					return fmt.Errorf("invalid --header [%s], expected key: value", header)
+				}
+
+				options = append(options, func(r *http.Request) error {
+					r.Header.Set(key, value)
+					return nil
+				})
+			}
+
+			resp, err := config.Client.Do(method, args[1], contentType, send, options...)
+			if err != nil {
+				return err
+			}
+
+			body, err := config.Client.ReadResponseBody(resp)
+			if err != nil {
+				return err
+			}
+
+			return printBody(cmd, body)
+		},
+	}
+
+	cmd.Flags().StringVar(&data, "data", "", `The body of the request, inline or a file as @file, i.e --data @group.json`)
+	cmd.Flags().StringVar(&contentType, "content-type", "application/json", "The content type of the --data")
+	cmd.Flags().StringArrayVar(&headers, "header", nil, `Extra request headers, as key: value, i.e --header "X-Request-Id: 42"`)
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+func isMethod(method string) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseHeader(header string) (key, value string, ok bool) {
+	idx := strings.IndexByte(header, ':')
+	if idx <= 0 {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(header[:idx]), strings.TrimSpace(header[idx+1:]), true
+}
+
+// printBody prints the JSON bodies as JSON, so the --pretty, the --indent and the --query are respected,
+// and the rest of them as they are.
+func printBody(cmd *cobra.Command, body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	// keep the numbers as they are, i.e the offsets which do not fit a float64.
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil && !dec.More() {
+		return utils.PrintJSON(cmd, v)
+	}
+
+	out := cmd.OutOrStdout()
+	if _, err := out.Write(body); err != nil {
+		return err
+	}
+
+	if body[len(body)-1] != '\n' {
+		_, err := fmt.Fprintln(out)
+		return err
+	}
+
+	return nil
+}
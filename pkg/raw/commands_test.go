@@ -0,0 +1,106 @@
+package raw
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+type rawRequest struct {
+	method, path, contentType, header, body string
+}
+
+func setupRawClient(t *testing.T, requests *[]rawRequest) func() {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, rawRequest{
+			method:      r.Method,
+			path:        r.URL.RequestURI(),
+			contentType: r.Header.Get("Content-Type"),
+			header:      r.Header.Get("X-Request-Id"),
+			body:        string(b),
+		})
+
+		switch r.URL.Path {
+		case "/api/v1/kafka/brokers":
+			w.Write([]byte(`[{"brokerId":1,"offset":9007199254740993}]`))
+		case "/api/v1/group":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("group created"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "not found"}`))
+		}
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	return func() {
+		config.Client = nil
+		teardown()
+	}
+}
+
+func TestRawGet(t *testing.T) {
+	var requests []rawRequest
+	defer setupRawClient(t, &requests)()
+
+	output, err := test.ExecuteCommand(NewRawCommand(), "get", "/api/v1/kafka/brokers?page=1", "--compact")
+	assert.Nil(t, err)
+	assert.Equal(t, "[{\"brokerId\":1,\"offset\":9007199254740993}]\n", output)
+
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, rawRequest{method: http.MethodGet, path: "/api/v1/kafka/brokers?page=1"}, requests[0])
+	}
+}
+
+func TestRawPostFile(t *testing.T) {
+	var requests []rawRequest
+	defer setupRawClient(t, &requests)()
+
+	dir, err := ioutil.TempDir("", "raw")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "group.json")
+	assert.Nil(t, ioutil.WriteFile(file, []byte(`{"name": "dev"}`), 0644))
+
+	output, err := test.ExecuteCommand(NewRawCommand(), "POST", "api/v1/group", "--data", "@"+file, "--header", "X-Request-Id: 42")
+	assert.Nil(t, err)
+	assert.Equal(t, "group created\n", output)
+
+	if assert.Len(t, requests, 1) {
+		assert.Equal(t, rawRequest{
+			method:      http.MethodPost,
+			path:        "/api/v1/group",
+			contentType: "application/json",
+			header:      "42",
+			body:        `{"name": "dev"}`,
+		}, requests[0])
+	}
+}
+
+func TestRawErrors(t *testing.T) {
+	var requests []rawRequest
+	defer setupRawClient(t, &requests)()
+
+	_, err := test.ExecuteCommand(NewRawCommand(), "FETCH", "/api/v1/group")
+	assert.EqualError(t, err, "invalid method [FETCH], expected one of: GET, POST, PUT, PATCH, DELETE, HEAD")
+
+	_, err = test.ExecuteCommand(NewRawCommand(), "DELETE", "/api/v1/unknown")
+	var resErr api.ResourceError
+	if assert.IsType(t, resErr, err) {
+		resErr = err.(api.ResourceError)
+		assert.Equal(t, http.StatusNotFound, resErr.StatusCode)
+	}
+}
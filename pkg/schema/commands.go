@@ -15,6 +15,7 @@ import (
 	"github.com/landoop/lenses-go/pkg"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -111,7 +112,7 @@ func NewSchemasGroupCommand() *cobra.Command {
 				// remove the prev line(the processing current/total line) so we can show a clean table or errors.
 				fmt.Fprintf(os.Stdout, "\n\033[1A\033[K")
 
-				if err := bite.PrintObject(cmd, totalSchemas); err != nil {
+				if err := utils.PrintObject(cmd, totalSchemas); err != nil {
 					errors <- err
 				}
 
@@ -153,7 +154,7 @@ func NewSchemasGroupCommand() *cobra.Command {
 	}
 
 	root.Flags().BoolVar(&unwrap, "unwrap", false, "prints only the names as a list of strings separated by line endings")
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
 	root.AddCommand(NewGlobalCompatibilityLevelGroupCommand())
 
 	return root
@@ -258,7 +259,7 @@ func NewSchemaGroupCommand() *cobra.Command {
 	// it's not required, the default is "latest", get a schema based on a specific version.
 	root.Flags().StringVar(&versionStringOrInt, "version", api.SchemaLatestVersion, "Latest or numeric value lookup schema based on a specific  version")
 	// if true then the schema will be NOT printed with indent.
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
 
 	// subcommands.
 	root.AddCommand(NewRegisterSchemaCommand())
@@ -325,13 +326,13 @@ func NewGetSchemaVersionsCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, outlineIntResults("version", versions))
-			return bite.PrintObject(cmd, bite.OutlineIntResults(cmd, "version", versions))
+			return utils.PrintObject(cmd, bite.OutlineIntResults(cmd, "version", versions))
 		},
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", `--name="name"`)
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -358,7 +359,7 @@ func NewDeleteSchemaCommand() *cobra.Command {
 
 			if bite.ExpectsFeedback(cmd) {
 				// return printJSON(cmd, outlineIntResults("version", deletedVersions))
-				return bite.PrintObject(cmd, bite.OutlineIntResults(cmd, "version", deletedVersions))
+				return utils.PrintObject(cmd, bite.OutlineIntResults(cmd, "version", deletedVersions))
 			}
 
 			return nil
@@ -366,7 +367,7 @@ func NewDeleteSchemaCommand() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&name, "name", "", `Schema name to delete`)
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 	bite.CanBeSilent(cmd)
 
 	return cmd
@@ -518,7 +519,7 @@ func getSchemaByID(cmd *cobra.Command, id int, client *api.Client) error {
 	}
 
 	// return printJSON(cmd, schemaRawJSON)
-	return bite.PrintJSON(cmd, schemaRawJSON)
+	return utils.PrintJSON(cmd, schemaRawJSON)
 }
 
 // the only valid version string is the "latest"
@@ -561,7 +562,7 @@ func getSchemaByVersion(cmd *cobra.Command, client *api.Client, name, versionStr
 		return err
 	}
 
-	return bite.PrintObject(cmd, schema)
+	return utils.PrintObject(cmd, schema)
 }
 
 func joinValidCompatibilityLevels(sep string) string {
@@ -7,10 +7,10 @@ import (
 
 	"github.com/c-bata/go-prompt"
 	"github.com/kataras/golog"
-	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
 	"github.com/landoop/lenses-go/pkg/sql"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -80,7 +80,7 @@ Crtl+D to exit
 
 		},
 	}
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -2,27 +2,35 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"net"
 	"os"
 	"os/signal"
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/landoop/lenses-go/pkg/websocket"
 	"github.com/spf13/cobra"
 )
 
 //InteractiveShell parameter to enable shell as interactive
 var InteractiveShell bool
-var sqlLiveStream, sqlStats, sqlKeys, sqlKeysOnly, sqlMeta bool
+var sqlLiveStream, sqlKeys, sqlKeysOnly, sqlMeta, sqlOffsets bool
+var sqlOutputFile string
+var sqlInto, sqlKeyField, sqlValueFormat, sqlValueSubject string
+var sqlStats time.Duration
 var gCmd *cobra.Command
 
+// defaultStatsInterval is the stats interval when the `--stats` flag is passed without a value.
+const defaultStatsInterval = 2 * time.Second
+
 type (
 	responseWithKeysWithMeta struct {
 		Key      json.RawMessage    `json:"key"`
@@ -77,7 +85,15 @@ func readAndQuoteQueries(args []string) ([]string, error) {
 	return []string{query}, nil
 }
 
-func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool, liveStream bool, stats bool) error {
+func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool, offsets bool, liveStream bool, stats time.Duration, outputFile string, into *topicWriter) error {
+	maxRecords, err := utils.GetMaxRecords(cmd)
+	if outputFile != "" || into != nil {
+		maxRecords, err = utils.GetRedirectedMaxRecords(cmd)
+	}
+	if err != nil {
+		return err
+	}
+
 	currentConfig := config.Manager.Config.GetCurrent()
 
 	message := websocket.Message{
@@ -86,145 +102,349 @@ func runSQL(cmd *cobra.Command, sql string, meta bool, keys bool, keysOnly bool,
 		Live:  liveStream,
 		Stats: 2,
 	}
-	conn, err := websocket.OpenLiveConnection(websocket.LiveConfiguration{
+	if stats >= time.Second {
+		message.Stats = int(stats.Seconds())
+	}
+
+	liveConfig := websocket.LiveConfiguration{
 		Host:    currentConfig.Host,
 		Debug:   currentConfig.Debug,
 		Message: message,
-	})
+	}
+	// a browsing query would run again from the start, reconnect only on live-streams.
+	if !liveStream {
+		liveConfig.NoReconnect = true
+	}
+
+	// closing the connection on ctrl/cmd+c cancels the query on the server too.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch,
+		// kill -SIGINT XXXX or Ctrl+c
+		os.Interrupt,
+		syscall.SIGINT, // register that too, it should be ok
+		// kill -SIGTERM XXXX
+		syscall.SIGTERM,
+	)
+	defer signal.Stop(ch)
+
+	go func() {
+		select {
+		case <-ch:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	printer := &recordPrinter{cmd: cmd, meta: meta, keys: keys, keysOnly: keysOnly, offsets: offsets}
+	if into != nil {
+		printer.topic = into
+		err = streamSQL(ctx, liveConfig, printer, stats, maxRecords)
+		truncated := err == utils.ErrMaxRecords
+		if truncated {
+			err = nil
+		}
+		if closeErr := into.Close(); err == nil {
+			err = closeErr
+		}
+
+		// on failure too, so the records which were already produced are known.
+		fmt.Fprintf(cmd.ErrOrStderr(), "Produced %d records to the topic [%s]\n", into.produced, into.topic)
+		if truncated && err == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: only the first %d records of the query were produced to the topic [%s], the --%s\n",
+				into.produced, into.topic, utils.MaxRecordsFlag)
+		}
+		return err
+	}
+
+	if outputFile == "" {
+		if err = streamSQL(ctx, liveConfig, printer, stats, maxRecords); err == utils.ErrMaxRecords {
+			return nil
+		}
+		return err
+	}
+
+	if printer.file, err = newResultWriter(outputFile); err != nil {
+		return err
+	}
+
+	err = streamSQL(ctx, liveConfig, printer, stats, maxRecords)
+	truncated := err == utils.ErrMaxRecords
+	if truncated {
+		err = nil
+	}
+	if closeErr := printer.file.Close(); err == nil {
+		err = closeErr
+	}
 	if err != nil {
 		return err
 	}
 
-	go func() {
-		// print each error on screen, do not exit because
-		// a query may be errorred but another, most important may running for a long time.
+	// the records are not printed, the stderr keeps the stdout clean for scripts.
+	fmt.Fprintf(cmd.ErrOrStderr(), "Wrote %d records to [%s]\n", printer.written, outputFile)
+	if truncated {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Warning: the [%s] is truncated, it has only the first %d records of the query, the --%s\n",
+			outputFile, printer.written, utils.MaxRecordsFlag)
+	}
+	return nil
+}
+
+// streamSQL prints the records of the query as they arrive,
+// and, if `stats` is greater than zero, the records count and the throughput every `stats` interval.
+// It returns when the query ends, fails, the `maxRecords` are printed, zero means unlimited, or the `ctx` is done.
+// On the `maxRecords` it returns the `utils.ErrMaxRecords`, so the callers know the records are truncated.
+func streamSQL(ctx context.Context, liveConfig websocket.LiveConfiguration, printer *recordPrinter, stats time.Duration, maxRecords int) error {
+	cmd := printer.cmd
+
+	// closing the connection on the --max-records cancels the query on the server too.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages, err := websocket.Subscribe(ctx, liveConfig)
+	if err != nil {
+		return err
+	}
+
+	var (
+		records int64
+		started = time.Now()
+		tick    <-chan time.Time
+	)
+
+	if stats > 0 {
+		ticker := time.NewTicker(stats)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// stats are printed to the stderr, so the records output can still be piped.
+	printStats := func() {
+		elapsed := time.Since(started)
+		fmt.Fprintf(cmd.ErrOrStderr(), "[stats] records: %d, throughput: %.2f records/sec, elapsed: %s\n",
+			records, float64(records)/elapsed.Seconds(), elapsed.Round(time.Millisecond))
+	}
+
+	for {
 		select {
-		case err := <-conn.Err():
-			// ignore error and don't print that caused by ctrl/cmd+c while trying to read.
-			if errNet, isNetworkClosed := err.(*net.OpError); isNetworkClosed && errNet.Op == "read" {
-				if strings.Contains(errNet.Error(), "use of closed") {
-					return
+		case <-tick:
+			printStats()
+		case resp, ok := <-messages:
+			if !ok {
+				if stats > 0 {
+					printStats()
 				}
+				return nil
 			}
 
-			fmt.Fprintf(cmd.OutOrStderr(), "[%s]\n", err)
+			switch {
+			case resp.Type == websocket.ErrorResponse || resp.Type == websocket.InvalidRequestResponse:
+				// parse it, otherwise it shows it very ugly.
+				var errStr string
+				json.Unmarshal(resp.Data.Value, &errStr)
+				return fmt.Errorf("[%s]: [%s]", resp.Type, errStr)
+			case resp.Err != nil:
+				// print each connection error on screen, a live-stream reconnects.
+				fmt.Fprintf(cmd.OutOrStderr(), "[%s]\n", resp.Err)
+			case resp.Type == websocket.StatsResponse:
+				if stats > 0 {
+					if err := utils.PrintJSON(cmd, resp.LiveResponse); err != nil {
+						return err
+					}
+				}
+			case resp.Type == websocket.RecordMessageResponse:
+				records++
+				if err := printer.print(resp.LiveResponse); err != nil {
+					golog.Error(err)
+					return err
+				}
+
+				if utils.MaxRecordsReached(cmd, int(records), maxRecords) {
+					if stats > 0 {
+						printStats()
+					}
+					return utils.ErrMaxRecords
+				}
+			}
 		}
-	}()
+	}
+}
+
+// recordPrinter prints the query records based on the `--keys`, `--keys-only`, `--meta` and `--offsets` flags.
+type recordPrinter struct {
+	cmd                           *cobra.Command
+	meta, keys, keysOnly, offsets bool
+
+	// the `--offsets` warning and table header are printed once.
+	warned, headerPrinted bool
+
+	// file receives the records instead of the output, see `--output-file`.
+	file    resultWriter
+	written int
 
-	// we exit on error, the only one place that we directly exit from here.
-	errorReporter := func(resp websocket.LiveResponse) error {
-		// parse it, otherwise it shows it very ugly.
-		var errStr string
-		json.Unmarshal(resp.Data.Value, &errStr)
-		_, err = fmt.Fprintf(cmd.OutOrStderr(), "[%s]: [%s]\n", resp.Type, errStr)
-		os.Exit(1)
+	// topic receives the records instead of the output, see `--into`.
+	topic *topicWriter
+}
+
+// writeFile writes the "record" to the `--output-file`.
+func (p *recordPrinter) writeFile(record interface{}) error {
+	if err := p.file.write(record); err != nil {
 		return err
 	}
 
-	// login error or anything? depends on the back-end.
-	conn.OnError(errorReporter)
-	conn.OnInvalidRequest(errorReporter)
+	p.written++
+	return nil
+}
+
+func (p *recordPrinter) print(resp websocket.LiveResponse) error {
+	cmd, meta, keys, keysOnly := p.cmd, p.meta, p.keys, p.keysOnly
 
-	if stats {
-		conn.OnStats(func(resp websocket.LiveResponse) error {
-			err := bite.PrintJSON(cmd, resp)
-			return err
-		})
+	if p.topic != nil {
+		return p.topic.write(resp.Data)
 	}
 
-	// first subscribe to any incoming kafka messages (as result of the lsql publish).
-	conn.OnRecordMessage(func(resp websocket.LiveResponse) error {
+	if p.offsets {
+		if resp.Data.Metadata.HasCoordinates() {
+			return p.printWithCoordinates(resp)
+		}
 
-		var data interface{}
+		if !p.warned {
+			p.warned = true
+			fmt.Fprintln(cmd.ErrOrStderr(), "Warning: the server did not return the record coordinates, --offsets is ignored")
+		}
+	}
 
-		if keysOnly {
-			// keys and metadata only
-			if meta {
-				data = responseWithKeysWithMetaOnly{
-					Key:      resp.Data.Key,
-					Metadata: resp.Data.Metadata,
-				}
-			} else {
-				data = resp.Data.Key
+	var data interface{}
+
+	if keysOnly {
+		// keys and metadata only
+		if meta {
+			data = responseWithKeysWithMetaOnly{
+				Key:      resp.Data.Key,
+				Metadata: resp.Data.Metadata,
 			}
 		} else {
-			// data only
-			if !keys && !meta {
-				data = resp.Data.Value
-			}
+			data = resp.Data.Key
+		}
+	} else {
+		// data only
+		if !keys && !meta {
+			data = resp.Data.Value
+		}
 
-			// data and metadata
-			if !keys && meta {
-				data = responseWithMeta{
-					Value:    resp.Data.Value,
-					Metadata: resp.Data.Metadata,
-				}
+		// data and metadata
+		if !keys && meta {
+			data = responseWithMeta{
+				Value:    resp.Data.Value,
+				Metadata: resp.Data.Metadata,
 			}
+		}
 
-			// keys and data
-			if keys && !meta {
-				data = responseWithKeys{
-					Key:   resp.Data.Key,
-					Value: resp.Data.Value,
-				}
+		// keys and data
+		if keys && !meta {
+			data = responseWithKeys{
+				Key:   resp.Data.Key,
+				Value: resp.Data.Value,
 			}
+		}
 
-			// keys, data and metadata
-			if keys && meta {
-				data = responseWithKeysWithMeta{
-					Key:      resp.Data.Key,
-					Value:    resp.Data.Value,
-					Metadata: resp.Data.Metadata,
-				}
+		// keys, data and metadata
+		if keys && meta {
+			data = responseWithKeysWithMeta{
+				Key:      resp.Data.Key,
+				Value:    resp.Data.Value,
+				Metadata: resp.Data.Metadata,
 			}
 		}
+	}
 
-		if err := bite.PrintJSON(cmd, data); err != nil {
-			golog.Error(err)
-			return err
-		}
+	if p.file != nil {
+		return p.writeFile(data)
+	}
 
-		return nil
-	})
+	if utils.IsNDJSON(cmd) {
+		return utils.PrintRecord(cmd, data)
+	}
 
-	conn.OnEnd(func(resp websocket.LiveResponse) error {
-		if !InteractiveShell && sqlLiveStream {
-			os.Exit(0)
-		} else {
-			p, err := os.FindProcess(os.Getpid())
-			if err != nil {
-				return err
-			}
+	return utils.PrintJSON(cmd, data)
+}
+
+// recordWithCoordinates is the JSON output of a record with the `--offsets` flag.
+type recordWithCoordinates struct {
+	Topic     string          `json:"topic,omitempty"`
+	Partition int             `json:"partition"`
+	Offset    int             `json:"offset"`
+	Timestamp int             `json:"timestamp"`
+	Key       json.RawMessage `json:"key,omitempty"`
+	Value     json.RawMessage `json:"value,omitempty"`
+}
 
-			p.Signal(os.Interrupt)
+// printWithCoordinates prints the record with its topic, partition, offset and timestamp,
+// as fields on JSON and YAML output or as leading columns otherwise.
+func (p *recordPrinter) printWithCoordinates(resp websocket.LiveResponse) error {
+	meta := resp.Data.Metadata
+	record := recordWithCoordinates{
+		Topic:     meta.Topic,
+		Partition: meta.Partition,
+		Offset:    meta.Offset,
+		Timestamp: meta.Timestamp,
+	}
+
+	if p.keys || p.keysOnly {
+		record.Key = resp.Data.Key
+	}
+
+	if !p.keysOnly {
+		record.Value = resp.Data.Value
+	}
+
+	if p.file != nil {
+		return p.writeFile(record)
+	}
+
+	if utils.IsNDJSON(p.cmd) {
+		return utils.PrintRecord(p.cmd, record)
+	}
+
+	if output := strings.ToUpper(bite.GetOutPutFlag(p.cmd)); output == "JSON" || output == "YAML" {
+		return utils.PrintJSON(p.cmd, record)
+	}
+
+	out := p.cmd.OutOrStdout()
+	if !p.headerPrinted {
+		p.headerPrinted = true
+		header := "TOPIC\tPARTITION\tOFFSET\tTIMESTAMP"
+		if record.Key != nil {
+			header += "\tKEY"
 		}
-		conn.Close()
-		return nil
-	})
+		if record.Value != nil {
+			header += "\tVALUE"
+		}
+		fmt.Fprintln(out, header)
+	}
 
-	ch := make(chan os.Signal, 1)
-	signal.Notify(ch,
-		// kill -SIGINT XXXX or Ctrl+c
-		os.Interrupt,
-		syscall.SIGINT, // register that too, it should be ok
-		// os.Kill  is equivalent with the syscall.SIGKILL
-		os.Kill,
-		syscall.SIGKILL, // register that too, it should be ok
-		// kill -SIGTERM XXXX
-		syscall.SIGTERM,
-	)
+	timestamp := time.Unix(0, int64(meta.Timestamp)*int64(time.Millisecond)).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	row := fmt.Sprintf("%s\t%d\t%d\t%s", meta.Topic, meta.Partition, meta.Offset, timestamp)
+	if record.Key != nil {
+		row += "\t" + string(record.Key)
+	}
+	if record.Value != nil {
+		row += "\t" + string(record.Value)
+	}
 
-	return conn.Wait(ch)
+	_, err := fmt.Fprintln(out, row)
+	return err
 }
 
 //NewLiveLSQLCommand creates `query` command
 func NewLiveLSQLCommand() *cobra.Command {
 
 	cmd := &cobra.Command{
-		Use:              "query",
-		Short:            "Queries, either browsing for continuous (live-stream)",
-		Example:          `query "SELECT * FROM cc_payments LIMIT 10"`,
+		Use:   "query",
+		Short: "Queries, either browsing for continuous (live-stream)",
+		Example: `query "SELECT * FROM cc_payments LIMIT 10" [--output-file payments.csv]
+query "SELECT * FROM cc_payments WHERE amount > 100" --into large_payments [--key-field id] [--value-format avro]`,
 		SilenceErrors:    true,
 		TraverseChildren: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -258,19 +478,40 @@ func NewLiveLSQLCommand() *cobra.Command {
 			}
 
 			checkValidation(validation)
-			runSQL(cmd, queries[0], sqlMeta, sqlKeys, sqlKeysOnly, sqlLiveStream, sqlStats)
-			return nil
+
+			var into *topicWriter
+			if sqlInto != "" {
+				if sqlOutputFile != "" {
+					return fmt.Errorf("--into and --output-file can not be used together")
+				}
+
+				if into, err = newTopicWriter(client, sqlInto, sqlKeyField, sqlValueFormat, sqlValueSubject, sqlLiveStream); err != nil {
+					return err
+				}
+			}
+
+			return runSQL(cmd, queries[0], sqlMeta, sqlKeys, sqlKeysOnly, sqlOffsets, sqlLiveStream, sqlStats, sqlOutputFile, into)
 
 		},
 	}
 
 	cmd.Flags().BoolVar(&sqlLiveStream, "live-stream", false, "Run in continuous query mode")
-	cmd.Flags().BoolVar(&sqlStats, "stats", false, "Print query stats")
+	cmd.Flags().DurationVar(&sqlStats, "stats", 0, "Print query stats, the records count and the throughput every interval, i.e --stats=5s")
+	cmd.Flags().Lookup("stats").NoOptDefVal = defaultStatsInterval.String()
 	cmd.Flags().BoolVar(&sqlKeys, "keys", false, "Print message keys")
 	cmd.Flags().BoolVar(&sqlKeysOnly, "keys-only", false, "Print message keys only")
 	cmd.Flags().BoolVar(&sqlMeta, "meta", false, "Print message metadata")
+	cmd.Flags().BoolVar(&sqlOffsets, "offsets", false, "Print each record's topic, partition, offset and timestamp, as leading columns or JSON fields")
+	cmd.Flags().StringVar(&sqlOutputFile, "output-file", "", "Write the records to this file instead of printing them, as csv, json or ndjson based on its extension, i.e --output-file result.csv, "+
+		"all the records are written unless the --max-records is set explicitly")
+
+	cmd.Flags().StringVar(&sqlInto, "into", "", "Produce the value of each record to this topic instead of printing it, the produced records are counted on the stderr, "+
+		"all the records are produced unless the --max-records is set explicitly")
+	cmd.Flags().StringVar(&sqlKeyField, "key-field", "", "The field of the record's value to use as the key of the --into records, defaults to the record's key")
+	cmd.Flags().StringVar(&sqlValueFormat, "value-format", "", "The value encoding of the --into records: string, json or avro, defaults to the topic's value type")
+	cmd.Flags().StringVar(&sqlValueSubject, "value-subject", "", "The schema registry subject of the avro encoding, defaults to <topic>-value")
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
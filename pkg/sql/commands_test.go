@@ -0,0 +1,302 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/landoop/lenses-go/pkg/websocket"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use, the query prints while the test reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestStreamSQLPrintsRowsAsTheyArrive(t *testing.T) {
+	release := make(chan struct{})
+	upgrader := gorilla.Upgrader{}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var msg websocket.Message
+		assert.Nil(t, conn.ReadJSON(&msg))
+
+		for i := 1; i <= 3; i++ {
+			conn.WriteJSON(websocket.LiveResponse{
+				Type: websocket.RecordMessageResponse,
+				Data: websocket.Data{Value: json.RawMessage(fmt.Sprintf(`{"row":%d}`, i))},
+			})
+
+			if i == 1 {
+				// hold the rest of the rows until the first one is printed.
+				<-release
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		conn.WriteJSON(websocket.LiveResponse{Type: websocket.EndResponse})
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	out, errOut := new(syncBuffer), new(syncBuffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- streamSQL(context.Background(), websocket.LiveConfiguration{
+			Host:    server.URL,
+			Message: websocket.Message{SQL: "SELECT * FROM payments LIMIT 3"},
+		}, &recordPrinter{cmd: cmd}, 10*time.Millisecond, 0)
+	}()
+
+	// the first row should be printed while the query is still running.
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(out.String(), `{"row":1}`) {
+		if time.Now().After(deadline) {
+			t.Fatal("first row was not printed before the query completed")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(release)
+
+	select {
+	case err := <-done:
+		assert.Nil(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("query did not complete")
+	}
+
+	output := out.String()
+	assert.True(t, strings.Index(output, `{"row":1}`) < strings.Index(output, `{"row":2}`))
+	assert.True(t, strings.Index(output, `{"row":2}`) < strings.Index(output, `{"row":3}`))
+	assert.Contains(t, errOut.String(), "[stats] records: 3,")
+}
+
+func TestStreamSQLCanceled(t *testing.T) {
+	upgrader := gorilla.Upgrader{}
+	closed := make(chan struct{})
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var msg websocket.Message
+		conn.ReadJSON(&msg)
+		// a never ending query, it stops when the client closes the connection.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				close(closed)
+				return
+			}
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+	err := streamSQL(ctx, websocket.LiveConfiguration{Host: server.URL}, &recordPrinter{cmd: cmd}, 0, 0)
+	assert.Nil(t, err)
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the query connection was not closed")
+	}
+}
+
+func decodeLiveResponse(t *testing.T, s string) websocket.LiveResponse {
+	var resp websocket.LiveResponse
+	if err := json.Unmarshal([]byte(s), &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func newRecordPrinterCommand(output string) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	out, errOut := new(bytes.Buffer), new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.Flags().String("output", output, "")
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+	return cmd, out, errOut
+}
+
+func TestRecordPrinterOffsets(t *testing.T) {
+	resp := decodeLiveResponse(t, `{"type": "RECORD", "data": {
+		"key": "k1", "value": {"amount": 10},
+		"metadata": {"topic": "payments", "partition": 2, "offset": 42, "timestamp": 1580392100854}
+	}}`)
+
+	cmd, out, errOut := newRecordPrinterCommand("json")
+	printer := &recordPrinter{cmd: cmd, keys: true, offsets: true}
+	assert.Nil(t, printer.print(resp))
+
+	var record map[string]interface{}
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, map[string]interface{}{
+		"topic":     "payments",
+		"partition": float64(2),
+		"offset":    float64(42),
+		"timestamp": float64(1580392100854),
+		"key":       "k1",
+		"value":     map[string]interface{}{"amount": float64(10)},
+	}, record)
+	assert.Empty(t, errOut.String())
+
+	cmd, out, _ = newRecordPrinterCommand("table")
+	printer = &recordPrinter{cmd: cmd, offsets: true}
+	assert.Nil(t, printer.print(resp))
+	assert.Nil(t, printer.print(resp))
+	assert.Equal(t, "TOPIC\tPARTITION\tOFFSET\tTIMESTAMP\tVALUE\n"+
+		"payments\t2\t42\t2020-01-30T13:48:20.854Z\t{\"amount\": 10}\n"+
+		"payments\t2\t42\t2020-01-30T13:48:20.854Z\t{\"amount\": 10}\n", out.String())
+}
+
+func TestRecordPrinterNDJSON(t *testing.T) {
+	resp := decodeLiveResponse(t, `{"type": "RECORD", "data": {
+		"key": "k1", "value": {"amount": 10, "tags": ["a", "b"]},
+		"metadata": {"topic": "payments", "partition": 2, "offset": 42, "timestamp": 1580392100854}
+	}}`)
+
+	cmd, out, _ := newRecordPrinterCommand("ndjson")
+	printer := &recordPrinter{cmd: cmd, offsets: true}
+	assert.Nil(t, printer.print(resp))
+	printer = &recordPrinter{cmd: cmd, keys: true}
+	assert.Nil(t, printer.print(resp))
+
+	// a line per record, the record with its coordinates too.
+	assert.Equal(t, `{"topic":"payments","partition":2,"offset":42,"timestamp":1580392100854,"value":{"amount":10,"tags":["a","b"]}}`+"\n"+
+		`{"key":"k1","value":{"amount":10,"tags":["a","b"]}}`+"\n", out.String())
+}
+
+func TestPrintLiveMessagesNDJSON(t *testing.T) {
+	messages := make(chan websocket.LiveMessage, 3)
+	for i := 0; i < 2; i++ {
+		resp := decodeLiveResponse(t, fmt.Sprintf(`{"type": "RECORD", "data": {"value": [%d], "metadata": {"partition": 1, "offset": %d}}}`, i, i))
+		messages <- websocket.LiveMessage{LiveResponse: resp}
+	}
+	messages <- websocket.LiveMessage{LiveResponse: websocket.LiveResponse{Type: websocket.EndResponse}}
+	close(messages)
+
+	cmd, out, _ := newRecordPrinterCommand("ndjson")
+	assert.Nil(t, printLiveMessages(cmd, messages))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if assert.Len(t, lines, 2) {
+		for i, line := range lines {
+			var data websocket.Data
+			assert.Nil(t, json.Unmarshal([]byte(line), &data), line)
+			assert.Equal(t, fmt.Sprintf("[%d]", i), string(data.Value))
+			assert.Equal(t, i, data.Metadata.Offset)
+		}
+	}
+}
+
+func TestRecordPrinterOffsetsMissingCoordinates(t *testing.T) {
+	resp := decodeLiveResponse(t, `{"type": "RECORD", "data": {"value": {"amount": 10}, "metadata": {"timestamp": 1580392100854}}}`)
+
+	cmd, out, errOut := newRecordPrinterCommand("table")
+	printer := &recordPrinter{cmd: cmd, offsets: true}
+	assert.Nil(t, printer.print(resp))
+	assert.Nil(t, printer.print(resp))
+
+	// printed as without --offsets, the warning is printed once.
+	assert.Equal(t, "{\"amount\":10}\n{\"amount\":10}\n", out.String())
+	assert.Equal(t, 1, strings.Count(errOut.String(), "--offsets is ignored"))
+}
+
+func TestStreamSQLMaxRecords(t *testing.T) {
+	upgrader := gorilla.Upgrader{}
+	closed := make(chan int, 1)
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var msg websocket.Message
+		conn.ReadJSON(&msg)
+
+		// a query without a LIMIT, it writes records until the client closes the connection.
+		go func() {
+			for {
+				if _, _, err := conn.ReadMessage(); err != nil {
+					conn.Close()
+					return
+				}
+			}
+		}()
+
+		for i := 1; ; i++ {
+			err := conn.WriteJSON(websocket.LiveResponse{
+				Type: websocket.RecordMessageResponse,
+				Data: websocket.Data{Value: json.RawMessage(fmt.Sprintf(`{"row":%d}`, i))},
+			})
+			if err != nil {
+				closed <- i
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	out, errOut := new(syncBuffer), new(syncBuffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+	cmd.SetErr(errOut)
+
+	err := streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, &recordPrinter{cmd: cmd}, 0, 3)
+	assert.Equal(t, utils.ErrMaxRecords, err)
+	assert.Equal(t, "{\"row\":1}\n{\"row\":2}\n{\"row\":3}\n", out.String())
+	assert.Equal(t, "Stopped after 3 records, the --max-records limit, use --max-records 0 to read all of them\n", errOut.String())
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the query connection was not closed")
+	}
+}
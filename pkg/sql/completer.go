@@ -60,6 +60,7 @@ func optionSuggestions() []prompt.Suggest {
 		{Text: "!keys-only", Description: "Toggle printing keys only from message, no value"},
 		{Text: "!live-stream", Description: "Toggle continuous query mode"},
 		{Text: "!meta", Description: "Toggle printing message metadata"},
+		{Text: "!offsets", Description: "Toggle printing record topic, partition, offset and timestamp"},
 		{Text: "!stats", Description: "Toggle printing query stats"},
 		{Text: "!options", Description: "Print current options"},
 		{Text: "!pretty", Description: "Toggle pretty printing query output"},
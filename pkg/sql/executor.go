@@ -51,7 +51,7 @@ func (e *Executor) Execute(sql string) {
 		trimmed := strings.Trim(sql, " ")
 
 		if trimmed == "!options" {
-			fmt.Printf("Options: keys=%t, keysOnly=%t, meta=%t, stats=%t, live-stream=%t\n", sqlKeys, sqlKeysOnly, sqlMeta, sqlStats, sqlLiveStream)
+			fmt.Printf("Options: keys=%t, keysOnly=%t, meta=%t, offsets=%t, stats=%s, live-stream=%t\n", sqlKeys, sqlKeysOnly, sqlMeta, sqlOffsets, sqlStats, sqlLiveStream)
 			return
 		}
 
@@ -101,14 +101,25 @@ func (e *Executor) Execute(sql string) {
 			return
 		}
 
+		if trimmed == "!offsets" {
+			if sqlOffsets {
+				sqlOffsets = false
+			} else {
+				sqlOffsets = true
+			}
+
+			fmt.Printf("Option [%s] set to [%t]\n", trimmed, sqlOffsets)
+			return
+		}
+
 		if trimmed == "!stats" {
-			if sqlStats {
-				sqlStats = false
+			if sqlStats > 0 {
+				sqlStats = 0
 			} else {
-				sqlStats = true
+				sqlStats = defaultStatsInterval
 			}
 
-			fmt.Printf("Option [%s] set to [%t]\n", trimmed, sqlStats)
+			fmt.Printf("Option [%s] set to [%s]\n", trimmed, sqlStats)
 			return
 		}
 
@@ -153,7 +164,9 @@ func (e *Executor) Execute(sql string) {
 				return
 			}
 
-			runSQL(e.interactiveCmd, finalQ, sqlMeta, sqlKeys, sqlKeysOnly, sqlLiveStream, sqlStats)
+			if err := runSQL(e.interactiveCmd, finalQ, sqlMeta, sqlKeys, sqlKeysOnly, sqlOffsets, sqlLiveStream, sqlStats, sqlOutputFile, nil); err != nil {
+				golog.Error(err)
+			}
 
 			file, err := os.Create(e.sqlHistoryPath)
 			if err != nil {
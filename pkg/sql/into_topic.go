@@ -0,0 +1,112 @@
+package sql
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/websocket"
+)
+
+// intoBatchSize is the maximum amount of the query records that are produced to the `--into` topic with a single request.
+const intoBatchSize = 100
+
+// topicWriter produces the records of a query to the `--into` topic, in batches of `batchSize` records.
+// The value of each record is produced, its key is the `keyField` of the value or the record's key if it's not set.
+type topicWriter struct {
+	client   *api.Client
+	topic    string
+	keyField string
+	opts     api.ProduceOptions
+	// batchSize is 1 on live-streams, so the records are produced as they arrive.
+	batchSize int
+
+	batch []api.ProduceRecord
+	// produced is the amount of the records written to the topic, so far.
+	produced int
+}
+
+// newTopicWriter returns the `topicWriter` of the "topic", the "valueFormat" is the value encoding: string, json or avro,
+// empty for the topic's value type.
+func newTopicWriter(client *api.Client, topic, keyField, valueFormat, valueSubject string, liveStream bool) (*topicWriter, error) {
+	encoding, err := api.ParseEncoding(valueFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &topicWriter{
+		client:    client,
+		topic:     topic,
+		keyField:  keyField,
+		opts:      api.ProduceOptions{ValueEncoding: encoding, ValueSubject: valueSubject},
+		batchSize: intoBatchSize,
+	}
+
+	if liveStream {
+		w.batchSize = 1
+	}
+
+	return w, nil
+}
+
+func (w *topicWriter) write(data websocket.Data) error {
+	key, err := w.recordKey(data)
+	if err != nil {
+		return err
+	}
+
+	w.batch = append(w.batch, api.ProduceRecord{Key: key, Value: data.Value})
+	if len(w.batch) < w.batchSize {
+		return nil
+	}
+
+	return w.flush()
+}
+
+// recordKey returns the `keyField` of the record's value, the strings without their quotes and the rest as json,
+// or the record's key if the `keyField` is not set.
+func (w *topicWriter) recordKey(data websocket.Data) (string, error) {
+	raw := data.Key
+	if w.keyField != "" {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data.Value, &fields); err != nil {
+			return "", fmt.Errorf("the --key-field [%s] requires the records to be json objects: %v", w.keyField, err)
+		}
+
+		var ok bool
+		if raw, ok = fields[w.keyField]; !ok {
+			return "", fmt.Errorf("the --key-field [%s] is missing from the record %s", w.keyField, data.Value)
+		}
+	}
+
+	return csvValue(raw), nil
+}
+
+// flush produces the pending records, the records before the first failed one are counted as produced.
+func (w *topicWriter) flush() error {
+	if len(w.batch) == 0 {
+		return nil
+	}
+
+	batch := w.batch
+	w.batch = nil
+
+	results, err := w.client.ProduceToTopicWithOptions(w.topic, w.opts, batch)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		if result.Error != "" {
+			return fmt.Errorf("unable to produce a record to the topic [%s]: %s", w.topic, result.Error)
+		}
+		w.produced++
+	}
+
+	return nil
+}
+
+// Close produces the remaining records.
+func (w *topicWriter) Close() error {
+	return w.flush()
+}
@@ -0,0 +1,170 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/landoop/lenses-go/pkg/websocket"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type producedRequest struct {
+	ValueType string              `json:"valueType"`
+	Records   []api.ProduceRecord `json:"records"`
+}
+
+// newProduceServer accepts the records produced to the "large_payments" topic, the record with the "failValue" fails.
+func newProduceServer(t *testing.T, requests *[]producedRequest, failValue string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !assert.Equal(t, "/api/v1/kafka/topics/large_payments/messages", r.URL.Path) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var req producedRequest
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		*requests = append(*requests, req)
+
+		results := make([]api.ProduceResult, len(req.Records))
+		for i, record := range req.Records {
+			results[i].Offset = int64(i)
+			if failValue != "" && string(record.Value) == failValue {
+				results[i].Error = "record too large"
+			}
+		}
+
+		json.NewEncoder(w).Encode(results)
+	}))
+}
+
+func newTopicWriterTest(t *testing.T, host, keyField string, liveStream bool) *topicWriter {
+	client, err := api.OpenConnection(api.ClientConfig{Host: host, Token: "secret"})
+	assert.Nil(t, err)
+
+	w, err := newTopicWriter(client, "large_payments", keyField, "json", "", liveStream)
+	assert.Nil(t, err)
+	return w
+}
+
+func TestStreamSQLInto(t *testing.T) {
+	rows := []string{`{"id":"p1","amount":120}`, `{"id":"p2","amount":300}`, `{"id":3,"amount":150}`}
+	server := newRowsServer(t, rows...)
+	defer server.Close()
+
+	var requests []producedRequest
+	produceServer := newProduceServer(t, &requests, "")
+	defer produceServer.Close()
+
+	out := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(out)
+
+	into := newTopicWriterTest(t, produceServer.URL, "id", false)
+	into.batchSize = 2
+
+	printer := &recordPrinter{cmd: cmd, topic: into}
+	assert.Nil(t, streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, printer, 0, 0))
+	assert.Nil(t, into.Close())
+
+	assert.Equal(t, 3, into.produced)
+	assert.Empty(t, out.String(), "the records should not be printed")
+
+	if assert.Len(t, requests, 2) {
+		assert.Equal(t, "JSON", requests[0].ValueType)
+		assert.Equal(t, []api.ProduceRecord{
+			{Key: "p1", Value: json.RawMessage(rows[0])},
+			{Key: "p2", Value: json.RawMessage(rows[1])},
+		}, requests[0].Records)
+		assert.Equal(t, []api.ProduceRecord{{Key: "3", Value: json.RawMessage(rows[2])}}, requests[1].Records)
+	}
+}
+
+func TestTopicWriterRecordKey(t *testing.T) {
+	w := &topicWriter{}
+	key, err := w.recordKey(websocket.Data{Key: json.RawMessage(`"k1"`), Value: json.RawMessage(`{"id":1}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, "k1", key)
+
+	key, err = w.recordKey(websocket.Data{Key: json.RawMessage(`null`), Value: json.RawMessage(`{"id":1}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, "", key)
+
+	w.keyField = "customer"
+	key, err = w.recordKey(websocket.Data{Value: json.RawMessage(`{"customer":{"id":7}}`)})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":7}`, key)
+
+	_, err = w.recordKey(websocket.Data{Value: json.RawMessage(`{"id":1}`)})
+	assert.EqualError(t, err, `the --key-field [customer] is missing from the record {"id":1}`)
+
+	_, err = w.recordKey(websocket.Data{Value: json.RawMessage(`"text"`)})
+	assert.Error(t, err)
+}
+
+func TestStreamSQLIntoStopsOnFailure(t *testing.T) {
+	rows := []string{`{"id":"p1"}`, `{"id":"p2"}`, `{"id":"p3"}`, `{"id":"p4"}`}
+	server := newRowsServer(t, rows...)
+	defer server.Close()
+
+	var requests []producedRequest
+	produceServer := newProduceServer(t, &requests, rows[2])
+	defer produceServer.Close()
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(new(bytes.Buffer))
+
+	// produced one by one, like on live-streams.
+	into := newTopicWriterTest(t, produceServer.URL, "", true)
+	printer := &recordPrinter{cmd: cmd, topic: into}
+	err := streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, printer, 0, 0)
+	assert.EqualError(t, err, "unable to produce a record to the topic [large_payments]: record too large")
+	assert.Nil(t, into.Close())
+
+	assert.Equal(t, 2, into.produced)
+	assert.Len(t, requests, 3, "the records after the failed one should not be produced")
+
+	_, err = newTopicWriter(nil, "large_payments", "", "protobuf", "", false)
+	assert.EqualError(t, err, "unknown encoding [protobuf], available: string, json, avro")
+}
+
+func TestStreamSQLIntoMaxRecords(t *testing.T) {
+	rows := make([]string, utils.DefaultMaxRecords+5)
+	for i := range rows {
+		rows[i] = fmt.Sprintf(`{"id":%d}`, i)
+	}
+	server := newRowsServer(t, rows...)
+	defer server.Close()
+
+	var requests []producedRequest
+	produceServer := newProduceServer(t, &requests, "")
+	defer produceServer.Close()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int(utils.MaxRecordsFlag, utils.DefaultMaxRecords, "")
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	// the default --max-records does not apply to the --into.
+	maxRecords, err := utils.GetRedirectedMaxRecords(cmd)
+	assert.Nil(t, err)
+
+	into := newTopicWriterTest(t, produceServer.URL, "", false)
+	printer := &recordPrinter{cmd: cmd, topic: into}
+	assert.Nil(t, streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, printer, 0, maxRecords))
+	assert.Nil(t, into.Close())
+
+	assert.Equal(t, len(rows), into.produced)
+	var produced int
+	for _, req := range requests {
+		produced += len(req.Records)
+	}
+	assert.Equal(t, len(rows), produced)
+}
@@ -0,0 +1,94 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/kataras/golog"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/landoop/lenses-go/pkg/websocket"
+	"github.com/spf13/cobra"
+)
+
+//NewLiveCommand creates `live` command
+func NewLiveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "live",
+		Short:            "Subscribe to a continuous query and print its records as they arrive, until interrupted",
+		Example:          `live "SELECT * FROM cc_payments" [--output=json]`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf(`exactly one sql statement is required, the correct form is: live "your query"`)
+			}
+
+			queries, err := readAndQuoteQueries(args)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			ch := make(chan os.Signal, 1)
+			signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+			defer signal.Stop(ch)
+			go func() {
+				select {
+				case <-ch:
+					cancel()
+				case <-ctx.Done():
+				}
+			}()
+
+			messages, err := config.Client.LiveSubscribe(ctx, queries[0])
+			if err != nil {
+				return err
+			}
+
+			return printLiveMessages(cmd, messages)
+		},
+	}
+
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+func printLiveMessages(cmd *cobra.Command, messages <-chan websocket.LiveMessage) error {
+	structured := utils.IsStructured(cmd)
+
+	var lastErr error
+	for msg := range messages {
+		if msg.Err != nil {
+			// close reasons and connection drops, the subscription may reconnect.
+			fmt.Fprintf(cmd.OutOrStderr(), "[%s]\n", msg.Err)
+			lastErr = msg.Err
+			continue
+		}
+
+		lastErr = nil
+		if msg.Type != websocket.RecordMessageResponse {
+			golog.Debugf("live: [%s]", msg.Type)
+			continue
+		}
+
+		if structured {
+			if err := utils.PrintRecord(cmd, msg.Data); err != nil {
+				return err
+			}
+			continue
+		}
+
+		meta := msg.Data.Metadata
+		fmt.Fprintf(cmd.OutOrStdout(), "partition: %d, offset: %d, key: %s, value: %s\n",
+			meta.Partition, meta.Offset, string(msg.Data.Key), string(msg.Data.Value))
+	}
+
+	return lastErr
+}
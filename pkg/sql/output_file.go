@@ -0,0 +1,229 @@
+package sql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// resultWriter writes the records of a query to the --output-file, as they arrive, see `newResultWriter`.
+type resultWriter interface {
+	write(record interface{}) error
+	// Close completes the file, i.e the closing bracket of a JSON array, and closes it.
+	Close() error
+}
+
+// newResultWriter creates the "path" and returns its writer based on its extension, csv, json or ndjson.
+func newResultWriter(path string) (resultWriter, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	switch ext {
+	case ".csv", ".json", ".ndjson":
+	default:
+		return nil, fmt.Errorf("unsupported extension [%s] of the --output-file [%s], expected .csv, .json or .ndjson", ext, path)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &fileOutput{f: f, buf: bufio.NewWriter(f)}
+	switch ext {
+	case ".csv":
+		return &csvWriter{fileOutput: out, w: csv.NewWriter(out.buf)}, nil
+	case ".json":
+		return &jsonWriter{fileOutput: out}, nil
+	default:
+		return &ndjsonWriter{fileOutput: out}, nil
+	}
+}
+
+// fileOutput is the buffered file of a `resultWriter`, the buffer is flushed as it fills up, so the records are not kept in memory.
+type fileOutput struct {
+	f   *os.File
+	buf *bufio.Writer
+}
+
+func (o *fileOutput) Close() error {
+	if err := o.buf.Flush(); err != nil {
+		o.f.Close()
+		return err
+	}
+
+	return o.f.Close()
+}
+
+// ndjsonWriter writes each record as a compact JSON object on its own line.
+type ndjsonWriter struct {
+	*fileOutput
+}
+
+func (w *ndjsonWriter) write(record interface{}) error {
+	return writeJSONLine(w.buf, record)
+}
+
+func writeJSONLine(w io.Writer, record interface{}) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	b = append(b, '\n')
+	_, err = w.Write(b)
+	return err
+}
+
+// jsonWriter writes the records as a JSON array, one record per line.
+type jsonWriter struct {
+	*fileOutput
+	records int
+}
+
+func (w *jsonWriter) write(record interface{}) error {
+	sep := ",\n"
+	if w.records == 0 {
+		sep = "[\n"
+	}
+	w.records++
+
+	if _, err := w.buf.WriteString(sep); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.buf.Write(b)
+	return err
+}
+
+func (w *jsonWriter) Close() error {
+	end := "\n]\n"
+	if w.records == 0 {
+		end = "[]\n"
+	}
+
+	if _, err := w.buf.WriteString(end); err != nil {
+		w.fileOutput.Close()
+		return err
+	}
+
+	return w.fileOutput.Close()
+}
+
+// csvWriter writes the records as CSV rows, the columns are the fields of the first record, in their order,
+// the missing fields are empty and the fields which were not part of the first record are skipped.
+// The records which are not JSON objects are written as a single "value" column.
+type csvWriter struct {
+	*fileOutput
+	w       *csv.Writer
+	columns []string
+}
+
+func (w *csvWriter) write(record interface{}) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	keys, fields, isObject, err := decodeFields(b)
+	if err != nil {
+		return err
+	}
+
+	if !isObject {
+		keys, fields = []string{"value"}, map[string]json.RawMessage{"value": b}
+	}
+
+	if w.columns == nil {
+		w.columns = keys
+		if err = w.w.Write(w.columns); err != nil {
+			return err
+		}
+	}
+
+	row := make([]string, len(w.columns))
+	for i, column := range w.columns {
+		row[i] = csvValue(fields[column])
+	}
+
+	if err = w.w.Write(row); err != nil {
+		return err
+	}
+
+	// flush to the file buffer, the rows are not kept until the end.
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	if err := w.w.Error(); err != nil {
+		w.fileOutput.Close()
+		return err
+	}
+
+	return w.fileOutput.Close()
+}
+
+// decodeFields returns the keys, in their order, and the raw values of the JSON object "b",
+// it reports false if "b" is not a JSON object.
+func decodeFields(b []byte) ([]string, map[string]json.RawMessage, bool, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, nil, false, nil
+	}
+
+	var keys []string
+	fields := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err = dec.Token()
+		if err != nil {
+			return nil, nil, false, err
+		}
+
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err = dec.Decode(&value); err != nil {
+			return nil, nil, false, err
+		}
+
+		if _, exists := fields[key]; !exists {
+			keys = append(keys, key)
+		}
+		fields[key] = value
+	}
+
+	return keys, fields, true, nil
+}
+
+// csvValue returns the text of a field, the strings without their quotes, the objects and the arrays as JSON
+// and the missing and the null fields as empty.
+func csvValue(raw json.RawMessage) string {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	if raw[0] == '"' {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			return s
+		}
+	}
+
+	return string(raw)
+}
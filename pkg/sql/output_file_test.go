@@ -0,0 +1,184 @@
+package sql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/landoop/lenses-go/pkg/websocket"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRowsServer(t *testing.T, rows ...string) *httptest.Server {
+	upgrader := gorilla.Upgrader{}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var msg websocket.Message
+		assert.Nil(t, conn.ReadJSON(&msg))
+
+		for _, row := range rows {
+			conn.WriteJSON(websocket.LiveResponse{
+				Type: websocket.RecordMessageResponse,
+				Data: websocket.Data{Value: json.RawMessage(row)},
+			})
+		}
+
+		conn.WriteJSON(websocket.LiveResponse{Type: websocket.EndResponse})
+	}))
+}
+
+func TestStreamSQLOutputFile(t *testing.T) {
+	rows := []string{
+		`{"id": 1, "name": "alice", "amount": 10.5, "tags": ["a", "b"]}`,
+		`{"name": "bob, jr", "id": 2, "amount": null}`,
+		`{"id": 3, "name": "carol \"cc\"", "amount": 7, "extra": true}`,
+	}
+
+	dir, err := ioutil.TempDir("", "sql-output-file")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{
+			file: "result.csv",
+			expected: "id,name,amount,tags\n" +
+				"1,alice,10.5,\"[\"\"a\"\",\"\"b\"\"]\"\n" +
+				"2,\"bob, jr\",,\n" +
+				"3,\"carol \"\"cc\"\"\",7,\n",
+		},
+		{
+			file: "result.ndjson",
+			expected: `{"id":1,"name":"alice","amount":10.5,"tags":["a","b"]}` + "\n" +
+				`{"name":"bob, jr","id":2,"amount":null}` + "\n" +
+				`{"id":3,"name":"carol \"cc\"","amount":7,"extra":true}` + "\n",
+		},
+		{
+			file: "result.json",
+			expected: "[\n" + `{"id":1,"name":"alice","amount":10.5,"tags":["a","b"]}` + ",\n" +
+				`{"name":"bob, jr","id":2,"amount":null}` + ",\n" +
+				`{"id":3,"name":"carol \"cc\"","amount":7,"extra":true}` + "\n]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			server := newRowsServer(t, rows...)
+			defer server.Close()
+
+			out := new(bytes.Buffer)
+			cmd := &cobra.Command{}
+			cmd.SetOut(out)
+
+			path := filepath.Join(dir, tt.file)
+			file, err := newResultWriter(path)
+			assert.Nil(t, err)
+
+			printer := &recordPrinter{cmd: cmd, file: file}
+			err = streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, printer, 0, 0)
+			assert.Nil(t, err)
+			assert.Nil(t, file.Close())
+
+			assert.Equal(t, 3, printer.written)
+			assert.Empty(t, out.String(), "the records should not be printed")
+
+			contents, err := ioutil.ReadFile(path)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.expected, string(contents))
+
+			if tt.file == "result.json" {
+				var records []map[string]interface{}
+				assert.Nil(t, json.Unmarshal(contents, &records))
+				assert.Len(t, records, 3)
+			}
+		})
+	}
+}
+
+func TestResultWriterEmptyAndNonObjects(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sql-output-file")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = newResultWriter(filepath.Join(dir, "result.txt"))
+	assert.EqualError(t, err, "unsupported extension [.txt] of the --output-file ["+filepath.Join(dir, "result.txt")+"], expected .csv, .json or .ndjson")
+
+	path := filepath.Join(dir, "empty.json")
+	w, err := newResultWriter(path)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close())
+	contents, _ := ioutil.ReadFile(path)
+	assert.Equal(t, "[]\n", string(contents))
+
+	path = filepath.Join(dir, "keys.csv")
+	w, err = newResultWriter(path)
+	assert.Nil(t, err)
+	assert.Nil(t, w.write(json.RawMessage(`"k1"`)))
+	assert.Nil(t, w.write(json.RawMessage(`42`)))
+	assert.Nil(t, w.Close())
+	contents, _ = ioutil.ReadFile(path)
+	assert.Equal(t, "value\nk1\n42\n", string(contents))
+}
+
+func TestStreamSQLOutputFileMaxRecords(t *testing.T) {
+	rows := make([]string, utils.DefaultMaxRecords+5)
+	for i := range rows {
+		rows[i] = fmt.Sprintf(`{"id":%d}`, i)
+	}
+
+	dir, err := ioutil.TempDir("", "sql-output-file")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Int(utils.MaxRecordsFlag, utils.DefaultMaxRecords, "")
+	cmd.SetOut(new(bytes.Buffer))
+	cmd.SetErr(new(bytes.Buffer))
+
+	// the default --max-records does not apply to the files.
+	maxRecords, err := utils.GetRedirectedMaxRecords(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, maxRecords)
+
+	server := newRowsServer(t, rows...)
+	defer server.Close()
+
+	file, err := newResultWriter(filepath.Join(dir, "result.ndjson"))
+	assert.Nil(t, err)
+	printer := &recordPrinter{cmd: cmd, file: file}
+	assert.Nil(t, streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, printer, 0, maxRecords))
+	assert.Nil(t, file.Close())
+	assert.Equal(t, len(rows), printer.written)
+
+	// unless it's set explicitly.
+	assert.Nil(t, cmd.ParseFlags([]string{"--max-records", "2"}))
+	maxRecords, err = utils.GetRedirectedMaxRecords(cmd)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, maxRecords)
+
+	file, err = newResultWriter(filepath.Join(dir, "result.csv"))
+	assert.Nil(t, err)
+	printer = &recordPrinter{cmd: cmd, file: file}
+	err = streamSQL(context.Background(), websocket.LiveConfiguration{Host: server.URL}, printer, 0, maxRecords)
+	assert.Equal(t, utils.ErrMaxRecords, err)
+	assert.Nil(t, file.Close())
+	assert.Equal(t, 2, printer.written)
+}
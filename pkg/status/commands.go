@@ -0,0 +1,232 @@
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/landoop/bite"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// runningState is the state of the running processors and connectors.
+const runningState = "RUNNING"
+
+// statusConcurrency is the maximum number of the concurrent connector status requests.
+const statusConcurrency = 4
+
+// Section is a part of the `Summary`, it's unavailable if its endpoint failed, i.e not supported by the server
+// or not permitted to the current user, the rest of the summary is still printed.
+type Section struct {
+	Available bool `json:"available"`
+	Count     int  `json:"count"`
+	// Running is the amount of the running processors and connectors, nil for the rest of the sections.
+	Running *int `json:"running,omitempty"`
+	// Error is the reason the section is unavailable.
+	Error string `json:"error,omitempty"`
+	// Unknown is the amount of the connectors whose status could not be fetched, they're not counted as running.
+	Unknown int `json:"unknown,omitempty"`
+	// Errors are the failures of the single connectors and connect clusters of an available section.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// Summary is the overview of the connected cluster, printed by the `status` command.
+type Summary struct {
+	Host          string `json:"host"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	// ServerVersionError is the reason the server's version is missing.
+	ServerVersionError string  `json:"serverVersionError,omitempty"`
+	Brokers            Section `json:"brokers"`
+	Topics             Section `json:"topics"`
+	ConsumerGroups     Section `json:"consumerGroups"`
+	Processors         Section `json:"processors"`
+	Connectors         Section `json:"connectors"`
+}
+
+//NewStatusCommand creates the `status` command
+func NewStatusCommand() *cobra.Command {
+	var machineFriendly bool
+
+	cmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"info"},
+		Short:   "Print a summary of the connected cluster: the server version, the brokers, topics, consumer groups, processors and connectors",
+		Example: `status
+info --machine-friendly`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			summary := Collect(config.Client)
+			if machineFriendly || strings.EqualFold(bite.GetOutPutFlag(cmd), "json") {
+				return utils.PrintJSON(cmd, summary)
+			}
+
+			printSummary(cmd.OutOrStdout(), summary)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&machineFriendly, "machine-friendly", false, "Print the summary as JSON, same as --output json")
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// Collect returns the `Summary` of the cluster of the "client", the failed endpoints mark their sections as unavailable.
+func Collect(client *api.Client) Summary {
+	summary := Summary{Host: client.Config.Host}
+
+	if version, err := client.GetServerVersion(); err == nil {
+		summary.ServerVersion = version.Version
+	} else {
+		summary.ServerVersionError = err.Error()
+	}
+
+	brokers, err := client.GetBrokers()
+	summary.Brokers = newSection(len(brokers), nil, err)
+
+	topics, err := client.GetTopicsNames()
+	summary.Topics = newSection(len(topics), nil, err)
+
+	groups, err := client.GetConsumerGroups()
+	summary.ConsumerGroups = newSection(len(groups), nil, err)
+
+	summary.Processors = processorsSection(client)
+	summary.Connectors = connectorsSection(client)
+	return summary
+}
+
+func newSection(count int, running *int, err error) Section {
+	if err != nil {
+		return Section{Error: err.Error()}
+	}
+
+	return Section{Available: true, Count: count, Running: running}
+}
+
+func processorsSection(client *api.Client) Section {
+	processors, err := client.GetProcessors()
+	if err != nil {
+		return newSection(0, nil, err)
+	}
+
+	running := 0
+	for _, processor := range processors.Streams {
+		if processor.DeploymentState == runningState {
+			running++
+		}
+	}
+
+	return newSection(len(processors.Streams), &running, nil)
+}
+
+// connectorsSection counts the connectors of all the connect clusters, the status of each one is fetched for the running ones,
+// up to `statusConcurrency` at a time. A failed status or connect cluster is reported in the `Section#Errors`
+// and the rest of the section is kept, it's unavailable only if all the connect clusters failed.
+func connectorsSection(client *api.Client) Section {
+	clusters, err := client.GetConnectClusters()
+	if err != nil {
+		return newSection(0, nil, err)
+	}
+
+	type connector struct{ cluster, name string }
+
+	var (
+		connectors []connector
+		failures   []string
+	)
+	for _, cluster := range clusters {
+		names, err := client.GetConnectors(cluster.Name)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("connect cluster [%s]: %v", cluster.Name, err))
+			continue
+		}
+
+		for _, name := range names {
+			connectors = append(connectors, connector{cluster.Name, name})
+		}
+	}
+
+	if len(clusters) > 0 && len(failures) == len(clusters) {
+		return Section{Error: strings.Join(failures, ", ")}
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		running int
+		unknown int
+		indexes = make(chan int)
+	)
+
+	for w := 0; w < statusConcurrency && w < len(connectors); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				c := connectors[i]
+				status, err := client.GetConnectorStatus(c.cluster, c.name)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					unknown++
+					failures = append(failures, fmt.Sprintf("connector [%s/%s]: %v", c.cluster, c.name, err))
+				case status.Connector.State == runningState:
+					running++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range connectors {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	sort.Strings(failures)
+	section := newSection(len(connectors), &running, nil)
+	section.Unknown, section.Errors = unknown, failures
+	return section
+}
+
+// printSummary prints the "summary" as text, one line per section.
+func printSummary(w io.Writer, summary Summary) {
+	server := summary.ServerVersion
+	if summary.ServerVersionError != "" {
+		server = "unavailable: " + summary.ServerVersionError
+	}
+
+	fmt.Fprintf(w, "%-16s %s %s\n", "Server", summary.Host, server)
+	printSection(w, "Brokers", summary.Brokers)
+	printSection(w, "Topics", summary.Topics)
+	printSection(w, "Consumer groups", summary.ConsumerGroups)
+	printSection(w, "Processors", summary.Processors)
+	printSection(w, "Connectors", summary.Connectors)
+}
+
+func printSection(w io.Writer, name string, section Section) {
+	switch {
+	case !section.Available:
+		fmt.Fprintf(w, "%-16s unavailable: %s\n", name, section.Error)
+	case section.Running != nil && section.Unknown > 0:
+		fmt.Fprintf(w, "%-16s %d (%d running, %d unknown)\n", name, section.Count, *section.Running, section.Unknown)
+	case section.Running != nil:
+		fmt.Fprintf(w, "%-16s %d (%d running)\n", name, section.Count, *section.Running)
+	default:
+		fmt.Fprintf(w, "%-16s %d\n", name, section.Count)
+	}
+
+	if section.Available {
+		for _, err := range section.Errors {
+			fmt.Fprintf(w, "%-16s %s\n", "", err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package status
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// statusHandler serves a cluster with a connect cluster of two connectors, one running,
+// the consumer groups and the processors endpoints are unavailable.
+var statusHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/api/version":
+		w.Write([]byte(`{"version": "4.0.0"}`))
+	case "/api/v1/kafka/brokers":
+		w.Write([]byte(`[{"id": 1}, {"id": 2}, {"id": 3}]`))
+	case "/api/topics":
+		w.Write([]byte(`[{"topicName": "orders"}, {"topicName": "payments"}]`))
+	case "/api/consumers":
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("Not found"))
+	case "/api/streams":
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte("Forbidden"))
+	case "/api/config":
+		w.Write([]byte(`{"lenses.kafka.connect.clusters": [{"name": "dev"}]}`))
+	case "/api/proxy-connect/dev/connectors":
+		w.Write([]byte(`["orders-source", "payments-sink"]`))
+	case "/api/proxy-connect/dev/connectors/orders-source/status":
+		w.Write([]byte(`{"name": "orders-source", "connector": {"state": "RUNNING"}}`))
+	case "/api/proxy-connect/dev/connectors/payments-sink/status":
+		w.Write([]byte(`{"name": "payments-sink", "connector": {"state": "FAILED"}}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+})
+
+func TestStatusCommand(t *testing.T) {
+	httpClient, teardown := test.TestingHTTPClient(statusHandler)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	output, err := test.ExecuteCommand(NewStatusCommand(), "--machine-friendly")
+	assert.Nil(t, err)
+
+	var summary Summary
+	assert.Nil(t, json.Unmarshal([]byte(output), &summary))
+	assert.Equal(t, "4.0.0", summary.ServerVersion)
+	assert.Equal(t, Section{Available: true, Count: 3}, summary.Brokers)
+	assert.Equal(t, Section{Available: true, Count: 2}, summary.Topics)
+	assert.Equal(t, Section{Error: "not found"}, summary.ConsumerGroups)
+	assert.Equal(t, Section{Error: "forbidden"}, summary.Processors)
+
+	running := 1
+	assert.Equal(t, Section{Available: true, Count: 2, Running: &running}, summary.Connectors)
+
+	output, err = test.ExecuteCommand(NewStatusCommand())
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Brokers          3\n")
+	assert.Contains(t, output, "Consumer groups  unavailable: not found\n")
+	assert.Contains(t, output, "Connectors       2 (1 running)\n")
+}
+
+func TestStatusConnectorsFailures(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/config":
+			w.Write([]byte(`{"lenses.kafka.connect.clusters": [{"name": "dev"}, {"name": "prod"}]}`))
+		case "/api/proxy-connect/dev/connectors":
+			w.Write([]byte(`["orders-source", "payments-sink", "audit-sink"]`))
+		case "/api/proxy-connect/dev/connectors/orders-source/status":
+			w.Write([]byte(`{"name": "orders-source", "connector": {"state": "RUNNING"}}`))
+		case "/api/proxy-connect/dev/connectors/audit-sink/status":
+			w.Write([]byte(`{"name": "audit-sink", "connector": {"state": "RUNNING"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("Not found"))
+		}
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	// the failed status and connect cluster are reported, the rest of the connectors are still counted.
+	section := connectorsSection(config.Client)
+	running := 2
+	assert.Equal(t, Section{
+		Available: true,
+		Count:     3,
+		Running:   &running,
+		Unknown:   1,
+		Errors: []string{
+			"connect cluster [prod]: not found",
+			"connector [dev/payments-sink]: not found",
+		},
+	}, section)
+
+	output, err := test.ExecuteCommand(NewStatusCommand())
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Connectors       3 (2 running, 1 unknown)\n")
+	assert.Contains(t, output, "                 connector [dev/payments-sink]: not found\n")
+}
@@ -1,14 +1,21 @@
 package topic
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/kataras/golog"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +28,10 @@ type topicView struct {
 
 //NewTopicsGroupCommand creates `topics` command
 func NewTopicsGroupCommand() *cobra.Command {
-	var namesOnly, unwrap bool
+	var (
+		namesOnly, unwrap bool
+		listOpts          api.QueryFiltering
+	)
 
 	root := &cobra.Command{
 		Use:           "topics",
@@ -31,6 +41,10 @@ func NewTopicsGroupCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			client := config.Client
 
+			if flags := cmd.Flags(); flags.Changed("page") || flags.Changed("page-size") || flags.Changed("filter") {
+				return printTopicsPages(cmd, client, listOpts, flags.Changed("page"), namesOnly, unwrap)
+			}
+
 			if namesOnly {
 				topicNames, err := client.GetTopicsNames()
 				if err != nil {
@@ -46,7 +60,7 @@ func NewTopicsGroupCommand() *cobra.Command {
 				}
 
 				// return printJSON(cmd, outlineStringResults("name", topicNames))
-				return bite.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", topicNames))
+				return utils.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", topicNames))
 			}
 
 			topics, err := client.GetTopics()
@@ -64,7 +78,7 @@ func NewTopicsGroupCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, topics)
-			return bite.PrintObject(cmd, topicsView, func(t topicView) bool {
+			return utils.PrintObject(cmd, topicsView, func(t topicView) bool {
 				return !t.IsControlTopic // on JSON we print everything.
 			})
 		},
@@ -72,15 +86,337 @@ func NewTopicsGroupCommand() *cobra.Command {
 
 	root.Flags().BoolVar(&namesOnly, "names", false, "Print topic names only")
 	root.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap")
+	root.Flags().IntVar(&listOpts.Page, "page", 0, "Fetch only this page of topics, pages start from 1")
+	root.Flags().IntVar(&listOpts.PageSize, "page-size", api.DefaultPageSize, "The amount of topics to fetch per page, the topics are printed as each page arrives")
+	root.Flags().StringVar(&listOpts.Name, "filter", "", "Select only the topics whose name contains the filter")
 
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
+	utils.CanWatch(root)
 
 	root.AddCommand(NewGetAvailableTopicConfigKeysCommand())
 	root.AddCommand(NewTopicsMetadataSubgroupCommand())
+	root.AddCommand(NewTopicsPeekCommand())
+	root.AddCommand(NewTopicsProduceCommand())
 
 	return root
 }
 
+//NewTopicsPeekCommand creates `topics peek` command
+func NewTopicsPeekCommand() *cobra.Command {
+	var (
+		from, keyFormat, valueFormat string
+		opts                         api.PeekOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "peek",
+		Short: "Print a sample of the records of a topic as they arrive, without writing a query",
+		Example: `topics peek my-topic --max 20 --from latest
+topics peek my-topic --partition 0 --from 1500 --value-format json
+topics peek my-topic --from 2020-01-01T10:00:00Z`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("exactly one topic name is required, the correct form is: topics peek <name>")
+			}
+
+			if err := parsePeekFrom(from, &opts); err != nil {
+				return err
+			}
+
+			var err error
+			if opts.KeyDeserializer, err = api.ParseDeserializer(keyFormat); err != nil {
+				return err
+			}
+			if opts.ValueDeserializer, err = api.ParseDeserializer(valueFormat); err != nil {
+				return err
+			}
+
+			maxRecords, err := utils.GetMaxRecords(cmd)
+			if err != nil {
+				return err
+			}
+
+			var records int
+			structured := utils.IsStructured(cmd)
+			err = config.Client.PeekTopicContext(context.Background(), args[0], opts, func(record api.PeekRecord) error {
+				if structured {
+					if err := utils.PrintRecord(cmd, record); err != nil {
+						return err
+					}
+				} else {
+					fmt.Fprintf(cmd.OutOrStdout(), "partition: %d, offset: %d, key: %v, value: %v\n",
+						record.Partition, record.Offset, peekText(record.Key), peekText(record.Value))
+					if record.Note != "" {
+						fmt.Fprintf(cmd.OutOrStdout(), "  note: %s\n", record.Note)
+					}
+				}
+
+				// stop reading, the stream is closed on return.
+				records++
+				if utils.MaxRecordsReached(cmd, records, maxRecords) {
+					return utils.ErrMaxRecords
+				}
+				return nil
+			})
+
+			if err == utils.ErrMaxRecords {
+				return nil
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Max, "max", api.DefaultPeekMax, "The maximum amount of records to print, of each partition with --from latest")
+	cmd.Flags().StringVar(&from, "from", "earliest", "Where to start from: earliest, latest, an offset or a RFC3339 time")
+	cmd.Flags().IntSliceVar(&opts.Partitions, "partition", nil, "Read only from this partition, can be repeated, all partitions by default")
+	cmd.Flags().StringVar(&keyFormat, "key-format", "", "The key deserializer: string, json, avro or bytes, defaults to the topic's key type")
+	cmd.Flags().StringVar(&valueFormat, "value-format", "", "The value deserializer: string, json, avro or bytes, defaults to the topic's value type")
+
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// produceBatchSize is the maximum amount of the records read from the stdin that are produced with a single request.
+var produceBatchSize = 500
+
+//NewTopicsProduceCommand creates `topics produce` command
+func NewTopicsProduceCommand() *cobra.Command {
+	var (
+		key, value, valueFormat string
+		partition               int
+		headers                 map[string]string
+		opts                    api.ProduceOptions
+	)
+
+	cmd := &cobra.Command{
+		Use:   "produce",
+		Short: "Produce records to a topic, from the flags or from the stdin, one json record per line",
+		Example: `topics produce my-topic --key k1 --value '{"amount": 10}' [--partition 0] [--header source=cli]
+cat records.jsonl | topics produce my-topic --value-format avro [--value-subject my-topic-value]
+  each line is a json record: {"key": "k1", "value": {"amount": 10}, "headers": {"source": "cli"}, "partition": 0}`,
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("exactly one topic name is required, the correct form is: topics produce <name>")
+			}
+
+			var err error
+			if opts.ValueEncoding, err = api.ParseEncoding(valueFormat); err != nil {
+				return err
+			}
+
+			// the results of all the batches are printed together, as a single list.
+			var produced []api.ProduceResult
+			client := config.Client
+			produce := func(records []api.ProduceRecord, first int) error {
+				results, err := client.ProduceToTopicWithOptions(args[0], opts, records)
+				if err != nil {
+					return err
+				}
+
+				for i := range results {
+					results[i].Record += first
+				}
+
+				produced = append(produced, results...)
+				return nil
+			}
+
+			if cmd.Flags().Changed("value") {
+				record := api.ProduceRecord{Key: key, Value: flagValue(value), Headers: headers}
+				if cmd.Flags().Changed("partition") {
+					record.Partition = &partition
+				}
+
+				err = produce([]api.ProduceRecord{record}, 0)
+			} else {
+				err = readProduceRecords(cmd.InOrStdin(), produceBatchSize, produce)
+			}
+
+			// the records of the previous batches are produced even if a batch fails.
+			if len(produced) > 0 {
+				if printErr := utils.PrintObject(cmd, produced); printErr != nil && err == nil {
+					err = printErr
+				}
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&key, "key", "", "The key of the single record to produce")
+	cmd.Flags().StringVar(&value, "value", "", "The value of the single record to produce, records are read from the stdin when it's not set")
+	cmd.Flags().IntVar(&partition, "partition", 0, "The partition of the single record, selected by the default partitioner when it's not set")
+	cmd.Flags().StringToStringVar(&headers, "header", nil, "A header of the single record, key=value, can be repeated")
+	cmd.Flags().StringVar(&valueFormat, "value-format", "", "The value encoding: string, json or avro, defaults to the topic's value type")
+	cmd.Flags().StringVar(&opts.ValueSubject, "value-subject", "", "The schema registry subject of the avro encoding, defaults to <topic>-value")
+
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
+// flagValue returns the json of the `topics produce --value` flag, a text which is not json is sent as a string.
+func flagValue(value string) json.RawMessage {
+	if json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+
+	b, _ := json.Marshal(value)
+	return b
+}
+
+// readProduceRecords reads a json record per line and calls the "produce" for every "batchSize" records,
+// the "first" is the index of the batch's first record.
+func readProduceRecords(r io.Reader, batchSize int, produce func(records []api.ProduceRecord, first int) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+
+	var (
+		batch []api.ProduceRecord
+		first int
+		line  int
+	)
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		var record api.ProduceRecord
+		if err := json.Unmarshal([]byte(text), &record); err != nil {
+			return fmt.Errorf("invalid record at line [%d]: %v", line, err)
+		}
+
+		batch = append(batch, record)
+		if len(batch) == batchSize {
+			if err := produce(batch, first); err != nil {
+				return err
+			}
+
+			first += len(batch)
+			batch = nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(batch) == 0 {
+		if first == 0 {
+			return fmt.Errorf("no records to produce, pass the --value flag or json records through the stdin")
+		}
+		return nil
+	}
+
+	return produce(batch, first)
+}
+
+// parsePeekFrom sets the starting position of the "opts" based on the `topics peek --from` flag.
+func parsePeekFrom(from string, opts *api.PeekOptions) error {
+	switch strings.ToLower(from) {
+	case "", "earliest":
+		return nil
+	case "latest":
+		opts.FromLatest = true
+		return nil
+	}
+
+	if offset, err := strconv.ParseInt(from, 10, 64); err == nil {
+		if offset < 0 {
+			return fmt.Errorf("invalid --from offset [%d], offsets start from 0", offset)
+		}
+		opts.FromOffset = offset
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return fmt.Errorf("invalid --from [%s], expected earliest, latest, an offset or a RFC3339 time", from)
+	}
+
+	opts.FromTimestamp = t
+	return nil
+}
+
+func peekText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return string(b)
+}
+
+// printTopicsPages prints the topics page by page, as they arrive, instead of fetching all of them first.
+// With --sort-by all the pages are fetched and printed together, so the sort covers the whole list.
+func printTopicsPages(cmd *cobra.Command, client *api.Client, listOpts api.QueryFiltering, singlePage, namesOnly, unwrap bool) error {
+	printPage := func(topics []api.Topic) error {
+		if namesOnly {
+			names := make([]string, len(topics))
+			for i := range topics {
+				names[i] = topics[i].TopicName
+			}
+
+			if unwrap {
+				for _, name := range names {
+					fmt.Fprintln(cmd.OutOrStdout(), name)
+				}
+				return nil
+			}
+
+			return utils.PrintObject(cmd, bite.OutlineStringResults(cmd, "name", names))
+		}
+
+		topicsView := make([]topicView, len(topics))
+		for i, topic := range topics {
+			topicsView[i] = newTopicView(cmd, client, topic)
+		}
+
+		return utils.PrintObject(cmd, topicsView, func(t topicView) bool {
+			return !t.IsControlTopic
+		})
+	}
+
+	if singlePage {
+		page, err := client.GetTopicsPage(listOpts)
+		if err != nil {
+			return err
+		}
+
+		return printPage(page.Values)
+	}
+
+	if len(utils.GetSortBy(cmd)) > 0 {
+		var topics []api.Topic
+		err := client.WalkTopics(listOpts, func(page []api.Topic) error {
+			topics = append(topics, page...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		return printPage(topics)
+	}
+
+	return client.WalkTopics(listOpts, printPage)
+}
+
 //NewGetAvailableTopicConfigKeysCommand creates `topics keys` command
 func NewGetAvailableTopicConfigKeysCommand() *cobra.Command {
 	var unwrap bool
@@ -106,13 +442,13 @@ func NewGetAvailableTopicConfigKeysCommand() *cobra.Command {
 				return nil
 			}
 
-			return bite.PrintObject(cmd, bite.OutlineStringResults(cmd, "key", keys))
+			return utils.PrintObject(cmd, bite.OutlineStringResults(cmd, "key", keys))
 		},
 	}
 
 	cmd.Flags().BoolVar(&unwrap, "unwrap", false, "--unwrap Display the names separated by new lines, disables the Table or JSON view")
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -140,7 +476,7 @@ func NewTopicsMetadataSubgroupCommand() *cobra.Command {
 					return err
 				}
 
-				return bite.PrintObject(cmd, viewMeta)
+				return utils.PrintObject(cmd, viewMeta)
 			}
 
 			metas, err := client.GetTopicsMetadata()
@@ -161,13 +497,13 @@ func NewTopicsMetadataSubgroupCommand() *cobra.Command {
 				}
 			}
 
-			return bite.PrintObject(cmd, viewMetas)
+			return utils.PrintObject(cmd, viewMetas)
 		},
 	}
 
 	rootSub.Flags().StringVar(&topicName, "name", "", "Topic to return metadata for")
 
-	bite.CanPrintJSON(rootSub)
+	utils.CanPrintJSON(rootSub)
 
 	rootSub.AddCommand(NewTopicMetadataDeleteCommand())
 	rootSub.AddCommand(NewTopicMetadataCreateCommand())
@@ -265,12 +601,12 @@ func NewTopicGroupCommand() *cobra.Command {
 				return err
 			}
 
-			return bite.PrintObject(cmd, newTopicView(cmd, client, topic))
+			return utils.PrintObject(cmd, newTopicView(cmd, client, topic))
 		},
 	}
 
 	root.Flags().StringVar(&topicName, "name", "", "Topic name")
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
 
 	// subcommands
 	root.AddCommand(NewTopicCreateCommand())
@@ -0,0 +1,268 @@
+package topic
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
+	"github.com/landoop/lenses-go/pkg/websocket"
+	test "github.com/landoop/lenses-go/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupProduceClient(t *testing.T, requests *[]string) func() {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/kafka/topics/payments/messages", r.URL.Path)
+
+		b, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, string(b))
+
+		var payload struct {
+			Records []api.ProduceRecord `json:"records"`
+		}
+		assert.Nil(t, json.Unmarshal(b, &payload))
+
+		results := make([]api.ProduceResult, len(payload.Records))
+		for i := range results {
+			results[i].Offset = int64(len(*requests)*100 + i)
+		}
+		json.NewEncoder(w).Encode(results)
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	config.Client = client
+
+	return func() {
+		config.Client = nil
+		teardown()
+	}
+}
+
+func TestTopicsProduceSingleMessageFlags(t *testing.T) {
+	var requests []string
+	defer setupProduceClient(t, &requests)()
+
+	cmd := NewTopicsProduceCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "payments", "--key", "k1", "--value", "hello", "--partition", "0", "--header", "source=cli")
+	assert.Nil(t, err)
+
+	if assert.Len(t, requests, 1) {
+		assert.JSONEq(t, `{"records": [{"key": "k1", "value": "hello", "headers": {"source": "cli"}, "partition": 0}]}`, requests[0])
+	}
+
+	var results []api.ProduceResult
+	assert.Nil(t, json.Unmarshal([]byte(output), &results))
+	assert.Equal(t, []api.ProduceResult{{Record: 0, Offset: 100}}, results)
+}
+
+func TestTopicsProduceStdinBatches(t *testing.T) {
+	var requests []string
+	defer setupProduceClient(t, &requests)()
+
+	stdin := `{"key": "k1", "value": {"amount": 1}}
+
+{"key": "k2", "value": {"amount": 2}, "partition": 1}
+{"key": "k3", "value": "three"}
+`
+	var batches [][]api.ProduceRecord
+	var firsts []int
+	err := readProduceRecords(strings.NewReader(stdin), 2, func(records []api.ProduceRecord, first int) error {
+		batches = append(batches, records)
+		firsts = append(firsts, first)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 2}, firsts)
+	if assert.Len(t, batches, 2) {
+		assert.Len(t, batches[0], 2)
+		assert.Equal(t, 1, *batches[0][1].Partition)
+		assert.Equal(t, `"three"`, string(batches[1][0].Value))
+	}
+
+	// through the command, a single request for the three records.
+	cmd := NewTopicsProduceCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	cmd.SetIn(strings.NewReader(stdin))
+	_, err = test.ExecuteCommand(cmd, "payments", "--value-format", "json")
+	assert.Nil(t, err)
+	if assert.Len(t, requests, 1) {
+		assert.Contains(t, requests[0], `"valueType":"JSON"`)
+	}
+
+	// the results of the batches are printed as a single list.
+	defer func(size int) { produceBatchSize = size }(produceBatchSize)
+	produceBatchSize = 2
+	requests = nil
+	cmd = NewTopicsProduceCommand()
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	cmd.SetIn(strings.NewReader(stdin))
+	output, err := test.ExecuteCommand(cmd, "payments")
+	assert.Nil(t, err)
+	assert.Len(t, requests, 2)
+
+	var results []api.ProduceResult
+	assert.Nil(t, json.Unmarshal([]byte(output), &results))
+	assert.Equal(t, []api.ProduceResult{{Record: 0, Offset: 100}, {Record: 1, Offset: 101}, {Record: 2, Offset: 200}}, results)
+
+	assert.EqualError(t, readProduceRecords(strings.NewReader("{not json}\n"), 2, nil),
+		"invalid record at line [1]: invalid character 'n' looking for beginning of object key string")
+	assert.NotNil(t, readProduceRecords(strings.NewReader(""), 2, nil))
+}
+
+func TestTopicsNDJSON(t *testing.T) {
+	var pages []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/kafka/topics", r.URL.Path)
+
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+		if page == "1" {
+			w.Write([]byte(`{"pagesAmount": 2, "totalCount": 3, "values": [{"topicName": "a"}, {"topicName": "b"}]}`))
+			return
+		}
+		w.Write([]byte(`{"pagesAmount": 2, "totalCount": 3, "values": [{"topicName": "c"}]}`))
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	cmd := NewTopicsGroupCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd, "--page-size", "2", "--output", "ndjson")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, pages)
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if assert.Len(t, lines, 3) {
+		for i, name := range []string{"a", "b", "c"} {
+			var topic api.Topic
+			assert.Nil(t, json.Unmarshal([]byte(lines[i]), &topic), lines[i])
+			assert.Equal(t, name, topic.TopicName)
+		}
+	}
+}
+
+func TestTopicsPagesSortBy(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			w.Write([]byte(`{"pagesAmount": 2, "totalCount": 3, "values": [{"topicName": "b"}, {"topicName": "c"}]}`))
+			return
+		}
+		w.Write([]byte(`{"pagesAmount": 2, "totalCount": 3, "values": [{"topicName": "a"}]}`))
+	})
+
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	var err error
+	config.Client, err = api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	cmd := NewTopicsGroupCommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	utils.AddSortByFlag(cmd)
+	output, err := test.ExecuteCommand(cmd, "--page-size", "2", "--output", "ndjson", "--sort-by", "topicName")
+	assert.Nil(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+	if assert.Len(t, lines, 3) {
+		for i, name := range []string{"a", "b", "c"} {
+			var topic api.Topic
+			assert.Nil(t, json.Unmarshal([]byte(lines[i]), &topic), lines[i])
+			assert.Equal(t, name, topic.TopicName)
+		}
+	}
+}
+
+func TestTopicsPeekMaxRecords(t *testing.T) {
+	upgrader := gorilla.Upgrader{}
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/topics/payments" {
+			w.Write([]byte(`{"topicName": "payments", "keyType": "STRING", "valueType": "STRING"}`))
+			return
+		}
+
+		assert.Equal(t, "/api/ws/v2/sql/execute", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		var msg websocket.Message
+		conn.ReadJSON(&msg)
+		assert.Contains(t, msg.SQL, "LIMIT 20")
+
+		// more records than the --max-records, from the latest offsets of many partitions.
+		for i := 0; i < 10; i++ {
+			conn.WriteJSON(websocket.LiveResponse{
+				Type: websocket.RecordMessageResponse,
+				Data: websocket.Data{Value: json.RawMessage(fmt.Sprintf(`"v%d"`, i)), Metadata: websocket.MetaData{Offset: i}},
+			})
+		}
+		conn.WriteJSON(websocket.LiveResponse{Type: websocket.EndResponse})
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	var err error
+	config.Client, err = api.OpenConnection(api.ClientConfig{Host: server.URL, Token: "secret"})
+	assert.Nil(t, err)
+	defer func() { config.Client = nil }()
+
+	cmd := NewTopicsPeekCommand()
+	cmd.Flags().Int(utils.MaxRecordsFlag, utils.DefaultMaxRecords, "")
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "table", "")
+	output, err := test.ExecuteCommand(cmd, "payments", "--max-records", "2")
+	assert.Nil(t, err)
+
+	assert.Equal(t, "partition: 0, offset: 0, key: null, value: v0\n"+
+		"partition: 0, offset: 1, key: null, value: v1\n"+
+		"Stopped after 2 records, the --max-records limit, use --max-records 0 to read all of them\n", output)
+
+	// a line per record on ndjson.
+	cmd = NewTopicsPeekCommand()
+	cmd.Flags().Int(utils.MaxRecordsFlag, utils.DefaultMaxRecords, "")
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "ndjson", "")
+	output, err = test.ExecuteCommand(cmd, "payments", "--max-records", "2")
+	assert.Nil(t, err)
+
+	lines := strings.Split(output, "\n")
+	if assert.Len(t, lines, 4, output) {
+		for i, line := range lines[:2] {
+			var record api.PeekRecord
+			assert.Nil(t, json.Unmarshal([]byte(line), &record), line)
+			assert.Equal(t, int64(i), record.Offset)
+			assert.Equal(t, fmt.Sprintf("v%d", i), record.Value)
+		}
+		assert.Equal(t, "Stopped after 2 records, the --max-records limit, use --max-records 0 to read all of them", lines[2])
+	}
+
+	cmd = NewTopicsPeekCommand()
+	cmd.Flags().Int(utils.MaxRecordsFlag, utils.DefaultMaxRecords, "")
+	_, err = test.ExecuteCommand(cmd, "payments", "--max-records", "-1")
+	assert.EqualError(t, err, "invalid --max-records [-1], it should be a positive amount of records or 0 for unlimited")
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -20,7 +21,7 @@ func newTopicView(cmd *cobra.Command, client *api.Client, topic api.Topic) (t to
 	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
 
 	// don't spend time here if we are not in the machine-friendly mode, table mode does not show so much details and couldn't be, schemas are big.
-	if output != "JSON" && output != "YAML" {
+	if output != "JSON" && output != "YAML" && output != utils.NDJSON {
 		return
 	}
 
@@ -7,15 +7,15 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"reflect"
 	"runtime"
 	"strings"
 
-	"github.com/kataras/golog"
-
 	"github.com/kataras/survey"
 	"github.com/landoop/bite"
 	"github.com/landoop/lenses-go/pkg/api"
 	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -69,7 +69,10 @@ func NewConfigurationContextCommand() *cobra.Command {
 	bite.CanBeSilent(root)
 
 	root.AddCommand(NewUpdateConfigurationContextCommand())
+	root.AddCommand(NewEditConfigurationContextCommand())
+	root.AddCommand(NewCreateConfigurationContextCommand())
 	root.AddCommand(NewDeleteConfigurationContextCommand())
+	root.AddCommand(NewRenameConfigurationContextCommand())
 	root.AddCommand(NewUseContextCommand())
 
 	return root
@@ -117,13 +120,130 @@ func NewDeleteConfigurationContextCommand() *cobra.Command {
 	return cmd
 }
 
+//NewCreateConfigurationContextCommand creates `context create` command
+func NewCreateConfigurationContextCommand() *cobra.Command {
+	var (
+		clientConfig                                                            api.ClientConfig
+		user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache string
+		testConnection, use, overwrite                                          bool
+	)
+
+	cmd := &cobra.Command{
+		Use:           "create",
+		Aliases:       []string{"add"},
+		Short:         "Create a configuration context without prompts, based on the flags",
+		Example:       `context create context_name --host=https://lenses.io --user=admin --password=admin [--use] [--test-connection]`,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("one argument is required for the context name")
+			}
+
+			name := args[0]
+			c := config.Manager.Config
+
+			if c.ContextExists(name) && !overwrite {
+				return fmt.Errorf("context [%s] already exists, use the --overwrite flag to replace it", name)
+			}
+
+			if auth, ok := config.MakeAuthentication(user, pass, kerberosConf, kerberosRealm, kerberosKeytab, kerberosCCache); ok {
+				clientConfig.Authentication = auth
+			} else if kerberosConf != "" {
+				return fmt.Errorf("invalid context [%s]: kerberos authentication: --password, --kerberos-keytab or --kerberos-ccache is required", name)
+			}
+
+			if err := clientConfig.Validate(); err != nil {
+				return fmt.Errorf("invalid context [%s]: %v", name, err)
+			}
+			clientConfig.FormatHost()
+
+			if testConnection {
+				if _, err := api.OpenConnection(clientConfig); err != nil {
+					return fmt.Errorf("connection test of context [%s] failed: %v", name, err)
+				}
+			}
+
+			// the configuration manager adds an empty current context when no configuration exists,
+			// don't save it along with the new one.
+			if current, ok := c.Contexts[c.CurrentContext]; ok && !current.IsValid() {
+				delete(c.Contexts, c.CurrentContext)
+				use = true
+			}
+
+			c.AddContext(name, &clientConfig)
+			if use {
+				c.SetCurrent(name)
+			}
+
+			if err := config.Manager.Save(); err != nil {
+				return fmt.Errorf("error while saving the configuration after creating the [%s] context: [%v]", name, err)
+			}
+
+			if use {
+				return bite.PrintInfo(cmd, "[%s] context created, it is the current context now", name)
+			}
+
+			return bite.PrintInfo(cmd, "[%s] context created", name)
+		},
+	}
+
+	// note that these flags shadow the global ones, which are applied to the current context.
+	cmd.Flags().StringVar(&clientConfig.Host, "host", "", "Lenses host")
+	cmd.Flags().StringVar(&clientConfig.Token, "token", "", "Lenses auth token, instead of the user and password")
+	cmd.Flags().StringVar(&clientConfig.Timeout, "timeout", "", "Timeout for the connection establishment")
+	cmd.Flags().BoolVar(&clientConfig.Insecure, "insecure", false, "All insecure http requests")
+	cmd.Flags().StringVar(&clientConfig.DefaultOutput, "default-output", "", "The output format of the commands when this context is active, TABLE, JSON, YAML or NDJSON")
+	cmd.Flags().StringVar(&user, "user", "", "User")
+	cmd.Flags().StringVar(&pass, "password", "", "Password")
+	cmd.Flags().StringVar(&kerberosConf, "kerberos-conf", "", "krb5.conf, kerberos authentication instead of basic")
+	cmd.Flags().StringVar(&kerberosRealm, "kerberos-realm", "", "Kerberos realm")
+	cmd.Flags().StringVar(&kerberosKeytab, "kerberos-keytab", "", "KeyTab file")
+	cmd.Flags().StringVar(&kerberosCCache, "kerberos-ccache", "", "Kerberos ccache file")
+	cmd.Flags().BoolVar(&testConnection, "test-connection", false, "Connect to the host before saving the context")
+	cmd.Flags().BoolVar(&use, "use", false, "Set the created context as the current one")
+	cmd.Flags().BoolVar(&overwrite, "overwrite", false, "Replace the context if it already exists")
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
+//NewRenameConfigurationContextCommand creates `context rename` command
+func NewRenameConfigurationContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "rename",
+		Short:         "Rename a configuration context",
+		Example:       `context rename old_context_name new_context_name`,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("two arguments are required, the current and the new context name")
+			}
+
+			oldName, newName := args[0], args[1]
+			if err := config.Manager.Config.RenameContext(oldName, newName); err != nil {
+				return err
+			}
+
+			if err := config.Manager.Save(); err != nil {
+				return fmt.Errorf("error while saving the configuration after renaming the [%s] context: [%v]", oldName, err)
+			}
+
+			return bite.PrintInfo(cmd, "[%s] context renamed to [%s]", oldName, newName)
+		},
+	}
+
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
 //NewUpdateConfigurationContextCommand creates `context set` command
 func NewUpdateConfigurationContextCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:           "set",
-		Aliases:       []string{"edit", "update", "create", "add"},
-		Short:         "Edit an existing or add a configuration context e.g. lenses-cli context create my-new-context",
-		Example:       `context edit context_name`,
+		Aliases:       []string{"update"},
+		Short:         "Configure an existing or add a configuration context from scratch, see `context edit` to change some of its fields",
+		Example:       `context set context_name`,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) == 0 {
@@ -145,6 +265,10 @@ func NewUpdateConfigurationContextCommand() *cobra.Command {
 				return bite.PrintInfo(cmd, "[%s] was successfully validated and saved, it is the current context now", name)
 			}
 
+			if !utils.CanPrompt() {
+				return fmt.Errorf("[%s] is invalid, connection failed", name)
+			}
+
 			retry := true
 			if err := survey.AskOne(&survey.Confirm{
 				Message: fmt.Sprintf("[%s] is invalid, connection failed, do you mind to retry fixing it?", name),
@@ -166,6 +290,180 @@ func NewUpdateConfigurationContextCommand() *cobra.Command {
 	return cmd
 }
 
+// askContextQuestions asks the questions of the `context edit`, the tests script the answers through it.
+var askContextQuestions = survey.Ask
+
+// contextEditMessages are the prompts of the context fields by their `survey` tag, the fields without a prompt are not edited.
+var contextEditMessages = map[string]string{
+	"host":     "Host",
+	"timeout":  "Timeout of the connection establishment, i.e 30s",
+	"insecure": "Enable insecure https connections?",
+	"debug":    "Enable debug mode?",
+	"username": "Username",
+	"password": "Password, leave it empty to keep the current one",
+	"realm":    "Realm",
+	"keytab":   "Keytab file location",
+	"ccache":   "CCache file location",
+}
+
+//NewEditConfigurationContextCommand creates `context edit` command
+func NewEditConfigurationContextCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "edit",
+		Short:         "Edit the fields of a configuration context, the prompts are filled with their current values and the empty answers keep them",
+		Example:       `context edit context_name`,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("one argument is required for the context name")
+			}
+
+			name := args[0]
+			current, ok := config.Manager.Config.Contexts[name]
+			if !ok {
+				return fmt.Errorf("context [%s] not found", name)
+			}
+
+			if err := utils.RequirePrompt("the context fields", "use the 'context create --overwrite' command instead"); err != nil {
+				return err
+			}
+
+			edited, err := editContext(*current)
+			if err != nil {
+				return err
+			}
+
+			if err = edited.Validate(); err != nil {
+				return fmt.Errorf("invalid context [%s]: %v", name, err)
+			}
+
+			*current = edited
+			if err = config.Manager.Save(); err != nil {
+				return fmt.Errorf("error while saving the configuration after editing the [%s] context: [%v]", name, err)
+			}
+
+			return bite.PrintInfo(cmd, "[%s] context updated", name)
+		},
+	}
+
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
+// editContext asks for the fields of the "cfg" and its authentication, the answers are filled to it, see `ClientConfig.Fill`.
+func editContext(cfg api.ClientConfig) (api.ClientConfig, error) {
+	var answers api.ClientConfig
+	if err := askContextQuestions(editQuestions(cfg), &answers); err != nil {
+		return cfg, err
+	}
+
+	auth, err := editAuthentication(cfg.Authentication)
+	if err != nil {
+		return cfg, err
+	}
+	answers.Authentication = auth
+
+	cfg.Fill(answers)
+	// the Fill sets the booleans only when true, their confirmations default to the current values.
+	cfg.Debug, cfg.Insecure = answers.Debug, answers.Insecure
+
+	return cfg, nil
+}
+
+// editAuthentication asks for the fields of the current authentication method, the method itself can't be changed.
+func editAuthentication(auth api.Authentication) (api.Authentication, error) {
+	switch a := auth.(type) {
+	case api.BasicAuthentication:
+		err := editFields(&a)
+		return a, err
+	case api.KerberosAuthentication:
+		answers := make(map[string]interface{})
+		if err := askContextQuestions([]*survey.Question{{
+			Name:   "confFile",
+			Prompt: &survey.Input{Message: "krb5.conf file location", Default: a.ConfFile},
+		}}, &answers); err != nil {
+			return nil, err
+		}
+
+		if confFile, _ := answers["confFile"].(string); confFile != "" {
+			a.ConfFile = confFile
+		}
+
+		var err error
+		switch method := a.Method.(type) {
+		case api.KerberosWithPassword:
+			err = editFields(&method)
+			a.Method = method
+		case api.KerberosWithKeytab:
+			err = editFields(&method)
+			a.Method = method
+		case api.KerberosFromCCache:
+			err = editFields(&method)
+			a.Method = method
+		}
+
+		return a, err
+	}
+
+	return auth, nil
+}
+
+// editFields asks for the fields of the struct pointed by "ptr", the empty answers keep the current values.
+func editFields(ptr interface{}) error {
+	v := reflect.ValueOf(ptr).Elem()
+	answers := reflect.New(v.Type())
+	if err := askContextQuestions(editQuestions(v.Interface()), answers.Interface()); err != nil {
+		return err
+	}
+
+	for i := 0; i < v.NumField(); i++ {
+		if _, ok := contextEditMessages[v.Type().Field(i).Tag.Get("survey")]; !ok {
+			continue
+		}
+
+		answer := answers.Elem().Field(i)
+		if answer.Kind() == reflect.String && answer.String() == "" {
+			continue
+		}
+
+		v.Field(i).Set(answer)
+	}
+
+	return nil
+}
+
+// editQuestions returns the questions of the string and bool fields of the struct "v" which have a prompt,
+// see `contextEditMessages`, the prompts default to the current values, except the passwords.
+func editQuestions(v interface{}) []*survey.Question {
+	var qs []*survey.Question
+
+	val := reflect.ValueOf(v)
+	for i := 0; i < val.NumField(); i++ {
+		name := val.Type().Field(i).Tag.Get("survey")
+		message, ok := contextEditMessages[name]
+		if !ok {
+			continue
+		}
+
+		var prompt survey.Prompt
+		switch field := val.Field(i); {
+		case name == "password":
+			prompt = &survey.Password{Message: message}
+		case field.Kind() == reflect.String:
+			prompt = &survey.Input{Message: message, Default: field.String()}
+		case field.Kind() == reflect.Bool:
+			prompt = &survey.Confirm{Message: message, Default: field.Bool()}
+		default:
+			continue
+		}
+
+		qs = append(qs, &survey.Question{Name: name, Prompt: prompt})
+	}
+
+	return qs
+}
+
 //NewUseContextCommand creates `context use` command
 func NewUseContextCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -180,15 +478,16 @@ func NewUseContextCommand() *cobra.Command {
 
 			name := args[0]
 
-			if config.Manager.Config.ContextExists(name) {
-				config.Manager.Config.SetCurrent(name)
-				config.Manager.Save()
-				bite.PrintInfo(cmd, "Current context set to [%s]", name)
-				return nil
+			if !config.Manager.Config.ContextExists(name) {
+				return fmt.Errorf("context [%s] not found", name)
 			}
 
-			golog.Errorf("Context [%s] not found", name)
-			return nil
+			config.Manager.Config.SetCurrent(name)
+			if err := config.Manager.Save(); err != nil {
+				return fmt.Errorf("error while saving the configuration after switching to the [%s] context: [%v]", name, err)
+			}
+
+			return bite.PrintInfo(cmd, "Current context set to [%s]", name)
 		},
 	}
 
@@ -198,18 +497,34 @@ func NewUseContextCommand() *cobra.Command {
 //NewConfigureCommand creates `configure` command
 func NewConfigureCommand(name string) *cobra.Command {
 	var (
-		reset       bool
-		noBanner    bool // if true doesn't print the banner (useful for running inside other commands).
-		defLocation bool // if true doesn't asks for location to save (useful for running inside other commands).
+		reset          bool
+		noBanner       bool // if true doesn't print the banner (useful for running inside other commands).
+		defLocation    bool // if true doesn't asks for location to save (useful for running inside other commands).
+		nonInteractive bool // if true doesn't prompt at all, the values come from the flags and the environment.
 	)
 
 	cmd := &cobra.Command{
 		Use:           "configure",
 		Short:         "Setup your environment for extensive CLI use. Create and save the required CLI configuration and client credentials",
-		Example:       `configure`,
+		Example:       `configure or configure --non-interactive --host=https://lenses.io --user=admin --pass=admin`,
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			// the context is configured on purpose, save the --context and --host flags too.
+			config.Manager.KeepOverrides()
+
+			if nonInteractive {
+				if err := configureNonInteractive(name); err != nil {
+					return err
+				}
+
+				return config.Manager.Save()
+			}
+
 			if !config.Manager.Config.IsValid() || reset {
+				if err := utils.RequirePrompt("the configuration", ConfigureNonInteractiveHint); err != nil {
+					return err
+				}
+
 				// This is the only command and place the user has direct interaction with the CLI
 				// and it's not used by a third-party tool because of the survey.
 				// So, print our "banner" :)
@@ -454,6 +769,22 @@ Docs at https://docs.lenses.io
 
 					currentConfig.Authentication = basicAuth
 				}
+
+				// confirm the credentials before saving.
+				if _, err := api.TestConnection(*currentConfig); err != nil {
+					saveAnyway := false
+					if askErr := survey.AskOne(&survey.Confirm{
+						Message: fmt.Sprintf("Connection test failed: %v, save the configuration anyway?", err),
+						Default: false,
+					}, &saveAnyway, nil); askErr != nil {
+						return askErr
+					}
+
+					if !saveAnyway {
+						return err
+					}
+				}
+
 				//
 				// If all ok continue by saving the result to the desired system filepath.
 				//
@@ -475,7 +806,7 @@ Docs at https://docs.lenses.io
 
 			} else {
 				nFlags := cmd.Root().Flags().NFlag()
-				if nFlags == 0 || (nFlags == 1 && cmd.Root().Flag("context").Changed) || (nFlags <= 2 && cmd.Root().Flag("config").Changed) {
+				if nFlags == 0 || (nFlags == 1 && cmd.Root().Flag("context").Changed) || (nFlags <= 2 && (cmd.Root().Flag("config").Changed || cmd.Root().Flag("config-file").Changed)) {
 					// flags given like --user and --pass and --host, then we don't want to save anything,
 					// user may need to re-configure, give a note about the --reset flag.
 					return fmt.Errorf("configuration already exists, try 'configure --reset' instead")
@@ -488,11 +819,70 @@ Docs at https://docs.lenses.io
 	cmd.Flags().BoolVar(&reset, "reset", false, "reset the current configuration")
 	cmd.Flags().BoolVar(&noBanner, "no-banner", false, "disables the banner output")
 	cmd.Flags().BoolVar(&defLocation, "default-location", false, "will not ask for the location to save on, the result will be saved to the $HOME/.lenses/lenses-cli.yml")
+	cmd.Flags().BoolVar(&nonInteractive, "non-interactive", false, "will not prompt, the values are taken from the flags and the "+
+		strings.Join([]string{hostEnvKey, tokenEnvKey, userEnvKey, passwordEnvKey}, ", ")+" environment variables, i.e for CI")
 	return cmd
 }
 
+// ConfigureNonInteractiveHint tells the user how to configure the cli when there is no terminal to prompt, see `utils.RequirePrompt`.
+const ConfigureNonInteractiveHint = "please pass the --host and the --token or the --user and --pass flags, " +
+	"or use the 'configure --non-interactive' command"
+
+// The environment variables of the `configure --non-interactive`, the flags have priority.
+const (
+	hostEnvKey     = "LENSES_CLI_HOST"
+	tokenEnvKey    = "LENSES_CLI_TOKEN"
+	userEnvKey     = "LENSES_CLI_USER"
+	passwordEnvKey = "LENSES_CLI_PASSWORD"
+)
+
+// configureNonInteractive fills the "name" or the current context from the environment, where the flags were not given,
+// and fails with the missing values instead of prompting for them.
+func configureNonInteractive(name string) error {
+	if name != "" {
+		config.Manager.Config.SetCurrent(name)
+	}
+	currentConfig := config.Manager.Config.GetCurrent()
+
+	if currentConfig.Host == "" {
+		currentConfig.Host = os.Getenv(hostEnvKey)
+	}
+
+	if currentConfig.Token == "" {
+		currentConfig.Token = os.Getenv(tokenEnvKey)
+	}
+
+	if currentConfig.Authentication == nil {
+		if user, pass := os.Getenv(userEnvKey), os.Getenv(passwordEnvKey); user != "" || pass != "" {
+			currentConfig.Authentication = api.BasicAuthentication{Username: user, Password: pass}
+		}
+	}
+
+	var missing []string
+	if currentConfig.Host == "" {
+		missing = append(missing, fmt.Sprintf("the host: --host flag or %s", hostEnvKey))
+	}
+
+	if currentConfig.Token == "" && currentConfig.Authentication == nil {
+		missing = append(missing, fmt.Sprintf("the credentials: --token flag or %s, or the --user and --pass flags or %s and %s",
+			tokenEnvKey, userEnvKey, passwordEnvKey))
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("configure --non-interactive: missing %s", strings.Join(missing, "; "))
+	}
+
+	if err := currentConfig.Validate(); err != nil {
+		return fmt.Errorf("configure --non-interactive: invalid context [%s]: %v", config.Manager.Config.CurrentContext, err)
+	}
+
+	return nil
+}
+
 //NewLoginCommand create `login` command
 func NewLoginCommand(app *bite.Application) *cobra.Command {
+	var printToken bool
+
 	cmd := &cobra.Command{
 		Use:              "login",
 		Short:            "Login, generate the access token using the generated configuration via the 'configure' command. ",
@@ -501,6 +891,10 @@ func NewLoginCommand(app *bite.Application) *cobra.Command {
 		TraverseChildren: true,
 		Hidden:           true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if printToken {
+				return loginAndPrintToken(cmd)
+			}
+
 			client := config.Client
 
 			if _, err := api.OpenConnection(*config.Manager.Config.GetCurrent()); err != nil {
@@ -601,9 +995,98 @@ func NewLoginCommand(app *bite.Application) *cobra.Command {
 
 		}}
 
+	cmd.Flags().BoolVar(&printToken, "print-token", false, "Authenticate without any prompts, print just the generated token and cache it to the configuration file, if any")
+
 	return cmd
 }
 
+// loginAndPrintToken generates a new token based on the current configuration and flags,
+// prints it and, if the configuration came from a file, caches it there for the next commands.
+func loginAndPrintToken(cmd *cobra.Command) error {
+	currentConfig := config.Manager.Config.GetCurrent()
+	// always generate a new one.
+	currentConfig.Token = ""
+
+	client, err := api.OpenConnection(*currentConfig)
+	if err != nil {
+		return err
+	}
+
+	currentConfig.Token = client.Config.Token
+	currentConfig.TokenExpiry = client.Config.TokenExpiry
+	if config.Manager.CanSave() {
+		if err = config.Manager.Save(); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), currentConfig.Token)
+	return err
+}
+
+//NewLogoutCommand creates `logout` command
+func NewLogoutCommand() *cobra.Command {
+	var allContexts bool
+
+	cmd := &cobra.Command{
+		Use:              "logout",
+		Short:            "Revoke the token of the current context, or of all contexts, and remove it from the configuration file",
+		Example:          "logout [--all-contexts]",
+		SilenceErrors:    true,
+		TraverseChildren: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := config.Manager.Config
+
+			contexts := map[string]*api.ClientConfig{c.CurrentContext: c.GetCurrent()}
+			if allContexts {
+				contexts = c.Contexts
+			}
+
+			for name, clientConfig := range contexts {
+				if clientConfig.Token == "" {
+					continue
+				}
+
+				// revoke first, the token is removed locally even if the server does not support it.
+				if err := revokeToken(*clientConfig); err != nil {
+					fmt.Fprintf(cmd.OutOrStderr(), "Warning: unable to revoke the token of context [%s], it's only removed locally: [%v]\n", name, err)
+				}
+
+				clientConfig.Token = ""
+			}
+
+			if !config.Manager.CanSave() {
+				fmt.Fprintln(cmd.OutOrStderr(), "Warning: no configuration file to remove the tokens from")
+				return nil
+			}
+
+			if err := config.Manager.Save(); err != nil {
+				return err
+			}
+
+			bite.PrintInfo(cmd, "Logged out.")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&allContexts, "all-contexts", false, "Revoke and remove the tokens of all contexts, not only the current one")
+	bite.CanBeSilent(cmd)
+
+	return cmd
+}
+
+func revokeToken(clientConfig api.ClientConfig) error {
+	// token only, don't authenticate again.
+	clientConfig.Authentication = nil
+
+	client, err := api.OpenConnection(clientConfig)
+	if err != nil {
+		return err
+	}
+
+	return client.Logout()
+}
+
 //NewGetLicenseInfoCommand creates `license` command
 func NewGetLicenseInfoCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -618,11 +1101,11 @@ func NewGetLicenseInfoCommand() *cobra.Command {
 			}
 
 			// return printJSON(cmd, lc)
-			return bite.PrintObject(cmd, lc)
+			return utils.PrintObject(cmd, lc)
 		},
 	}
 
-	bite.CanPrintJSON(cmd)
+	utils.CanPrintJSON(cmd)
 
 	return cmd
 }
@@ -708,6 +1191,11 @@ func printConfigurationContext(cmd *cobra.Command, name string) bool {
 }
 
 func showOptionsForConfigurationContext(cmd *cobra.Command, name string) error {
+	// the context is already printed as invalid, nothing to ask without a terminal.
+	if !utils.CanPrompt() {
+		return nil
+	}
+
 	var action string
 
 	if err := survey.AskOne(&survey.Select{
@@ -1,11 +1,22 @@
 package user
 
 import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/kataras/survey"
+	"github.com/kataras/survey/core"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	"github.com/landoop/lenses-go/pkg/utils"
 	test "github.com/landoop/lenses-go/test"
 )
 
@@ -97,3 +108,379 @@ func TestContextCommands(t *testing.T) {
 
 	test.RunCommandTests(t, scenarios)
 }
+
+func TestLoginPrintToken(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/login":
+			body, _ := ioutil.ReadAll(r.Body)
+			if !strings.Contains(string(body), `"password": "secret"`) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte("generated-token"))
+		case "/api/auth":
+			w.Write([]byte(`{"token": "generated-token", "user": "admin"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	login := func(pass string) (string, error) {
+		flags := pflag.NewFlagSet("login", pflag.ContinueOnError)
+		config.Manager = config.NewConfigurationManager(flags)
+		defer test.ResetConfigManager()
+
+		err := flags.Parse([]string{"--host=" + server.URL, "--user=admin", "--pass=" + pass})
+		assert.Nil(t, err)
+
+		ok, err := config.Manager.Load()
+		assert.Nil(t, err)
+		assert.True(t, ok)
+		// auth by flags, nothing to cache to.
+		assert.False(t, config.Manager.CanSave())
+
+		return test.ExecuteCommand(NewLoginCommand(nil), "--print-token")
+	}
+
+	output, err := login("secret")
+	assert.Nil(t, err)
+	assert.Equal(t, "generated-token\n", output)
+
+	output, err = login("wrong")
+	assert.NotNil(t, err)
+	assert.NotContains(t, output, "generated-token")
+}
+
+func TestLogout(t *testing.T) {
+	var revoked []string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/logout" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		token := r.URL.Query().Get("token")
+		revoked = append(revoked, token)
+		if token == "unsupported-token" {
+			// revocation is not available.
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	auth := api.BasicAuthentication{Username: "user", Password: "pass"}
+	logout := func(args ...string) (string, *api.Config) {
+		dir, err := ioutil.TempDir("", "lenses-cli-logout")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dir)
+
+		configFile := filepath.Join(dir, "lenses-cli.yml")
+		b, err := api.ConfigMarshalYAML(api.Config{
+			CurrentContext: "master",
+			Contexts: map[string]*api.ClientConfig{
+				"master": {Host: server.URL, Token: "master-token", Authentication: auth},
+				"dev":    {Host: server.URL, Token: "unsupported-token", Authentication: auth},
+			},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, ioutil.WriteFile(configFile, b, 0600))
+
+		flags := pflag.NewFlagSet("logout", pflag.ContinueOnError)
+		config.Manager = config.NewConfigurationManager(flags)
+		defer test.ResetConfigManager()
+		assert.Nil(t, flags.Parse([]string{"--config=" + configFile}))
+
+		_, err = config.Manager.Load()
+		assert.Nil(t, err)
+
+		output, err := test.ExecuteCommand(NewLogoutCommand(), args...)
+		assert.Nil(t, err)
+
+		var saved api.Config
+		assert.Nil(t, api.TryReadConfigFromFile(configFile, &saved))
+		return output, &saved
+	}
+
+	output, saved := logout()
+	assert.Equal(t, []string{"master-token"}, revoked)
+	assert.Equal(t, "", saved.Contexts["master"].Token)
+	assert.Equal(t, "unsupported-token", saved.Contexts["dev"].Token)
+	assert.Contains(t, output, "Logged out.")
+
+	revoked = nil
+	output, saved = logout("--all-contexts")
+	assert.ElementsMatch(t, []string{"master-token", "unsupported-token"}, revoked)
+	assert.Equal(t, "", saved.Contexts["master"].Token)
+	assert.Equal(t, "", saved.Contexts["dev"].Token)
+	assert.Contains(t, output, "Warning: unable to revoke the token of context [dev]")
+}
+
+// loadTestConfigFile writes the "cfg" to a temporary configuration file and loads it to the `config.Manager`
+// through the --config flag, like the cli does.
+func loadTestConfigFile(t *testing.T, cfg api.Config) (string, func()) {
+	dir, err := ioutil.TempDir("", "lenses-cli-config")
+	assert.Nil(t, err)
+
+	configFile := filepath.Join(dir, "lenses-cli.yml")
+	b, err := api.ConfigMarshalYAML(cfg)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(configFile, b, 0600))
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	config.Manager = config.NewConfigurationManager(flags)
+	assert.Nil(t, flags.Parse([]string{"--config=" + configFile}))
+
+	_, err = config.Manager.Load()
+	assert.Nil(t, err)
+
+	return configFile, func() {
+		test.ResetConfigManager()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestContextCreate(t *testing.T) {
+	configFile, teardown := loadTestConfigFile(t, api.Config{
+		CurrentContext: "master",
+		Contexts: map[string]*api.ClientConfig{
+			"master": {Host: "http://domain.com", Authentication: api.BasicAuthentication{Username: "user", Password: "pass"}},
+		},
+	})
+	defer teardown()
+
+	readSaved := func() api.Config {
+		var saved api.Config
+		assert.Nil(t, api.TryReadConfigFromFile(configFile, &saved))
+		return saved
+	}
+
+	output, err := test.ExecuteCommand(NewCreateConfigurationContextCommand(), "dev", "--host=http://dev.domain.com:9991", "--user=dev", "--password=devpass")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "[dev] context created")
+
+	saved := readSaved()
+	assert.Equal(t, "master", saved.CurrentContext)
+	assert.Equal(t, "http://dev.domain.com:9991", saved.Contexts["dev"].Host)
+	assert.Equal(t, "http://domain.com:80", saved.Contexts["master"].Host)
+
+	// duplicate.
+	_, err = test.ExecuteCommand(NewCreateConfigurationContextCommand(), "dev", "--host=http://dev.domain.com", "--token=tok")
+	assert.EqualError(t, err, "context [dev] already exists, use the --overwrite flag to replace it")
+
+	// validation failures.
+	_, err = test.ExecuteCommand(NewCreateConfigurationContextCommand(), "other", "--host=http://other.domain.com")
+	assert.EqualError(t, err, "invalid context [other]: token or authentication is required")
+
+	_, err = test.ExecuteCommand(NewCreateConfigurationContextCommand(), "other", "--host=http://other.domain.com", "--token=tok", "--timeout=soon")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid context [other]: invalid timeout [soon]")
+	saved = readSaved()
+	assert.False(t, saved.ContextExists("other"))
+
+	// overwrite and use.
+	output, err = test.ExecuteCommand(NewCreateConfigurationContextCommand(), "dev", "--host=http://dev.domain.com", "--token=tok", "--overwrite", "--use")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "it is the current context now")
+
+	saved = readSaved()
+	assert.Equal(t, "dev", saved.CurrentContext)
+	assert.Equal(t, "tok", saved.Contexts["dev"].Token)
+	assert.Len(t, saved.Contexts, 2)
+}
+
+func TestConfigureNonInteractive(t *testing.T) {
+	configure := func(globalFlags []string, env map[string]string) (*api.Config, error) {
+		dir, err := ioutil.TempDir("", "lenses-cli-config")
+		assert.Nil(t, err)
+		defer os.RemoveAll(dir)
+
+		configFile := filepath.Join(dir, "lenses-cli.yml")
+		b, err := api.ConfigMarshalYAML(api.Config{
+			CurrentContext: "master",
+			Contexts:       map[string]*api.ClientConfig{"master": {Host: "http://domain.com", Token: "master-token"}},
+		})
+		assert.Nil(t, err)
+		assert.Nil(t, ioutil.WriteFile(configFile, b, 0600))
+
+		for k, v := range env {
+			os.Setenv(k, v)
+			defer os.Unsetenv(k)
+		}
+
+		flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		config.Manager = config.NewConfigurationManager(flags)
+		defer test.ResetConfigManager()
+		assert.Nil(t, flags.Parse(append([]string{"--config=" + configFile, "--context=ci"}, globalFlags...)))
+
+		// like the cli does, the configure command ignores the unknown context error.
+		config.Manager.Load()
+
+		if _, err = test.ExecuteCommand(NewConfigureCommand(""), "--non-interactive"); err != nil {
+			return nil, err
+		}
+
+		var saved api.Config
+		assert.Nil(t, api.TryReadConfigFromFile(configFile, &saved))
+		return &saved, nil
+	}
+
+	_, err := configure(nil, nil)
+	assert.EqualError(t, err, "configure --non-interactive: missing the host: --host flag or LENSES_CLI_HOST; "+
+		"the credentials: --token flag or LENSES_CLI_TOKEN, or the --user and --pass flags or LENSES_CLI_USER and LENSES_CLI_PASSWORD")
+
+	_, err = configure([]string{"--host=http://ci.domain.com"}, map[string]string{userEnvKey: "user"})
+	assert.EqualError(t, err, "configure --non-interactive: invalid context [ci]: basic authentication: username and password are both required")
+
+	saved, err := configure([]string{"--host=http://ci.domain.com", "--user=user", "--pass=pass", "--timeout=15s"}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "ci", saved.CurrentContext)
+	assert.Equal(t, "http://ci.domain.com:80", saved.Contexts["ci"].Host)
+	assert.Equal(t, "15s", saved.Contexts["ci"].Timeout)
+	assert.Equal(t, "user", saved.Contexts["ci"].Authentication.(api.BasicAuthentication).Username)
+	assert.Equal(t, "master-token", saved.Contexts["master"].Token)
+
+	saved, err = configure(nil, map[string]string{hostEnvKey: "http://env.domain.com", tokenEnvKey: "tok"})
+	assert.Nil(t, err)
+	assert.Equal(t, "http://env.domain.com:80", saved.Contexts["ci"].Host)
+	assert.Equal(t, "tok", saved.Contexts["ci"].Token)
+}
+
+func TestConfigureWithoutTerminal(t *testing.T) {
+	canPrompt := utils.CanPrompt
+	utils.CanPrompt = func() bool { return false }
+	defer func() { utils.CanPrompt = canPrompt }()
+
+	_, teardown := loadTestConfigFile(t, api.Config{
+		CurrentContext: "master",
+		Contexts: map[string]*api.ClientConfig{
+			"master": {Host: "http://domain.com", Token: "token"},
+		},
+	})
+	defer teardown()
+
+	output, err := test.ExecuteCommand(NewConfigureCommand(""), "--reset")
+	assert.EqualError(t, err, "unable to prompt for the configuration, no terminal is attached, "+ConfigureNonInteractiveHint)
+	assert.NotContains(t, output, "Docs at", "the banner of the prompts should not be printed")
+
+	// the invalid contexts are listed without asking what to do with them.
+	config.Manager.Config.AddContext("invalid", &api.ClientConfig{Host: "http://unreachable.invalid"})
+	output, err = test.ExecuteCommand(NewGetConfigurationContextsCommand())
+	assert.Nil(t, err)
+	assert.Contains(t, output, "[invalid] [invalid]")
+}
+
+// scriptedAnswers answers the questions by their name, like the user would type them,
+// the questions without an answer are answered with an empty input, so they get their default.
+func scriptedAnswers(answers map[string]interface{}) func([]*survey.Question, interface{}) error {
+	return func(qs []*survey.Question, response interface{}) error {
+		for _, q := range qs {
+			ans, ok := answers[q.Name]
+			if !ok {
+				switch prompt := q.Prompt.(type) {
+				case *survey.Input:
+					ans = prompt.Default
+				case *survey.Confirm:
+					ans = prompt.Default
+				default:
+					ans = ""
+				}
+			}
+
+			if err := core.WriteAnswer(response, q.Name, ans); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func TestContextEdit(t *testing.T) {
+	canPrompt := utils.CanPrompt
+	utils.CanPrompt = func() bool { return true }
+	ask := askContextQuestions
+	defer func() { utils.CanPrompt, askContextQuestions = canPrompt, ask }()
+
+	master := api.ClientConfig{
+		Host:           "http://domain.com:80",
+		Timeout:        "15s",
+		Debug:          true,
+		Authentication: api.BasicAuthentication{Username: "user", Password: "pass"},
+	}
+	dev := api.ClientConfig{
+		Host: "http://dev.domain.com:80",
+		Authentication: api.KerberosAuthentication{
+			ConfFile: "/etc/krb5.conf",
+			Method:   api.KerberosWithKeytab{Username: "svc-lenses", Realm: "EXAMPLE.COM", KeytabFile: "/tmp/svc.keytab"},
+		},
+	}
+
+	// the passwords are saved encrypted.
+	encrypted := master
+	assert.Nil(t, config.EncryptPassword(&encrypted))
+	configFile, teardown := loadTestConfigFile(t, api.Config{
+		CurrentContext: "master",
+		Contexts:       map[string]*api.ClientConfig{"master": &encrypted, "dev": &dev},
+	})
+	defer teardown()
+
+	readSaved := func() api.Config {
+		var saved api.Config
+		assert.Nil(t, api.TryReadConfigFromFile(configFile, &saved))
+		for _, cfg := range saved.Contexts {
+			config.DecryptPassword(cfg)
+		}
+		return saved
+	}
+
+	// only the timeout is changed, the password is left empty.
+	askContextQuestions = scriptedAnswers(map[string]interface{}{"timeout": "30s"})
+	output, err := test.ExecuteCommand(NewConfigurationContextCommand(), "edit", "master")
+	assert.Nil(t, err)
+	assert.Contains(t, output, "[master] context updated")
+
+	saved := readSaved()
+	expected := master
+	expected.Timeout = "30s"
+	assert.Equal(t, &expected, saved.Contexts["master"])
+	assert.Equal(t, &dev, saved.Contexts["dev"])
+
+	// the booleans can be disabled too.
+	askContextQuestions = scriptedAnswers(map[string]interface{}{"debug": false, "password": "newpass"})
+	_, err = test.ExecuteCommand(NewConfigurationContextCommand(), "edit", "master")
+	assert.Nil(t, err)
+
+	saved = readSaved()
+	expected.Debug = false
+	expected.Authentication = api.BasicAuthentication{Username: "user", Password: "newpass"}
+	assert.Equal(t, &expected, saved.Contexts["master"])
+
+	// the fields of the kerberos method.
+	askContextQuestions = scriptedAnswers(map[string]interface{}{"keytab": "/etc/security/svc.keytab"})
+	_, err = test.ExecuteCommand(NewConfigurationContextCommand(), "edit", "dev")
+	assert.Nil(t, err)
+
+	saved = readSaved()
+	expectedDev := dev
+	expectedDev.Authentication = api.KerberosAuthentication{
+		ConfFile: "/etc/krb5.conf",
+		Method:   api.KerberosWithKeytab{Username: "svc-lenses", Realm: "EXAMPLE.COM", KeytabFile: "/etc/security/svc.keytab"},
+	}
+	assert.Equal(t, &expectedDev, saved.Contexts["dev"])
+	assert.Equal(t, "master", saved.CurrentContext)
+
+	// invalid answers are not saved.
+	askContextQuestions = scriptedAnswers(map[string]interface{}{"timeout": "soon"})
+	_, err = test.ExecuteCommand(NewConfigurationContextCommand(), "edit", "master")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid context [master]: invalid timeout [soon]")
+	assert.Equal(t, "30s", readSaved().Contexts["master"].Timeout)
+
+	_, err = test.ExecuteCommand(NewConfigurationContextCommand(), "edit", "missing")
+	assert.EqualError(t, err, "context [missing] not found")
+}
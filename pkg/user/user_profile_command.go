@@ -21,19 +21,74 @@ func NewUserGroupCommand() *cobra.Command {
 			if user := config.Client.User; user.Name != "" {
 				// if logged in using the user password, then we have those info,
 				// let's print it as well.
-				return bite.PrintObject(cmd, user)
+				return utils.PrintObject(cmd, user)
 			}
 			return nil
 		},
 	}
 
-	bite.CanPrintJSON(root)
+	utils.CanPrintJSON(root)
 
 	root.AddCommand(NewUserProfileGroupCommand())
 
 	return root
 }
 
+// whoAmI describes the output of the `whoami` command.
+type whoAmI struct {
+	Context        string   `json:"context" yaml:"context" header:"Context"`
+	Host           string   `json:"host" yaml:"host" header:"Host"`
+	Authentication string   `json:"authentication" yaml:"authentication" header:"Authentication"`
+	User           string   `json:"user" yaml:"user" header:"User"`
+	Permissions    []string `json:"permissions" yaml:"permissions" header:"Permissions"`
+}
+
+//NewWhoAmICommand creates `whoami` command
+func NewWhoAmICommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "whoami",
+		Short:            "Print the authenticated user, its permissions and the active context",
+		Example:          "whoami",
+		TraverseChildren: true,
+		SilenceErrors:    true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientConfig := config.Client.Config
+
+			info := whoAmI{
+				Context: config.Manager.Config.CurrentContext,
+				Host:    clientConfig.Host,
+			}
+
+			if _, ok := clientConfig.IsBasicAuth(); ok {
+				info.Authentication = "basic"
+			} else if _, ok := clientConfig.IsKerberosAuth(); ok {
+				info.Authentication = "kerberos"
+			} else {
+				info.Authentication = "token"
+			}
+
+			user, err := config.Client.GetCurrentUser()
+			if err != nil {
+				return err
+			}
+
+			info.User = user.Name
+			info.Permissions = user.Permissions
+
+			if info.User == "" && info.Authentication == "token" {
+				bite.PrintInfo(cmd, "Only a token is configured, the server returned no user information for it.")
+			}
+
+			return utils.PrintObject(cmd, info)
+		},
+	}
+
+	bite.CanBeSilent(cmd)
+	utils.CanPrintJSON(cmd)
+
+	return cmd
+}
+
 //NewUserProfileGroupCommand creates `users profile` command
 func NewUserProfileGroupCommand() *cobra.Command {
 	rootSub := &cobra.Command{
@@ -53,12 +108,12 @@ func NewUserProfileGroupCommand() *cobra.Command {
 				return bite.PrintInfo(cmd, "No user profile available.")
 			}
 
-			return bite.PrintObject(cmd, profile)
+			return utils.PrintObject(cmd, profile)
 		},
 	}
 
 	bite.CanBeSilent(rootSub)
-	bite.CanPrintJSON(rootSub)
+	utils.CanPrintJSON(rootSub)
 
 	rootSub.AddCommand(NewCreateUserProfilePropertyValueCommand())
 	rootSub.AddCommand(NewDeleteUserProfilePropertyValueCommand())
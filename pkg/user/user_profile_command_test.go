@@ -0,0 +1,56 @@
+package user
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	config "github.com/landoop/lenses-go/pkg/configs"
+	test "github.com/landoop/lenses-go/test"
+)
+
+const currentUserResponse = `
+{
+	"token": "secret",
+	"user": "admin",
+	"schemaRegistryDelete": true,
+	"permissions": ["AlterConfig", "ViewKafkaConsumers", "ManageKafkaConsumers"]
+}
+`
+
+func TestWhoAmICommand(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/auth", r.URL.Path)
+		assert.Equal(t, "secret", r.Header.Get("X-Kafka-Lenses-Token"))
+		w.Write([]byte(currentUserResponse))
+	})
+	httpClient, teardown := test.TestingHTTPClient(h)
+	defer teardown()
+
+	client, err := api.OpenConnection(test.ClientConfig, api.UsingClient(httpClient))
+	assert.Nil(t, err)
+
+	test.SetupMasterContext()
+	defer test.ResetConfigManager()
+	config.Client = client
+	defer func() { config.Client = nil }()
+
+	cmd := NewWhoAmICommand()
+	var outputValue string
+	cmd.PersistentFlags().StringVar(&outputValue, "output", "json", "")
+	output, err := test.ExecuteCommand(cmd)
+	assert.Nil(t, err)
+
+	var info whoAmI
+	assert.Nil(t, json.Unmarshal([]byte(output), &info))
+	assert.Equal(t, whoAmI{
+		Context:        "master",
+		Host:           "http://domain.com:80",
+		Authentication: "basic",
+		User:           "admin",
+		Permissions:    []string{"AlterConfig", "ViewKafkaConsumers", "ManageKafkaConsumers"},
+	}, info)
+}
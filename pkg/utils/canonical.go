@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"reflect"
+)
+
+// canonicalKeys are the fields which identify the elements of a list, by priority, see `Canonicalize`.
+var canonicalKeys = []string{"name", "key"}
+
+// Canonicalize sorts, in place, the lists of the "v" whose elements are identified by a "name" or a "key" field,
+// i.e the configuration of a connection, so the same resource is always written the same way.
+// The order of the other lists is kept, the keys of the maps are already sorted by the JSON and the YAML encoders.
+func Canonicalize(v interface{}) error {
+	return canonicalize(reflect.ValueOf(v))
+}
+
+func canonicalize(v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return canonicalize(v.Elem())
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			if f := typ.Field(i); f.PkgPath != "" && !f.Anonymous {
+				continue // unexported.
+			}
+
+			if err := canonicalize(v.Field(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if err := canonicalize(v.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := canonicalize(v.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		if v.Kind() == reflect.Slice && v.CanInterface() {
+			if key := canonicalKey(v); key != "" {
+				return SortSlice(v.Interface(), []string{key})
+			}
+		}
+	}
+
+	return nil
+}
+
+// canonicalKey returns the first of the `canonicalKeys` which is a text field of all the elements of the "list", if any.
+func canonicalKey(list reflect.Value) string {
+	if list.Len() == 0 {
+		return ""
+	}
+
+	for _, key := range canonicalKeys {
+		found := true
+		for i := 0; i < list.Len() && found; i++ {
+			field, ok := lookupField(indirect(list.Index(i)), key)
+			found = ok && field.Kind() == reflect.String
+		}
+
+		if found {
+			return key
+		}
+	}
+
+	return ""
+}
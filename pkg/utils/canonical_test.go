@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalize(t *testing.T) {
+	type property struct {
+		Key   string      `json:"key"`
+		Value interface{} `json:"value"`
+	}
+
+	type resource struct {
+		Name       string                 `json:"name"`
+		Properties []property             `json:"properties"`
+		Tags       []string               `json:"tags"`
+		Extra      map[string]interface{} `json:"extra"`
+	}
+
+	r := resource{
+		Name:       "r",
+		Properties: []property{{"b", 1}, {"a", 2}},
+		Tags:       []string{"z", "y"},
+		Extra: map[string]interface{}{
+			"nested": []interface{}{map[string]interface{}{"name": "y"}, map[string]interface{}{"name": "x"}},
+		},
+	}
+
+	assert.Nil(t, Canonicalize(r))
+	assert.Equal(t, []property{{"a", 2}, {"b", 1}}, r.Properties)
+	assert.Equal(t, []string{"z", "y"}, r.Tags, "the lists without a name or a key keep their order")
+	assert.Equal(t, "x", r.Extra["nested"].([]interface{})[0].(map[string]interface{})["name"])
+
+	list := []resource{{Name: "b"}, {Name: "a"}}
+	assert.Nil(t, Canonicalize(list))
+	assert.Equal(t, "a", list[0].Name)
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/kataras/golog"
+	"github.com/landoop/bite"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// NoColorFlag is the persistent flag which disables the colors of the output, see `SetupColor`.
+	NoColorFlag = "no-color"
+	// noColorEnvKey is the https://no-color.org environment variable, any non-empty value disables the colors.
+	noColorEnvKey = "NO_COLOR"
+)
+
+// CanColor reports whether the standard output supports colors, it's false when the output is piped or redirected, i.e on CI.
+// It's a variable so the tests can force the terminal path.
+var CanColor = func() bool {
+	return IsTerminal(os.Stdout)
+}
+
+// AddNoColorFlag adds the --no-color persistent flag to the root command, see `SetupColor`.
+func AddNoColorFlag(root *cobra.Command) {
+	root.PersistentFlags().Bool(NoColorFlag, false, "Disable the colors of the logs and the tables, "+
+		"they are disabled as well when the "+noColorEnvKey+" environment variable is set or the output is not a terminal")
+}
+
+// ColorEnabled reports whether the output of the "cmd" can be colored,
+// the --no-color flag, the NO_COLOR environment variable and a non-terminal output disable the colors.
+func ColorEnabled(cmd *cobra.Command) bool {
+	if noColor, _ := cmd.Flags().GetBool(NoColorFlag); noColor {
+		return false
+	}
+
+	if os.Getenv(noColorEnvKey) != "" {
+		return false
+	}
+
+	return CanColor()
+}
+
+// SetupColor disables the colors of the logs and of the table headers if the `ColorEnabled` is false.
+// The JSON and YAML outputs and the JSON logs are never colored.
+func SetupColor(cmd *cobra.Command) {
+	if ColorEnabled(cmd) {
+		return
+	}
+
+	// the level prefixes are colored only on terminals.
+	golog.Default.Printer.IsTerminal = false
+	logLines.Printer.IsTerminal = false
+
+	if app := bite.Get(cmd); app != nil {
+		app.TableHeaderFgColor, app.TableHeaderBgColor = "", ""
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/kataras/golog"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoColor(t *testing.T) {
+	var out bytes.Buffer
+	golog.SetOutput(&out)
+	defer golog.SetOutput(os.Stdout)
+
+	canColor := CanColor
+	CanColor = func() bool { return true }
+	defer func() { CanColor = canColor }()
+
+	run := func(args ...string) string {
+		out.Reset()
+		// as if the output was a terminal.
+		golog.Default.Printer.IsTerminal = true
+
+		root := &cobra.Command{
+			Use: "lenses-cli",
+			PersistentPreRun: func(cmd *cobra.Command, args []string) {
+				SetupColor(cmd)
+			},
+			Run: func(cmd *cobra.Command, args []string) {
+				golog.Warnf("processor [%s] already exists", "p1")
+			},
+		}
+		AddNoColorFlag(root)
+		root.SetArgs(args)
+		assert.Nil(t, root.Execute())
+		return out.String()
+	}
+
+	assert.Contains(t, run(), "\x1b[")
+
+	output := run("--no-color")
+	assert.Contains(t, output, "[WARN]")
+	assert.Contains(t, output, "processor [p1] already exists")
+	assert.NotContains(t, output, "\x1b[")
+
+	os.Setenv(noColorEnvKey, "1")
+	defer os.Unsetenv(noColorEnvKey)
+	assert.NotContains(t, run(), "\x1b[")
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/landoop/tableprinter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ColumnsFlag is the persistent flag which selects and orders the columns of the table output, see `PrintObject`.
+const ColumnsFlag = "columns"
+
+// AddColumnsFlag adds the --columns persistent flag to the root command.
+func AddColumnsFlag(root *cobra.Command) {
+	root.PersistentFlags().StringSlice(ColumnsFlag, nil, "Print only these columns of the table output, in this order, i.e --columns name,owner,groups")
+}
+
+// getColumns returns the --columns of the "cmd", if any.
+func getColumns(cmd *cobra.Command) []string {
+	if flag := cmd.Flag(ColumnsFlag); flag != nil {
+		if value, ok := flag.Value.(pflag.SliceValue); ok {
+			return value.GetSlice()
+		}
+	}
+
+	return nil
+}
+
+// printColumns prints the "v" as a table of the selected "columns" only, in their order.
+// The columns are matched, case-insensitively, against the `header` struct tags of the "v",
+// an unknown column is an error which lists the available ones.
+func printColumns(cmd *cobra.Command, v interface{}, columns []string, tableOnlyFilters ...interface{}) error {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if !value.IsValid() {
+		return nil
+	}
+
+	parser := tableprinter.WhichParser(value.Type())
+	if parser == nil {
+		return fmt.Errorf("the --%s are not supported by this command", ColumnsFlag)
+	}
+
+	headers, rows, nums := parser.Parse(value, tableprinter.MakeFilters(value, tableOnlyFilters...))
+
+	headers, rows, nums, err := selectColumns(headers, rows, nums, columns)
+	if err != nil {
+		return err
+	}
+
+	tableprinter.New(cmd.OutOrStdout()).Render(headers, rows, nums, true)
+	return nil
+}
+
+// selectColumns keeps the "columns" of the "headers" and of their "rows", in the order of the "columns",
+// the "nums" are the positions of the number columns, they are right-aligned.
+func selectColumns(headers []string, rows [][]string, nums []int, columns []string) ([]string, [][]string, []int, error) {
+	positions := make([]int, len(columns))
+	for i, column := range columns {
+		positions[i] = -1
+		for j, header := range headers {
+			if strings.EqualFold(strings.TrimSpace(column), header) {
+				positions[i] = j
+				break
+			}
+		}
+
+		if positions[i] == -1 {
+			return nil, nil, nil, fmt.Errorf("unknown column [%s], available columns: %s", column, strings.Join(headers, ", "))
+		}
+	}
+
+	isNumber := make(map[int]bool, len(nums))
+	for _, pos := range nums {
+		isNumber[pos] = true
+	}
+
+	selectedHeaders := make([]string, len(positions))
+	var selectedNums []int
+	for i, pos := range positions {
+		selectedHeaders[i] = headers[pos]
+		if isNumber[pos] {
+			selectedNums = append(selectedNums, i)
+		}
+	}
+
+	selectedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		selectedRows[i] = make([]string, len(positions))
+		for j, pos := range positions {
+			if pos < len(row) {
+				selectedRows[i][j] = row[pos]
+			}
+		}
+	}
+
+	return selectedHeaders, selectedRows, selectedNums, nil
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type columnsRow struct {
+	Name   string   `json:"name" header:"Name"`
+	Owner  string   `json:"owner" header:"Owner"`
+	Groups []string `json:"groups" header:"Groups"`
+	Tokens int      `json:"tokens" header:"Tokens"`
+}
+
+func printTestColumns(columns ...string) (string, error) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output", "table", "")
+	AddColumnsFlag(cmd)
+	cmd.PersistentFlags().Set(ColumnsFlag, strings.Join(columns, ","))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	err := PrintObject(cmd, []columnsRow{{Name: "ingestion", Owner: "admin", Groups: []string{"dev"}, Tokens: 2}})
+	return out.String(), err
+}
+
+func TestSelectColumns(t *testing.T) {
+	headers := []string{"Name", "Owner", "Groups", "Tokens"}
+	rows := [][]string{{"ingestion", "admin", "dev", "2"}}
+
+	selected, selectedRows, nums, err := selectColumns(headers, rows, []int{3}, []string{"tokens", "NAME"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Tokens", "Name"}, selected)
+	assert.Equal(t, [][]string{{"2", "ingestion"}}, selectedRows)
+	assert.Equal(t, []int{0}, nums)
+
+	_, _, _, err = selectColumns(headers, rows, nil, []string{"name", "email"})
+	assert.EqualError(t, err, "unknown column [email], available columns: Name, Owner, Groups, Tokens")
+}
+
+func TestPrintObjectColumns(t *testing.T) {
+	out, err := printTestColumns("owner", "name")
+	assert.Nil(t, err)
+	assert.True(t, strings.Index(out, "OWNER") < strings.Index(out, "NAME"), out)
+	assert.Contains(t, out, "ingestion")
+	assert.NotContains(t, out, "GROUPS")
+	assert.NotContains(t, out, "TOKENS")
+
+	_, err = printTestColumns("email")
+	assert.EqualError(t, err, "unknown column [email], available columns: Name, Owner, Groups, Tokens")
+}
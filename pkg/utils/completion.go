@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// CompletionTimeout is the maximum time a dynamic completion waits for the API to respond,
+// after that no suggestions are returned so the shell never hangs on a tab press.
+var CompletionTimeout = 2 * time.Second
+
+// CompletionCacheTTL is the time the fetched names are kept on disk,
+// every tab press spawns a new process so the cache can not live in memory.
+var CompletionCacheTTL = 10 * time.Second
+
+// CompletionCacheDir is the directory that the completion names are cached to.
+var CompletionCacheDir = filepath.Join(api.DefaultConfigurationHomeDir, "cache", "completion")
+
+// CompletionFunc is the cobra signature of a dynamic args or flag completion function.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+type completionCacheEntry struct {
+	Created time.Time `json:"created"`
+	Names   []string  `json:"names"`
+}
+
+func completionCacheFile(key string) string {
+	h := sha256.Sum256([]byte(key))
+	return filepath.Join(CompletionCacheDir, hex.EncodeToString(h[:8])+".json")
+}
+
+func readCompletionCache(key string) ([]string, bool) {
+	b, err := ioutil.ReadFile(completionCacheFile(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry completionCacheEntry
+	if err = json.Unmarshal(b, &entry); err != nil {
+		return nil, false
+	}
+
+	if time.Since(entry.Created) > CompletionCacheTTL {
+		return nil, false
+	}
+
+	return entry.Names, true
+}
+
+func writeCompletionCache(key string, names []string) {
+	b, err := json.Marshal(completionCacheEntry{Created: time.Now(), Names: names})
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(CompletionCacheDir, 0750); err != nil {
+		return
+	}
+
+	// best effort, a failed write just means that the next tab press will hit the API again.
+	ioutil.WriteFile(completionCacheFile(key), b, 0600)
+}
+
+// FetchCompletionNames returns the names of the `list` func, served from the on-disk cache
+// if the same `key` was fetched less than `CompletionCacheTTL` ago.
+// It gives up after `CompletionTimeout`.
+func FetchCompletionNames(key string, list func() ([]string, error)) ([]string, error) {
+	if names, ok := readCompletionCache(key); ok {
+		return names, nil
+	}
+
+	type result struct {
+		names []string
+		err   error
+	}
+
+	ch := make(chan result, 1)
+	go func() {
+		names, err := list()
+		ch <- result{names, err}
+	}()
+
+	select {
+	case r := <-ch:
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		writeCompletionCache(key, r.names)
+		return r.names, nil
+	case <-time.After(CompletionTimeout):
+		return nil, fmt.Errorf("completion timed out after %s", CompletionTimeout)
+	}
+}
+
+// CompleteNames returns a `CompletionFunc` which suggests the names returned by the `list` func
+// that start with the text being completed. Errors and timeouts result to no suggestions.
+//
+// The `key` should identify the resource and the environment, i.e "connections@context/user@http://host:80".
+func CompleteNames(key string, list func() ([]string, error)) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		names, err := FetchCompletionNames(key, list)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		var suggestions []string
+		for _, name := range names {
+			if strings.HasPrefix(name, toComplete) {
+				suggestions = append(suggestions, name)
+			}
+		}
+
+		return suggestions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/landoop/bite"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// IndentFlag is the amount of spaces to indent the JSON output with, see `CanPrintJSON`.
+	IndentFlag = "indent"
+	// CompactFlag prints the JSON output in a single line, see `CanPrintJSON`.
+	CompactFlag = "compact"
+)
+
+// CanPrintJSON is like the `bite.CanPrintJSON` but it adds the --indent and the --compact flags too,
+// they are respected by the `PrintObject` and the `PrintJSON`.
+func CanPrintJSON(cmd *cobra.Command) {
+	bite.CanPrintJSON(cmd)
+	cmd.Flags().Int(IndentFlag, 0, "Indent the JSON output by this amount of spaces, it overrides the --pretty")
+	cmd.Flags().Bool(CompactFlag, false, "Print the JSON output in a single line, it overrides the --pretty")
+}
+
+// jsonIndent returns the indent of the JSON output of the "cmd" and true if the --indent or the --compact is set,
+// an empty indent means a single line. Otherwise the JSON output is left to the --pretty.
+func jsonIndent(cmd *cobra.Command) (string, bool, error) {
+	indent, _ := cmd.Flags().GetInt(IndentFlag)
+	compact, _ := cmd.Flags().GetBool(CompactFlag)
+
+	switch {
+	case indent < 0:
+		return "", false, fmt.Errorf("invalid --%s [%d], it should be a positive amount of spaces", IndentFlag, indent)
+	case compact && indent > 0:
+		return "", false, fmt.Errorf("the --%s and the --%s can't be used together", CompactFlag, IndentFlag)
+	case compact:
+		return "", true, nil
+	case indent > 0:
+		return strings.Repeat(" ", indent), true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// PrintJSON is like the `bite.PrintJSON` but it respects the --indent and the --compact flags of the `CanPrintJSON`,
+// the --query is applied to the "v" first, see `ApplyQuery`.
+func PrintJSON(cmd *cobra.Command, v interface{}) error {
+	result, err := ApplyQuery(cmd, v)
+	if err != nil {
+		return err
+	}
+
+	return printJSON(cmd, result)
+}
+
+// printJSON is the `PrintJSON` without the --query.
+func printJSON(cmd *cobra.Command, v interface{}) error {
+	indent, ok, err := jsonIndent(cmd)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return bite.WriteJSON(cmd.OutOrStdout(), v, bite.GetJSONPrettyFlag(cmd), "")
+	}
+
+	return writeJSON(cmd.OutOrStdout(), v, indent)
+}
+
+// writeJSON writes the "v" as JSON indented by the "indent", or in a single line if the "indent" is empty.
+func writeJSON(w io.Writer, v interface{}, indent string) error {
+	var compact bytes.Buffer
+	if err := bite.WriteJSON(&compact, v, false, ""); err != nil {
+		return err
+	}
+
+	if indent == "" {
+		_, err := w.Write(compact.Bytes())
+		return err
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, bytes.TrimSpace(compact.Bytes()), "", indent); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+
+	_, err := w.Write(indented.Bytes())
+	return err
+}
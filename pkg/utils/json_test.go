@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func printTestJSON(t *testing.T, args ...string) (string, error) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output", "json", "")
+	CanPrintJSON(cmd)
+	assert.Nil(t, cmd.ParseFlags(args))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	payload := []map[string]interface{}{{"name": "ingestion", "groups": []string{"dev"}}}
+	err := PrintObject(cmd, payload)
+	return out.String(), err
+}
+
+func TestPrintJSONIndent(t *testing.T) {
+	// unchanged, single line without the --pretty.
+	out, err := printTestJSON(t)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"groups":["dev"],"name":"ingestion"}]`+"\n", out)
+
+	out, err = printTestJSON(t, "--indent", "4")
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n    {\n        \"groups\": [\n            \"dev\"\n        ],\n        \"name\": \"ingestion\"\n    }\n]\n", out)
+
+	out, err = printTestJSON(t, "--pretty", "--compact")
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"groups":["dev"],"name":"ingestion"}]`+"\n", out)
+
+	// the query is applied first.
+	out, err = printTestJSON(t, "--indent", "2", "--query", "[0].groups")
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n  \"dev\"\n]\n", out)
+
+	_, err = printTestJSON(t, "--compact", "--indent", "2")
+	assert.EqualError(t, err, "the --compact and the --indent can't be used together")
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// LayoutTokens are the tokens of an export layout, see `ExportPath`.
+var LayoutTokens = []string{"{type}", "{name}"}
+
+var layoutTokenPattern = regexp.MustCompile(`\{[^{}]*\}`)
+
+// ValidateLayout returns an error if the export "layout" contains unknown tokens or it's not a relative path.
+func ValidateLayout(layout string) error {
+	for _, token := range layoutTokenPattern.FindAllString(layout, -1) {
+		known := false
+		for _, t := range LayoutTokens {
+			if token == t {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return fmt.Errorf("unknown token %s in the layout [%s], expected %s", token, layout, strings.Join(LayoutTokens, " or "))
+		}
+	}
+
+	if !strings.Contains(layout, "{name}") {
+		return fmt.Errorf("invalid layout [%s], the {name} token is required so the resources don't overwrite each other", layout)
+	}
+
+	_, err := renderLayout(layout, "type", "name")
+	return err
+}
+
+// renderLayout returns the "layout" with its tokens replaced, it must stay inside the export directory.
+func renderLayout(layout, resourceType, name string) (string, error) {
+	rendered := strings.NewReplacer("{type}", resourceType, "{name}", name).Replace(filepath.ToSlash(layout))
+	rendered = path.Clean(rendered)
+
+	if path.IsAbs(rendered) || rendered == ".." || strings.HasPrefix(rendered, "../") {
+		return "", fmt.Errorf("invalid layout [%s], expected a path relative to the export directory", layout)
+	}
+
+	return rendered, nil
+}
+
+// ExportPath returns the directory, relative to the export directory, and the file name of an exported resource
+// of the "resourceType", i.e "connections" or "kafka/topics", based on the "layout" of the --layout flag.
+//
+// The "layout" is a path template of the `LayoutTokens`, i.e "{type}/{name}", without the file extension,
+// the one of the "fileName" is used. The "{name}" path separators are replaced, so it can't escape its directory.
+// An empty "layout" keeps the "fileName" in the "resourceType" directory.
+func ExportPath(layout, resourceType, name, fileName string) (string, string, error) {
+	if layout == "" {
+		return resourceType, fileName, nil
+	}
+
+	if err := ValidateLayout(layout); err != nil {
+		return "", "", err
+	}
+
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		name = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+
+	rendered, err := renderLayout(layout, resourceType, name)
+	if err != nil {
+		return "", "", err
+	}
+
+	return path.Dir(rendered), path.Base(rendered) + filepath.Ext(fileName), nil
+}
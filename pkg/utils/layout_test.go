@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportPath(t *testing.T) {
+	tests := []struct {
+		layout, resourceType, name, fileName string
+		dir, file                            string
+	}{
+		{"", "kafka/topics", "orders", "topic-orders.yaml", "kafka/topics", "topic-orders.yaml"},
+		{"{type}/{name}", "connections", "slack", "connection-slack-slack.yaml", "connections", "slack.yaml"},
+		{"{type}/{name}", "kafka/topics", "orders", "topic-orders.json", "kafka/topics", "orders.json"},
+		{"{type}/by-name/{name}/resource", "groups", "admins", "groups-admins.yaml", "groups/by-name/admins", "resource.yaml"},
+		{"{name}", "policies", "pii", "policies-pii.yaml", ".", "pii.yaml"},
+		{"{type}/{name}", "schemas", "a/b", "schema-a/b.yaml", "schemas", "a_b.yaml"},
+		{"{type}/{name}", "kafka/acls", "", "acls.yaml", "kafka/acls", "acls.yaml"},
+	}
+
+	for _, tt := range tests {
+		dir, file, err := ExportPath(tt.layout, tt.resourceType, tt.name, tt.fileName)
+		assert.Nil(t, err, tt.layout)
+		assert.Equal(t, tt.dir, dir, tt.layout)
+		assert.Equal(t, tt.file, file, tt.layout)
+	}
+}
+
+func TestValidateLayout(t *testing.T) {
+	assert.Nil(t, ValidateLayout("{type}/{name}"))
+	assert.Nil(t, ValidateLayout("export/{type}-{name}"))
+
+	assert.EqualError(t, ValidateLayout("{kind}/{name}"),
+		"unknown token {kind} in the layout [{kind}/{name}], expected {type} or {name}")
+	assert.EqualError(t, ValidateLayout("{type}"),
+		"invalid layout [{type}], the {name} token is required so the resources don't overwrite each other")
+	assert.EqualError(t, ValidateLayout("../{name}"),
+		"invalid layout [../{name}], expected a path relative to the export directory")
+	assert.EqualError(t, ValidateLayout("/tmp/{name}"),
+		"invalid layout [/tmp/{name}], expected a path relative to the export directory")
+
+	_, _, err := ExportPath("{type}/{id}", "connections", "slack", "connection-slack.yaml")
+	assert.EqualError(t, err, "unknown token {id} in the layout [{type}/{id}], expected {type} or {name}")
+}
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/kataras/golog"
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// VerboseFlag is the persistent, repeatable, flag of the logs level, see `SetupVerbosity`.
+	VerboseFlag = "verbose"
+	// LogFormatFlag is the persistent flag of the logs format, "text" or "json".
+	LogFormatFlag = "log-format"
+	// logFormatEnvKey is the environment variable of the logs format, the --log-format flag has priority.
+	logFormatEnvKey = "LENSES_LOG_FORMAT"
+)
+
+// AddLogFormatFlag adds the --log-format persistent flag to the root command, see `SetupLogFormat`.
+func AddLogFormatFlag(root *cobra.Command) {
+	root.PersistentFlags().String(LogFormatFlag, "", "Format of the logs, text or json for one JSON object per line, defaults to the "+logFormatEnvKey+" environment variable or text")
+}
+
+// SetupLogFormat applies the --log-format flag, or the LENSES_LOG_FORMAT environment variable, of the "cmd".
+// The "json" format writes all the logs, the `api.Logger`'s and the `golog`'s ones, as JSON objects to "w".
+func SetupLogFormat(cmd *cobra.Command, w io.Writer) error {
+	format, _ := cmd.Flags().GetString(LogFormatFlag)
+	if format == "" {
+		format = os.Getenv(logFormatEnvKey)
+	}
+
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", "text":
+		return nil
+	case "json":
+		logger := api.NewJSONLogger(w)
+		api.SetLogger(logger)
+		// the messages which are not written through the `api.Logger` yet.
+		golog.Handle(func(l *golog.Log) bool {
+			logger.Log(l.Level, l.Time, l.Message)
+			return true
+		})
+		return nil
+	default:
+		return fmt.Errorf("invalid log format [%s], expected text or json", format)
+	}
+}
+
+// AddVerboseFlag adds the -v, --verbose persistent flag to the root command, see `SetupVerbosity`.
+func AddVerboseFlag(root *cobra.Command) {
+	root.PersistentFlags().CountP(VerboseFlag, "v", "Verbose output, -v for the progress messages, i.e the imported files, and -vv for the debug messages too. "+
+		"The HTTP requests are logged only on the context's debug mode")
+}
+
+// SetupVerbosity sets the logs level based on the -v flag of the "cmd":
+// the warnings and the errors by default, the info messages too with -v and the debug messages too with -vv.
+// It is independent of the `api.ClientConfig#Debug`, which logs the HTTP requests and responses as well.
+func SetupVerbosity(cmd *cobra.Command) {
+	verbosity, _ := cmd.Flags().GetCount(VerboseFlag)
+
+	switch {
+	case verbosity <= 0:
+		golog.SetLevel("warn")
+	case verbosity == 1:
+		golog.SetLevel("info")
+	default:
+		golog.SetLevel("debug")
+	}
+}
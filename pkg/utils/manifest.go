@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// ManifestFileName is the name of the manifest of an export directory, see `Manifest`.
+const ManifestFileName = "manifest.json"
+
+// Manifest lists the exported files of a directory with their checksums, so a backup can be verified before it's imported.
+type Manifest struct {
+	ServerVersion string         `json:"serverVersion,omitempty"`
+	Timestamp     time.Time      `json:"timestamp"`
+	Files         []ManifestFile `json:"files"`
+}
+
+// ManifestFile is an entry of the `Manifest`, its "Path" is relative to the directory of the manifest, with forward slashes.
+type ManifestFile struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Checksum returns the hex encoded SHA-256 of the "data".
+func Checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Add adds, or replaces, the entry of the file at the "relPath" of the "dir" and keeps the files sorted by their path.
+func (m *Manifest) Add(dir, relPath string) error {
+	data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(relPath)))
+	if err != nil {
+		return err
+	}
+
+	m.put(ManifestFile{Path: path.Clean(filepath.ToSlash(relPath)), SHA256: Checksum(data), Size: int64(len(data))})
+	return nil
+}
+
+// put adds, or replaces, the "file" entry at its sorted position.
+func (m *Manifest) put(file ManifestFile) {
+	i := sort.Search(len(m.Files), func(i int) bool { return m.Files[i].Path >= file.Path })
+	if i < len(m.Files) && m.Files[i].Path == file.Path {
+		m.Files[i] = file
+		return
+	}
+
+	m.Files = append(m.Files, ManifestFile{})
+	copy(m.Files[i+1:], m.Files[i:])
+	m.Files[i] = file
+}
+
+// Get returns the entry of the "relPath", if it's listed.
+func (m Manifest) Get(relPath string) (ManifestFile, bool) {
+	relPath = path.Clean(filepath.ToSlash(relPath))
+	for _, file := range m.Files {
+		if file.Path == relPath {
+			return file, true
+		}
+	}
+
+	return ManifestFile{}, false
+}
+
+// Verify returns an error if the "data" of the file at the "relPath" is not listed in the manifest
+// or its checksum does not match.
+func (m Manifest) Verify(relPath string, data []byte) error {
+	file, ok := m.Get(relPath)
+	if !ok {
+		return fmt.Errorf("the file [%s] is not listed in the manifest", relPath)
+	}
+
+	if sum := Checksum(data); sum != file.SHA256 {
+		return fmt.Errorf("checksum mismatch of the file [%s], expected [%s] but got [%s]", relPath, file.SHA256, sum)
+	}
+
+	return nil
+}
+
+// VerifyFile is like the `Verify` but it reads the file at the "relPath" of the "dir",
+// the size of a truncated file is checked before its checksum.
+func (m Manifest) VerifyFile(dir, relPath string) error {
+	data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(relPath)))
+	if err != nil {
+		return err
+	}
+
+	if file, ok := m.Get(relPath); ok && file.Size != int64(len(data)) {
+		return fmt.Errorf("size mismatch of the file [%s], expected [%d] bytes but got [%d]", relPath, file.Size, len(data))
+	}
+
+	return m.Verify(relPath, data)
+}
+
+// ReadManifest reads the manifest of the "filename".
+func ReadManifest(filename string) (Manifest, error) {
+	var m Manifest
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return m, err
+	}
+
+	if err = json.Unmarshal(data, &m); err != nil {
+		return m, fmt.Errorf("unable to decode the manifest [%s]: %v", filename, err)
+	}
+
+	return m, nil
+}
+
+// LoadManifest reads the `ManifestFileName` of the "dir", an empty manifest is returned if it does not exist yet.
+func LoadManifest(dir string) (Manifest, error) {
+	m, err := ReadManifest(filepath.Join(dir, ManifestFileName))
+	if os.IsNotExist(err) {
+		return Manifest{}, nil
+	}
+
+	return m, err
+}
+
+// WriteManifest writes the "m" to the `ManifestFileName` of the "dir". It's written to a temporary file first
+// and renamed over the previous one, so an interrupted write never leaves a truncated manifest.
+func WriteManifest(dir string, m Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, "."+ManifestFileName+".*")
+	if err != nil {
+		return err
+	}
+
+	_, err = tmp.Write(append(data, '\n'))
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(dir, ManifestFileName))
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// CheckpointFileName is the journal of the files written by an export in progress, see `Checkpoint`.
+const CheckpointFileName = "." + ManifestFileName + ".checkpoint"
+
+// Checkpoint is the `Manifest` of a directory during an export. The entries of the written files are appended to its
+// `CheckpointFileName`, one JSON object per line, instead of rewriting the whole manifest after each file,
+// so an interrupted export keeps its progress. The `Commit` writes the manifest and removes the journal.
+type Checkpoint struct {
+	Manifest Manifest
+
+	dir     string
+	journal *os.File
+}
+
+// OpenCheckpoint loads the manifest of the "dir", if it exists, with the entries of the journal of an interrupted export.
+// A last entry which was cut in the middle is ignored, its file is not listed so it's exported again.
+func OpenCheckpoint(dir string) (*Checkpoint, error) {
+	m, err := LoadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// the entries are kept sorted by the `put`.
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+
+	journalPath := filepath.Join(dir, CheckpointFileName)
+	if data, err := ioutil.ReadFile(journalPath); err == nil {
+		for _, line := range bytes.Split(data, []byte("\n")) {
+			var file ManifestFile
+			if json.Unmarshal(line, &file) != nil || file.Path == "" {
+				continue
+			}
+			m.put(file)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return &Checkpoint{Manifest: m, dir: dir}, nil
+}
+
+// Add adds the entry of the written file at the "relPath" of the directory and appends it to the journal.
+func (c *Checkpoint) Add(relPath string) error {
+	if err := c.Manifest.Add(c.dir, relPath); err != nil {
+		return err
+	}
+
+	file, _ := c.Manifest.Get(relPath)
+	line, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+
+	if c.journal == nil {
+		if c.journal, err = os.OpenFile(filepath.Join(c.dir, CheckpointFileName), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644); err != nil {
+			return err
+		}
+	}
+
+	_, err = c.journal.Write(append(line, '\n'))
+	return err
+}
+
+// Commit writes the `Manifest` to the `ManifestFileName` of the directory and removes the journal.
+func (c *Checkpoint) Commit() error {
+	if c.journal != nil {
+		if err := c.journal.Close(); err != nil {
+			return err
+		}
+		c.journal = nil
+	}
+
+	if err := WriteManifest(c.dir, c.Manifest); err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(c.dir, CheckpointFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "manifest-checkpoint")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "topics"), 0755))
+	for _, name := range []string{"topics/b.yaml", "topics/a.yaml", "topics/c.yaml"} {
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+
+	checkpoint, err := OpenCheckpoint(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, checkpoint.Add("topics/b.yaml"))
+	assert.Nil(t, checkpoint.Add("topics/a.yaml"))
+
+	// interrupted without a manifest, in the middle of the entry of the third file.
+	_, err = os.Stat(filepath.Join(dir, ManifestFileName))
+	assert.True(t, os.IsNotExist(err))
+	journal, err := os.OpenFile(filepath.Join(dir, CheckpointFileName), os.O_WRONLY|os.O_APPEND, 0644)
+	assert.Nil(t, err)
+	_, err = journal.WriteString(`{"path": "topics/c.yaml", "sha`)
+	assert.Nil(t, err)
+	assert.Nil(t, journal.Close())
+
+	checkpoint, err = OpenCheckpoint(dir)
+	assert.Nil(t, err)
+	if assert.Len(t, checkpoint.Manifest.Files, 2) {
+		assert.Equal(t, "topics/a.yaml", checkpoint.Manifest.Files[0].Path)
+		assert.Equal(t, "topics/b.yaml", checkpoint.Manifest.Files[1].Path)
+	}
+	assert.Nil(t, checkpoint.Manifest.VerifyFile(dir, "topics/b.yaml"))
+
+	assert.Nil(t, checkpoint.Add("topics/c.yaml"))
+	assert.Nil(t, checkpoint.Commit())
+
+	// the journal and the temporary file of the manifest are removed.
+	files, err := ioutil.ReadDir(dir)
+	assert.Nil(t, err)
+	var names []string
+	for _, f := range files {
+		names = append(names, f.Name())
+	}
+	assert.Equal(t, []string{ManifestFileName, "topics"}, names)
+
+	manifest, err := LoadManifest(dir)
+	assert.Nil(t, err)
+	assert.Len(t, manifest.Files, 3)
+	for _, f := range manifest.Files {
+		assert.Nil(t, manifest.VerifyFile(dir, f.Path))
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// PageFlag is the flag of the `CanPage` which selects the page to print.
+	PageFlag = "page"
+	// PageSizeFlag is the flag of the `CanPage` which sets the amount of the results of a page.
+	PageSizeFlag = "page-size"
+
+	// pagedAnnotation marks the commands of the `CanPage`, the topics command has its own --page of the paged endpoint.
+	pagedAnnotation = "lenses-go/paged"
+)
+
+// CanPage adds the --page and --page-size flags to a list command whose endpoint returns all the results at once,
+// the `PrintObject` prints only that page of the results, after the --sort-by, see `PageSlice`.
+func CanPage(cmd *cobra.Command) {
+	cmd.Flags().Int(PageFlag, 0, "Print only this page of the results, pages start from 1, all of them if not set")
+	cmd.Flags().Int(PageSizeFlag, api.DefaultPageSize, "The amount of results of a --page")
+
+	if cmd.Annotations == nil {
+		cmd.Annotations = make(map[string]string)
+	}
+	cmd.Annotations[pagedAnnotation] = "true"
+}
+
+// GetPage returns the --page and --page-size of the "cmd" and true if the "cmd" can page and the --page is set, see `CanPage`.
+func GetPage(cmd *cobra.Command) (api.QueryFiltering, bool, error) {
+	var opts api.QueryFiltering
+	if cmd.Annotations[pagedAnnotation] == "" {
+		return opts, false, nil
+	}
+
+	opts.Page, _ = cmd.Flags().GetInt(PageFlag)
+	opts.PageSize, _ = cmd.Flags().GetInt(PageSizeFlag)
+
+	if opts.Page < 0 {
+		return opts, false, fmt.Errorf("invalid --%s [%d], pages start from 1", PageFlag, opts.Page)
+	}
+
+	if opts.PageSize <= 0 {
+		return opts, false, fmt.Errorf("invalid --%s [%d], it should be a positive amount", PageSizeFlag, opts.PageSize)
+	}
+
+	return opts, opts.Page > 0, nil
+}
+
+// PageSlice returns the page of the "opts" of the "v", if it's a slice, see `api.QueryFiltering#Bounds`.
+// Values which are not slices are returned as they are.
+func PageSlice(v interface{}, opts api.QueryFiltering) interface{} {
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return v
+	}
+
+	_, start, end := opts.Bounds(value.Len())
+	return value.Slice(start, end).Interface()
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/landoop/lenses-go/pkg/api"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageSlice(t *testing.T) {
+	items := newSortItems()
+	assert.Equal(t, items[2:], PageSlice(items, api.QueryFiltering{Page: 2, PageSize: 2}))
+	assert.Equal(t, items[3:], PageSlice(&items, api.QueryFiltering{Page: 2, PageSize: 3}))
+	assert.Equal(t, []sortItem{}, PageSlice(items, api.QueryFiltering{Page: 3, PageSize: 2}))
+
+	// not a slice.
+	assert.Equal(t, items[0], PageSlice(items[0], api.QueryFiltering{Page: 2}))
+}
+
+func TestPrintObjectPage(t *testing.T) {
+	print := func(args ...string) ([]string, error) {
+		var (
+			output string
+			items  = newSortItems()
+		)
+
+		cmd := &cobra.Command{Use: "test", RunE: func(cmd *cobra.Command, args []string) error {
+			return PrintObject(cmd, items)
+		}}
+		cmd.Flags().StringVar(&output, "output", "json", "")
+		AddSortByFlag(cmd)
+		CanPage(cmd)
+
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			return nil, err
+		}
+
+		var got []sortItem
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &got), buf.String())
+		return sortedNames(got), nil
+	}
+
+	names, err := print()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"c", "a", "b", "d"}, names)
+
+	// the page of the sorted results.
+	names, err = print("--sort-by", "name", "--page", "2", "--page-size", "3")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"d"}, names)
+
+	_, err = print("--page", "-1")
+	assert.EqualError(t, err, "invalid --page [-1], pages start from 1")
+	_, err = print("--page", "1", "--page-size", "0")
+	assert.EqualError(t, err, "invalid --page-size [0], it should be a positive amount")
+}
@@ -0,0 +1,158 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/landoop/bite"
+	"github.com/spf13/cobra"
+)
+
+// NDJSON is the --output of the newline-delimited JSON, one compact JSON object per line, see `PrintObject`.
+const NDJSON = "NDJSON"
+
+// IsNDJSON reports whether the --output of the "cmd" is ndjson.
+func IsNDJSON(cmd *cobra.Command) bool {
+	return strings.ToUpper(bite.GetOutPutFlag(cmd)) == NDJSON
+}
+
+// IsStructured reports whether the --output of the "cmd" is json, yaml or ndjson, the outputs of the `PrintObject`.
+func IsStructured(cmd *cobra.Command) bool {
+	switch strings.ToUpper(bite.GetOutPutFlag(cmd)) {
+	case "JSON", "YAML", NDJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTable reports whether the --output of the "cmd" is the table, the default one.
+func isTable(cmd *cobra.Command) bool {
+	output := strings.ToUpper(bite.GetOutPutFlag(cmd))
+	return output == "" || output == "TABLE"
+}
+
+// PrintObject is like the `bite.PrintObject` but on --output ndjson it writes each element of a slice,
+// or the "v" itself if it's not a slice, as a compact JSON object on its own line and flushes it,
+// so the list commands which print page by page stream their results as they are fetched.
+// The --query jmespath expression is applied to each element, on the rest of the outputs it's applied to the "v"
+// and its result is printed instead, see `ApplyQuery`.
+// On the table output, the --columns select and order the printed columns, see `AddColumnsFlag`.
+// The slices are sorted by the --sort-by fields first, on every output, see `SortSlice`,
+// then only the --page of them is printed, see `CanPage`,
+// and the JSON output respects the --indent and the --compact, see `CanPrintJSON`.
+func PrintObject(cmd *cobra.Command, v interface{}, tableOnlyFilters ...interface{}) error {
+	if err := SortSlice(v, GetSortBy(cmd)); err != nil {
+		return err
+	}
+
+	opts, paged, err := GetPage(cmd)
+	if err != nil {
+		return err
+	}
+	if paged {
+		v = PageSlice(v, opts)
+	}
+
+	if !IsNDJSON(cmd) {
+		if HasQuery(cmd) {
+			return printQueryResult(cmd, v)
+		}
+
+		if columns := getColumns(cmd); len(columns) > 0 && isTable(cmd) {
+			return printColumns(cmd, v, columns, tableOnlyFilters...)
+		}
+
+		if strings.ToUpper(bite.GetOutPutFlag(cmd)) == "JSON" {
+			return PrintJSON(cmd, v)
+		}
+
+		return bite.PrintObject(cmd, v, tableOnlyFilters...)
+	}
+
+	out := cmd.OutOrStdout()
+	writeLine := func(v interface{}) error { return bite.WriteJSON(out, v, false, "") }
+	if query := bite.GetJSONQueryFlag(cmd); query != "" {
+		expr, err := compileQuery(query)
+		if err != nil {
+			return err
+		}
+
+		writeLine = func(v interface{}) error {
+			result, err := searchQuery(expr, query, v)
+			if err != nil {
+				return err
+			}
+
+			return bite.WriteJSON(out, result, false, "")
+		}
+	}
+
+	value := reflect.ValueOf(v)
+	if kind := value.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		if err := writeLine(v); err != nil {
+			return err
+		}
+
+		return flush(out)
+	}
+
+	for i := 0; i < value.Len(); i++ {
+		if err := writeLine(value.Index(i).Interface()); err != nil {
+			return err
+		}
+
+		if err := flush(out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PrintRecord prints a record of a stream, i.e of a query or a topic peek, like the `PrintObject`,
+// but on --output ndjson the record is written on its own line as it is, even if it's a list, and flushed.
+func PrintRecord(cmd *cobra.Command, v interface{}) error {
+	if !IsNDJSON(cmd) {
+		return PrintObject(cmd, v)
+	}
+
+	result, err := ApplyQuery(cmd, v)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if err = bite.WriteJSON(out, result, false, ""); err != nil {
+		return err
+	}
+
+	return flush(out)
+}
+
+// printQueryResult prints the result of the --query over the "v", the table output can't describe an arbitrary result
+// so it writes it as it is, see `writeQueryResult`.
+func printQueryResult(cmd *cobra.Command, v interface{}) error {
+	result, err := ApplyQuery(cmd, v)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(bite.GetOutPutFlag(cmd)) {
+	case "JSON":
+		return printJSON(cmd, result)
+	case "YAML":
+		return bite.WriteYAML(cmd.OutOrStdout(), result)
+	default:
+		return writeQueryResult(cmd.OutOrStdout(), result)
+	}
+}
+
+// flush flushes the "w" if it's buffered, i.e a `bufio.Writer`.
+func flush(w interface{}) error {
+	if f, ok := w.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+
+	return nil
+}
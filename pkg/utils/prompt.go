@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+)
+
+// CanPrompt reports whether the interactive prompts can run, both the standard input and output should be terminals,
+// i.e it's false when the cli runs on CI or its input is piped, where a prompt would wait forever.
+// It's a variable so the tests can force the non-terminal path.
+var CanPrompt = func() bool {
+	return IsTerminal(os.Stdin) && IsTerminal(os.Stdout)
+}
+
+// RequirePrompt returns an error if the `CanPrompt` is false, "what" describes what would be asked
+// and "instead" tells the user which flags or environment variables to set instead.
+func RequirePrompt(what, instead string) error {
+	if CanPrompt() {
+		return nil
+	}
+
+	return fmt.Errorf("unable to prompt for %s, no terminal is attached, %s", what, instead)
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/landoop/bite"
+	"github.com/spf13/cobra"
+)
+
+// QueryFlag is the jmespath expression applied to the result of a command before it's printed,
+// it's registered by the `CanPrintJSON`, see `ApplyQuery`.
+const QueryFlag = "query"
+
+// HasQuery reports whether the --query of the "cmd" is set.
+func HasQuery(cmd *cobra.Command) bool {
+	return bite.GetJSONQueryFlag(cmd) != ""
+}
+
+// ApplyQuery returns the result of the --query jmespath expression of the "cmd" over the "v",
+// or the "v" itself if the --query is not set.
+// The expression is evaluated against the "v" itself, so the fields are named after the Go fields, i.e `[?ClusterName=='dev'].Name`,
+// as they have always been, see `searchQuery`. Their first letter is case insensitive, so most of the JSON names match too,
+// i.e `connections get --name kafka --query 'configuration[?key==`kafkaBootstrapServers`].value | [0]'`.
+func ApplyQuery(cmd *cobra.Command, v interface{}) (interface{}, error) {
+	query := bite.GetJSONQueryFlag(cmd)
+	if query == "" {
+		return v, nil
+	}
+
+	expr, err := compileQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	return searchQuery(expr, query, v)
+}
+
+func compileQuery(query string) (*jmespath.JMESPath, error) {
+	expr, err := jmespath.Compile(query)
+	if err != nil {
+		if syntaxErr, ok := err.(jmespath.SyntaxError); ok {
+			return nil, fmt.Errorf("invalid --%s, %v at:\n%s", QueryFlag, syntaxErr, syntaxErr.HighlightLocation())
+		}
+
+		return nil, fmt.Errorf("invalid --%s [%s]: %v", QueryFlag, query, err)
+	}
+
+	return expr, nil
+}
+
+// searchQuery evaluates the "expr" against the "v", the result of a command. If it finds nothing, a null or an empty list or object,
+// it's evaluated against the JSON form of the "v" as well, so the JSON names which differ from the Go fields, i.e `createdBy`, match too.
+func searchQuery(expr *jmespath.JMESPath, query string, v interface{}) (interface{}, error) {
+	result, err := expr.Search(v)
+	if err == nil && !isEmptyResult(result) {
+		return result, nil
+	}
+
+	b, jsonErr := json.Marshal(v)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+
+	var data interface{}
+	if jsonErr = json.Unmarshal(b, &data); jsonErr != nil {
+		return nil, jsonErr
+	}
+
+	jsonResult, jsonErr := expr.Search(data)
+	switch {
+	case jsonErr == nil && (err != nil || !isEmptyResult(jsonResult)):
+		return jsonResult, nil
+	case err == nil:
+		return result, nil
+	default:
+		return nil, fmt.Errorf("failed to evaluate the --%s [%s]: %v", QueryFlag, query, err)
+	}
+}
+
+func isEmptyResult(result interface{}) bool {
+	if result == nil {
+		return true
+	}
+
+	value := reflect.ValueOf(result)
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return value.IsNil()
+	}
+
+	return false
+}
+
+// writeQueryResult writes the result of a --query on the table output, the strings as they are,
+// i.e without quotes so they can be piped to other commands, the rest as JSON. A null result writes nothing.
+func writeQueryResult(w io.Writer, result interface{}) error {
+	switch value := result.(type) {
+	case nil:
+		return nil
+	case string:
+		_, err := fmt.Fprintln(w, value)
+		return err
+	default:
+		return bite.WriteJSON(w, value, true, "")
+	}
+}
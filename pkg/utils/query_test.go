@@ -0,0 +1,133 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type queryTestConnection struct {
+	Name         string   `json:"name" header:"Name"`
+	TemplateName string   `json:"templateName" header:"Template"`
+	Tags         []string `json:"tags" header:"Tags"`
+	Owner        string   `json:"createdBy" header:"Owner"`
+}
+
+func printTestQuery(t *testing.T, v interface{}, output string, args ...string) (string, error) {
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output", output, "")
+	CanPrintJSON(cmd)
+	// the --pretty and the --query are shared by the commands, reset them.
+	assert.Nil(t, cmd.ParseFlags(append([]string{"--pretty=false", "--query="}, args...)))
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	err := PrintObject(cmd, v)
+	return out.String(), err
+}
+
+func TestQueryFieldExtraction(t *testing.T) {
+	connection := queryTestConnection{Name: "slack", TemplateName: "Slack", Tags: []string{"dev", "ops"}}
+
+	// the fields are named as in the JSON output and the strings are printed as they are on the table output.
+	out, err := printTestQuery(t, connection, "table", "--query", "templateName")
+	assert.Nil(t, err)
+	assert.Equal(t, "Slack\n", out)
+
+	out, err = printTestQuery(t, connection, "json", "--query", "templateName")
+	assert.Nil(t, err)
+	assert.Equal(t, `"Slack"`+"\n", out)
+
+	out, err = printTestQuery(t, connection, "json", "--query", "{name: name, first: tags[0]}")
+	assert.Nil(t, err)
+	assert.Equal(t, `{"first":"dev","name":"slack"}`+"\n", out)
+
+	out, err = printTestQuery(t, connection, "yaml", "--query", "tags")
+	assert.Nil(t, err)
+	assert.Equal(t, "- dev\n- ops\n\n", out)
+
+	// a missing field prints nothing on the table output.
+	out, err = printTestQuery(t, connection, "table", "--query", "missing")
+	assert.Nil(t, err)
+	assert.Equal(t, "", out)
+}
+
+func TestQueryProjection(t *testing.T) {
+	connections := []queryTestConnection{
+		{Name: "slack", TemplateName: "Slack", Tags: []string{"dev"}},
+		{Name: "kafka", TemplateName: "Kafka"},
+		{Name: "alerts", TemplateName: "Slack"},
+	}
+
+	out, err := printTestQuery(t, connections, "json", "--query", "[?templateName=='Slack'].name")
+	assert.Nil(t, err)
+	assert.Equal(t, `["slack","alerts"]`+"\n", out)
+
+	out, err = printTestQuery(t, connections, "table", "--query", "[].name")
+	assert.Nil(t, err)
+	assert.Equal(t, "[\n  \"slack\",\n  \"kafka\",\n  \"alerts\"\n]\n", out)
+
+	// on ndjson the query is applied to each element.
+	out, err = printTestQuery(t, connections, NDJSON, "--query", "name")
+	assert.Nil(t, err)
+	assert.Equal(t, "\"slack\"\n\"kafka\"\n\"alerts\"\n", out)
+
+	// the --sort-by applies before the query.
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output", "json", "")
+	CanPrintJSON(cmd)
+	AddSortByFlag(cmd)
+	assert.Nil(t, cmd.ParseFlags([]string{"--sort-by", "name", "--query", "[].name", "--compact"}))
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	assert.Nil(t, PrintObject(cmd, connections))
+	assert.Equal(t, `["alerts","kafka","slack"]`+"\n", buf.String())
+}
+
+func TestQueryFieldNames(t *testing.T) {
+	connections := []queryTestConnection{
+		{Name: "slack", TemplateName: "Slack", Owner: "admin"},
+		{Name: "kafka", TemplateName: "Kafka", Owner: "ops"},
+	}
+
+	// the Go fields, as the --query has always been evaluated.
+	out, err := printTestQuery(t, connections, "json", "--query", "[?TemplateName=='Kafka'].Name")
+	assert.Nil(t, err)
+	assert.Equal(t, `["kafka"]`+"\n", out)
+
+	out, err = printTestQuery(t, connections, "json", "--query", "[].Owner")
+	assert.Nil(t, err)
+	assert.Equal(t, `["admin","ops"]`+"\n", out)
+
+	// the JSON names which differ from the Go fields match too.
+	out, err = printTestQuery(t, connections, "json", "--query", "[?createdBy=='ops'].name")
+	assert.Nil(t, err)
+	assert.Equal(t, `["kafka"]`+"\n", out)
+
+	out, err = printTestQuery(t, connections[0], "table", "--query", "createdBy")
+	assert.Nil(t, err)
+	assert.Equal(t, "admin\n", out)
+
+	// nothing matches either of them.
+	out, err = printTestQuery(t, connections, "json", "--query", "[?createdBy=='nobody'].name")
+	assert.Nil(t, err)
+	assert.Equal(t, "[]\n", out)
+}
+
+func TestQueryInvalidExpression(t *testing.T) {
+	connections := []queryTestConnection{{Name: "slack"}}
+
+	for _, output := range []string{"table", "json", "yaml", NDJSON} {
+		out, err := printTestQuery(t, connections, output, "--query", "[?name=='slack'")
+		assert.EqualError(t, err, "invalid --query, SyntaxError: Expected tRbracket, received: tEOF at:\n[?name=='slack'\n               ^", output)
+		assert.Equal(t, "", out, output)
+	}
+
+	_, err := printTestQuery(t, connections, "json", "--query", "length(name)")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to evaluate the --query [length(name)]: Invalid type for: <nil>")
+	}
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// MaxRecordsFlag is the persistent flag which limits the records of the queries and the topic peeks, see `GetMaxRecords`.
+	MaxRecordsFlag = "max-records"
+	// DefaultMaxRecords is the default --max-records, so an accidental query without a LIMIT does not flood the terminal.
+	DefaultMaxRecords = 10000
+)
+
+// ErrMaxRecords can be returned by the record handlers to stop the stream once the --max-records are read,
+// see `MaxRecordsReached`.
+var ErrMaxRecords = errors.New("max records reached")
+
+// AddMaxRecordsFlag adds the --max-records persistent flag to the root command.
+func AddMaxRecordsFlag(root *cobra.Command) {
+	root.PersistentFlags().Int(MaxRecordsFlag, DefaultMaxRecords, "Stop reading the records of the queries and the topic peeks after this amount, 0 means unlimited")
+}
+
+// GetMaxRecords returns the --max-records of the "cmd", the `DefaultMaxRecords` if the flag is missing.
+func GetMaxRecords(cmd *cobra.Command) (int, error) {
+	flag := cmd.Flag(MaxRecordsFlag)
+	if flag == nil {
+		return DefaultMaxRecords, nil
+	}
+
+	max, err := strconv.Atoi(flag.Value.String())
+	if err != nil || max < 0 {
+		return 0, fmt.Errorf("invalid --%s [%s], it should be a positive amount of records or 0 for unlimited", MaxRecordsFlag, flag.Value.String())
+	}
+
+	return max, nil
+}
+
+// GetRedirectedMaxRecords is like the `GetMaxRecords` but for the records which are written to a file instead of the terminal,
+// the default --max-records guards the terminal only, so they are unlimited unless the --max-records is set explicitly.
+func GetRedirectedMaxRecords(cmd *cobra.Command) (int, error) {
+	if flag := cmd.Flag(MaxRecordsFlag); flag == nil || !flag.Changed {
+		return 0, nil
+	}
+
+	return GetMaxRecords(cmd)
+}
+
+// MaxRecordsReached reports whether the "records" read so far reached the "max", 0 means unlimited,
+// and if so it prints a note to the standard error of the "cmd", so the output can still be piped.
+func MaxRecordsReached(cmd *cobra.Command, records, max int) bool {
+	if max <= 0 || records < max {
+		return false
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Stopped after %d records, the --%s limit, use --%s 0 to read all of them\n", max, MaxRecordsFlag, MaxRecordsFlag)
+	return true
+}
@@ -0,0 +1,265 @@
+package utils
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// SortByFlag is the persistent flag which sorts the list results before they are printed or exported, see `SortSlice`.
+const SortByFlag = "sort-by"
+
+// AddSortByFlag adds the --sort-by persistent flag to the root command.
+func AddSortByFlag(root *cobra.Command) {
+	root.PersistentFlags().StringSlice(SortByFlag, nil, "Sort the list results by these fields, as field[:asc|desc], i.e --sort-by name,owner:desc")
+}
+
+// GetSortBy returns the --sort-by keys of the "cmd", if any.
+func GetSortBy(cmd *cobra.Command) []string {
+	if flag := cmd.Flag(SortByFlag); flag != nil {
+		if value, ok := flag.Value.(pflag.SliceValue); ok {
+			return value.GetSlice()
+		}
+	}
+
+	return nil
+}
+
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+func parseSortKeys(keys []string) ([]sortKey, error) {
+	sortKeys := make([]sortKey, 0, len(keys))
+	for _, key := range keys {
+		field, order := strings.TrimSpace(key), "asc"
+		if idx := strings.LastIndexByte(field, ':'); idx != -1 {
+			field, order = field[:idx], strings.ToLower(field[idx+1:])
+		}
+
+		if field == "" {
+			return nil, fmt.Errorf("invalid --%s [%s], expected field[:asc|desc]", SortByFlag, key)
+		}
+
+		if order != "asc" && order != "desc" {
+			return nil, fmt.Errorf("invalid order [%s] of the --%s [%s], expected asc or desc", order, SortByFlag, key)
+		}
+
+		sortKeys = append(sortKeys, sortKey{field: field, desc: order == "desc"})
+	}
+
+	return sortKeys, nil
+}
+
+// SortSlice sorts the "v" in place, if it's a slice, by the "keys" in order, each key is a field[:asc|desc].
+// The fields are matched, case-insensitively, against the json and the header struct tags
+// and the names of the fields of the elements, or against the keys of map elements.
+// The sort is stable, so the elements with equal keys keep their order. Values which are not slices are left as they are.
+func SortSlice(v interface{}, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(v))
+	if value.Kind() != reflect.Slice {
+		return nil
+	}
+
+	sortKeys, err := parseSortKeys(keys)
+	if err != nil {
+		return err
+	}
+
+	// resolve the fields of each element before sorting, so an unknown field is reported without a panic.
+	n := value.Len()
+	fields := make([][]reflect.Value, n)
+	for i := 0; i < n; i++ {
+		elem := indirect(value.Index(i))
+		fields[i] = make([]reflect.Value, len(sortKeys))
+		for k, key := range sortKeys {
+			field, ok := lookupField(elem, key.field)
+			if !ok {
+				return fmt.Errorf("unknown field [%s] of the --%s, available fields: %s", key.field, SortByFlag, strings.Join(fieldNames(elem), ", "))
+			}
+			fields[i][k] = field
+		}
+	}
+
+	// sort the indexes and then apply their order, so the fields stay in sync with the elements.
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		for k, key := range sortKeys {
+			c := compareValues(fields[order[i]][k], fields[order[j]][k])
+			if c == 0 {
+				continue
+			}
+
+			if key.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+
+		return false
+	})
+
+	sorted := reflect.MakeSlice(value.Type(), n, n)
+	for i, idx := range order {
+		sorted.Index(i).Set(value.Index(idx))
+	}
+	reflect.Copy(value, sorted)
+
+	return nil
+}
+
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+
+	return v
+}
+
+// tagName returns the name of the "key" struct tag of the "f", without its options.
+func tagName(f reflect.StructField, key string) string {
+	name := f.Tag.Get(key)
+	if idx := strings.IndexByte(name, ','); idx != -1 {
+		name = name[:idx]
+	}
+
+	return name
+}
+
+// lookupField returns the field of the "v" which matches the "name", the fields of the embedded structs included.
+func lookupField(v reflect.Value, name string) (reflect.Value, bool) {
+	switch v.Kind() {
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if key.Kind() == reflect.String && strings.EqualFold(key.String(), name) {
+				return indirect(v.MapIndex(key)), true
+			}
+		}
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.PkgPath != "" && !f.Anonymous {
+				continue // unexported.
+			}
+
+			if strings.EqualFold(f.Name, name) || strings.EqualFold(tagName(f, "json"), name) || strings.EqualFold(tagName(f, "header"), name) {
+				return indirect(v.Field(i)), true
+			}
+		}
+
+		for i := 0; i < typ.NumField(); i++ {
+			if f := typ.Field(i); f.Anonymous {
+				if field, ok := lookupField(indirect(v.Field(i)), name); ok {
+					return field, true
+				}
+			}
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// fieldNames returns the names of the fields of the "v" which can be sorted by, the json names if any.
+func fieldNames(v reflect.Value) (names []string) {
+	switch v.Kind() {
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			if key.Kind() == reflect.String {
+				names = append(names, key.String())
+			}
+		}
+		sort.Strings(names)
+	case reflect.Struct:
+		typ := v.Type()
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.Anonymous {
+				names = append(names, fieldNames(indirect(v.Field(i)))...)
+				continue
+			}
+
+			if f.PkgPath != "" {
+				continue
+			}
+
+			name := tagName(f, "json")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			names = append(names, name)
+		}
+	}
+
+	return
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareValues returns -1, 0 or 1 if the "a" is less, equal or greater than the "b",
+// missing values are less than the rest and the values of unknown kinds are compared as text.
+func compareValues(a, b reflect.Value) int {
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	}
+
+	if a.Kind() == b.Kind() {
+		switch a.Kind() {
+		case reflect.String:
+			return strings.Compare(a.String(), b.String())
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return compareOrdered(a.Int() < b.Int(), a.Int() > b.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return compareOrdered(a.Uint() < b.Uint(), a.Uint() > b.Uint())
+		case reflect.Float32, reflect.Float64:
+			return compareOrdered(a.Float() < b.Float(), a.Float() > b.Float())
+		case reflect.Bool:
+			return compareOrdered(!a.Bool() && b.Bool(), a.Bool() && !b.Bool())
+		case reflect.Struct:
+			if a.Type() == timeType && b.Type() == timeType {
+				ta, tb := a.Interface().(time.Time), b.Interface().(time.Time)
+				return compareOrdered(ta.Before(tb), ta.After(tb))
+			}
+		}
+	}
+
+	if !a.CanInterface() || !b.CanInterface() {
+		return 0
+	}
+
+	return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+func compareOrdered(less, greater bool) int {
+	if less {
+		return -1
+	}
+
+	if greater {
+		return 1
+	}
+
+	return 0
+}
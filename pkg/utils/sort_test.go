@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sortBase struct {
+	Owner string `json:"owner" header:"Owner"`
+}
+
+type sortItem struct {
+	sortBase `header:"inline"`
+	Name     string `json:"name" header:"Name"`
+	Runners  int    `json:"runners" header:"Instances"`
+}
+
+func sortedNames(items []sortItem) []string {
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = item.Name
+	}
+
+	return names
+}
+
+func newSortItems() []sortItem {
+	return []sortItem{
+		{sortBase{"bob"}, "c", 2},
+		{sortBase{"alice"}, "a", 10},
+		{sortBase{"bob"}, "b", 1},
+		{sortBase{"alice"}, "d", 2},
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	items := newSortItems()
+	assert.Nil(t, SortSlice(items, []string{"name"}))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, sortedNames(items))
+
+	assert.Nil(t, SortSlice(items, []string{"Name:desc"}))
+	assert.Equal(t, []string{"d", "c", "b", "a"}, sortedNames(items))
+
+	// numbers are not sorted as text, the header tag matches too.
+	assert.Nil(t, SortSlice(items, []string{"instances"}))
+	assert.Equal(t, []string{"b", "d", "c", "a"}, sortedNames(items))
+
+	// the embedded fields and multiple keys.
+	items = newSortItems()
+	assert.Nil(t, SortSlice(items, []string{"owner", "runners:desc"}))
+	assert.Equal(t, []string{"a", "d", "c", "b"}, sortedNames(items))
+
+	// stable, the ties keep their order.
+	items = newSortItems()
+	assert.Nil(t, SortSlice(&items, []string{"owner:asc"}))
+	assert.Equal(t, []string{"a", "d", "c", "b"}, sortedNames(items))
+
+	// maps, i.e the outlined names.
+	maps := []map[string]interface{}{{"name": "b"}, {"name": "a"}}
+	assert.Nil(t, SortSlice(maps, []string{"name"}))
+	assert.Equal(t, "a", maps[0]["name"])
+
+	// not a slice.
+	assert.Nil(t, SortSlice(sortItem{}, []string{"unknown"}))
+}
+
+func TestSortSliceErrors(t *testing.T) {
+	items := newSortItems()
+	assert.EqualError(t, SortSlice(items, []string{"name", "email"}),
+		"unknown field [email] of the --sort-by, available fields: owner, name, runners")
+	assert.Equal(t, []string{"c", "a", "b", "d"}, sortedNames(items), "unchanged on errors")
+
+	assert.EqualError(t, SortSlice(items, []string{"name:up"}),
+		"invalid order [up] of the --sort-by [name:up], expected asc or desc")
+	assert.EqualError(t, SortSlice(items, []string{":desc"}),
+		"invalid --sort-by [:desc], expected field[:asc|desc]")
+}
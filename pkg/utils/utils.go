@@ -141,18 +141,12 @@ func GetEnvVars(prefix string) []string {
 }
 
 //FindFiles fidn the files in provided directory
-func FindFiles(dir string) []os.FileInfo {
-	files, err := ioutil.ReadDir(dir)
-	if err != nil {
-		golog.Fatal(err)
-	}
-	return files
+func FindFiles(dir string) ([]os.FileInfo, error) {
+	return ioutil.ReadDir(dir)
 }
 
 //PrintLogLines prints lines as logs
 func PrintLogLines(logs []api.LogLine) error {
-	golog.SetTimeFormat("")
-
 	for _, logLine := range logs {
 		logLine.Message, _ = url.QueryUnescape(logLine.Message) // for LSQL lines.
 		line := logLine.Time + " " + logLine.Message
@@ -185,15 +179,19 @@ func ReadLines(path string) ([]string, error) {
 	return lines, scanner.Err()
 }
 
+// logLines prints the server's log lines of the `RichLog`, they carry their own time.
+// It's not the cli's logger, these lines are the output of the commands, so they are printed regardless of the -v verbosity.
+var logLines = golog.New().SetTimeFormat("")
+
 //RichLog based on level logs properly
 func RichLog(level string, log string) {
 	switch strings.ToLower(level) {
 	case "info":
-		golog.Infof(log)
+		logLines.Infof(log)
 	case "warn":
-		golog.Warnf(log)
+		logLines.Warnf(log)
 	case "error":
-		golog.Errorf(log)
+		logLines.Errorf(log)
 	default:
 		// app.Print(log)
 	}
@@ -255,7 +253,6 @@ func WriteByteFile(fileName string, data []byte) error {
 	)
 
 	if err != nil {
-		golog.Fatal(err)
 		return err
 	}
 	defer file.Close()
@@ -263,7 +260,6 @@ func WriteByteFile(fileName string, data []byte) error {
 	_, writeErr := file.Write(data)
 
 	if writeErr != nil {
-		golog.Fatal(writeErr)
 		return writeErr
 	}
 
@@ -282,7 +278,6 @@ func WriteStringFile(fileName string, data []string) error {
 	)
 
 	if err != nil {
-		golog.Fatal(err)
 		return err
 	}
 	defer file.Close()
@@ -291,7 +286,6 @@ func WriteStringFile(fileName string, data []string) error {
 		_, writeErr := file.WriteString(fmt.Sprintf("%s\n", d))
 
 		if writeErr != nil {
-			golog.Fatal(writeErr)
 			return writeErr
 		}
 	}
@@ -319,7 +313,6 @@ func WriteBytesFile(landscapeDir, basePath, fileName string, data []byte) error
 	)
 
 	if err != nil {
-		golog.Fatal(err)
 		return err
 	}
 	defer file.Close()
@@ -327,7 +320,6 @@ func WriteBytesFile(landscapeDir, basePath, fileName string, data []byte) error
 	_, writeErr := file.Write(data)
 
 	if writeErr != nil {
-		golog.Fatal(writeErr)
 		return writeErr
 	}
 
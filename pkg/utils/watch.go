@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+// DefaultWatchInterval is the default refresh interval of the `--watch` mode.
+const DefaultWatchInterval = 2 * time.Second
+
+// clearScreen moves the cursor to the top left corner and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// CanWatch lets a read-only command opt-in to the `--watch` mode by registering the `--watch` and `--interval` flags
+// and wrapping its `RunE`, so the fetch and print of the command are re-run on every interval.
+//
+// Call it after the `RunE` of the command is set.
+func CanWatch(cmd *cobra.Command) {
+	cmd.Flags().Bool("watch", false, "Re-run the command and refresh its output on every --interval, until interrupted (Ctrl-C)")
+	cmd.Flags().Duration("interval", DefaultWatchInterval, "The refresh interval of --watch")
+
+	run := cmd.RunE
+	if run == nil {
+		return
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, args []string) error {
+		return Watch(cmd, func() error {
+			return run(cmd, args)
+		})
+	}
+}
+
+// Watch runs `frame` once, or, if the `--watch` flag is set, repeatedly every `--interval`
+// until the process is interrupted or the command's context is done.
+//
+// When the output is a terminal the screen is cleared before each frame,
+// otherwise the frames are appended to the output.
+func Watch(cmd *cobra.Command, frame func() error) error {
+	watch, _ := cmd.Flags().GetBool("watch")
+	if !watch {
+		return frame()
+	}
+
+	interval, _ := cmd.Flags().GetDuration("interval")
+	if interval <= 0 {
+		return fmt.Errorf("invalid --interval value [%s], it should be greater than zero", interval)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	out := cmd.OutOrStdout()
+	tty := IsTerminal(out)
+
+	for i := 0; ; i++ {
+		if tty {
+			fmt.Fprint(out, clearScreen)
+		} else if i > 0 {
+			fmt.Fprintln(out)
+		}
+
+		if err := frame(); err != nil {
+			return err
+		}
+
+		select {
+		case <-interrupt:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// IsTerminal reports whether the writer is a terminal.
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
@@ -0,0 +1,205 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kataras/golog"
+)
+
+const (
+	// DefaultMaxReconnects is the default number of reconnection attempts of a `Subscribe`,
+	// after a connection drop, before it gives up.
+	DefaultMaxReconnects = 5
+	// DefaultReconnectBackoff is the default wait time before the first reconnection attempt of a `Subscribe`,
+	// it's doubled on each failed attempt, up to `maxReconnectBackoff`.
+	DefaultReconnectBackoff = 500 * time.Millisecond
+
+	maxReconnectBackoff = 30 * time.Second
+)
+
+// LiveMessage is a message of a live subscription, see `Subscribe`.
+type LiveMessage struct {
+	LiveResponse
+
+	// Err is not nil when the message reports a failure instead of a response from the server,
+	// i.e the server's close reason or an "ERROR" response.
+	// The subscription keeps reconnecting after a connection drop,
+	// the channel is closed when the subscription is over.
+	Err error
+}
+
+// Subscribe opens a websocket connection based on the `config`, sends the `config.Message`
+// (the token and the query) and streams the decoded responses to the returned channel.
+//
+// If the connection drops it reconnects with an exponential backoff, unless `LiveConfiguration.NoReconnect` is set,
+// see `LiveConfiguration.MaxReconnects` and `LiveConfiguration.ReconnectBackoff`.
+// The channel is closed when the `ctx` is done, on an "END" response,
+// when the server closes the connection normally or rejects the query, or when the reconnection attempts are exhausted.
+//
+// It returns an error if the first connection failed.
+func Subscribe(ctx context.Context, config LiveConfiguration) (<-chan LiveMessage, error) {
+	if config.Debug {
+		golog.SetLevel("debug")
+	}
+
+	if config.HandshakeTimeout == 0 {
+		config.HandshakeTimeout = 45 * time.Second
+	}
+
+	if config.MaxReconnects == 0 {
+		config.MaxReconnects = DefaultMaxReconnects
+	}
+
+	if config.ReconnectBackoff <= 0 {
+		config.ReconnectBackoff = DefaultReconnectBackoff
+	}
+
+	config.Host = strings.Replace(config.Host, "https://", "wss://", 1)
+	config.Host = strings.Replace(config.Host, "http://", "ws://", 1)
+	endpoint := fmt.Sprintf("%s/api/ws/v2/sql/execute", config.Host)
+
+	conn, err := dialLive(ctx, endpoint, config)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan LiveMessage)
+	go func() {
+		defer close(messages)
+
+		send := func(msg LiveMessage) bool {
+			select {
+			case messages <- msg:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for {
+			reconnect := readLive(ctx, conn, send)
+			if !reconnect || config.NoReconnect {
+				return
+			}
+
+			conn, err = redialLive(ctx, endpoint, config)
+			if err != nil {
+				send(LiveMessage{Err: err})
+				return
+			}
+		}
+	}()
+
+	return messages, nil
+}
+
+func dialLive(ctx context.Context, endpoint string, config LiveConfiguration) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: config.HandshakeTimeout,
+		ReadBufferSize:   config.ReadBufferSize,
+		WriteBufferSize:  config.WriteBufferSize,
+		TLSClientConfig:  config.TLSClientConfig,
+	}
+
+	conn, _, err := dialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		err = fmt.Errorf("connect failure for [%s]: %v", config.Host, err)
+		golog.Debug(err)
+		return nil, err
+	}
+
+	// the token is sent along with the query.
+	if err = conn.WriteJSON(config.Message); err != nil {
+		conn.Close()
+		golog.Debug(err)
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// redialLive tries to connect again, waiting before each attempt, the wait is doubled on every failure.
+func redialLive(ctx context.Context, endpoint string, config LiveConfiguration) (*websocket.Conn, error) {
+	backoff := config.ReconnectBackoff
+
+	var err error
+	for attempt := 1; config.MaxReconnects < 0 || attempt <= config.MaxReconnects; attempt++ {
+		golog.Debugf("live: reconnecting in [%s], attempt [%d]", backoff, attempt)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		var conn *websocket.Conn
+		if conn, err = dialLive(ctx, endpoint, config); err == nil {
+			return conn, nil
+		}
+
+		if backoff *= 2; backoff > maxReconnectBackoff {
+			backoff = maxReconnectBackoff
+		}
+	}
+
+	return nil, fmt.Errorf("live: giving up after [%d] reconnection attempts: %v", config.MaxReconnects, err)
+}
+
+// readLive reads and sends the responses until the connection is over,
+// it reports whether the subscription should reconnect.
+func readLive(ctx context.Context, conn *websocket.Conn, send func(LiveMessage) bool) bool {
+	// unblock the read when the ctx is done.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	defer conn.Close()
+
+	for {
+		var resp LiveResponse
+		if err := conn.ReadJSON(&resp); err != nil {
+			if ctx.Err() != nil {
+				return false
+			}
+
+			if closeErr, ok := err.(*websocket.CloseError); ok {
+				reason := fmt.Errorf("live: connection closed by the server: [%d] %s", closeErr.Code, closeErr.Text)
+				if !send(LiveMessage{Err: reason}) {
+					return false
+				}
+
+				// a normal close means that the server has nothing more to send.
+				return closeErr.Code != websocket.CloseNormalClosure
+			}
+
+			return send(LiveMessage{Err: fmt.Errorf("live: connection dropped: %v", err)})
+		}
+
+		golog.Debugf("read: [%#+v]", resp)
+
+		switch resp.Type {
+		case ErrorResponse, InvalidRequestResponse:
+			// the query was rejected, there is no point to reconnect.
+			send(LiveMessage{LiveResponse: resp, Err: fmt.Errorf("live: [%s]: %s", resp.Type, string(resp.Data.Value))})
+			return false
+		case EndResponse:
+			send(LiveMessage{LiveResponse: resp})
+			return false
+		}
+
+		if !send(LiveMessage{LiveResponse: resp}) {
+			return false
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeReconnects(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	connections := 0
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/ws/v2/sql/execute", r.URL.Path)
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+		connections++
+
+		var msg Message
+		assert.Nil(t, conn.ReadJSON(&msg))
+		assert.Equal(t, Message{Token: "secret", SQL: "SELECT * FROM payments", Live: true}, msg)
+
+		record := LiveResponse{Type: RecordMessageResponse, Data: Data{Value: json.RawMessage(`{"n":1}`)}}
+		if connections == 1 {
+			conn.WriteJSON(record)
+			// drop the first connection, the client should reconnect.
+			conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "restarting"))
+			return
+		}
+
+		record.Data.Value = json.RawMessage(`{"n":2}`)
+		conn.WriteJSON(record)
+		conn.WriteJSON(LiveResponse{Type: EndResponse})
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages, err := Subscribe(ctx, LiveConfiguration{
+		Host:             server.URL,
+		Message:          Message{Token: "secret", SQL: "SELECT * FROM payments", Live: true},
+		ReconnectBackoff: 10 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+
+	var got []LiveMessage
+	for msg := range messages {
+		got = append(got, msg)
+	}
+
+	assert.Equal(t, 2, connections)
+	if assert.Len(t, got, 4) {
+		assert.Equal(t, `{"n":1}`, string(got[0].Data.Value))
+		assert.EqualError(t, got[1].Err, "live: connection closed by the server: [1011] restarting")
+		assert.Equal(t, `{"n":2}`, string(got[2].Data.Value))
+		assert.Equal(t, EndResponse, got[3].Type)
+	}
+}
+
+func TestSubscribeConnectFailure(t *testing.T) {
+	_, err := Subscribe(context.Background(), LiveConfiguration{Host: "http://127.0.0.1:0"})
+	assert.NotNil(t, err)
+}
+
+func TestSubscribeNoReconnect(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	connections := 0
+
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if !assert.Nil(t, err) {
+			return
+		}
+		defer conn.Close()
+		connections++
+
+		var msg Message
+		assert.Nil(t, conn.ReadJSON(&msg))
+		conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "restarting"))
+	})
+	server := httptest.NewServer(h)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	messages, err := Subscribe(ctx, LiveConfiguration{
+		Host:             server.URL,
+		Message:          Message{Token: "secret", SQL: "SELECT * FROM payments"},
+		NoReconnect:      true,
+		ReconnectBackoff: 10 * time.Millisecond,
+	})
+	assert.Nil(t, err)
+
+	var got []LiveMessage
+	for msg := range messages {
+		got = append(got, msg)
+	}
+
+	assert.Equal(t, 1, connections)
+	if assert.Len(t, got, 1) {
+		assert.EqualError(t, got[0].Err, "live: connection closed by the server: [1011] restarting")
+	}
+}
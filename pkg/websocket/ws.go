@@ -42,11 +42,15 @@ const (
 type (
 	//MetaData is a topic metadata returned by Lenses
 	MetaData struct {
-		Timestamp int `json:"timestamp"`
-		KeySize   int `json:"__keysize"`
-		ValueSize int `json:"__valuesize"`
-		Partition int `json:"partition"`
-		Offset    int `json:"offset"`
+		Topic     string `json:"topic,omitempty"`
+		Timestamp int    `json:"timestamp"`
+		KeySize   int    `json:"__keysize"`
+		ValueSize int    `json:"__valuesize"`
+		Partition int    `json:"partition"`
+		Offset    int    `json:"offset"`
+
+		// coordinates is true if the partition and the offset were sent by the server, see `HasCoordinates`.
+		coordinates bool
 	}
 
 	// Data is the data payload for a record returned from Lenses
@@ -70,6 +74,35 @@ type (
 	}
 )
 
+// UnmarshalJSON decodes the metadata and keeps track of whether the record coordinates were sent.
+func (m *MetaData) UnmarshalJSON(b []byte) error {
+	type metaData MetaData // avoid recursion.
+	var (
+		v      metaData
+		fields map[string]json.RawMessage
+	)
+
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	_, hasPartition := fields["partition"]
+	_, hasOffset := fields["offset"]
+	v.coordinates = hasPartition && hasOffset
+
+	*m = MetaData(v)
+	return nil
+}
+
+// HasCoordinates reports whether the server sent the partition and the offset of the record.
+func (m MetaData) HasCoordinates() bool {
+	return m.coordinates
+}
+
 type (
 	//Message for WS
 	Message struct {
@@ -101,6 +134,16 @@ type (
 		// TLSClientConfig specifies the TLS configuration to use with tls.Client.
 		// If nil, the default configuration is used.
 		TLSClientConfig *tls.Config
+
+		// MaxReconnects is the number of reconnection attempts of a `Subscribe` after a connection drop,
+		// defaults to `DefaultMaxReconnects`, a negative value means no limit.
+		MaxReconnects int
+		// NoReconnect disables the reconnection of a `Subscribe`, the subscription is over on the first connection drop,
+		// i.e for snapshot queries which would send their records again.
+		NoReconnect bool
+		// ReconnectBackoff is the wait time before the first reconnection attempt of a `Subscribe`,
+		// defaults to `DefaultReconnectBackoff`.
+		ReconnectBackoff time.Duration
 	}
 
 	// LiveConnection is the websocket connection.